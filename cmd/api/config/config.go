@@ -1,15 +1,33 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/kelseyhightower/envconfig"
 )
 
 type Config struct {
-	Port     int       `required:"true" split_words:"true"`
-	Secret   string    `required:"true" split_words:"true"`
-	UseSSL   bool      `required:"true" split_words:"true"`
-	LogLevel string    `default:"info" split_words:"true"`
-	DB       *DBConfig `required:"true" split_words:"true"`
+	Port          int       `required:"true" split_words:"true"`
+	Secret        string    `required:"true" split_words:"true"`
+	UseSSL        bool      `required:"true" split_words:"true"`
+	LogLevel      string    `default:"info" split_words:"true"`
+	DB            *DBConfig `required:"true" split_words:"true"`
+	OIDCProviders []string  `envconfig:"OIDC_PROVIDERS"`
+	// TrustedProxies are the IPs or CIDRs of the proxies (e.g. the front-end),
+	// whose X-Forwarded-For header is trusted to determine the IP of the client.
+	TrustedProxies []string             `split_words:"true"`
+	Login          *LoginConfig         `split_words:"true"`
+	RateLimit      *RateLimitConfig     `split_words:"true"`
+	Metadata       *MetadataConfig      `split_words:"true"`
+	Blob           *BlobConfig          `split_words:"true"`
+	Notifications  *NotificationsConfig `split_words:"true"`
+	Webhooks       *WebhooksConfig      `split_words:"true"`
+
+	// OIDC holds the configuration of each provider listed in OIDCProviders.
+	// Each provider is configured via the GIRA_OIDC_<NAME>_ prefix, e.g. GIRA_OIDC_GOOGLE_ISSUER.
+	OIDC []*OIDCConfig `ignored:"true"`
 }
 
 type DBConfig struct {
@@ -20,10 +38,104 @@ type DBConfig struct {
 	Name     string `required:"true" split_words:"true"`
 }
 
+// LoginConfig is the configuration of the brute-force protection of the login endpoint.
+type LoginConfig struct {
+	MaxAttempts       int           `default:"5" split_words:"true"`
+	MaxClientAttempts int           `default:"20" split_words:"true"`
+	Lockout           time.Duration `default:"1m" split_words:"true"`
+	MaxLockout        time.Duration `default:"1h" split_words:"true"`
+	ResetAfter        time.Duration `default:"1h" split_words:"true"`
+}
+
+// RateLimitConfig is the configuration of the rate limiting of the API.
+// The limits are per user for authenticated requests and per client IP for the rest.
+type RateLimitConfig struct {
+	Enabled       bool          `default:"true" split_words:"true"`
+	Requests      int           `default:"100" split_words:"true"`
+	Period        time.Duration `default:"1m" split_words:"true"`
+	LoginRequests int           `default:"10" split_words:"true"`
+	LoginPeriod   time.Duration `default:"1m" split_words:"true"`
+}
+
+// MetadataConfig is the configuration of the RAWG metadata provider, used to fetch the details of games.
+// The provider is disabled, unless an API key is set.
+type MetadataConfig struct {
+	URL      string        `default:"https://api.rawg.io/api"`
+	APIKey   string        `split_words:"true"`
+	CacheTTL time.Duration `default:"168h" split_words:"true"`
+}
+
+// BlobConfig is the configuration of the store of the uploaded covers.
+// Type is either filesystem, which keeps them in Dir, or s3, which keeps them in a bucket of an S3-compatible storage (AWS S3, MinIO, etc.)
+type BlobConfig struct {
+	Type string        `default:"filesystem"`
+	Dir  string        `default:"data/blobs"`
+	S3   *BlobS3Config `split_words:"true"`
+}
+
+// BlobS3Config is the configuration of an S3-compatible object storage.
+type BlobS3Config struct {
+	Endpoint  string `split_words:"true"`
+	Region    string `default:"us-east-1" split_words:"true"`
+	Bucket    string `split_words:"true"`
+	AccessKey string `split_words:"true"`
+	SecretKey string `split_words:"true"`
+}
+
+// NotificationsConfig is the configuration of the reminders about stale games.
+// The in-app and webhook notifications are always available, the email ones only if an SMTP host is set.
+type NotificationsConfig struct {
+	Enabled  bool          `default:"true"`
+	Interval time.Duration `default:"1h"`
+	SMTP     *SMTPConfig   `envconfig:"SMTP"`
+}
+
+// SMTPConfig is the configuration of the SMTP server, used to send the email notifications.
+type SMTPConfig struct {
+	Host     string
+	Port     int `default:"587"`
+	Username string
+	Password string
+	From     string `default:"gira@localhost"`
+}
+
+// WebhooksConfig is the configuration of the delivery of the events of the games to the webhooks of the users.
+// A failed delivery is retried up to MaxAttempts times in total, waiting Backoff before the first retry and twice as long before each next one.
+// The webhooks cannot point to loopback, private or link-local addresses, unless they are in AllowedNetworks (IPs or CIDRs).
+type WebhooksConfig struct {
+	Enabled         bool          `default:"true"`
+	MaxAttempts     int           `default:"5" split_words:"true"`
+	Backoff         time.Duration `default:"1s"`
+	AllowedNetworks []string      `split_words:"true"`
+}
+
+// OIDCConfig is the configuration of an OpenID Connect provider,
+// needed to exchange the authorization codes and verify the ID tokens it issues.
+// RedirectURL must be the same as the one of the front-end, that starts the login.
+// LinkByEmail should be set only for the providers, that are trusted to verify the emails of their users,
+// because it links their identities to the existing users with the same email.
+type OIDCConfig struct {
+	Name         string `ignored:"true"`
+	Issuer       string `required:"true" split_words:"true"`
+	ClientID     string `required:"true" split_words:"true"`
+	ClientSecret string `split_words:"true"`
+	RedirectURL  string `required:"true" split_words:"true"`
+	LinkByEmail  bool   `split_words:"true"`
+}
+
 func NewFromEnv() (*Config, error) {
 	var config Config
 	if err := envconfig.Process("GIRA", &config); err != nil {
 		return nil, err
 	}
+
+	for _, name := range config.OIDCProviders {
+		oidcConfig := OIDCConfig{Name: name}
+		if err := envconfig.Process(fmt.Sprintf("GIRA_OIDC_%s", strings.ToUpper(name)), &oidcConfig); err != nil {
+			return nil, fmt.Errorf("error while loading config for OIDC provider %s: %w", name, err)
+		}
+		config.OIDC = append(config.OIDC, &oidcConfig)
+	}
+
 	return &config, nil
 }
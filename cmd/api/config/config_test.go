@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/asankov/gira/cmd/api/config"
 	"github.com/stretchr/testify/require"
@@ -34,6 +35,148 @@ func TestNewConfig(t *testing.T) {
 	require.Equal(t, config.DB.Name, "name")
 }
 
+func TestNewConfigOIDC(t *testing.T) {
+	setRequiredEnv(t)
+	setenv(t, "GIRA_OIDC_PROVIDERS", "google,corp")
+	setenv(t, "GIRA_OIDC_GOOGLE_ISSUER", "https://accounts.google.com")
+	setenv(t, "GIRA_OIDC_GOOGLE_CLIENT_ID", "google-client")
+	setenv(t, "GIRA_OIDC_GOOGLE_CLIENT_SECRET", "google-secret")
+	setenv(t, "GIRA_OIDC_GOOGLE_REDIRECT_URL", "http://localhost:4000/users/login/oidc/google/callback")
+	setenv(t, "GIRA_OIDC_GOOGLE_LINK_BY_EMAIL", "true")
+	setenv(t, "GIRA_OIDC_CORP_ISSUER", "https://sso.corp.com")
+	setenv(t, "GIRA_OIDC_CORP_CLIENT_ID", "corp-client")
+	setenv(t, "GIRA_OIDC_CORP_REDIRECT_URL", "http://localhost:4000/users/login/oidc/corp/callback")
+
+	config, err := config.NewFromEnv()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"google", "corp"}, config.OIDCProviders)
+	require.Len(t, config.OIDC, 2)
+	require.Equal(t, "google", config.OIDC[0].Name)
+	require.Equal(t, "https://accounts.google.com", config.OIDC[0].Issuer)
+	require.Equal(t, "google-client", config.OIDC[0].ClientID)
+	require.Equal(t, "google-secret", config.OIDC[0].ClientSecret)
+	require.Equal(t, "http://localhost:4000/users/login/oidc/google/callback", config.OIDC[0].RedirectURL)
+	require.True(t, config.OIDC[0].LinkByEmail)
+	require.Equal(t, "corp", config.OIDC[1].Name)
+	require.Equal(t, "https://sso.corp.com", config.OIDC[1].Issuer)
+	require.Equal(t, "corp-client", config.OIDC[1].ClientID)
+	require.Empty(t, config.OIDC[1].ClientSecret)
+	require.False(t, config.OIDC[1].LinkByEmail)
+}
+
+func TestNewConfigOIDCMissingProviderConfig(t *testing.T) {
+	setRequiredEnv(t)
+	setenv(t, "GIRA_OIDC_PROVIDERS", "google")
+
+	config, err := config.NewFromEnv()
+
+	require.Error(t, err)
+	require.Nil(t, config)
+}
+
+func TestNewConfigLogin(t *testing.T) {
+	setRequiredEnv(t)
+	setenv(t, "GIRA_TRUSTED_PROXIES", "10.0.0.0/8,127.0.0.1")
+	setenv(t, "GIRA_LOGIN_MAX_ATTEMPTS", "3")
+	setenv(t, "GIRA_LOGIN_LOCKOUT", "30s")
+
+	config, err := config.NewFromEnv()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/8", "127.0.0.1"}, config.TrustedProxies)
+	require.Equal(t, 3, config.Login.MaxAttempts)
+	require.Equal(t, 20, config.Login.MaxClientAttempts)
+	require.Equal(t, 30*time.Second, config.Login.Lockout)
+	require.Equal(t, time.Hour, config.Login.MaxLockout)
+	require.Equal(t, time.Hour, config.Login.ResetAfter)
+}
+
+func TestNewConfigRateLimit(t *testing.T) {
+	setRequiredEnv(t)
+	setenv(t, "GIRA_RATE_LIMIT_REQUESTS", "50")
+	setenv(t, "GIRA_RATE_LIMIT_LOGIN_PERIOD", "5m")
+
+	config, err := config.NewFromEnv()
+
+	require.NoError(t, err)
+	require.True(t, config.RateLimit.Enabled)
+	require.Equal(t, 50, config.RateLimit.Requests)
+	require.Equal(t, time.Minute, config.RateLimit.Period)
+	require.Equal(t, 10, config.RateLimit.LoginRequests)
+	require.Equal(t, 5*time.Minute, config.RateLimit.LoginPeriod)
+}
+
+func TestNewConfigBlob(t *testing.T) {
+	setRequiredEnv(t)
+
+	config, err := config.NewFromEnv()
+
+	require.NoError(t, err)
+	require.Equal(t, "filesystem", config.Blob.Type)
+	require.Equal(t, "data/blobs", config.Blob.Dir)
+}
+
+func TestNewConfigBlobS3(t *testing.T) {
+	setRequiredEnv(t)
+	setenv(t, "GIRA_BLOB_TYPE", "s3")
+	setenv(t, "GIRA_BLOB_S3_ENDPOINT", "http://localhost:9000")
+	setenv(t, "GIRA_BLOB_S3_BUCKET", "gira")
+	setenv(t, "GIRA_BLOB_S3_ACCESS_KEY", "access-key")
+	setenv(t, "GIRA_BLOB_S3_SECRET_KEY", "secret-key")
+
+	config, err := config.NewFromEnv()
+
+	require.NoError(t, err)
+	require.Equal(t, "s3", config.Blob.Type)
+	require.Equal(t, "http://localhost:9000", config.Blob.S3.Endpoint)
+	require.Equal(t, "us-east-1", config.Blob.S3.Region)
+	require.Equal(t, "gira", config.Blob.S3.Bucket)
+	require.Equal(t, "access-key", config.Blob.S3.AccessKey)
+	require.Equal(t, "secret-key", config.Blob.S3.SecretKey)
+}
+
+func TestNewConfigMetadata(t *testing.T) {
+	setRequiredEnv(t)
+	setenv(t, "GIRA_METADATA_API_KEY", "rawg-key")
+
+	config, err := config.NewFromEnv()
+
+	require.NoError(t, err)
+	require.Equal(t, "https://api.rawg.io/api", config.Metadata.URL)
+	require.Equal(t, "rawg-key", config.Metadata.APIKey)
+	require.Equal(t, 7*24*time.Hour, config.Metadata.CacheTTL)
+}
+
+func TestNewConfigNotifications(t *testing.T) {
+	setRequiredEnv(t)
+	setenv(t, "GIRA_NOTIFICATIONS_INTERVAL", "30m")
+	setenv(t, "GIRA_NOTIFICATIONS_SMTP_HOST", "smtp.example.com")
+	setenv(t, "GIRA_NOTIFICATIONS_SMTP_USERNAME", "gira")
+
+	config, err := config.NewFromEnv()
+
+	require.NoError(t, err)
+	require.True(t, config.Notifications.Enabled)
+	require.Equal(t, 30*time.Minute, config.Notifications.Interval)
+	require.Equal(t, "smtp.example.com", config.Notifications.SMTP.Host)
+	require.Equal(t, 587, config.Notifications.SMTP.Port)
+	require.Equal(t, "gira", config.Notifications.SMTP.Username)
+	require.Equal(t, "gira@localhost", config.Notifications.SMTP.From)
+}
+
+func TestNewConfigWebhooks(t *testing.T) {
+	setRequiredEnv(t)
+	setenv(t, "GIRA_WEBHOOKS_MAX_ATTEMPTS", "3")
+
+	config, err := config.NewFromEnv()
+
+	require.NoError(t, err)
+	require.True(t, config.Webhooks.Enabled)
+	require.Equal(t, 3, config.Webhooks.MaxAttempts)
+	require.Equal(t, time.Second, config.Webhooks.Backoff)
+}
+
 func TestRequiredValues(t *testing.T) {
 	config, err := config.NewFromEnv()
 
@@ -41,6 +184,17 @@ func TestRequiredValues(t *testing.T) {
 	require.Nil(t, config)
 }
 
+func setRequiredEnv(t *testing.T) {
+	setenv(t, "GIRA_PORT", "4000")
+	setenv(t, "GIRA_SECRET", "sec")
+	setenv(t, "GIRA_USE_SSL", "false")
+	setenv(t, "GIRA_DB_HOST", "localhost")
+	setenv(t, "GIRA_DB_PORT", "5432")
+	setenv(t, "GIRA_DB_PASSWORD", "pass")
+	setenv(t, "GIRA_DB_USER", "user")
+	setenv(t, "GIRA_DB_NAME", "name")
+}
+
 func setenv(t *testing.T, key, value string) {
 	t.Helper()
 	t.Cleanup(func() {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/asankov/gira/cmd/api/config"
@@ -10,6 +11,14 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/asankov/gira/internal/auth"
+	"github.com/asankov/gira/internal/blob"
+	"github.com/asankov/gira/internal/live"
+	"github.com/asankov/gira/internal/metadata"
+	"github.com/asankov/gira/internal/middleware"
+	"github.com/asankov/gira/internal/notify"
+	"github.com/asankov/gira/internal/oidc"
+	"github.com/asankov/gira/internal/webhooks"
+	"github.com/asankov/gira/pkg/models"
 	"github.com/asankov/gira/pkg/models/postgres"
 
 	// to register PostreSQL driver
@@ -49,12 +58,140 @@ func run() error {
 	}
 	defer db.Close()
 
+	providers := []*oidc.Provider{}
+	for _, p := range config.OIDC {
+		providers = append(providers, oidc.NewProvider(&oidc.ProviderOptions{
+			Name:         p.Name,
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			LinkByEmail:  p.LinkByEmail,
+		}))
+	}
+
+	trustedProxies, err := middleware.ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("error while parsing trusted proxies: %w", err)
+	}
+
+	// the webhooks of the users and of their notifications share a client, that cannot reach the internal network of the API
+	webhookNetworks, err := webhooks.ParseNetworks(config.Webhooks.AllowedNetworks)
+	if err != nil {
+		return fmt.Errorf("error while parsing webhook allowed networks: %w", err)
+	}
+	webhookClient := webhooks.NewClient(&webhooks.ClientOptions{AllowedNetworks: webhookNetworks})
+
+	var rateLimitStore middleware.Store
+	if config.RateLimit.Enabled {
+		rateLimitStore = middleware.NewMemoryStore()
+	}
+
+	// the metadata provider is optional, so it is left nil, unless configured
+	var metadataProvider server.MetadataProvider
+	if config.Metadata.APIKey != "" {
+		metadataProvider = metadata.NewCached(&metadata.CachedOptions{
+			Provider: metadata.NewRAWG(&metadata.RAWGOptions{
+				URL:    config.Metadata.URL,
+				APIKey: config.Metadata.APIKey,
+			}),
+			Cache: postgres.NewMetadataCacheModel(db),
+			Name:  "rawg",
+			TTL:   config.Metadata.CacheTTL,
+			Log:   log,
+		})
+	}
+
+	var blobStore server.BlobStore
+	switch config.Blob.Type {
+	case "filesystem":
+		blobStore = blob.NewFileSystem(config.Blob.Dir)
+	case "s3":
+		blobStore = blob.NewS3(&blob.S3Options{
+			Endpoint:  config.Blob.S3.Endpoint,
+			Region:    config.Blob.S3.Region,
+			Bucket:    config.Blob.S3.Bucket,
+			AccessKey: config.Blob.S3.AccessKey,
+			SecretKey: config.Blob.S3.SecretKey,
+		})
+	default:
+		return fmt.Errorf("unknown blob store type %q, must be one of filesystem, s3", config.Blob.Type)
+	}
+
+	notificationModel := postgres.NewNotificationModel(db)
+	if config.Notifications.Enabled {
+		notifiers := map[models.NotificationChannel]notify.Notifier{
+			models.NotificationInApp:   notify.NewInApp(notificationModel),
+			models.NotificationWebhook: notify.NewWebhook(webhookClient),
+		}
+		// the email notifications are optional, so they are skipped, unless an SMTP server is configured
+		if smtp := config.Notifications.SMTP; smtp.Host != "" {
+			notifiers[models.NotificationEmail] = notify.NewEmail(&notify.EmailOptions{
+				Host:     smtp.Host,
+				Port:     smtp.Port,
+				Username: smtp.Username,
+				Password: smtp.Password,
+				From:     smtp.From,
+			})
+		}
+
+		scheduler := notify.NewScheduler(&notify.SchedulerOptions{
+			Store:     notificationModel,
+			Notifiers: notifiers,
+			Interval:  config.Notifications.Interval,
+			Log:       log,
+		})
+		go scheduler.Run(context.Background())
+	}
+
+	webhookModel := postgres.NewWebhookModel(db)
+	// the webhooks can be disabled, e.g. if the API must not make outgoing requests, so the dispatcher is left nil then
+	var webhookDispatcher server.WebhookDispatcher
+	if config.Webhooks.Enabled {
+		webhookDispatcher = webhooks.NewDispatcher(&webhooks.DispatcherOptions{
+			Store:       webhookModel,
+			Client:      webhookClient,
+			MaxAttempts: config.Webhooks.MaxAttempts,
+			Backoff:     config.Webhooks.Backoff,
+			Log:         log,
+		})
+	}
+
 	s := &server.Server{
 		Log:            log,
-		GameModel:      postgres.NewGameModel(db),
-		UserModel:      postgres.NewUserModel(db),
-		FranchiseModel: postgres.NewFranchiseModel(db),
-		Authenticator:  auth.NewAutheniticator(config.Secret),
+		TrustedProxies: trustedProxies,
+		RateLimitStore: rateLimitStore,
+		RateLimits: &server.RateLimits{
+			Default: middleware.Limit{Requests: config.RateLimit.Requests, Period: config.RateLimit.Period},
+			Login:   middleware.Limit{Requests: config.RateLimit.LoginRequests, Period: config.RateLimit.LoginPeriod},
+		},
+		GameModel:         postgres.NewGameModel(db),
+		UserModel:         postgres.NewUserModel(db),
+		FranchiseModel:    postgres.NewFranchiseModel(db),
+		StatsModel:        postgres.NewStatsModel(db),
+		ExportModel:       postgres.NewExportModel(db),
+		MetadataProvider:  metadataProvider,
+		CoverModel:        postgres.NewCoverModel(db),
+		ChecklistModel:    postgres.NewChecklistModel(db),
+		PlaythroughModel:  postgres.NewPlaythroughModel(db),
+		SocialModel:       postgres.NewSocialModel(db),
+		EventModel:        postgres.NewEventModel(db),
+		BoardModel:        postgres.NewBoardModel(db),
+		GoalModel:         postgres.NewGoalModel(db),
+		NotificationModel: notificationModel,
+		WebhookModel:      webhookModel,
+		WebhookDispatcher: webhookDispatcher,
+		ChangeBroker:      live.NewBroker(&live.BrokerOptions{Log: log}),
+		BlobStore:         blobStore,
+		Authenticator:     auth.NewAutheniticator(config.Secret),
+		IdentityVerifier:  oidc.NewRegistry(providers...),
+		LoginThrottler: auth.NewThrottler(&auth.ThrottlerOptions{
+			AccountAttempts: config.Login.MaxAttempts,
+			ClientAttempts:  config.Login.MaxClientAttempts,
+			Lockout:         config.Login.Lockout,
+			MaxLockout:      config.Login.MaxLockout,
+			ResetAfter:      config.Login.ResetAfter,
+		}),
 	}
 
 	if err := s.Start(config.Port); err != nil {
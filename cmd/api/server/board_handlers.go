@@ -0,0 +1,221 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/gorilla/mux"
+)
+
+const maxBoardNameLength = 255
+
+func (s *Server) handleBoardsGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		boards, err := s.BoardModel.Boards(user.ID)
+		if err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, &models.BoardsResponse{Boards: boards}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleBoardCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var req models.BoardRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.respondError(w, r, "error decoding body", http.StatusBadRequest)
+			return
+		}
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			s.respondError(w, r, "'name' is required", http.StatusBadRequest)
+			return
+		}
+		if len(name) > maxBoardNameLength {
+			s.respondError(w, r, "'name' is too long", http.StatusBadRequest)
+			return
+		}
+
+		board, err := s.BoardModel.CreateBoard(user.ID, name)
+		if err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, board, http.StatusCreated)
+	}
+}
+
+func (s *Server) handleBoardGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		board, err := s.BoardModel.Board(user.ID, mux.Vars(r)["id"])
+		if err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, board, http.StatusOK)
+	}
+}
+
+func (s *Server) handleBoardDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if err := s.BoardModel.DeleteBoard(user.ID, mux.Vars(r)["id"]); err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) handleBoardGamesGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		games, err := s.GameModel.AllForBoard(user.ID, mux.Vars(r)["id"])
+		if err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, &models.GamesResponse{Games: games}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleBoardInvitationCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		req, ok := s.decodeBoardMember(w, r)
+		if !ok {
+			return
+		}
+		if req.Username == "" {
+			s.respondError(w, r, "'username' is required", http.StatusBadRequest)
+			return
+		}
+
+		invitation, err := s.BoardModel.InviteToBoard(user.ID, mux.Vars(r)["id"], req.Username, req.Role)
+		if err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, invitation, http.StatusCreated)
+	}
+}
+
+func (s *Server) handleBoardMemberPatch() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		req, ok := s.decodeBoardMember(w, r)
+		if !ok {
+			return
+		}
+
+		args := mux.Vars(r)
+		if err := s.BoardModel.ChangeMemberRole(user.ID, args["id"], args["username"], req.Role); err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		board, err := s.BoardModel.Board(user.ID, args["id"])
+		if err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, board, http.StatusOK)
+	}
+}
+
+func (s *Server) handleBoardMemberDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		args := mux.Vars(r)
+		if err := s.BoardModel.RemoveMember(user.ID, args["id"], args["username"]); err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) handleInvitationsGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		invitations, err := s.BoardModel.Invitations(user.ID)
+		if err != nil {
+			s.boardError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, &models.BoardInvitationsResponse{Invitations: invitations}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleInvitationAccept() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		board, err := s.BoardModel.AcceptInvitation(user.ID, mux.Vars(r)["id"])
+		if err != nil {
+			s.invitationError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, board, http.StatusOK)
+	}
+}
+
+func (s *Server) handleInvitationDecline() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if err := s.BoardModel.DeclineInvitation(user.ID, mux.Vars(r)["id"]); err != nil {
+			s.invitationError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// decodeBoardMember decodes and validates the body of the requests, that invite or change a member of a board.
+// The role defaults to editor. If the body is not valid, it responds with an error and returns false.
+func (s *Server) decodeBoardMember(w http.ResponseWriter, r *http.Request) (*models.BoardMemberRequest, bool) {
+	var req models.BoardMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, r, "error decoding body", http.StatusBadRequest)
+		return nil, false
+	}
+	req.Username = strings.TrimSpace(req.Username)
+	if req.Role == "" {
+		req.Role = models.BoardRoleEditor
+	}
+	if err := req.Role.Validate(); err != nil {
+		s.respondError(w, r, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return &req, true
+}
+
+func (s *Server) boardError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, postgres.ErrNoRecord):
+		s.respondError(w, r, "Board or member not found", http.StatusNotFound)
+	case errors.Is(err, postgres.ErrForbidden):
+		s.respondError(w, r, "Your role on the board does not allow that", http.StatusForbidden)
+	case errors.Is(err, postgres.ErrUnknownUser), errors.Is(err, postgres.ErrAlreadyMember), errors.Is(err, postgres.ErrLastOwner):
+		s.respondError(w, r, err.Error(), http.StatusBadRequest)
+	default:
+		s.Log.Errorf("Error while processing board: %v", err)
+		s.internalError(w, r)
+	}
+}
+
+func (s *Server) invitationError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, postgres.ErrNoRecord) {
+		s.respondError(w, r, "Invitation not found", http.StatusNotFound)
+		return
+	}
+	s.Log.Errorf("Error while processing invitation: %v", err)
+	s.internalError(w, r)
+}
@@ -0,0 +1,361 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var coopBoard = &models.Board{
+	ID:   "1",
+	Name: "Co-op",
+	Role: models.BoardRoleOwner,
+	Members: []*models.BoardMember{
+		{Username: user.Username, Role: models.BoardRoleOwner},
+		{Username: "ivan", Role: models.BoardRoleEditor},
+	},
+}
+
+func TestBoard(t *testing.T) {
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		expect       func(*fixtures.BoardModelMock)
+		expectedCode int
+		expected     *models.Board
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			path:   "/boards",
+			body:   `{"name": " Co-op "}`,
+			expect: func(m *fixtures.BoardModelMock) {
+				m.EXPECT().CreateBoard(user.ID, "Co-op").Return(&models.Board{ID: "1", Name: "Co-op", Role: models.BoardRoleOwner}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expected:     &models.Board{ID: "1", Name: "Co-op", Role: models.BoardRoleOwner},
+		},
+		{
+			name:   "Get",
+			method: http.MethodGet,
+			path:   "/boards/1",
+			expect: func(m *fixtures.BoardModelMock) {
+				m.EXPECT().Board(user.ID, "1").Return(coopBoard, nil)
+			},
+			expectedCode: http.StatusOK,
+			expected:     coopBoard,
+		},
+		{
+			name:   "Change role",
+			method: http.MethodPatch,
+			path:   "/boards/1/members/ivan",
+			body:   `{"role": "viewer"}`,
+			expect: func(m *fixtures.BoardModelMock) {
+				m.EXPECT().ChangeMemberRole(user.ID, "1", "ivan", models.BoardRoleViewer).Return(nil)
+				m.EXPECT().Board(user.ID, "1").Return(coopBoard, nil)
+			},
+			expectedCode: http.StatusOK,
+			expected:     coopBoard,
+		},
+		{
+			name:   "Accept invitation",
+			method: http.MethodPost,
+			path:   "/invitations/3/accept",
+			expect: func(m *fixtures.BoardModelMock) {
+				m.EXPECT().AcceptInvitation(user.ID, "3").Return(&models.Board{ID: "1", Name: "Co-op", Role: models.BoardRoleEditor}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expected:     &models.Board{ID: "1", Name: "Co-op", Role: models.BoardRoleEditor},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			boardModel := fixtures.NewBoardModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: boardModel, GameModel: fixtures.NewGameModelMock(ctrl)})
+			testCase.expect(boardModel)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(testCase.method, testCase.path, strings.NewReader(testCase.body)))
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+
+			var res models.Board
+			fixtures.Decode(t, w.Body, &res)
+			assert.Equal(t, testCase.expected, &res)
+		})
+	}
+}
+
+func TestBoardsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	boards := []*models.Board{{ID: "1", Name: "Co-op", Role: models.BoardRoleViewer}}
+	boardModel := fixtures.NewBoardModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: boardModel, GameModel: fixtures.NewGameModelMock(ctrl)})
+	boardModel.EXPECT().
+		Boards(user.ID).
+		Return(boards, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/boards", nil))
+
+	gassert.StatusOK(t, w)
+
+	var res models.BoardsResponse
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, boards, res.Boards)
+}
+
+func TestBoardGamesGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	games := []*models.Game{{ID: "1", Name: "It Takes Two", Status: models.StatusInProgress, BoardID: "1"}}
+	gameModel := fixtures.NewGameModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: fixtures.NewBoardModelMock(ctrl), GameModel: gameModel})
+	gameModel.EXPECT().
+		AllForBoard(user.ID, "1").
+		Return(games, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/boards/1/games", nil))
+
+	gassert.StatusOK(t, w)
+
+	var res models.GamesResponse
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, games, res.Games)
+}
+
+func TestBoardInvitationCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invitation := &models.BoardInvitation{ID: "3", BoardID: "1", BoardName: "Co-op", Username: "ivan", Role: models.BoardRoleEditor, InvitedBy: user.Username}
+	boardModel := fixtures.NewBoardModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: boardModel, GameModel: fixtures.NewGameModelMock(ctrl)})
+	boardModel.EXPECT().
+		InviteToBoard(user.ID, "1", "ivan", models.BoardRoleEditor).
+		Return(invitation, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/boards/1/invitations", strings.NewReader(`{"username": "ivan"}`)))
+
+	gassert.StatusCode(t, w, http.StatusCreated)
+
+	var res models.BoardInvitation
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, invitation, &res)
+}
+
+func TestInvitationsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invitations := []*models.BoardInvitation{{ID: "3", BoardID: "1", BoardName: "Co-op", Username: user.Username, Role: models.BoardRoleViewer, InvitedBy: "ivan"}}
+	boardModel := fixtures.NewBoardModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: boardModel, GameModel: fixtures.NewGameModelMock(ctrl)})
+	boardModel.EXPECT().
+		Invitations(user.ID).
+		Return(invitations, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/invitations", nil))
+
+	gassert.StatusOK(t, w)
+
+	var res models.BoardInvitationsResponse
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, invitations, res.Invitations)
+}
+
+func TestBoardNoContent(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		expect func(*fixtures.BoardModelMock)
+	}{
+		{
+			name:   "Delete board",
+			method: http.MethodDelete,
+			path:   "/boards/1",
+			expect: func(m *fixtures.BoardModelMock) {
+				m.EXPECT().DeleteBoard(user.ID, "1").Return(nil)
+			},
+		},
+		{
+			name:   "Remove member",
+			method: http.MethodDelete,
+			path:   "/boards/1/members/ivan",
+			expect: func(m *fixtures.BoardModelMock) {
+				m.EXPECT().RemoveMember(user.ID, "1", "ivan").Return(nil)
+			},
+		},
+		{
+			name:   "Decline invitation",
+			method: http.MethodPost,
+			path:   "/invitations/3/decline",
+			expect: func(m *fixtures.BoardModelMock) {
+				m.EXPECT().DeclineInvitation(user.ID, "3").Return(nil)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			boardModel := fixtures.NewBoardModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: boardModel, GameModel: fixtures.NewGameModelMock(ctrl)})
+			testCase.expect(boardModel)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(testCase.method, testCase.path, nil))
+
+			gassert.StatusCode(t, w, http.StatusNoContent)
+		})
+	}
+}
+
+func TestBoardBadRequest(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "No name", method: http.MethodPost, path: "/boards", body: `{"name": " "}`},
+		{name: "Name too long", method: http.MethodPost, path: "/boards", body: `{"name": "` + strings.Repeat("a", 256) + `"}`},
+		{name: "No username", method: http.MethodPost, path: "/boards/1/invitations", body: `{"role": "viewer"}`},
+		{name: "Invalid role", method: http.MethodPatch, path: "/boards/1/members/ivan", body: `{"role": "admin"}`},
+		{name: "Invalid body", method: http.MethodPost, path: "/boards/1/invitations", body: `[]`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: fixtures.NewBoardModelMock(ctrl), GameModel: fixtures.NewGameModelMock(ctrl)})
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(testCase.method, testCase.path, strings.NewReader(testCase.body)))
+
+			gassert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestBoardError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "Not found", err: postgres.ErrNoRecord, expectedCode: http.StatusNotFound},
+		{name: "Not an owner", err: postgres.ErrForbidden, expectedCode: http.StatusForbidden},
+		{name: "Unknown user", err: postgres.ErrUnknownUser, expectedCode: http.StatusBadRequest},
+		{name: "Already a member", err: postgres.ErrAlreadyMember, expectedCode: http.StatusBadRequest},
+		{name: "DB error", err: errors.New("this is an intentional error"), expectedCode: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			boardModel := fixtures.NewBoardModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: boardModel, GameModel: fixtures.NewGameModelMock(ctrl)})
+			boardModel.EXPECT().
+				InviteToBoard(user.ID, "1", "ivan", models.BoardRoleViewer).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/boards/1/invitations", strings.NewReader(`{"username": "ivan", "role": "viewer"}`)))
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
+
+func TestBoardLastOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	boardModel := fixtures.NewBoardModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: boardModel, GameModel: fixtures.NewGameModelMock(ctrl)})
+	boardModel.EXPECT().
+		RemoveMember(user.ID, "1", user.Username).
+		Return(postgres.ErrLastOwner)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodDelete, "/boards/1/members/"+user.Username, nil))
+
+	gassert.StatusCode(t, w, http.StatusBadRequest)
+}
+
+func TestBoardGameForbidden(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		expect func(*fixtures.GameModelMock)
+	}{
+		{
+			name:   "Add game as a viewer",
+			method: http.MethodPost,
+			path:   "/games",
+			body:   `{"name": "It Takes Two", "boardId": "1"}`,
+			expect: func(m *fixtures.GameModelMock) {
+				m.EXPECT().Insert(&models.Game{Name: "It Takes Two", BoardID: "1", UserID: user.ID}).Return(nil, postgres.ErrForbidden)
+			},
+		},
+		{
+			name:   "Change status as a viewer",
+			method: http.MethodPatch,
+			path:   "/games/1",
+			body:   `{"status": "Done"}`,
+			expect: func(m *fixtures.GameModelMock) {
+				m.EXPECT().ChangeGameStatus(user.ID, "1", models.StatusDone).Return(postgres.ErrForbidden)
+			},
+		},
+		{
+			name:   "Delete game as a viewer",
+			method: http.MethodDelete,
+			path:   "/games/1",
+			expect: func(m *fixtures.GameModelMock) {
+				m.EXPECT().DeleteGame(user.ID, "1").Return(postgres.ErrForbidden)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gameModel := fixtures.NewGameModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{BoardModel: fixtures.NewBoardModelMock(ctrl), GameModel: gameModel})
+			testCase.expect(gameModel)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(testCase.method, testCase.path, strings.NewReader(testCase.body)))
+
+			gassert.StatusCode(t, w, http.StatusForbidden)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/gorilla/mux"
+)
+
+// maxChecklistItemName is the maximum length of the name of a checklist item
+const maxChecklistItemName = 255
+
+func (s *Server) handleChecklistGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		checklist, err := s.ChecklistModel.Checklist(user.ID, mux.Vars(r)["id"])
+		s.respondChecklist(w, r, user, checklist, err)
+	}
+}
+
+func (s *Server) handleChecklistPatch() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var req models.ChecklistRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.respondError(w, r, "error decoding body", http.StatusBadRequest)
+			return
+		}
+		if req.AutoProgress == nil {
+			s.respondError(w, r, "'autoProgress' is required", http.StatusBadRequest)
+			return
+		}
+
+		checklist, err := s.ChecklistModel.SetChecklistAutoProgress(user.ID, mux.Vars(r)["id"], *req.AutoProgress)
+		s.respondChecklist(w, r, user, checklist, err)
+	}
+}
+
+func (s *Server) handleChecklistItemCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		req, ok := s.decodeChecklistItem(w, r)
+		if !ok {
+			return
+		}
+		if req.Name == "" {
+			s.respondError(w, r, "'name' is required", http.StatusBadRequest)
+			return
+		}
+
+		checklist, err := s.ChecklistModel.AddChecklistItem(user.ID, mux.Vars(r)["id"], req.Name)
+		s.respondChecklist(w, r, user, checklist, err)
+	}
+}
+
+func (s *Server) handleChecklistItemPatch() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		req, ok := s.decodeChecklistItem(w, r)
+		if !ok {
+			return
+		}
+		if req.Name == "" && req.Done == nil {
+			s.respondError(w, r, "'name' or 'done' is required", http.StatusBadRequest)
+			return
+		}
+
+		vars := mux.Vars(r)
+		checklist, err := s.ChecklistModel.UpdateChecklistItem(user.ID, vars["id"], vars["itemId"], req.Name, req.Done)
+		s.respondChecklist(w, r, user, checklist, err)
+	}
+}
+
+func (s *Server) handleChecklistItemDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		vars := mux.Vars(r)
+		checklist, err := s.ChecklistModel.DeleteChecklistItem(user.ID, vars["id"], vars["itemId"])
+		s.respondChecklist(w, r, user, checklist, err)
+	}
+}
+
+func (s *Server) decodeChecklistItem(w http.ResponseWriter, r *http.Request) (*models.ChecklistItemRequest, bool) {
+	var req models.ChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, r, "error decoding body", http.StatusBadRequest)
+		return nil, false
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if len(req.Name) > maxChecklistItemName {
+		s.respondError(w, r, "'name' is too long", http.StatusBadRequest)
+		return nil, false
+	}
+	return &req, true
+}
+
+// respondChecklist responds with the checklist, or with the error returned while fetching or changing it.
+// If the change of the checklist changed the progress of the game, the change is announced.
+func (s *Server) respondChecklist(w http.ResponseWriter, r *http.Request, user *models.User, checklist *models.Checklist, err error) {
+	if err != nil {
+		s.gameChangeError(w, r, "Game or checklist item not found", "changing checklist", err)
+		return
+	}
+	if checklist.ProgressChanged {
+		gameID := mux.Vars(r)["id"]
+		s.gameChanged(user.ID, s.gameViewers(gameID), &models.Change{Type: models.ChangeGameUpdated, GameID: gameID, Progress: checklist.Progress})
+	}
+
+	s.respond(w, r, checklist, http.StatusOK)
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var checklist = &models.Checklist{
+	Items: []*models.ChecklistItem{
+		{ID: "1", Name: "Find all feathers", Done: true},
+		{ID: "2", Name: "Kill all templars"},
+	},
+	AutoProgress: true,
+	Progress:     &models.GameProgress{Current: 1, Final: 2},
+}
+
+func TestChecklist(t *testing.T) {
+	done := true
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		expect func(*fixtures.ChecklistModelMock)
+	}{
+		{
+			name:   "Get",
+			method: http.MethodGet,
+			path:   "/games/1/checklist",
+			expect: func(m *fixtures.ChecklistModelMock) {
+				m.EXPECT().Checklist(user.ID, "1").Return(checklist, nil)
+			},
+		},
+		{
+			name:   "Set auto progress",
+			method: http.MethodPatch,
+			path:   "/games/1/checklist",
+			body:   `{"autoProgress": true}`,
+			expect: func(m *fixtures.ChecklistModelMock) {
+				m.EXPECT().SetChecklistAutoProgress(user.ID, "1", true).Return(checklist, nil)
+			},
+		},
+		{
+			name:   "Add item",
+			method: http.MethodPost,
+			path:   "/games/1/checklist/items",
+			body:   `{"name": "  Kill all templars "}`,
+			expect: func(m *fixtures.ChecklistModelMock) {
+				m.EXPECT().AddChecklistItem(user.ID, "1", "Kill all templars").Return(checklist, nil)
+			},
+		},
+		{
+			name:   "Tick off item",
+			method: http.MethodPatch,
+			path:   "/games/1/checklist/items/1",
+			body:   `{"done": true}`,
+			expect: func(m *fixtures.ChecklistModelMock) {
+				m.EXPECT().UpdateChecklistItem(user.ID, "1", "1", "", &done).Return(checklist, nil)
+			},
+		},
+		{
+			name:   "Rename item",
+			method: http.MethodPatch,
+			path:   "/games/1/checklist/items/1",
+			body:   `{"name": "Find all the feathers"}`,
+			expect: func(m *fixtures.ChecklistModelMock) {
+				m.EXPECT().UpdateChecklistItem(user.ID, "1", "1", "Find all the feathers", nil).Return(checklist, nil)
+			},
+		},
+		{
+			name:   "Delete item",
+			method: http.MethodDelete,
+			path:   "/games/1/checklist/items/1",
+			expect: func(m *fixtures.ChecklistModelMock) {
+				m.EXPECT().DeleteChecklistItem(user.ID, "1", "1").Return(checklist, nil)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			checklistModel := fixtures.NewChecklistModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{ChecklistModel: checklistModel})
+			testCase.expect(checklistModel)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(testCase.method, testCase.path, strings.NewReader(testCase.body)))
+
+			gassert.StatusOK(t, w)
+
+			var res models.Checklist
+			fixtures.Decode(t, w.Body, &res)
+			assert.Equal(t, checklist, &res)
+		})
+	}
+}
+
+func TestChecklistBadRequest(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "Invalid body", method: http.MethodPost, path: "/games/1/checklist/items", body: "{"},
+		{name: "No name", method: http.MethodPost, path: "/games/1/checklist/items", body: `{"name": "  "}`},
+		{name: "Too long name", method: http.MethodPost, path: "/games/1/checklist/items", body: `{"name": "` + strings.Repeat("a", 256) + `"}`},
+		{name: "No changes", method: http.MethodPatch, path: "/games/1/checklist/items/1", body: `{}`},
+		{name: "No auto progress", method: http.MethodPatch, path: "/games/1/checklist", body: `{}`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := newAuthorizedServer(t, ctrl, &Options{ChecklistModel: fixtures.NewChecklistModelMock(ctrl)})
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(testCase.method, testCase.path, strings.NewReader(testCase.body)))
+
+			gassert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestChecklistError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "Not found", err: postgres.ErrNoRecord, expectedCode: http.StatusNotFound},
+		{name: "Board viewer", err: postgres.ErrForbidden, expectedCode: http.StatusForbidden},
+		{name: "DB error", err: errors.New("this is an intentional error"), expectedCode: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			checklistModel := fixtures.NewChecklistModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{ChecklistModel: checklistModel})
+			checklistModel.EXPECT().
+				DeleteChecklistItem(user.ID, "1", "3").
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodDelete, "/games/1/checklist/items/3", nil))
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
+
+// the viewers of a board can see the checklists of its games, but can not tick their items
+func TestChecklistItemPatchBoardViewer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checklistModel := fixtures.NewChecklistModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{ChecklistModel: checklistModel})
+	done := true
+	checklistModel.EXPECT().
+		UpdateChecklistItem(user.ID, "1", "3", "", &done).
+		Return(nil, postgres.ErrForbidden)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPatch, "/games/1/checklist/items/3", strings.NewReader(`{"done":true}`)))
+
+	gassert.StatusCode(t, w, http.StatusForbidden)
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/asankov/gira/internal/blob"
+	"github.com/asankov/gira/internal/images"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// maxCoverSize is the maximum size of an uploaded cover
+	maxCoverSize int64 = 10 << 20
+	// coverFullSize is the maximum width and height of a stored cover
+	coverFullSize = 1024
+	// coverThumbnailSize is the maximum width and height of the thumbnail of a cover, shown in lists
+	coverThumbnailSize = 200
+
+	coverSizeFull      = "full"
+	coverSizeThumbnail = "thumbnail"
+)
+
+var errNoBlobStore = errors.New("no blob store is configured")
+
+// coverKey returns the key, under which the cover of the given size of the given game or franchise is stored.
+func coverKey(kind models.CoverKind, id, size string) string {
+	return fmt.Sprintf("covers/%s/%s/%s.jpg", kind, id, size)
+}
+
+// handleCoverPut uploads the cover of the given game or franchise. The body is the image itself - a JPEG, PNG or GIF.
+// The image is stored scaled down, along with a thumbnail, both as JPEG.
+func (s *Server) handleCoverPut(kind models.CoverKind) authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if s.BlobStore == nil {
+			s.respondError(w, r, errNoBlobStore.Error(), http.StatusNotImplemented)
+			return
+		}
+
+		id := mux.Vars(r)["id"]
+		if _, ok := s.coverUpdatedAt(w, r, user, kind, id, true); !ok {
+			return
+		}
+
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCoverSize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				s.respondError(w, r, fmt.Sprintf("The cover can be at most %d MB", maxCoverSize>>20), http.StatusRequestEntityTooLarge)
+				return
+			}
+			s.respondError(w, r, "Error reading body", http.StatusBadRequest)
+			return
+		}
+		img, err := images.Decode(data)
+		if err != nil {
+			s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		for size, maxSize := range map[string]int{coverSizeFull: coverFullSize, coverSizeThumbnail: coverThumbnailSize} {
+			encoded, err := images.EncodeJPEG(images.Fit(img, maxSize))
+			if err != nil {
+				s.Log.Errorf("Error while encoding cover of %s %s: %v", kind, id, err)
+				s.internalError(w, r)
+				return
+			}
+			if err := s.BlobStore.Put(r.Context(), coverKey(kind, id, size), "image/jpeg", encoded); err != nil {
+				s.Log.Errorf("Error while storing cover of %s %s: %v", kind, id, err)
+				s.internalError(w, r)
+				return
+			}
+		}
+
+		updatedAt := time.Now().UTC()
+		if err := s.CoverModel.SetCoverUpdatedAt(user.ID, kind, id, &updatedAt); err != nil {
+			s.Log.Errorf("Error while updating cover of %s %s: %v", kind, id, err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, models.Cover{UpdatedAt: &updatedAt}, http.StatusOK)
+	}
+}
+
+// handleCoverGet returns the cover of the given game or franchise as an image.
+// The size query parameter selects between the full cover (the default) and the thumbnail.
+func (s *Server) handleCoverGet(kind models.CoverKind) authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if s.BlobStore == nil {
+			s.respondError(w, r, errNoBlobStore.Error(), http.StatusNotImplemented)
+			return
+		}
+
+		size := r.URL.Query().Get("size")
+		if size == "" {
+			size = coverSizeFull
+		}
+		if size != coverSizeFull && size != coverSizeThumbnail {
+			s.respondError(w, r, fmt.Sprintf("size must be one of %s, %s", coverSizeFull, coverSizeThumbnail), http.StatusBadRequest)
+			return
+		}
+
+		id := mux.Vars(r)["id"]
+		updatedAt, ok := s.coverUpdatedAt(w, r, user, kind, id, false)
+		if !ok {
+			return
+		}
+		if updatedAt == nil {
+			s.respondError(w, r, "Cover not found", http.StatusNotFound)
+			return
+		}
+
+		object, err := s.BlobStore.Get(r.Context(), coverKey(kind, id, size))
+		if err != nil {
+			if errors.Is(err, blob.ErrNotFound) {
+				s.respondError(w, r, "Cover not found", http.StatusNotFound)
+				return
+			}
+			s.Log.Errorf("Error while fetching cover of %s %s: %v", kind, id, err)
+			s.internalError(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", object.ContentType)
+		w.Header().Set("Last-Modified", updatedAt.UTC().Format(http.TimeFormat))
+		w.Header().Set("Cache-Control", "private, max-age=86400")
+		if _, err := w.Write(object.Data); err != nil {
+			s.Log.Errorf("Error while writing cover of %s %s: %v", kind, id, err)
+		}
+	}
+}
+
+// handleCoverDelete deletes the cover of the given game or franchise.
+func (s *Server) handleCoverDelete(kind models.CoverKind) authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if s.BlobStore == nil {
+			s.respondError(w, r, errNoBlobStore.Error(), http.StatusNotImplemented)
+			return
+		}
+
+		id := mux.Vars(r)["id"]
+		if _, ok := s.coverUpdatedAt(w, r, user, kind, id, true); !ok {
+			return
+		}
+
+		if err := s.CoverModel.SetCoverUpdatedAt(user.ID, kind, id, nil); err != nil {
+			s.Log.Errorf("Error while updating cover of %s %s: %v", kind, id, err)
+			s.internalError(w, r)
+			return
+		}
+		s.deleteCover(r.Context(), kind, id)
+
+		s.respond(w, r, nil, http.StatusOK)
+	}
+}
+
+// coverUpdatedAt returns the time the cover of the given game or franchise was uploaded.
+// If write is true, the user must be able to change the game or franchise.
+// If the user does not have such game or franchise, or the time could not be fetched, it responds with an error and returns false.
+func (s *Server) coverUpdatedAt(w http.ResponseWriter, r *http.Request, user *models.User, kind models.CoverKind, id string, write bool) (*time.Time, bool) {
+	updatedAt, err := s.CoverModel.CoverUpdatedAt(user.ID, kind, id, write)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNoRecord) {
+			s.respondError(w, r, "Not found", http.StatusNotFound)
+			return nil, false
+		}
+		if errors.Is(err, postgres.ErrForbidden) {
+			s.respondError(w, r, "Your role does not allow changing the cover", http.StatusForbidden)
+			return nil, false
+		}
+		s.Log.Errorf("Error while fetching cover of %s %s: %v", kind, id, err)
+		s.internalError(w, r)
+		return nil, false
+	}
+	return updatedAt, true
+}
+
+// deleteCover deletes the stored images of the cover of the given game or franchise.
+// The cover is already unlinked from it, so errors are only logged.
+func (s *Server) deleteCover(ctx context.Context, kind models.CoverKind, id string) {
+	if s.BlobStore == nil {
+		return
+	}
+	for _, size := range []string{coverSizeFull, coverSizeThumbnail} {
+		if err := s.BlobStore.Delete(ctx, coverKey(kind, id, size)); err != nil {
+			s.Log.Errorf("Error while deleting cover of %s %s: %v", kind, id, err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/blob"
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type coverMocks struct {
+	coverModel *fixtures.CoverModelMock
+	store      *blob.FileSystem
+}
+
+// newCoverMocks returns the mocks of a server, that stores the covers in a temporary directory
+func newCoverMocks(t *testing.T, ctrl *gomock.Controller) *coverMocks {
+	return &coverMocks{
+		coverModel: fixtures.NewCoverModelMock(ctrl),
+		store:      blob.NewFileSystem(t.TempDir()),
+	}
+}
+
+func (m *coverMocks) options() *Options {
+	return &Options{
+		CoverModel: m.coverModel,
+		BlobStore:  m.store,
+	}
+}
+
+func pngImage(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestCoverPut(t *testing.T) {
+	testCases := []struct {
+		name string
+		path string
+		kind models.CoverKind
+	}{
+		{name: "Game", path: "/games/1/cover", kind: models.CoverKindGame},
+		{name: "Franchise", path: "/franchises/1/cover", kind: models.CoverKindFranchise},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := newCoverMocks(t, ctrl)
+			srv := newAuthorizedServer(t, ctrl, mocks.options())
+			mocks.coverModel.EXPECT().
+				CoverUpdatedAt(user.ID, testCase.kind, "1", true).
+				Return(nil, nil)
+			mocks.coverModel.EXPECT().
+				SetCoverUpdatedAt(user.ID, testCase.kind, "1", gomock.Not(gomock.Nil())).
+				Return(nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPut, testCase.path, bytes.NewReader(pngImage(t, 2000, 1000))))
+
+			gassert.StatusOK(t, w)
+			var cover models.Cover
+			fixtures.Decode(t, w.Body, &cover)
+			assert.NotNil(t, cover.UpdatedAt)
+
+			for size, expected := range map[string]image.Rectangle{
+				"full":      image.Rect(0, 0, 1024, 512),
+				"thumbnail": image.Rect(0, 0, 200, 100),
+			} {
+				object, err := mocks.store.Get(context.Background(), "covers/"+string(testCase.kind)+"/1/"+size+".jpg")
+				require.NoError(t, err)
+				config, format, err := image.DecodeConfig(bytes.NewReader(object.Data))
+				require.NoError(t, err)
+				assert.Equal(t, "jpeg", format)
+				assert.Equal(t, expected, image.Rect(0, 0, config.Width, config.Height))
+			}
+		})
+	}
+}
+
+func TestCoverPutInvalidImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newCoverMocks(t, ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.coverModel.EXPECT().
+		CoverUpdatedAt(user.ID, models.CoverKindGame, "1", true).
+		Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/games/1/cover", bytes.NewReader([]byte("not an image"))))
+
+	gassert.StatusCode(t, w, http.StatusBadRequest)
+}
+
+func TestCoverPutTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newCoverMocks(t, ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.coverModel.EXPECT().
+		CoverUpdatedAt(user.ID, models.CoverKindGame, "1", true).
+		Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/games/1/cover", bytes.NewReader(make([]byte, maxCoverSize+1))))
+
+	gassert.StatusCode(t, w, http.StatusRequestEntityTooLarge)
+}
+
+func TestCoverPutNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newCoverMocks(t, ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.coverModel.EXPECT().
+		CoverUpdatedAt(user.ID, models.CoverKindGame, "1", true).
+		Return(nil, postgres.ErrNoRecord)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/games/1/cover", bytes.NewReader(pngImage(t, 10, 10))))
+
+	gassert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestCoverPutStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authenticator := fixtures.NewAuthenticatorMock(ctrl)
+	userModel := fixtures.NewUserModelMock(ctrl)
+	coverModel := fixtures.NewCoverModelMock(ctrl)
+	store := fixtures.NewBlobStoreMock(ctrl)
+	srv := newServer(t, &Options{
+		Authenticator: authenticator,
+		UserModel:     userModel,
+		CoverModel:    coverModel,
+		BlobStore:     store,
+	})
+
+	authenticator.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(nil, nil)
+	userModel.EXPECT().
+		GetUserByToken(gomock.Eq(token)).
+		Return(user, nil)
+	coverModel.EXPECT().
+		CoverUpdatedAt(user.ID, models.CoverKindGame, "1", true).
+		Return(nil, nil)
+	store.EXPECT().
+		Put(gomock.Any(), gomock.Any(), "image/jpeg", gomock.Any()).
+		Return(errors.New("intentional error"))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/games/1/cover", bytes.NewReader(pngImage(t, 10, 10))))
+
+	gassert.StatusCode(t, w, http.StatusInternalServerError)
+}
+
+func TestCoverGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newCoverMocks(t, ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	updatedAt := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	mocks.coverModel.EXPECT().
+		CoverUpdatedAt(user.ID, models.CoverKindGame, "1", false).
+		Return(&updatedAt, nil)
+	require.NoError(t, mocks.store.Put(context.Background(), "covers/games/1/thumbnail.jpg", "image/jpeg", []byte("thumbnail")))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/games/1/cover?size=thumbnail", nil))
+
+	gassert.StatusOK(t, w)
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Tue, 01 Mar 2022 10:00:00 GMT", w.Header().Get("Last-Modified"))
+	assert.Equal(t, "thumbnail", w.Body.String())
+}
+
+func TestCoverGetNoCover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newCoverMocks(t, ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.coverModel.EXPECT().
+		CoverUpdatedAt(user.ID, models.CoverKindFranchise, "1", false).
+		Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/franchises/1/cover", nil))
+
+	gassert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestCoverGetInvalidSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newCoverMocks(t, ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/games/1/cover?size=huge", nil))
+
+	gassert.StatusCode(t, w, http.StatusBadRequest)
+}
+
+func TestCoverDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newCoverMocks(t, ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	updatedAt := time.Now()
+	mocks.coverModel.EXPECT().
+		CoverUpdatedAt(user.ID, models.CoverKindGame, "1", true).
+		Return(&updatedAt, nil)
+	mocks.coverModel.EXPECT().
+		SetCoverUpdatedAt(user.ID, models.CoverKindGame, "1", nil).
+		Return(nil)
+	require.NoError(t, mocks.store.Put(context.Background(), "covers/games/1/full.jpg", "image/jpeg", []byte("full")))
+	require.NoError(t, mocks.store.Put(context.Background(), "covers/games/1/thumbnail.jpg", "image/jpeg", []byte("thumbnail")))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodDelete, "/games/1/cover", nil))
+
+	gassert.StatusOK(t, w)
+	for _, key := range []string{"covers/games/1/full.jpg", "covers/games/1/thumbnail.jpg"} {
+		_, err := mocks.store.Get(context.Background(), key)
+		assert.ErrorIs(t, err, blob.ErrNotFound)
+	}
+}
+
+func TestCoverNoBlobStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authenticator := fixtures.NewAuthenticatorMock(ctrl)
+	userModel := fixtures.NewUserModelMock(ctrl)
+	srv := newServer(t, &Options{
+		Authenticator: authenticator,
+		UserModel:     userModel,
+	})
+
+	authenticator.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(nil, nil)
+	userModel.EXPECT().
+		GetUserByToken(gomock.Eq(token)).
+		Return(user, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/games/1/cover", nil))
+
+	gassert.StatusCode(t, w, http.StatusNotImplemented)
+}
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// handleUserExport streams all data of the authenticated user - their franchises, games and the status history of the games.
+// By default, it is a single JSON document. With ?format=csv, it is a ZIP archive with a CSV file for each of them.
+//
+// The data is written as it is read from the database, so the response can not be changed to an error
+// once it is started. If an error occurs midway, the response is cut short.
+func (s *Server) handleUserExport() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "csv" {
+			s.respondError(w, r, "format must be one of json, csv", http.StatusBadRequest)
+			return
+		}
+
+		now := time.Now().UTC()
+		filename := fmt.Sprintf("gira-export-%s", now.Format("2006-01-02"))
+		w.Header().Set("Cache-Control", "no-store")
+
+		var err error
+		if format == "csv" {
+			w.Header().Set("Content-Type", "application/zip")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, filename))
+			err = s.exportCSV(w, user)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, filename))
+			err = s.exportJSON(w, user, now)
+		}
+		if err != nil {
+			s.Log.Errorf("Error while exporting data of user %s: %v", user.ID, err)
+		}
+	}
+}
+
+// exportJSON writes the data of the user as a single JSON object.
+// The games are in the same format as the one returned by GET /games, so the export can be imported back via POST /games/import.
+func (s *Server) exportJSON(w io.Writer, user *models.User, now time.Time) error {
+	enc := json.NewEncoder(w)
+
+	if _, err := io.WriteString(w, `{"exportedAt":`); err != nil {
+		return err
+	}
+	if err := enc.Encode(now); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, `,"user":`); err != nil {
+		return err
+	}
+	if err := enc.Encode(&models.User{ID: user.ID, Username: user.Username, Email: user.Email}); err != nil {
+		return err
+	}
+
+	franchises := newJSONArrayWriter(w, "franchises")
+	if err := s.ExportModel.Franchises(user.ID, func(f *models.Franchise) error { return franchises.Write(f) }); err != nil {
+		return err
+	}
+	if err := franchises.Close(); err != nil {
+		return err
+	}
+
+	games := newJSONArrayWriter(w, "games")
+	if err := s.ExportModel.Games(user.ID, func(g *models.Game) error { return games.Write(g) }); err != nil {
+		return err
+	}
+	if err := games.Close(); err != nil {
+		return err
+	}
+
+	changes := newJSONArrayWriter(w, "statusChanges")
+	if err := s.ExportModel.StatusChanges(user.ID, func(c *models.StatusChange) error { return changes.Write(c) }); err != nil {
+		return err
+	}
+	if err := changes.Close(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "}\n")
+	return err
+}
+
+// jsonArrayWriter writes a JSON array, element by element, as a field of an object.
+// The field is preceded by a comma, so it can not be the first field of the object.
+type jsonArrayWriter struct {
+	w       io.Writer
+	enc     *json.Encoder
+	name    string
+	started bool
+	empty   bool
+}
+
+func newJSONArrayWriter(w io.Writer, name string) *jsonArrayWriter {
+	return &jsonArrayWriter{w: w, enc: json.NewEncoder(w), name: name, empty: true}
+}
+
+func (a *jsonArrayWriter) start() error {
+	if a.started {
+		return nil
+	}
+	a.started = true
+
+	_, err := fmt.Fprintf(a.w, ",%q:[", a.name)
+	return err
+}
+
+// Write writes a single element of the array.
+func (a *jsonArrayWriter) Write(v interface{}) error {
+	if err := a.start(); err != nil {
+		return err
+	}
+	if !a.empty {
+		if _, err := io.WriteString(a.w, ","); err != nil {
+			return err
+		}
+	}
+	a.empty = false
+	return a.enc.Encode(v)
+}
+
+// Close ends the array. It writes an empty array, if no elements were written.
+func (a *jsonArrayWriter) Close() error {
+	if err := a.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(a.w, "]")
+	return err
+}
+
+// exportCSV writes the data of the user as a ZIP archive of franchises.csv, games.csv and status_changes.csv.
+func (s *Server) exportCSV(w io.Writer, user *models.User) error {
+	zw := zip.NewWriter(w)
+
+	cw, err := createCSV(zw, "franchises.csv", "id", "name")
+	if err != nil {
+		return err
+	}
+	if err := s.ExportModel.Franchises(user.ID, func(f *models.Franchise) error {
+		return cw.Write([]string{f.ID, f.Name})
+	}); err != nil {
+		return err
+	}
+	if err := flushCSV(cw); err != nil {
+		return err
+	}
+
+	cw, err = createCSV(zw, "games.csv", "id", "name", "franchise_id", "franchise", "status", "current_progress", "final_progress", "updated_at", "finished_at", "wishlist", "release_date")
+	if err != nil {
+		return err
+	}
+	if err := s.ExportModel.Games(user.ID, func(g *models.Game) error {
+		var current, final string
+		if g.Progress != nil {
+			current, final = strconv.Itoa(g.Progress.Current), strconv.Itoa(g.Progress.Final)
+		}
+		return cw.Write([]string{g.ID, g.Name, g.FranchiseID, g.Franchise, string(g.Status), current, final, formatTime(g.UpdatedAt), formatTime(g.FinishedAt),
+			strconv.FormatBool(g.Wishlist), formatDate(g.ReleaseDate)})
+	}); err != nil {
+		return err
+	}
+	if err := flushCSV(cw); err != nil {
+		return err
+	}
+
+	cw, err = createCSV(zw, "status_changes.csv", "game_id", "from", "to", "changed_at")
+	if err != nil {
+		return err
+	}
+	if err := s.ExportModel.StatusChanges(user.ID, func(c *models.StatusChange) error {
+		return cw.Write([]string{c.GameID, string(c.From), string(c.To), formatTime(&c.ChangedAt)})
+	}); err != nil {
+		return err
+	}
+	if err := flushCSV(cw); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// createCSV creates a new file in the archive and writes the CSV header to it.
+func createCSV(zw *zip.Writer, name string, header ...string) (*csv.Writer, error) {
+	f, err := zw.Create(name)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating %s: %w", name, err)
+	}
+	cw := csv.NewWriter(f)
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func flushCSV(cw *csv.Writer) error {
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	exportChangedAt   = time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	exportReleaseDate = time.Date(2021, 9, 17, 0, 0, 0, 0, time.UTC)
+	exportGames       = []*models.Game{
+		{ID: "1", Name: "AC", FranchiseID: "1", Franchise: "Assassin's Creed", Status: models.StatusDone, Progress: &models.GameProgress{Current: 100, Final: 100}, UpdatedAt: &exportChangedAt, FinishedAt: &exportChangedAt},
+		{ID: "2", Name: "Hades", Status: models.StatusTODO, Progress: &models.GameProgress{Current: 0, Final: 100}, UpdatedAt: &exportChangedAt, Wishlist: true, ReleaseDate: &exportReleaseDate},
+	}
+	exportChanges = []*models.StatusChange{
+		{GameID: "1", From: models.StatusTODO, To: models.StatusDone, ChangedAt: exportChangedAt},
+	}
+)
+
+// newExportUserModel returns a user model, that returns a user with a password for the token,
+// so that the tests can check that the password is not exported
+func newExportUserModel(ctrl *gomock.Controller) *fixtures.UserModelMock {
+	userModel := fixtures.NewUserModelMock(ctrl)
+	userModel.EXPECT().
+		GetUserByToken(gomock.Eq(token)).
+		Return(&models.User{ID: "1", Username: "anton", Email: "anton@example.com", HashedPassword: []byte("hash")}, nil)
+	return userModel
+}
+
+func expectExport(exportModel *fixtures.ExportModelMock, franchises []*models.Franchise, games []*models.Game, changes []*models.StatusChange) {
+	exportModel.EXPECT().
+		Franchises("1", gomock.Any()).
+		DoAndReturn(func(userID string, fn func(*models.Franchise) error) error {
+			for _, f := range franchises {
+				if err := fn(f); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	exportModel.EXPECT().
+		Games("1", gomock.Any()).
+		DoAndReturn(func(userID string, fn func(*models.Game) error) error {
+			for _, g := range games {
+				if err := fn(g); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	exportModel.EXPECT().
+		StatusChanges("1", gomock.Any()).
+		DoAndReturn(func(userID string, fn func(*models.StatusChange) error) error {
+			for _, c := range changes {
+				if err := fn(c); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func TestUserExportJSON(t *testing.T) {
+	testCases := []struct {
+		name       string
+		franchises []*models.Franchise
+		games      []*models.Game
+		changes    []*models.StatusChange
+	}{
+		{
+			name:       "With data",
+			franchises: []*models.Franchise{{ID: "1", Name: "Assassin's Creed"}, {ID: "2", Name: "Far Cry"}},
+			games:      exportGames,
+			changes:    exportChanges,
+		},
+		{
+			name:       "Without data",
+			franchises: []*models.Franchise{},
+			games:      []*models.Game{},
+			changes:    []*models.StatusChange{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			exportModel := fixtures.NewExportModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{UserModel: newExportUserModel(ctrl), ExportModel: exportModel})
+			expectExport(exportModel, testCase.franchises, testCase.games, testCase.changes)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/users/me/export", nil))
+
+			gassert.StatusOK(t, w)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Header().Get("Content-Disposition"), `attachment; filename="gira-export-`)
+			assert.NotContains(t, w.Body.String(), "password")
+
+			var export struct {
+				ExportedAt    time.Time              `json:"exportedAt"`
+				User          *models.User           `json:"user"`
+				Franchises    []*models.Franchise    `json:"franchises"`
+				Games         []*models.Game         `json:"games"`
+				StatusChanges []*models.StatusChange `json:"statusChanges"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+			assert.False(t, export.ExportedAt.IsZero())
+			assert.Equal(t, &models.User{ID: "1", Username: "anton", Email: "anton@example.com"}, export.User)
+			assert.Equal(t, testCase.franchises, export.Franchises)
+			assert.Equal(t, testCase.games, export.Games)
+			assert.Equal(t, testCase.changes, export.StatusChanges)
+		})
+	}
+}
+
+func TestUserExportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exportModel := fixtures.NewExportModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{UserModel: newExportUserModel(ctrl), ExportModel: exportModel})
+	expectExport(exportModel, []*models.Franchise{{ID: "1", Name: "Assassin's Creed"}}, exportGames, exportChanges)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/users/me/export?format=csv", nil))
+
+	gassert.StatusOK(t, w)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	assert.Equal(t, map[string]string{
+		"franchises.csv": "id,name\n" +
+			"1,Assassin's Creed\n",
+		"games.csv": "id,name,franchise_id,franchise,status,current_progress,final_progress,updated_at,finished_at,wishlist,release_date\n" +
+			"1,AC,1,Assassin's Creed,Done,100,100,2021-01-02T03:04:05Z,2021-01-02T03:04:05Z,false,\n" +
+			"2,Hades,,,To Do,0,100,2021-01-02T03:04:05Z,,true,2021-09-17\n",
+		"status_changes.csv": "game_id,from,to,changed_at\n" +
+			"1,To Do,Done,2021-01-02T03:04:05Z\n",
+	}, files)
+}
+
+func TestUserExportBadFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := newAuthorizedServer(t, ctrl, &Options{UserModel: newExportUserModel(ctrl), ExportModel: fixtures.NewExportModelMock(ctrl)})
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/users/me/export?format=xml", nil))
+
+	gassert.StatusCode(t, w, http.StatusBadRequest)
+}
+
+func TestUserExportDBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exportModel := fixtures.NewExportModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{UserModel: newExportUserModel(ctrl), ExportModel: exportModel})
+	exportModel.EXPECT().
+		Franchises("1", gomock.Any()).
+		Return(nil)
+	exportModel.EXPECT().
+		Games("1", gomock.Any()).
+		Return(errors.New("intentional error"))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/users/me/export", nil))
+
+	// the response is already started, so it is only cut short
+	assert.False(t, strings.HasSuffix(w.Body.String(), "}\n"))
+	assert.False(t, json.Valid(w.Body.Bytes()))
+}
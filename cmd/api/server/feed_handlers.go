@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+const (
+	// defaultFeedLimit is the number of events in a page of the feed, if no limit is requested
+	defaultFeedLimit = 20
+	// maxFeedLimit is the maximum number of events in a page of the feed
+	maxFeedLimit = 100
+)
+
+func (s *Server) handleFeedGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var before int64
+		if b := r.URL.Query().Get("before"); b != "" {
+			var err error
+			if before, err = strconv.ParseInt(b, 10, 64); err != nil || before <= 0 {
+				s.respondError(w, r, "'before' is not a valid cursor", http.StatusBadRequest)
+				return
+			}
+		}
+
+		limit := defaultFeedLimit
+		if l := r.URL.Query().Get("limit"); l != "" {
+			var err error
+			if limit, err = strconv.Atoi(l); err != nil || limit <= 0 || limit > maxFeedLimit {
+				s.respondError(w, r, "'limit' should be a number between 1 and 100", http.StatusBadRequest)
+				return
+			}
+		}
+
+		// one more event is fetched, to know whether there is a next page
+		events, err := s.EventModel.Feed(user.ID, before, limit+1)
+		if err != nil {
+			s.Log.Errorf("Error while fetching feed: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		res := &models.FeedResponse{Events: events}
+		if len(events) > limit {
+			res.Events = events[:limit]
+			res.NextCursor = res.Events[limit-1].ID
+		}
+
+		s.respond(w, r, res, http.StatusOK)
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func feedEvents(ids ...string) []*models.Event {
+	createdAt := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+	events := []*models.Event{}
+	for _, id := range ids {
+		events = append(events, &models.Event{
+			ID:        id,
+			Kind:      models.EventStatusChanged,
+			Username:  "ivan",
+			GameID:    "1",
+			GameName:  "Hades",
+			From:      models.StatusInProgress,
+			To:        models.StatusDone,
+			CreatedAt: createdAt,
+		})
+	}
+	return events
+}
+
+func TestFeedGet(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		before         int64
+		limit          int
+		events         []*models.Event
+		expectedEvents []*models.Event
+		expectedCursor string
+	}{
+		{
+			name:           "Last page",
+			path:           "/feed",
+			limit:          defaultFeedLimit + 1,
+			events:         feedEvents("3", "2"),
+			expectedEvents: feedEvents("3", "2"),
+		},
+		{
+			name:           "Page with next page",
+			path:           "/feed?before=10&limit=2",
+			before:         10,
+			limit:          3,
+			events:         feedEvents("9", "7", "4"),
+			expectedEvents: feedEvents("9", "7"),
+			expectedCursor: "7",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			eventModel := fixtures.NewEventModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{EventModel: eventModel})
+			eventModel.EXPECT().
+				Feed(user.ID, testCase.before, testCase.limit).
+				Return(testCase.events, nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, testCase.path, nil))
+
+			gassert.StatusOK(t, w)
+
+			var res models.FeedResponse
+			fixtures.Decode(t, w.Body, &res)
+			assert.Equal(t, testCase.expectedEvents, res.Events)
+			assert.Equal(t, testCase.expectedCursor, res.NextCursor)
+		})
+	}
+}
+
+func TestFeedGetBadRequest(t *testing.T) {
+	testCases := []struct {
+		name string
+		path string
+	}{
+		{name: "Invalid cursor", path: "/feed?before=abc"},
+		{name: "Negative cursor", path: "/feed?before=-1"},
+		{name: "Invalid limit", path: "/feed?limit=abc"},
+		{name: "Too big limit", path: "/feed?limit=101"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := newAuthorizedServer(t, ctrl, &Options{EventModel: fixtures.NewEventModelMock(ctrl)})
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, testCase.path, nil))
+
+			gassert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestFeedGetError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	eventModel := fixtures.NewEventModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{EventModel: eventModel})
+	eventModel.EXPECT().
+		Feed(user.ID, int64(0), defaultFeedLimit+1).
+		Return(nil, errors.New("this is an intentional error"))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/feed", nil))
+
+	gassert.StatusCode(t, w, http.StatusInternalServerError)
+}
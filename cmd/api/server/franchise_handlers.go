@@ -7,9 +7,11 @@ import (
 
 	"github.com/asankov/gira/pkg/models"
 	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/gorilla/mux"
 	"github.com/hashicorp/go-multierror"
 )
 
+// handleFranchisesGet returns the tree of the franchises of the user, with the completion of each one, including its sub-series.
 func (s *Server) handleFranchisesGet() authorizedHandler {
 	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
 		franchises, err := s.FranchiseModel.All(user.ID)
@@ -20,7 +22,7 @@ func (s *Server) handleFranchisesGet() authorizedHandler {
 			return
 		}
 
-		s.respond(w, r, models.FranchisesResponse{Franchises: franchises}, http.StatusOK)
+		s.respond(w, r, models.FranchisesResponse{Franchises: models.FranchiseTree(franchises)}, http.StatusOK)
 	}
 }
 
@@ -45,6 +47,10 @@ func (s *Server) handleFranchisesCreate() authorizedHandler {
 				s.respondError(w, r, "Franchise with the same name already exists", http.StatusBadRequest)
 				return
 			}
+			if errors.Is(err, postgres.ErrInvalidParent) {
+				s.respondError(w, r, err.Error(), http.StatusBadRequest)
+				return
+			}
 			s.Log.Errorf("Error while inserting game into database: %v", err)
 			s.internalError(w, r)
 			return
@@ -54,6 +60,97 @@ func (s *Server) handleFranchisesCreate() authorizedHandler {
 	}
 }
 
+// handleFranchiseGet returns the given franchise with its games in play order
+func (s *Server) handleFranchiseGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		franchise, err := s.FranchiseModel.Get(user.ID, mux.Vars(r)["id"])
+		if err != nil {
+			if errors.Is(err, postgres.ErrNoRecord) {
+				s.respondError(w, r, "Franchise not found", http.StatusNotFound)
+				return
+			}
+			s.Log.Errorf("Error while fetching franchise from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, franchise, http.StatusOK)
+	}
+}
+
+// handleFranchiseOrderPut stores the release or play order of the games of the franchise.
+// The body should contain the IDs of all games of the franchise, in the new order.
+func (s *Server) handleFranchiseOrderPut() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var req models.FranchiseOrderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.respondError(w, r, "Error decoding body", http.StatusBadRequest)
+			return
+		}
+		if err := req.Order.Validate(); err != nil {
+			s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		franchiseID := mux.Vars(r)["id"]
+		if err := s.FranchiseModel.Reorder(user.ID, franchiseID, req.Order, req.GameIDs); err != nil {
+			switch {
+			case errors.Is(err, postgres.ErrNoRecord):
+				s.respondError(w, r, "Franchise not found", http.StatusNotFound)
+			case errors.Is(err, postgres.ErrInvalidOrder):
+				s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			default:
+				s.Log.Errorf("Error while ordering the games of franchise %s: %v", franchiseID, err)
+				s.internalError(w, r)
+			}
+			return
+		}
+
+		franchise, err := s.FranchiseModel.Get(user.ID, franchiseID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching franchise from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+		s.respond(w, r, franchise, http.StatusOK)
+	}
+}
+
+// handleFranchiseParentPut makes the franchise a sub-series of another one, or a top-level franchise if the parent is empty.
+func (s *Server) handleFranchiseParentPut() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var req models.FranchiseParentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.respondError(w, r, "Error decoding body", http.StatusBadRequest)
+			return
+		}
+
+		franchiseID := mux.Vars(r)["id"]
+		if err := s.FranchiseModel.SetParent(user.ID, franchiseID, req.ParentID); err != nil {
+			switch {
+			case errors.Is(err, postgres.ErrNoRecord):
+				s.respondError(w, r, "Franchise not found", http.StatusNotFound)
+			case errors.Is(err, postgres.ErrInvalidParent):
+				s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			default:
+				s.Log.Errorf("Error while changing the parent of franchise %s: %v", franchiseID, err)
+				s.internalError(w, r)
+			}
+			return
+		}
+		// the sub-series are a part of the franchise, so this changes the games of the franchise and of its old parent
+		s.trackGoals(user.ID)
+
+		franchise, err := s.FranchiseModel.Get(user.ID, franchiseID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching franchise from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+		s.respond(w, r, franchise, http.StatusOK)
+	}
+}
+
 func validateFranchise(franchise *models.Franchise) error {
 	var err *multierror.Error
 	if franchise.ID != "" {
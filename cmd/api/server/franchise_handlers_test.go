@@ -1,6 +1,7 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -195,3 +196,250 @@ func TestFranchisesCreateDBError(t *testing.T) {
 	fixtures.Decode(t, w.Body, &err)
 	require.NotEmpty(t, err.Error, "Error returned from server should not be empty")
 }
+
+func TestFranchiseGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	franchiseModel := fixtures.NewFranchiseModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{FranchiseModel: franchiseModel})
+
+	franchise := &models.Franchise{
+		ID:   "123",
+		Name: "Batman",
+		Games: []*models.Game{
+			{ID: "2", Name: "Arkham Origins", ReleaseOrder: 3, PlayOrder: 1},
+			{ID: "1", Name: "Arkham Asylum", ReleaseOrder: 1, PlayOrder: 2},
+			{ID: "3", Name: "Arkham City", ReleaseOrder: 2, PlayOrder: 3},
+		},
+	}
+	franchiseModel.EXPECT().
+		Get(user.ID, "123").
+		Return(franchise, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/franchises/123", nil))
+
+	var res models.Franchise
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, *franchise, res)
+}
+
+func TestFranchiseGetNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	franchiseModel := fixtures.NewFranchiseModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{FranchiseModel: franchiseModel})
+
+	franchiseModel.EXPECT().
+		Get(user.ID, "123").
+		Return(nil, postgres.ErrNoRecord)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/franchises/123", nil))
+
+	gassert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestFranchiseOrderPut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	franchiseModel := fixtures.NewFranchiseModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{FranchiseModel: franchiseModel})
+
+	gameIDs := []string{"2", "1", "3"}
+	franchise := &models.Franchise{ID: "123", Name: "Batman", Games: []*models.Game{
+		{ID: "2", Name: "Arkham Origins", ReleaseOrder: 3, PlayOrder: 1},
+		{ID: "1", Name: "Arkham Asylum", ReleaseOrder: 1, PlayOrder: 2},
+		{ID: "3", Name: "Arkham City", ReleaseOrder: 2, PlayOrder: 3},
+	}}
+	gomock.InOrder(
+		franchiseModel.EXPECT().
+			Reorder(user.ID, "123", models.FranchiseOrderPlay, gameIDs).
+			Return(nil),
+		franchiseModel.EXPECT().
+			Get(user.ID, "123").
+			Return(franchise, nil),
+	)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/franchises/123/order", fixtures.Marshal(t, models.FranchiseOrderRequest{
+		Order:   models.FranchiseOrderPlay,
+		GameIDs: gameIDs,
+	})))
+
+	var res models.Franchise
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, *franchise, res)
+}
+
+func TestFranchiseOrderPutError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		req    models.FranchiseOrderRequest
+		err    error
+		status int
+	}{
+		{
+			name:   "Invalid order",
+			req:    models.FranchiseOrderRequest{Order: "alphabetical", GameIDs: []string{"1"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Not all games",
+			req:    models.FranchiseOrderRequest{Order: models.FranchiseOrderRelease, GameIDs: []string{"1"}},
+			err:    postgres.ErrInvalidOrder,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Franchise not found",
+			req:    models.FranchiseOrderRequest{Order: models.FranchiseOrderRelease, GameIDs: []string{"1"}},
+			err:    postgres.ErrNoRecord,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "DB error",
+			req:    models.FranchiseOrderRequest{Order: models.FranchiseOrderRelease, GameIDs: []string{"1"}},
+			err:    errors.New("intentional error"),
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			franchiseModel := fixtures.NewFranchiseModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{FranchiseModel: franchiseModel})
+			if testCase.err != nil {
+				franchiseModel.EXPECT().
+					Reorder(user.ID, "123", testCase.req.Order, testCase.req.GameIDs).
+					Return(testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/franchises/123/order", fixtures.Marshal(t, testCase.req)))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestFranchisesGetTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	franchiseModel := fixtures.NewFranchiseModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{FranchiseModel: franchiseModel})
+
+	franchiseModel.EXPECT().
+		All(user.ID).
+		Return([]*models.Franchise{
+			{ID: "3", Name: "Spin-offs", ParentID: "1", Completion: &models.FranchiseCompletion{Games: 2, Finished: 0}},
+			{ID: "1", Name: "Zelda", Completion: &models.FranchiseCompletion{Games: 4, Finished: 3}},
+			{ID: "4", Name: "Hyrule Warriors", ParentID: "3", Completion: &models.FranchiseCompletion{Games: 1, Finished: 1}},
+			{ID: "2", Name: "Batman", Completion: &models.FranchiseCompletion{Games: 1, Finished: 1}},
+			{ID: "5", Name: "Main line", ParentID: "1", Completion: &models.FranchiseCompletion{}},
+		}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/franchises", nil))
+
+	var res models.FranchisesResponse
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, []*models.Franchise{
+		{ID: "2", Name: "Batman", Completion: &models.FranchiseCompletion{Games: 1, Finished: 1}},
+		{ID: "1", Name: "Zelda", Completion: &models.FranchiseCompletion{Games: 7, Finished: 4}, Children: []*models.Franchise{
+			{ID: "5", Name: "Main line", ParentID: "1", Completion: &models.FranchiseCompletion{}},
+			{ID: "3", Name: "Spin-offs", ParentID: "1", Completion: &models.FranchiseCompletion{Games: 3, Finished: 1}, Children: []*models.Franchise{
+				{ID: "4", Name: "Hyrule Warriors", ParentID: "3", Completion: &models.FranchiseCompletion{Games: 1, Finished: 1}},
+			}},
+		}},
+	}, res.Franchises)
+}
+
+func TestFranchisesCreateInvalidParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	franchiseModel := fixtures.NewFranchiseModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{FranchiseModel: franchiseModel})
+
+	franchiseModel.EXPECT().
+		Insert(&models.Franchise{Name: "Spin-offs", ParentID: "999", UserID: user.ID}).
+		Return(nil, postgres.ErrInvalidParent)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/franchises", fixtures.Marshal(t, models.Franchise{Name: "Spin-offs", ParentID: "999"})))
+
+	gassert.StatusCode(t, w, http.StatusBadRequest)
+}
+
+func TestFranchiseParentPut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	franchiseModel := fixtures.NewFranchiseModelMock(ctrl)
+	goalModel := fixtures.NewGoalModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{FranchiseModel: franchiseModel, GoalModel: goalModel})
+
+	franchise := &models.Franchise{ID: "3", Name: "Spin-offs", ParentID: "1"}
+	gomock.InOrder(
+		franchiseModel.EXPECT().
+			SetParent(user.ID, "3", "1").
+			Return(nil),
+		// the franchise gets the games of the sub-series, so its goals can be reached
+		goalModel.EXPECT().
+			TrackGoals(user.ID, gomock.Any()).
+			Return(nil),
+		franchiseModel.EXPECT().
+			Get(user.ID, "3").
+			Return(franchise, nil),
+	)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/franchises/3/parent", fixtures.Marshal(t, models.FranchiseParentRequest{ParentID: "1"})))
+
+	var res models.Franchise
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, *franchise, res)
+}
+
+func TestFranchiseParentPutError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "Cycle", err: postgres.ErrInvalidParent, status: http.StatusBadRequest},
+		{name: "Franchise not found", err: postgres.ErrNoRecord, status: http.StatusNotFound},
+		{name: "DB error", err: errors.New("intentional error"), status: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			franchiseModel := fixtures.NewFranchiseModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{FranchiseModel: franchiseModel})
+			franchiseModel.EXPECT().
+				SetParent(user.ID, "1", "3").
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/franchises/1/parent", fixtures.Marshal(t, models.FranchiseParentRequest{ParentID: "3"})))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
@@ -3,7 +3,9 @@ package server
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/asankov/gira/pkg/models"
 	"github.com/asankov/gira/pkg/models/postgres"
@@ -34,6 +36,22 @@ func (s *Server) handleGamesCreate() authorizedHandler {
 			return
 		}
 
+		// the details of the game come only from the metadata provider, except the release date,
+		// which can be set for games that are not known by the provider yet
+		game.CoverURL, game.Developer, game.Genres = "", "", nil
+		game.ReleaseDate = releaseDate(game.ReleaseDate)
+		if game.MetadataID != "" {
+			if err := s.fillMetadata(r.Context(), &game); err != nil {
+				if errors.Is(err, errNoMetadataProvider) || errors.Is(err, errUnknownMetadataID) {
+					s.respondError(w, r, err.Error(), http.StatusBadRequest)
+					return
+				}
+				s.Log.Errorf("Error while fetching metadata of game %s: %v", game.MetadataID, err)
+				s.respondError(w, r, "Error while fetching metadata", http.StatusBadGateway)
+				return
+			}
+		}
+
 		game.UserID = user.ID
 		g, err := s.GameModel.Insert(&game)
 		if err != nil {
@@ -41,10 +59,20 @@ func (s *Server) handleGamesCreate() authorizedHandler {
 				s.respondError(w, r, "Game with the same name already exists", http.StatusBadRequest)
 				return
 			}
+			if errors.Is(err, postgres.ErrNoRecord) {
+				s.respondError(w, r, "Board not found", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, postgres.ErrForbidden) {
+				s.respondError(w, r, "Your role on the board does not allow adding games", http.StatusForbidden)
+				return
+			}
 			s.Log.Errorf("Error while inserting game into database: %v", err)
 			s.internalError(w, r)
 			return
 		}
+		s.trackGoals(user.ID)
+		s.gameChanged(user.ID, s.gameViewers(g.ID), &models.Change{Type: models.ChangeGameCreated, GameID: g.ID, Game: g})
 
 		s.respond(w, r, g, http.StatusOK)
 	}
@@ -64,6 +92,29 @@ func (s *Server) handleGamesGet() authorizedHandler {
 	}
 }
 
+func (s *Server) handleGamesUpcoming() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		from := time.Now().UTC()
+		if f := r.URL.Query().Get("from"); f != "" {
+			var err error
+			if from, err = time.Parse(dateLayout, f); err != nil {
+				s.respondError(w, r, fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", f), http.StatusBadRequest)
+				return
+			}
+		}
+		wishlistOnly := r.URL.Query().Get("wishlist") == "true"
+
+		games, err := s.GameModel.Upcoming(user.ID, *releaseDate(&from), wishlistOnly)
+		if err != nil {
+			s.Log.Errorf("Error while fetching upcoming games from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, models.GamesResponse{Games: games}, http.StatusOK)
+	}
+}
+
 func (s *Server) handleGamesGetByID() authorizedHandler {
 	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
 		args := mux.Vars(r)
@@ -100,3 +151,15 @@ func validateGame(game *models.Game) error {
 
 	return err.ErrorOrNil()
 }
+
+const dateLayout = "2006-01-02"
+
+// releaseDate drops the time part of the given release date, because only the date is tracked
+func releaseDate(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	y, m, d := t.Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return &date
+}
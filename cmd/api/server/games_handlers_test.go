@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -202,6 +203,7 @@ func TestCreateGame(t *testing.T) {
 		Authenticator: authenticator,
 		GameModel:     gameModel,
 		UserModel:     userModel,
+		GoalModel:     newTrackingGoalModel(ctrl, user.ID),
 	})
 
 	authenticator.EXPECT().
@@ -331,3 +333,146 @@ func TestCreateGameDBError(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateGameWithReleaseDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gameModel := fixtures.NewGameModelMock(ctrl)
+	userModel := fixtures.NewUserModelMock(ctrl)
+	authenticator := fixtures.NewAuthenticatorMock(ctrl)
+	srv := newServer(t, &Options{
+		Authenticator: authenticator,
+		GameModel:     gameModel,
+		UserModel:     userModel,
+		GoalModel:     newTrackingGoalModel(ctrl, user.ID),
+	})
+
+	authenticator.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(user, nil)
+	userModel.
+		EXPECT().
+		GetUserByToken(token).
+		Return(user, nil)
+
+	// only the date is stored
+	releaseDate := time.Date(2023, 10, 20, 0, 0, 0, 0, time.UTC)
+	expectedGame := &models.Game{Name: "Spider-Man 2", Wishlist: true, ReleaseDate: &releaseDate}
+	gameModel.
+		EXPECT().
+		Insert(expectedGame).
+		Return(expectedGame, nil)
+
+	requestDate := time.Date(2023, 10, 20, 15, 30, 0, 0, time.UTC)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/games", fixtures.Marshal(t, &models.Game{Name: "Spider-Man 2", Wishlist: true, ReleaseDate: &requestDate}))
+	r.Header.Set(models.XAuthToken, token)
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+}
+
+func TestGetUpcomingGames(t *testing.T) {
+	releaseDate := time.Date(2023, 10, 20, 0, 0, 0, 0, time.UTC)
+	upcoming := []*models.Game{
+		{ID: "1", Name: "Spider-Man 2", Wishlist: true, ReleaseDate: &releaseDate},
+	}
+
+	testCases := []struct {
+		name         string
+		query        string
+		from         gomock.Matcher
+		wishlistOnly bool
+	}{
+		{name: "Defaults", query: "", from: gomock.Any(), wishlistOnly: false},
+		{name: "From date", query: "?from=2023-10-01", from: gomock.Eq(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)), wishlistOnly: false},
+		{name: "Wishlist only", query: "?wishlist=true", from: gomock.Any(), wishlistOnly: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gameModel := fixtures.NewGameModelMock(ctrl)
+			userModel := fixtures.NewUserModelMock(ctrl)
+			authenticator := fixtures.NewAuthenticatorMock(ctrl)
+			srv := newServer(t, &Options{
+				Authenticator: authenticator,
+				GameModel:     gameModel,
+				UserModel:     userModel,
+			})
+
+			authenticator.EXPECT().
+				DecodeToken(gomock.Eq(token)).
+				Return(user, nil)
+			userModel.
+				EXPECT().
+				GetUserByToken(token).
+				Return(user, nil)
+			gameModel.
+				EXPECT().
+				Upcoming(user.ID, testCase.from, testCase.wishlistOnly).
+				Return(upcoming, nil)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/games/upcoming"+testCase.query, nil)
+			r.Header.Set(models.XAuthToken, token)
+			srv.ServeHTTP(w, r)
+
+			gassert.StatusOK(t, w)
+
+			var res models.GamesResponse
+			fixtures.Decode(t, w.Body, &res)
+			assert.Equal(t, upcoming, res.Games)
+		})
+	}
+}
+
+func TestGetUpcomingGamesError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		query        string
+		dbErr        error
+		expectedCode int
+	}{
+		{name: "Invalid date", query: "?from=20.10.2023", expectedCode: http.StatusBadRequest},
+		{name: "DB error", dbErr: errors.New("this is an intentional error"), expectedCode: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gameModel := fixtures.NewGameModelMock(ctrl)
+			userModel := fixtures.NewUserModelMock(ctrl)
+			authenticator := fixtures.NewAuthenticatorMock(ctrl)
+			srv := newServer(t, &Options{
+				Authenticator: authenticator,
+				GameModel:     gameModel,
+				UserModel:     userModel,
+			})
+
+			authenticator.EXPECT().
+				DecodeToken(gomock.Eq(token)).
+				Return(user, nil)
+			userModel.
+				EXPECT().
+				GetUserByToken(token).
+				Return(user, nil)
+			if testCase.dbErr != nil {
+				gameModel.
+					EXPECT().
+					Upcoming(user.ID, gomock.Any(), false).
+					Return(nil, testCase.dbErr)
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/games/upcoming"+testCase.query, nil)
+			r.Header.Set(models.XAuthToken, token)
+			srv.ServeHTTP(w, r)
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package server
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+)
+
+var (
+	// maxImportSize is the maximum size of the body of an import request
+	maxImportSize int64 = 5 << 20
+	// maxImportGames is the maximum number of games in a single import
+	maxImportGames = 5000
+
+	errImportTooManyGames = fmt.Errorf("an import can contain at most %d games", maxImportGames)
+	errImportNoNameColumn = errors.New("the CSV header must contain a 'name' column")
+)
+
+// handleGamesImport imports games from a CSV or JSON body, depending on its Content-Type.
+//
+// The mode query parameter selects whether all games are imported or none (atomic, the default),
+// or only the valid ones are (best-effort).
+// If dryRun is true, nothing is saved, and only the outcome of the import is returned.
+func (s *Server) handleGamesImport() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		mode := models.ImportMode(r.URL.Query().Get("mode"))
+		if mode == "" {
+			mode = models.ImportModeAtomic
+		}
+		if mode != models.ImportModeAtomic && mode != models.ImportModeBestEffort {
+			s.respondError(w, r, fmt.Sprintf("mode must be one of %s, %s", models.ImportModeAtomic, models.ImportModeBestEffort), http.StatusBadRequest)
+			return
+		}
+		dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+
+		records, err := parseImport(r.Header.Get("Content-Type"), http.MaxBytesReader(w, r.Body, maxImportSize))
+		if err != nil {
+			s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		existing, err := s.GameModel.AllForUser(user.ID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching games from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+		franchises, err := s.FranchiseModel.All(user.ID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching franchises from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		result := &models.ImportResult{
+			Mode:          mode,
+			DryRun:        dryRun,
+			NewFranchises: []string{},
+			Rows:          checkImport(records, existing),
+		}
+		games := make([]*models.Game, 0, len(records))
+		for _, record := range records {
+			games = append(games, record.game)
+		}
+
+		// franchises are matched by name, regardless of the case
+		franchiseIDs := map[string]string{}
+		for _, f := range franchises {
+			franchiseIDs[strings.ToLower(f.Name)] = f.ID
+		}
+		valid := []int{}
+		for i, row := range result.Rows {
+			if row.Status != models.ImportRowOK {
+				continue
+			}
+			valid = append(valid, i)
+
+			key := strings.ToLower(games[i].Franchise)
+			if games[i].Franchise == "" {
+				continue
+			}
+			if _, ok := franchiseIDs[key]; !ok {
+				franchiseIDs[key] = ""
+				result.NewFranchises = append(result.NewFranchises, games[i].Franchise)
+			}
+		}
+
+		rejected := mode == models.ImportModeAtomic && len(valid) != len(games)
+		if rejected {
+			for _, i := range valid {
+				result.Rows[i].Status = models.ImportRowSkipped
+			}
+			result.NewFranchises = []string{}
+		}
+		if dryRun || rejected {
+			statusCode := http.StatusOK
+			if rejected && !dryRun {
+				statusCode = http.StatusConflict
+			}
+			s.respond(w, r, result, statusCode)
+			return
+		}
+
+		if mode == models.ImportModeAtomic {
+			// the new franchises are created along with the games, so that a failed import leaves none of them behind
+			toInsert := make([]*models.Game, 0, len(valid))
+			for _, i := range valid {
+				games[i].FranchiseID = franchiseIDs[strings.ToLower(games[i].Franchise)]
+				toInsert = append(toInsert, games[i])
+			}
+			inserted, err := s.GameModel.InsertMany(user.ID, result.NewFranchises, toInsert)
+			if err != nil {
+				if errors.Is(err, postgres.ErrNameAlreadyExists) {
+					s.respondError(w, r, "A game or franchise with the same name was created in the meantime", http.StatusConflict)
+					return
+				}
+				s.Log.Errorf("Error while importing games: %v", err)
+				s.internalError(w, r)
+				return
+			}
+			// the imported games are not on a board, so only the user can see them
+			for _, g := range inserted {
+				s.gameChanged(user.ID, []string{user.ID}, &models.Change{Type: models.ChangeGameCreated, GameID: g.ID, Game: g})
+			}
+			result.Imported = len(toInsert)
+			s.trackGoals(user.ID)
+			s.respond(w, r, result, http.StatusOK)
+			return
+		}
+
+		// a franchise, that could not be created, fails only its own games, in the same way as a game, that could not be saved
+		created := make([]string, 0, len(result.NewFranchises))
+		failedFranchises := map[string]*models.ImportRow{}
+		for _, name := range result.NewFranchises {
+			f, err := s.FranchiseModel.Insert(&models.Franchise{Name: name, UserID: user.ID})
+			if err != nil {
+				if errors.Is(err, postgres.ErrNameAlreadyExists) {
+					failedFranchises[strings.ToLower(name)] = &models.ImportRow{Status: models.ImportRowConflict, Error: "a franchise with the same name was created in the meantime"}
+					continue
+				}
+				s.Log.Errorf("Error while creating franchise %q: %v", name, err)
+				failedFranchises[strings.ToLower(name)] = &models.ImportRow{Status: models.ImportRowFailed, Error: "the franchise of the game could not be saved"}
+				continue
+			}
+			franchiseIDs[strings.ToLower(name)] = f.ID
+			created = append(created, name)
+		}
+		result.NewFranchises = created
+
+		for _, i := range valid {
+			if failed, ok := failedFranchises[strings.ToLower(games[i].Franchise)]; ok {
+				result.Rows[i].Status = failed.Status
+				result.Rows[i].Error = failed.Error
+				continue
+			}
+			games[i].FranchiseID = franchiseIDs[strings.ToLower(games[i].Franchise)]
+			inserted, err := s.GameModel.InsertMany(user.ID, nil, []*models.Game{games[i]})
+			if err != nil {
+				if errors.Is(err, postgres.ErrNameAlreadyExists) {
+					result.Rows[i].Status = models.ImportRowConflict
+					result.Rows[i].Error = "a game with the same name already exists"
+					continue
+				}
+				s.Log.Errorf("Error while importing game %q: %v", games[i].Name, err)
+				result.Rows[i].Status = models.ImportRowFailed
+				result.Rows[i].Error = "the game could not be saved"
+				continue
+			}
+			for _, g := range inserted {
+				s.gameChanged(user.ID, []string{user.ID}, &models.Change{Type: models.ChangeGameCreated, GameID: g.ID, Game: g})
+			}
+			result.Imported++
+		}
+		s.trackGoals(user.ID)
+		s.respond(w, r, result, http.StatusOK)
+	}
+}
+
+// importRecord is a single game of an import, along with the error that occurred while parsing it, if any.
+type importRecord struct {
+	game *models.Game
+	err  error
+}
+
+// checkImport validates the games and checks them for conflicts with the existing games and with each other.
+// It returns the outcome of each game, in the same order.
+func checkImport(records []*importRecord, existing []*models.Game) []*models.ImportRow {
+	names := map[string]bool{}
+	for _, game := range existing {
+		names[game.Name] = true
+	}
+
+	rows := make([]*models.ImportRow, 0, len(records))
+	for i, record := range records {
+		game := record.game
+		row := &models.ImportRow{Line: i + 1, Name: game.Name, Status: models.ImportRowOK}
+		rows = append(rows, row)
+
+		err := record.err
+		if err == nil {
+			err = validateImportGame(game)
+		}
+		if err != nil {
+			row.Status = models.ImportRowInvalid
+			row.Error = err.Error()
+			continue
+		}
+		if names[game.Name] {
+			row.Status = models.ImportRowConflict
+			row.Error = "a game with the same name already exists"
+			continue
+		}
+		names[game.Name] = true
+	}
+	return rows
+}
+
+func validateImportGame(game *models.Game) error {
+	if game.Name == "" {
+		return errNameRequired
+	}
+	if game.Status != "" {
+		if err := game.Status.Validate(); err != nil {
+			return err
+		}
+	}
+	if game.Progress != nil {
+		if game.Progress.Final <= 0 || game.Progress.Current < 0 || game.Progress.Current > game.Progress.Final {
+			return fmt.Errorf("progress %d/%d is not valid", game.Progress.Current, game.Progress.Final)
+		}
+	}
+	return nil
+}
+
+// parseImport parses the games from the body, as CSV if the content type is text/csv, and as JSON otherwise.
+func parseImport(contentType string, body io.Reader) ([]*importRecord, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "text/csv" {
+		return parseImportCSV(body)
+	}
+	return parseImportJSON(body)
+}
+
+// parseImportJSON parses either a list of games, or an object with a "games" list - the format returned by GET /games.
+func parseImportJSON(body io.Reader) ([]*importRecord, error) {
+	br := bufio.NewReader(body)
+	first, err := peekNonSpace(br)
+	if err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+
+	var games []*models.Game
+	if first == '[' {
+		err = json.NewDecoder(br).Decode(&games)
+	} else {
+		var resp models.GamesResponse
+		err = json.NewDecoder(br).Decode(&resp)
+		games = resp.Games
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+	if len(games) > maxImportGames {
+		return nil, errImportTooManyGames
+	}
+
+	records := make([]*importRecord, 0, len(games))
+	for _, game := range games {
+		if game == nil {
+			return nil, errors.New("error while decoding body: games can not be null")
+		}
+		game.Name = strings.TrimSpace(game.Name)
+		game.Franchise = strings.TrimSpace(game.Franchise)
+		if game.Progress != nil && game.Progress.Final == 0 {
+			game.Progress.Final = 100
+		}
+		records = append(records, &importRecord{game: game})
+	}
+	return records, nil
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return b, br.UnreadByte()
+		}
+	}
+}
+
+// parseImportCSV parses a CSV with a header row.
+// The columns are name (required), franchise, status and progress, in any order.
+// The progress is either the current progress (out of 100), or current/final, e.g. 3/10.
+func parseImportCSV(body io.Reader) ([]*importRecord, error) {
+	cr := csv.NewReader(body)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error while reading CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errImportNoNameColumn
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	records := []*importRecord{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error while reading CSV: %w", err)
+		}
+		if len(records) == maxImportGames {
+			return nil, errImportTooManyGames
+		}
+
+		game := &models.Game{
+			Name:      field(record, "name"),
+			Franchise: field(record, "franchise"),
+			Status:    models.Status(field(record, "status")),
+		}
+		// an unparsable progress makes only this game invalid, instead of failing the whole import
+		var progressErr error
+		if progress := field(record, "progress"); progress != "" {
+			game.Progress, progressErr = parseProgress(progress)
+		}
+		records = append(records, &importRecord{game: game, err: progressErr})
+	}
+	return records, nil
+}
+
+func parseProgress(progress string) (*models.GameProgress, error) {
+	current, final := progress, "100"
+	if i := strings.Index(progress, "/"); i >= 0 {
+		current, final = progress[:i], progress[i+1:]
+	}
+
+	c, err := strconv.Atoi(strings.TrimSpace(current))
+	if err != nil {
+		return nil, fmt.Errorf("progress %q is not valid", progress)
+	}
+	f, err := strconv.Atoi(strings.TrimSpace(final))
+	if err != nil {
+		return nil, fmt.Errorf("progress %q is not valid", progress)
+	}
+	return &models.GameProgress{Current: c, Final: f}, nil
+}
@@ -0,0 +1,319 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type importMocks struct {
+	gameModel      *fixtures.GameModelMock
+	franchiseModel *fixtures.FranchiseModelMock
+	goalModel      *fixtures.GoalModelMock
+}
+
+func newImportMocks(ctrl *gomock.Controller) *importMocks {
+	return &importMocks{
+		gameModel:      fixtures.NewGameModelMock(ctrl),
+		franchiseModel: fixtures.NewFranchiseModelMock(ctrl),
+		goalModel:      newTrackingGoalModel(ctrl, user.ID),
+	}
+}
+
+func (m *importMocks) options() *Options {
+	return &Options{
+		GameModel:      m.gameModel,
+		FranchiseModel: m.franchiseModel,
+		GoalModel:      m.goalModel,
+	}
+}
+
+func (m *importMocks) expectExisting() {
+	m.gameModel.EXPECT().
+		AllForUser(user.ID).
+		Return([]*models.Game{{ID: "1", Name: "AC"}}, nil)
+	m.franchiseModel.EXPECT().
+		All(user.ID).
+		Return([]*models.Franchise{{ID: "1", Name: "Assassin's Creed"}}, nil)
+}
+
+func newImportRequest(path, contentType, body string) *http.Request {
+	r := newTokenRequest(http.MethodPost, path, strings.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+	return r
+}
+
+func TestGamesImportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newImportMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.expectExisting()
+
+	// the new franchise is created in the same transaction as the games
+	mocks.gameModel.EXPECT().
+		InsertMany(user.ID, []string{"Far Cry"}, []*models.Game{
+			{Name: "ACII", Franchise: "assassin's creed", FranchiseID: "1", Status: models.StatusDone, Progress: &models.GameProgress{Current: 100, Final: 100}},
+			{Name: "Far Cry 3", Franchise: "Far Cry", Status: models.StatusInProgress, Progress: &models.GameProgress{Current: 3, Final: 10}},
+			{Name: "Hades"},
+		}).
+		Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest("/games/import", "text/csv", "Name,Franchise,Status,Progress\n"+
+		"ACII,assassin's creed,Done,100\n"+
+		"Far Cry 3,Far Cry,In Progress,3/10\n"+
+		"Hades,,,\n"))
+
+	gassert.StatusOK(t, w)
+
+	var result models.ImportResult
+	fixtures.Decode(t, w.Body, &result)
+	assert.Equal(t, models.ImportResult{
+		Mode:          models.ImportModeAtomic,
+		Imported:      3,
+		NewFranchises: []string{"Far Cry"},
+		Rows: []*models.ImportRow{
+			{Line: 1, Name: "ACII", Status: models.ImportRowOK},
+			{Line: 2, Name: "Far Cry 3", Status: models.ImportRowOK},
+			{Line: 3, Name: "Hades", Status: models.ImportRowOK},
+		},
+	}, result)
+}
+
+func TestGamesImportAtomicRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newImportMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.expectExisting()
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest("/games/import", "application/json",
+		`[{"name": "AC"}, {"name": "ACII", "franchise": "Far Cry"}, {"name": "ACII"}, {"name": ""}, {"name": "Hades", "status": "Unknown"}]`))
+
+	gassert.StatusCode(t, w, http.StatusConflict)
+
+	var result models.ImportResult
+	fixtures.Decode(t, w.Body, &result)
+	assert.Equal(t, 0, result.Imported)
+	assert.Empty(t, result.NewFranchises)
+	assert.Equal(t, []models.ImportRowStatus{
+		models.ImportRowConflict,
+		models.ImportRowSkipped,
+		models.ImportRowConflict,
+		models.ImportRowInvalid,
+		models.ImportRowInvalid,
+	}, rowStatuses(result))
+}
+
+func TestGamesImportDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newImportMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.expectExisting()
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest("/games/import?mode=best-effort&dryRun=true", "application/json",
+		`{"games": [{"name": "AC"}, {"name": "Far Cry 3", "franchise": "Far Cry"}]}`))
+
+	gassert.StatusOK(t, w)
+
+	var result models.ImportResult
+	fixtures.Decode(t, w.Body, &result)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 0, result.Imported)
+	assert.Equal(t, []string{"Far Cry"}, result.NewFranchises)
+	assert.Equal(t, []models.ImportRowStatus{models.ImportRowConflict, models.ImportRowOK}, rowStatuses(result))
+}
+
+func TestGamesImportBestEffort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newImportMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.expectExisting()
+
+	gomock.InOrder(
+		mocks.gameModel.EXPECT().
+			InsertMany(user.ID, nil, []*models.Game{{Name: "ACII"}}).
+			Return(nil, nil),
+		mocks.gameModel.EXPECT().
+			InsertMany(user.ID, nil, []*models.Game{{Name: "ACIII"}}).
+			Return(nil, postgres.ErrNameAlreadyExists),
+		mocks.gameModel.EXPECT().
+			InsertMany(user.ID, nil, []*models.Game{{Name: "ACIV"}}).
+			Return(nil, errors.New("intentional error")),
+	)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest("/games/import?mode=best-effort", "text/csv", "name,progress\n"+
+		"AC,\n"+
+		"ACII,\n"+
+		"ACIII,\n"+
+		"ACIV,\n"+
+		"ACV,abc\n"))
+
+	gassert.StatusOK(t, w)
+
+	var result models.ImportResult
+	fixtures.Decode(t, w.Body, &result)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, []models.ImportRowStatus{
+		models.ImportRowConflict,
+		models.ImportRowOK,
+		models.ImportRowConflict,
+		models.ImportRowFailed,
+		models.ImportRowInvalid,
+	}, rowStatuses(result))
+}
+
+func TestGamesImportBestEffortFranchiseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newImportMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.expectExisting()
+
+	// the games of the franchises, that could not be created, are not imported, but the rest of the games are
+	mocks.franchiseModel.EXPECT().
+		Insert(&models.Franchise{Name: "Far Cry", UserID: user.ID}).
+		Return(nil, errors.New("intentional error"))
+	mocks.franchiseModel.EXPECT().
+		Insert(&models.Franchise{Name: "Hitman", UserID: user.ID}).
+		Return(nil, postgres.ErrNameAlreadyExists)
+	mocks.franchiseModel.EXPECT().
+		Insert(&models.Franchise{Name: "Halo", UserID: user.ID}).
+		Return(&models.Franchise{ID: "2", Name: "Halo"}, nil)
+	gomock.InOrder(
+		mocks.gameModel.EXPECT().
+			InsertMany(user.ID, nil, []*models.Game{{Name: "ACII", Franchise: "Assassin's Creed", FranchiseID: "1"}}).
+			Return(nil, nil),
+		mocks.gameModel.EXPECT().
+			InsertMany(user.ID, nil, []*models.Game{{Name: "Halo 3", Franchise: "Halo", FranchiseID: "2"}}).
+			Return(nil, nil),
+	)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest("/games/import?mode=best-effort", "text/csv", "name,franchise\n"+
+		"ACII,Assassin's Creed\n"+
+		"Far Cry 3,Far Cry\n"+
+		"Far Cry 4,far cry\n"+
+		"Hitman 3,Hitman\n"+
+		"Halo 3,Halo\n"))
+
+	gassert.StatusOK(t, w)
+
+	var result models.ImportResult
+	fixtures.Decode(t, w.Body, &result)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, []string{"Halo"}, result.NewFranchises)
+	assert.Equal(t, []models.ImportRowStatus{
+		models.ImportRowOK,
+		models.ImportRowFailed,
+		models.ImportRowFailed,
+		models.ImportRowConflict,
+		models.ImportRowOK,
+	}, rowStatuses(result))
+}
+
+func TestGamesImportBadRequest(t *testing.T) {
+	testCases := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+	}{
+		{name: "Unknown mode", path: "/games/import?mode=unknown", contentType: "text/csv", body: "name\nAC\n"},
+		{name: "No name column", path: "/games/import", contentType: "text/csv", body: "franchise\nAC\n"},
+		{name: "Empty CSV", path: "/games/import", contentType: "text/csv", body: ""},
+		{name: "Invalid JSON", path: "/games/import", contentType: "application/json", body: "[{"},
+		{name: "Null game", path: "/games/import", contentType: "application/json", body: "[null]"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := newImportMocks(ctrl)
+			srv := newAuthorizedServer(t, ctrl, mocks.options())
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newImportRequest(testCase.path, testCase.contentType, testCase.body))
+
+			gassert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestGamesImportDBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newImportMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.expectExisting()
+
+	mocks.gameModel.EXPECT().
+		InsertMany(user.ID, gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("intentional error"))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest("/games/import", "text/csv", "name\nACII\n"))
+
+	gassert.StatusCode(t, w, http.StatusInternalServerError)
+}
+
+func TestGamesImportAtomicNewFranchiseDBError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "Name conflict", err: postgres.ErrNameAlreadyExists, code: http.StatusConflict},
+		{name: "Other error", err: errors.New("intentional error"), code: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := newImportMocks(ctrl)
+			srv := newAuthorizedServer(t, ctrl, mocks.options())
+			mocks.expectExisting()
+
+			// the franchise model mock fails the test, if the franchise is created outside of the transaction of the games
+			mocks.gameModel.EXPECT().
+				InsertMany(user.ID, []string{"Far Cry"}, []*models.Game{{Name: "Far Cry 3", Franchise: "Far Cry"}}).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newImportRequest("/games/import", "text/csv", "name,franchise\nFar Cry 3,Far Cry\n"))
+
+			gassert.StatusCode(t, w, testCase.code)
+		})
+	}
+}
+
+func rowStatuses(result models.ImportResult) []models.ImportRowStatus {
+	statuses := []models.ImportRowStatus{}
+	for _, row := range result.Rows {
+		statuses = append(statuses, row.Status)
+	}
+	return statuses
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-multierror"
+)
+
+var (
+	errTargetRequired    = errors.New("'target' should be a positive number")
+	errTargetNegative    = errors.New("'target' should not be negative")
+	errPeriodRequired    = errors.New("'startsAt' and 'endsAt' are required")
+	errPeriodInvalid     = errors.New("'endsAt' is before 'startsAt'")
+	errFranchiseRequired = errors.New("'franchiseId' is required")
+)
+
+// handleGoalsGet returns the goals of the user with their progress
+func (s *Server) handleGoalsGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		goals, err := s.GoalModel.Goals(user.ID, time.Now())
+		if err != nil {
+			s.Log.Errorf("Error while fetching goals from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, models.GoalsResponse{Goals: goals}, http.StatusOK)
+	}
+}
+
+// handleGoalCreate creates a goal for the user and returns it with its current progress
+func (s *Server) handleGoalCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var goal models.Goal
+		if err := json.NewDecoder(r.Body).Decode(&goal); err != nil {
+			s.respondError(w, r, "Error decoding body", http.StatusBadRequest)
+			return
+		}
+
+		if err := validateGoal(&goal); err != nil {
+			s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		goal.UserID = user.ID
+		created, err := s.GoalModel.InsertGoal(&goal, time.Now())
+		if err != nil {
+			if errors.Is(err, postgres.ErrUnknownFranchise) {
+				s.respondError(w, r, err.Error(), http.StatusBadRequest)
+				return
+			}
+			s.Log.Errorf("Error while inserting goal into the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, created, http.StatusCreated)
+	}
+}
+
+// handleGoalGet returns the given goal with its progress
+func (s *Server) handleGoalGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		goal, err := s.GoalModel.Goal(user.ID, mux.Vars(r)["id"], time.Now())
+		if err != nil {
+			s.goalError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, goal, http.StatusOK)
+	}
+}
+
+func (s *Server) handleGoalDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if err := s.GoalModel.DeleteGoal(user.ID, mux.Vars(r)["id"]); err != nil {
+			s.goalError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) goalError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, postgres.ErrNoRecord) {
+		s.respondError(w, r, "Goal not found", http.StatusNotFound)
+		return
+	}
+	s.Log.Errorf("Error while working with goal: %v", err)
+	s.internalError(w, r)
+}
+
+// trackGoals marks the goals, that the user reached with a change of their games, as achieved.
+// It should be called after every change, that can change the backlog of the user or the games of a franchise.
+// The tracking is best-effort, so an error does not fail the change.
+func (s *Server) trackGoals(userID string) {
+	if err := s.GoalModel.TrackGoals(userID, time.Now()); err != nil {
+		s.Log.Errorf("Error while tracking the goals of user %s: %v", userID, err)
+	}
+}
+
+// validateGoal validates the goal, depending on its kind.
+// The target of the finish-franchise goals is the number of games in the franchise, so it is not validated.
+func validateGoal(goal *models.Goal) error {
+	var err *multierror.Error
+	if goal.ID != "" {
+		err = multierror.Append(err, errIDNotAllowed)
+	}
+	if e := goal.Kind.Validate(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	switch goal.Kind {
+	case models.GoalFinishGames:
+		if goal.Target <= 0 {
+			err = multierror.Append(err, errTargetRequired)
+		}
+		if goal.StartsAt == nil || goal.EndsAt == nil {
+			err = multierror.Append(err, errPeriodRequired)
+		}
+	case models.GoalFinishFranchise:
+		if goal.FranchiseID == "" {
+			err = multierror.Append(err, errFranchiseRequired)
+		}
+	case models.GoalBacklog:
+		if goal.Target < 0 {
+			err = multierror.Append(err, errTargetNegative)
+		}
+	}
+	if goal.StartsAt != nil && goal.EndsAt != nil && goal.EndsAt.Before(*goal.StartsAt) {
+		err = multierror.Append(err, errPeriodInvalid)
+	}
+
+	return err.ErrorOrNil()
+}
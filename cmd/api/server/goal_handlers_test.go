@@ -0,0 +1,261 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	goalStart = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	goalEnd   = time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)
+	goal      = &models.Goal{
+		ID:       "1",
+		Kind:     models.GoalFinishGames,
+		Target:   12,
+		StartsAt: &goalStart,
+		EndsAt:   &goalEnd,
+		Current:  3,
+		Status:   models.GoalInProgress,
+	}
+)
+
+func TestGoalsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	goalModel := fixtures.NewGoalModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{GoalModel: goalModel})
+
+	goals := []*models.Goal{goal, {ID: "2", Kind: models.GoalBacklog, Target: 5, Current: 4, Status: models.GoalAchieved}}
+	goalModel.EXPECT().
+		Goals(user.ID, gomock.Any()).
+		Return(goals, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/goals", nil))
+
+	var res models.GoalsResponse
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, goals, res.Goals)
+}
+
+func TestGoalsGetError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	goalModel := fixtures.NewGoalModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{GoalModel: goalModel})
+
+	goalModel.EXPECT().
+		Goals(user.ID, gomock.Any()).
+		Return(nil, errors.New("intentional error"))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/goals", nil))
+
+	gassert.StatusCode(t, w, http.StatusInternalServerError)
+}
+
+func TestGoalCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	goalModel := fixtures.NewGoalModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{GoalModel: goalModel})
+
+	goalModel.EXPECT().
+		InsertGoal(&models.Goal{Kind: models.GoalFinishGames, Target: 12, StartsAt: &goalStart, EndsAt: &goalEnd, UserID: user.ID}, gomock.Any()).
+		Return(goal, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/goals", fixtures.Marshal(t, models.Goal{
+		Kind:     models.GoalFinishGames,
+		Target:   12,
+		StartsAt: &goalStart,
+		EndsAt:   &goalEnd,
+	})))
+
+	var res models.Goal
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusCode(t, w, http.StatusCreated)
+	require.Equal(t, *goal, res)
+}
+
+func TestGoalCreateError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		goal   models.Goal
+		err    error
+		status int
+	}{
+		{
+			name:   "Unknown kind",
+			goal:   models.Goal{Kind: "play-games", Target: 1},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "ID",
+			goal:   models.Goal{ID: "1", Kind: models.GoalBacklog},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Finish games without a target",
+			goal:   models.Goal{Kind: models.GoalFinishGames, StartsAt: &goalStart, EndsAt: &goalEnd},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Finish games without a period",
+			goal:   models.Goal{Kind: models.GoalFinishGames, Target: 12},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Ends before it starts",
+			goal:   models.Goal{Kind: models.GoalFinishGames, Target: 12, StartsAt: &goalEnd, EndsAt: &goalStart},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Finish franchise without a franchise",
+			goal:   models.Goal{Kind: models.GoalFinishFranchise},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Negative backlog",
+			goal:   models.Goal{Kind: models.GoalBacklog, Target: -1},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Unknown franchise",
+			goal:   models.Goal{Kind: models.GoalFinishFranchise, FranchiseID: "123"},
+			err:    postgres.ErrUnknownFranchise,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "DB error",
+			goal:   models.Goal{Kind: models.GoalBacklog, Target: 10},
+			err:    errors.New("intentional error"),
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			goalModel := fixtures.NewGoalModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{GoalModel: goalModel})
+			if testCase.err != nil {
+				goalModel.EXPECT().
+					InsertGoal(gomock.Any(), gomock.Any()).
+					Return(nil, testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/goals", fixtures.Marshal(t, testCase.goal)))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestGoalGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	goalModel := fixtures.NewGoalModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{GoalModel: goalModel})
+
+	goalModel.EXPECT().
+		Goal(user.ID, "1", gomock.Any()).
+		Return(goal, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/goals/1", nil))
+
+	var res models.Goal
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, *goal, res)
+}
+
+func TestGoalGetNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	goalModel := fixtures.NewGoalModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{GoalModel: goalModel})
+
+	goalModel.EXPECT().
+		Goal(user.ID, "1", gomock.Any()).
+		Return(nil, postgres.ErrNoRecord)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/goals/1", nil))
+
+	gassert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestGoalDelete(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "Success", status: http.StatusNoContent},
+		{name: "Not found", err: postgres.ErrNoRecord, status: http.StatusNotFound},
+		{name: "DB error", err: errors.New("intentional error"), status: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			goalModel := fixtures.NewGoalModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{GoalModel: goalModel})
+			goalModel.EXPECT().
+				DeleteGoal(user.ID, "1").
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodDelete, "/goals/1", nil))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestUsersGamesPatchTrackGoalsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	goalModel := fixtures.NewGoalModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{GoalModel: goalModel})
+	gameModel := fixtures.NewGameModelMock(ctrl)
+	srv.GameModel = gameModel
+
+	gameModel.EXPECT().
+		ChangeGameStatus(user.ID, "1", models.StatusDone).
+		Return(nil)
+	goalModel.EXPECT().
+		TrackGoals(user.ID, gomock.Any()).
+		Return(errors.New("intentional error"))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, models.ChangeGameStatusRequest{Status: models.StatusDone})))
+
+	// the status is changed, even if the goals could not be tracked
+	gassert.StatusOK(t, w)
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/asankov/gira/internal/importer"
+	"github.com/asankov/gira/pkg/models"
+)
+
+// handleGamesImportPreview parses the library export of a storefront (the source query parameter - steam or gog)
+// and returns the games in it, with their guessed franchises and statuses, and the duplicates marked.
+// Nothing is saved - the reviewed games are imported via POST /games/import.
+func (s *Server) handleGamesImportPreview() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		source := models.ImportSource(r.URL.Query().Get("source"))
+
+		library, err := importer.Parse(source, http.MaxBytesReader(w, r.Body, maxImportSize))
+		if err != nil {
+			if errors.Is(err, importer.ErrUnknownSource) {
+				s.respondError(w, r, "source must be one of steam, gog", http.StatusBadRequest)
+				return
+			}
+			s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(library) > maxImportGames {
+			s.respondError(w, r, errImportTooManyGames.Error(), http.StatusBadRequest)
+			return
+		}
+
+		existing, err := s.GameModel.AllForUser(user.ID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching games from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+		franchises, err := s.FranchiseModel.All(user.ID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching franchises from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, importer.Preview(source, library, existing, franchises), http.StatusOK)
+	}
+}
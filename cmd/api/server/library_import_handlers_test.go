@@ -0,0 +1,109 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGamesImportPreviewSteam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newImportMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.expectExisting()
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest("/games/import/preview?source=steam", "application/json", `{"response": {"games": [
+		{"appid": 1, "name": "AC", "playtime_forever": 0},
+		{"appid": 2, "name": "Assassin's Creed II", "playtime_forever": 30},
+		{"appid": 3, "name": "Hades", "playtime_forever": 0}
+	]}}`))
+
+	gassert.StatusOK(t, w)
+
+	var preview models.LibraryPreview
+	fixtures.Decode(t, w.Body, &preview)
+	assert.Equal(t, models.LibraryPreview{
+		Source: models.ImportSourceSteam,
+		Games: []*models.LibraryCandidate{
+			{Name: "AC", Status: models.StatusTODO, DuplicateOf: "AC"},
+			{Name: "Assassin's Creed II", Franchise: "Assassin's Creed", Status: models.StatusInProgress, Playtime: 30},
+			{Name: "Hades", Status: models.StatusTODO},
+		},
+	}, preview)
+}
+
+func TestGamesImportPreviewGOG(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newImportMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.expectExisting()
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest("/games/import/preview?source=gog", "text/csv", "title;gameMins\nHades;15\n"))
+
+	gassert.StatusOK(t, w)
+
+	var preview models.LibraryPreview
+	fixtures.Decode(t, w.Body, &preview)
+	assert.Equal(t, models.LibraryPreview{
+		Source: models.ImportSourceGOG,
+		Games: []*models.LibraryCandidate{
+			{Name: "Hades", Status: models.StatusInProgress, Playtime: 15},
+		},
+	}, preview)
+}
+
+func TestGamesImportPreviewBadRequest(t *testing.T) {
+	testCases := []struct {
+		name string
+		path string
+		body string
+	}{
+		{name: "No source", path: "/games/import/preview", body: `{"response": {"games": []}}`},
+		{name: "Unknown source", path: "/games/import/preview?source=epic", body: `{"response": {"games": []}}`},
+		{name: "Invalid Steam export", path: "/games/import/preview?source=steam", body: `{`},
+		{name: "GOG export without title", path: "/games/import/preview?source=gog", body: "name\nHades\n"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := newImportMocks(ctrl)
+			srv := newAuthorizedServer(t, ctrl, mocks.options())
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newImportRequest(testCase.path, "application/octet-stream", testCase.body))
+
+			gassert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestGamesImportPreviewDBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newImportMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options())
+	mocks.gameModel.EXPECT().
+		AllForUser(user.ID).
+		Return(nil, errors.New("intentional error"))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest("/games/import/preview?source=gog", "text/csv", "title\nHades\n"))
+
+	gassert.StatusCode(t, w, http.StatusInternalServerError)
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// streamHeartbeat is how often a comment is sent on an idle stream, so that the proxies do not close it
+const streamHeartbeat = 30 * time.Second
+
+// handleChangesStream streams the changes of the games, that the user can see, as Server-Sent Events.
+// Each change is sent as an event with the type of the change as its name and the change as JSON data.
+func (s *Server) handleChangesStream() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if s.ChangeBroker == nil {
+			s.respondError(w, r, "Live updates are disabled", http.StatusNotFound)
+			return
+		}
+
+		changes, cancel := s.ChangeBroker.Subscribe(user.ID)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		// nginx buffers the responses by default, which would hold the events back
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		rc := http.NewResponseController(w)
+		send := func(format string, args ...interface{}) bool {
+			if _, err := fmt.Fprintf(w, format, args...); err != nil {
+				return false
+			}
+			if err := rc.Flush(); err != nil {
+				s.Log.Errorf("Error while flushing the stream of changes: %v", err)
+				return false
+			}
+			return true
+		}
+
+		if !send(": connected\n\n") {
+			return
+		}
+
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case change, ok := <-changes:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(change)
+				if err != nil {
+					s.Log.Errorf("Error while encoding %s change: %v", change.Type, err)
+					continue
+				}
+				if !send("event: %s\ndata: %s\n\n", change.Type, data) {
+					return
+				}
+			case <-heartbeat.C:
+				if !send(": heartbeat\n\n") {
+					return
+				}
+			}
+		}
+	}
+}
+
+// gameViewers returns the users, that can see the game, if the changes are streamed live.
+// The live updates are best-effort, so an error while looking up the users does not fail the request.
+func (s *Server) gameViewers(gameID string) []string {
+	if s.ChangeBroker == nil {
+		return nil
+	}
+	userIDs, err := s.GameModel.Viewers(gameID)
+	if err != nil {
+		s.Log.Errorf("Error while fetching the viewers of game %s: %v", gameID, err)
+		return nil
+	}
+	return userIDs
+}
+
+// gameChanged dispatches the change of a game to the webhooks of the user, that made it,
+// and streams it to the given users, that can see the game.
+// It is called after each change of the status or the progress of a game, whether it is changed directly or through its playthroughs or checklist,
+// so that the webhooks and the live updates get the same events.
+func (s *Server) gameChanged(userID string, viewers []string, change *models.Change) {
+	switch change.Type {
+	case models.ChangeGameCreated:
+		s.dispatchWebhook(userID, &models.WebhookPayload{Event: models.WebhookGameCreated, GameID: change.GameID, Game: change.Game})
+	case models.ChangeGameUpdated:
+		if change.Status != "" {
+			s.dispatchWebhook(userID, &models.WebhookPayload{Event: models.WebhookGameStatusChanged, GameID: change.GameID, Status: change.Status})
+		}
+		if change.Progress != nil {
+			s.dispatchWebhook(userID, &models.WebhookPayload{Event: models.WebhookGameProgressChanged, GameID: change.GameID, Progress: change.Progress})
+		}
+	case models.ChangeGameDeleted:
+		s.dispatchWebhook(userID, &models.WebhookPayload{Event: models.WebhookGameDeleted, GameID: change.GameID})
+	}
+
+	if s.ChangeBroker == nil || len(viewers) == 0 {
+		return
+	}
+	s.ChangeBroker.Publish(viewers, change)
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangesStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	broker := fixtures.NewChangeBrokerMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{GameModel: fixtures.NewGameModelMock(ctrl), ChangeBroker: broker})
+
+	// the channel is closed after the change, so that the stream ends
+	changes := make(chan *models.Change, 1)
+	changes <- &models.Change{Type: models.ChangeGameUpdated, GameID: "1", Status: models.StatusDone}
+	close(changes)
+	cancelled := false
+	broker.EXPECT().
+		Subscribe(user.ID).
+		Return((<-chan *models.Change)(changes), func() { cancelled = true })
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/changes", nil))
+
+	gassert.StatusOK(t, w)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Equal(t, ": connected\n\n"+
+		"event: game.updated\n"+
+		`data: {"type":"game.updated","gameId":"1","status":"Done"}`+"\n\n", w.Body.String())
+	assert.True(t, cancelled, "the subscription should be cancelled")
+}
+
+func TestChangesStreamDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := newAuthorizedServer(t, ctrl, &Options{GameModel: fixtures.NewGameModelMock(ctrl), ChangeBroker: fixtures.NewChangeBrokerMock(ctrl)})
+	srv.ChangeBroker = nil
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/changes", nil))
+
+	gassert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestChangePublish(t *testing.T) {
+	progress := &models.GameProgress{Current: 10, Final: 100}
+	testCases := []struct {
+		name    string
+		request *http.Request
+		expect  func(m *dispatchMocks)
+		viewers []string
+		changes []*models.Change
+	}{
+		{
+			name:    "Game created",
+			request: newTokenRequest(http.MethodPost, "/games", fixtures.Marshal(t, models.Game{Name: "Hades"})),
+			expect: func(m *dispatchMocks) {
+				m.gameModel.EXPECT().
+					Insert(&models.Game{Name: "Hades", UserID: user.ID}).
+					Return(&models.Game{ID: "1", Name: "Hades"}, nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+			},
+			viewers: []string{user.ID, "2"},
+			changes: []*models.Change{{Type: models.ChangeGameCreated, GameID: "1", Game: &models.Game{ID: "1", Name: "Hades"}}},
+		},
+		{
+			name:    "Status and progress changed",
+			request: newTokenRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, models.ChangeGameStatusRequest{Status: models.StatusDone, Progress: progress})),
+			expect: func(m *dispatchMocks) {
+				m.gameModel.EXPECT().
+					ChangeGameStatus(user.ID, "1", models.StatusDone).
+					Return(nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+				m.gameModel.EXPECT().
+					ChangeGameProgress(user.ID, "1", progress).
+					Return(nil)
+			},
+			viewers: []string{user.ID, "2"},
+			changes: []*models.Change{
+				{Type: models.ChangeGameUpdated, GameID: "1", Status: models.StatusDone},
+				{Type: models.ChangeGameUpdated, GameID: "1", Progress: progress},
+			},
+		},
+		{
+			name:    "Deleted",
+			request: newTokenRequest(http.MethodDelete, "/games/1", nil),
+			expect: func(m *dispatchMocks) {
+				m.gameModel.EXPECT().
+					DeleteGame(user.ID, "1").
+					Return(nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+			},
+			viewers: []string{user.ID, "2"},
+			changes: []*models.Change{{Type: models.ChangeGameDeleted, GameID: "1"}},
+		},
+		{
+			name:    "Game imported",
+			request: newImportRequest("/games/import", "text/csv", "name\nHades\n"),
+			expect: func(m *dispatchMocks) {
+				m.gameModel.EXPECT().
+					AllForUser(user.ID).
+					Return([]*models.Game{}, nil)
+				m.franchiseModel.EXPECT().
+					All(user.ID).
+					Return([]*models.Franchise{}, nil)
+				m.gameModel.EXPECT().
+					InsertMany(user.ID, []string{}, []*models.Game{{Name: "Hades"}}).
+					Return([]*models.Game{{ID: "1", Name: "Hades"}}, nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+			},
+			// the imported games are not on a board, so they are not looked up
+			changes: []*models.Change{{Type: models.ChangeGameCreated, GameID: "1", Game: &models.Game{ID: "1", Name: "Hades"}}},
+		},
+		{
+			name:    "Status changed by playthrough",
+			request: newTokenRequest(http.MethodPut, "/games/1/playthroughs/2", fixtures.Marshal(t, playthrough)),
+			expect: func(m *dispatchMocks) {
+				m.playthroughModel.EXPECT().
+					UpdatePlaythrough(user.ID, playthrough).
+					Return(playthrough, models.StatusDone, nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+			},
+			viewers: []string{user.ID, "2"},
+			changes: []*models.Change{{Type: models.ChangeGameUpdated, GameID: "1", Status: models.StatusDone}},
+		},
+		{
+			name:    "Progress changed by checklist",
+			request: newTokenRequest(http.MethodDelete, "/games/1/checklist/items/3", nil),
+			expect: func(m *dispatchMocks) {
+				m.checklistModel.EXPECT().
+					DeleteChecklistItem(user.ID, "1", "3").
+					Return(&models.Checklist{AutoProgress: true, Progress: progress, ProgressChanged: true}, nil)
+			},
+			viewers: []string{user.ID, "2"},
+			changes: []*models.Change{{Type: models.ChangeGameUpdated, GameID: "1", Progress: progress}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gameModel := fixtures.NewGameModelMock(ctrl)
+			broker := fixtures.NewChangeBrokerMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{GameModel: gameModel, ChangeBroker: broker})
+			m := &dispatchMocks{
+				gameModel:        gameModel,
+				goalModel:        fixtures.NewGoalModelMock(ctrl),
+				franchiseModel:   fixtures.NewFranchiseModelMock(ctrl),
+				playthroughModel: fixtures.NewPlaythroughModelMock(ctrl),
+				checklistModel:   fixtures.NewChecklistModelMock(ctrl),
+			}
+			srv.GoalModel = m.goalModel
+			srv.FranchiseModel = m.franchiseModel
+			srv.PlaythroughModel = m.playthroughModel
+			srv.ChecklistModel = m.checklistModel
+
+			testCase.expect(m)
+			viewers := []string{user.ID}
+			if testCase.viewers != nil {
+				// the game is on a board, so all its members get the change
+				viewers = testCase.viewers
+				gameModel.EXPECT().
+					Viewers("1").
+					Return(testCase.viewers, nil)
+			}
+			for _, change := range testCase.changes {
+				broker.EXPECT().
+					Publish(viewers, change)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, testCase.request)
+
+			gassert.StatusOK(t, w)
+		})
+	}
+}
+
+func TestChangePublishSkipped(t *testing.T) {
+	testCases := []struct {
+		name       string
+		request    *http.Request
+		expect     func(gameModel *fixtures.GameModelMock)
+		viewersErr error
+	}{
+		{
+			name:    "Other field changed",
+			request: newTokenRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, models.ChangeGameStatusRequest{Rating: new(int)})),
+			expect: func(gameModel *fixtures.GameModelMock) {
+				gameModel.EXPECT().
+					ChangeGameRating(user.ID, "1", 0).
+					Return(nil)
+			},
+		},
+		{
+			name:    "Viewers error",
+			request: newTokenRequest(http.MethodDelete, "/games/1", nil),
+			expect: func(gameModel *fixtures.GameModelMock) {
+				gameModel.EXPECT().
+					Viewers("1").
+					Return(nil, errors.New("intentional error"))
+				gameModel.EXPECT().
+					DeleteGame(user.ID, "1").
+					Return(nil)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gameModel := fixtures.NewGameModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{GameModel: gameModel, GoalModel: newTrackingGoalModel(ctrl, user.ID), ChangeBroker: fixtures.NewChangeBrokerMock(ctrl)})
+
+			// the broker mock fails the test, if anything is published
+			testCase.expect(gameModel)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, testCase.request)
+
+			gassert.StatusOK(t, w)
+		})
+	}
+}
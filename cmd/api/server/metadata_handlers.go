@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/asankov/gira/internal/importer"
+	"github.com/asankov/gira/internal/metadata"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/gorilla/mux"
+)
+
+// maxMetadataQueryLength is the maximum length of a metadata search query
+const maxMetadataQueryLength = 100
+
+var (
+	errNoMetadataProvider = errors.New("no metadata provider is configured")
+	errUnknownMetadataID  = errors.New("'metadataId' does not match any game in the metadata provider")
+)
+
+// handleMetadataSearch searches the metadata provider for games, whose names match the q query parameter.
+func (s *Server) handleMetadataSearch() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if s.MetadataProvider == nil {
+			s.respondError(w, r, errNoMetadataProvider.Error(), http.StatusNotImplemented)
+			return
+		}
+
+		query := strings.TrimSpace(r.URL.Query().Get("q"))
+		if query == "" || len(query) > maxMetadataQueryLength {
+			s.respondError(w, r, "'q' is required parameter and can be at most 100 characters", http.StatusBadRequest)
+			return
+		}
+
+		games, err := s.MetadataProvider.Search(r.Context(), query)
+		if err != nil {
+			s.Log.Errorf("Error while searching metadata for %q: %v", query, err)
+			s.respondError(w, r, "Error while fetching metadata", http.StatusBadGateway)
+			return
+		}
+		if games == nil {
+			games = []*models.GameMetadata{}
+		}
+
+		s.respond(w, r, models.GameMetadataResponse{Games: games}, http.StatusOK)
+	}
+}
+
+// handleMetadataGet returns the details of the given game from the metadata provider,
+// along with the franchise of the user, that the game most likely belongs to.
+func (s *Server) handleMetadataGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if s.MetadataProvider == nil {
+			s.respondError(w, r, errNoMetadataProvider.Error(), http.StatusNotImplemented)
+			return
+		}
+
+		id := mux.Vars(r)["id"]
+		game, err := s.MetadataProvider.Details(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, metadata.ErrNotFound) {
+				s.respondError(w, r, "Game not found", http.StatusNotFound)
+				return
+			}
+			s.Log.Errorf("Error while fetching metadata of game %s: %v", id, err)
+			s.respondError(w, r, "Error while fetching metadata", http.StatusBadGateway)
+			return
+		}
+
+		franchises, err := s.FranchiseModel.All(user.ID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching franchises from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+		if franchise := importer.MatchFranchise(game.Name, franchises); franchise != nil {
+			game.FranchiseID = franchise.ID
+		}
+
+		s.respond(w, r, game, http.StatusOK)
+	}
+}
+
+// fillMetadata fills the details of the game from the metadata provider, by its MetadataID.
+func (s *Server) fillMetadata(ctx context.Context, game *models.Game) error {
+	if s.MetadataProvider == nil {
+		return errNoMetadataProvider
+	}
+
+	details, err := s.MetadataProvider.Details(ctx, game.MetadataID)
+	if err != nil {
+		if errors.Is(err, metadata.ErrNotFound) {
+			return errUnknownMetadataID
+		}
+		return err
+	}
+
+	game.CoverURL = details.CoverURL
+	game.Developer = details.Developer
+	game.Genres = details.Genres
+	if game.ReleaseDate == nil {
+		game.ReleaseDate = details.ReleaseDate
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/internal/metadata"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type metadataMocks struct {
+	gameModel      *fixtures.GameModelMock
+	franchiseModel *fixtures.FranchiseModelMock
+	goalModel      *fixtures.GoalModelMock
+}
+
+func newMetadataMocks(ctrl *gomock.Controller) *metadataMocks {
+	return &metadataMocks{
+		gameModel:      fixtures.NewGameModelMock(ctrl),
+		franchiseModel: fixtures.NewFranchiseModelMock(ctrl),
+		goalModel:      newTrackingGoalModel(ctrl, user.ID),
+	}
+}
+
+// options returns the options of a server with the given metadata provider.
+// The provider is not set, if it is nil.
+func (m *metadataMocks) options(provider *fixtures.MetadataProvider) *Options {
+	opts := &Options{
+		GameModel:      m.gameModel,
+		FranchiseModel: m.franchiseModel,
+		GoalModel:      m.goalModel,
+	}
+	if provider != nil {
+		opts.MetadataProvider = provider
+	}
+	return opts
+}
+
+func TestMetadataSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := fixtures.NewMetadataProvider()
+	mocks := newMetadataMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options(provider))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/metadata/games?q=far+cry", nil))
+
+	gassert.StatusOK(t, w)
+
+	var res models.GameMetadataResponse
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, []*models.GameMetadata{provider.Games[1]}, res.Games)
+}
+
+func TestMetadataSearchNoResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMetadataMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options(fixtures.NewMetadataProvider()))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/metadata/games?q=unknown", nil))
+
+	gassert.StatusOK(t, w)
+	assert.JSONEq(t, `{"games": []}`, w.Body.String())
+}
+
+func TestMetadataSearchError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		path         string
+		provider     *fixtures.MetadataProvider
+		providerErr  error
+		expectedCode int
+	}{
+		{name: "No query", path: "/metadata/games", provider: fixtures.NewMetadataProvider(), expectedCode: http.StatusBadRequest},
+		{name: "Blank query", path: "/metadata/games?q=+", provider: fixtures.NewMetadataProvider(), expectedCode: http.StatusBadRequest},
+		{name: "No provider", path: "/metadata/games?q=hades", expectedCode: http.StatusNotImplemented},
+		{name: "Provider error", path: "/metadata/games?q=hades", provider: fixtures.NewMetadataProvider(), providerErr: metadata.ErrProvider, expectedCode: http.StatusBadGateway},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			if testCase.provider != nil {
+				testCase.provider.Err = testCase.providerErr
+			}
+			mocks := newMetadataMocks(ctrl)
+			srv := newAuthorizedServer(t, ctrl, mocks.options(testCase.provider))
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, testCase.path, nil))
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
+
+func TestMetadataGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := fixtures.NewMetadataProvider()
+	mocks := newMetadataMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options(provider))
+
+	mocks.franchiseModel.EXPECT().
+		All(user.ID).
+		Return([]*models.Franchise{{ID: "1", Name: "Assassin's Creed"}, {ID: "7", Name: "Far Cry"}}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/metadata/games/4200", nil))
+
+	gassert.StatusOK(t, w)
+
+	expected := *provider.Games[1]
+	expected.FranchiseID = "7"
+	var res models.GameMetadata
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, expected, res)
+}
+
+func TestMetadataGetNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := newMetadataMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options(fixtures.NewMetadataProvider()))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/metadata/games/1", nil))
+
+	gassert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestCreateGameWithMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := fixtures.NewMetadataProvider()
+	mocks := newMetadataMocks(ctrl)
+	srv := newAuthorizedServer(t, ctrl, mocks.options(provider))
+
+	expectedGame := &models.Game{
+		Name:        "The Witcher 3",
+		FranchiseID: "1",
+		MetadataID:  "3328",
+		CoverURL:    provider.Games[0].CoverURL,
+		Developer:   provider.Games[0].Developer,
+		Genres:      provider.Games[0].Genres,
+		ReleaseDate: provider.Games[0].ReleaseDate,
+	}
+	mocks.gameModel.EXPECT().
+		Insert(expectedGame).
+		Return(expectedGame, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/games", fixtures.Marshal(t, &models.Game{
+		Name:        "The Witcher 3",
+		FranchiseID: "1",
+		MetadataID:  "3328",
+		// the details are always taken from the provider
+		CoverURL:  "https://evil.example.com/cover.jpg",
+		Developer: "Someone else",
+	})))
+
+	gassert.StatusOK(t, w)
+	assert.Equal(t, 1, provider.Lookups())
+}
+
+func TestCreateGameWithMetadataError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		provider     *fixtures.MetadataProvider
+		providerErr  error
+		metadataID   string
+		expectedCode int
+	}{
+		{name: "No provider", metadataID: "3328", expectedCode: http.StatusBadRequest},
+		{name: "Unknown game", provider: fixtures.NewMetadataProvider(), metadataID: "1", expectedCode: http.StatusBadRequest},
+		{name: "Provider error", provider: fixtures.NewMetadataProvider(), providerErr: metadata.ErrProvider, metadataID: "3328", expectedCode: http.StatusBadGateway},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			if testCase.provider != nil {
+				testCase.provider.Err = testCase.providerErr
+			}
+			mocks := newMetadataMocks(ctrl)
+			srv := newAuthorizedServer(t, ctrl, mocks.options(testCase.provider))
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/games", fixtures.Marshal(t, &models.Game{
+				Name:       "The Witcher 3",
+				MetadataID: testCase.metadataID,
+			})))
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
@@ -2,10 +2,17 @@ package server
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/asankov/gira/internal/middleware"
 	"github.com/asankov/gira/pkg/models"
 )
 
+var (
+	defaultRateLimit = middleware.Limit{Requests: 100, Period: time.Minute}
+	loginRateLimit   = middleware.Limit{Requests: 10, Period: time.Minute}
+)
+
 type authorizedHandler func(http.ResponseWriter, *http.Request, *models.User, string)
 
 func (s *Server) requireLogin(next authorizedHandler) http.Handler {
@@ -33,3 +40,49 @@ func (s *Server) requireLogin(next authorizedHandler) http.Handler {
 		next(w, r, user, token)
 	})
 }
+
+// rateLimit returns a middleware that limits the requests to the given limit.
+// If there is no RateLimitStore, the requests are not limited.
+func (s *Server) rateLimit(name string, limit middleware.Limit) func(http.Handler) http.Handler {
+	if s.RateLimitStore == nil {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	return middleware.RateLimit(&middleware.RateLimitOptions{
+		Name:  name,
+		Limit: limit,
+		Store: s.RateLimitStore,
+		Key:   s.rateLimitKey,
+		Log:   s.Log,
+	})
+}
+
+// rateLimitKey limits the requests of authenticated users per user,
+// so that users behind the same IP do not share the limit,
+// and the rest of the requests per client IP.
+// The token is only decoded, not checked against the DB,
+// since that is done by requireLogin, if needed.
+func (s *Server) rateLimitKey(r *http.Request) string {
+	if token := r.Header.Get(models.XAuthToken); token != "" && s.Authenticator != nil {
+		if user, err := s.Authenticator.DecodeToken(token); err == nil && user != nil && user.ID != "" {
+			return "user:" + user.ID
+		}
+	}
+	return "ip:" + middleware.ClientIP(r, s.TrustedProxies)
+}
+
+func (s *Server) defaultRateLimit() middleware.Limit {
+	if s.RateLimits == nil {
+		return defaultRateLimit
+	}
+	return s.RateLimits.Default
+}
+
+func (s *Server) loginRateLimit() middleware.Limit {
+	if s.RateLimits == nil {
+		return loginRateLimit
+	}
+	return s.RateLimits.Login
+}
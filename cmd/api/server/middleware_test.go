@@ -5,12 +5,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	gassert "github.com/asankov/gira/internal/fixtures/assert"
 
 	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/middleware"
 	"github.com/asankov/gira/pkg/models"
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
@@ -115,3 +117,52 @@ func TestRequireLoginError(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimitKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	authenticator := fixtures.NewAuthenticatorMock(ctrl)
+	srv := setupMiddlewareServer(authenticator, nil)
+
+	authenticator.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(&models.User{ID: "user-id"}, nil)
+	authenticator.EXPECT().
+		DecodeToken(gomock.Eq("invalid")).
+		Return(nil, errors.New("invalid token"))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(models.XAuthToken, token)
+	assert.Equal(t, "user:user-id", srv.rateLimitKey(r))
+
+	r.Header.Set(models.XAuthToken, "invalid")
+	assert.Equal(t, "ip:192.0.2.1", srv.rateLimitKey(r))
+
+	r.Header.Del(models.XAuthToken)
+	assert.Equal(t, "ip:192.0.2.1", srv.rateLimitKey(r))
+}
+
+func TestRateLimitLogin(t *testing.T) {
+	srv := newServer(t, &Options{
+		RateLimitStore: middleware.NewMemoryStore(),
+		RateLimits: &RateLimits{
+			Default: middleware.Limit{Requests: 10, Period: time.Minute},
+			Login:   middleware.Limit{Requests: 1, Period: time.Minute},
+		},
+	})
+
+	// the body is invalid, so that the handler returns before calling the models
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users/login", nil)
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusBadRequest)
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Limit"))
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/users/login", nil)
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusTooManyRequests)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+}
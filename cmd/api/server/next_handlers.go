@@ -0,0 +1,83 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/asankov/gira/internal/recommend"
+	"github.com/asankov/gira/pkg/models"
+)
+
+// maxSuggestionsLimit is the maximum number of suggestions, that can be requested
+const maxSuggestionsLimit = 100
+
+// maxHeuristicWeight is the maximum weight of a heuristic. It is high enough to make a single heuristic dominate the others.
+const maxHeuristicWeight = 100
+
+// handleGamesNext suggests what the user should play next, ranking the To Do games by the configured heuristics.
+func (s *Server) handleGamesNext() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		config, err := suggestionsConfig(r)
+		if err != nil {
+			s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		games, err := s.GameModel.AllForUser(user.ID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching games from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, models.SuggestionsResponse{Suggestions: recommend.Suggest(games, config)}, http.StatusOK)
+	}
+}
+
+// suggestionsConfig reads the configuration of the suggestions from the query:
+// ?weights=franchise:2,age:0 sets the weights of the heuristics, ?tags=RPG,Action the preferred genres
+// and ?limit= the number of suggestions.
+func suggestionsConfig(r *http.Request) (recommend.Config, error) {
+	query := r.URL.Query()
+	config := recommend.Config{Weights: map[models.Heuristic]float64{}}
+
+	if weights := query.Get("weights"); weights != "" {
+		for _, pair := range strings.Split(weights, ",") {
+			name, value, ok := strings.Cut(pair, ":")
+			if !ok {
+				return config, fmt.Errorf("'weights' should be a list of heuristic:weight pairs, got %q", pair)
+			}
+			heuristic := models.Heuristic(strings.TrimSpace(name))
+			if err := heuristic.Validate(); err != nil {
+				return config, err
+			}
+			weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			// ParseFloat accepts NaN and Inf, which would make all scores NaN
+			if err != nil || math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 0 || weight > maxHeuristicWeight {
+				return config, fmt.Errorf("the weight of %s should be a number between 0 and %d", heuristic, maxHeuristicWeight)
+			}
+			config.Weights[heuristic] = weight
+		}
+	}
+
+	if tags := query.Get("tags"); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				config.Tags = append(config.Tags, tag)
+			}
+		}
+	}
+
+	if l := query.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit <= 0 || limit > maxSuggestionsLimit {
+			return config, fmt.Errorf("'limit' should be a number between 1 and %d", maxSuggestionsLimit)
+		}
+		config.Limit = limit
+	}
+
+	return config, nil
+}
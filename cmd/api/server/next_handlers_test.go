@@ -0,0 +1,98 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGamesNext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gameModel := fixtures.NewGameModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{GameModel: gameModel})
+	gameModel.EXPECT().
+		AllForUser(user.ID).
+		Return([]*models.Game{
+			{ID: "1", Name: "Witcher 1", Franchise: "Witcher", FranchiseID: "1", Status: models.StatusDone, Genres: []string{"RPG"}},
+			{ID: "2", Name: "Witcher 2", Franchise: "Witcher", FranchiseID: "1", Status: models.StatusTODO, Genres: []string{"RPG"}},
+			{ID: "3", Name: "Doom", Status: models.StatusTODO, Genres: []string{"Shooter"}},
+			{ID: "4", Name: "Hades", Status: models.StatusInProgress},
+		}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/games/next?weights=franchise:3,progress:0,age:0,rating:0&tags=shooter&limit=5", nil))
+
+	gassert.StatusOK(t, w)
+
+	var res models.SuggestionsResponse
+	fixtures.Decode(t, w.Body, &res)
+	require.Len(t, res.Suggestions, 2)
+
+	first := res.Suggestions[0]
+	assert.Equal(t, "2", first.Game.ID)
+	assert.Equal(t, 3.0, first.Score)
+	assert.Equal(t, []*models.SuggestionReason{
+		{Heuristic: models.HeuristicFranchise, Value: 1, Weight: 3, Points: 3, Explanation: "Comes next in the Witcher franchise, after Witcher 1"},
+		{Heuristic: models.HeuristicTags, Value: 0, Weight: 1, Points: 0, Explanation: "None of the preferred genres"},
+	}, first.Reasons)
+
+	second := res.Suggestions[1]
+	assert.Equal(t, "3", second.Game.ID)
+	assert.Equal(t, 2.5, second.Score)
+}
+
+func TestGamesNextBadRequest(t *testing.T) {
+	testCases := []struct {
+		name string
+		path string
+	}{
+		{name: "Unknown heuristic", path: "/games/next?weights=popularity:1"},
+		{name: "Missing weight", path: "/games/next?weights=age"},
+		{name: "Invalid weight", path: "/games/next?weights=age:abc"},
+		{name: "Negative weight", path: "/games/next?weights=age:-1"},
+		{name: "NaN weight", path: "/games/next?weights=age:NaN"},
+		{name: "Infinite weight", path: "/games/next?weights=age:+Inf"},
+		{name: "Too big weight", path: "/games/next?weights=age:101"},
+		{name: "Invalid limit", path: "/games/next?limit=abc"},
+		{name: "Too big limit", path: "/games/next?limit=101"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := newAuthorizedServer(t, ctrl, &Options{GameModel: fixtures.NewGameModelMock(ctrl)})
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, testCase.path, nil))
+
+			gassert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestGamesNextError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gameModel := fixtures.NewGameModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{GameModel: gameModel})
+	gameModel.EXPECT().
+		AllForUser(user.ID).
+		Return(nil, errors.New("this is an intentional error"))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/games/next", nil))
+
+	gassert.StatusCode(t, w, http.StatusInternalServerError)
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// defaultNotificationsLimit is the number of notifications returned, if no limit is requested
+	defaultNotificationsLimit = 20
+	// maxNotificationsLimit is the maximum number of notifications returned at once
+	maxNotificationsLimit = 100
+)
+
+var (
+	errStaleAfterDays = fmt.Errorf("'staleAfterDays' should be a number between 1 and %d", models.MaxStaleAfterDays)
+	errWebhookURL     = errors.New("'webhookUrl' should be an absolute http or https URL")
+)
+
+// handleNotificationsGet returns the latest notifications of the user and the number of the unread ones
+func (s *Server) handleNotificationsGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		limit := defaultNotificationsLimit
+		if l := r.URL.Query().Get("limit"); l != "" {
+			var err error
+			if limit, err = strconv.Atoi(l); err != nil || limit <= 0 || limit > maxNotificationsLimit {
+				s.respondError(w, r, "'limit' should be a number between 1 and 100", http.StatusBadRequest)
+				return
+			}
+		}
+
+		notifications, unread, err := s.NotificationModel.Notifications(user.ID, limit)
+		if err != nil {
+			s.Log.Errorf("Error while fetching notifications from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, models.NotificationsResponse{Notifications: notifications, Unread: unread}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleNotificationRead() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if err := s.NotificationModel.MarkNotificationRead(user.ID, mux.Vars(r)["id"]); err != nil {
+			if errors.Is(err, postgres.ErrNoRecord) {
+				s.respondError(w, r, "Notification not found", http.StatusNotFound)
+				return
+			}
+			s.Log.Errorf("Error while marking notification as read: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) handleNotificationsRead() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if err := s.NotificationModel.MarkAllNotificationsRead(user.ID); err != nil {
+			s.Log.Errorf("Error while marking notifications as read: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) handleNotificationPreferencesGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		prefs, err := s.NotificationModel.NotificationPreferences(user.ID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching notification preferences from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, prefs, http.StatusOK)
+	}
+}
+
+func (s *Server) handleNotificationPreferencesPut() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var prefs models.NotificationPreferences
+		if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+			s.respondError(w, r, "Error decoding body", http.StatusBadRequest)
+			return
+		}
+
+		if err := validateNotificationPreferences(&prefs); err != nil {
+			s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := s.NotificationModel.SetNotificationPreferences(user.ID, &prefs); err != nil {
+			s.Log.Errorf("Error while saving notification preferences into the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, prefs, http.StatusOK)
+	}
+}
+
+// validateNotificationPreferences validates the preferences.
+// The webhook URL is optional, but if set, it should be an URL, that the API can post to.
+func validateNotificationPreferences(prefs *models.NotificationPreferences) error {
+	var err *multierror.Error
+	if prefs.StaleAfterDays < 1 || prefs.StaleAfterDays > models.MaxStaleAfterDays {
+		err = multierror.Append(err, errStaleAfterDays)
+	}
+	if prefs.WebhookURL != "" && !isHTTPURL(prefs.WebhookURL) {
+		err = multierror.Append(err, errWebhookURL)
+	}
+	return err.ErrorOrNil()
+}
+
+// isHTTPURL returns whether raw is an absolute http or https URL, that the API can post to
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.IsAbs() && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
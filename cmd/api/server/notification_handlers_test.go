@@ -0,0 +1,264 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	notificationCreatedAt = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	notificationPrefs     = &models.NotificationPreferences{
+		StaleReminders: true,
+		StaleAfterDays: 30,
+		InApp:          true,
+		WebhookURL:     "https://example.com/hook",
+	}
+)
+
+func TestNotificationsGet(t *testing.T) {
+	testCases := []struct {
+		name  string
+		path  string
+		limit int
+	}{
+		{name: "Default limit", path: "/notifications", limit: 20},
+		{name: "Limit", path: "/notifications?limit=5", limit: 5},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			notificationModel := fixtures.NewNotificationModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{NotificationModel: notificationModel})
+
+			notifications := []*models.Notification{
+				{ID: "2", Kind: models.NotificationStaleGame, GameID: "1", GameName: "Hades", Message: "Hello", CreatedAt: notificationCreatedAt},
+				{ID: "1", Kind: models.NotificationStaleGame, GameID: "2", GameName: "Celeste", Message: "Hi", CreatedAt: notificationCreatedAt, ReadAt: &notificationCreatedAt},
+			}
+			notificationModel.EXPECT().
+				Notifications(user.ID, testCase.limit).
+				Return(notifications, 1, nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, testCase.path, nil))
+
+			var res models.NotificationsResponse
+			fixtures.Decode(t, w.Body, &res)
+
+			gassert.StatusOK(t, w)
+			require.Equal(t, notifications, res.Notifications)
+			require.Equal(t, 1, res.Unread)
+		})
+	}
+}
+
+func TestNotificationsGetError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		path   string
+		err    error
+		status int
+	}{
+		{name: "Invalid limit", path: "/notifications?limit=abc", status: http.StatusBadRequest},
+		{name: "Limit too big", path: "/notifications?limit=1000", status: http.StatusBadRequest},
+		{name: "DB error", path: "/notifications", err: errors.New("intentional error"), status: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			notificationModel := fixtures.NewNotificationModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{NotificationModel: notificationModel})
+			if testCase.err != nil {
+				notificationModel.EXPECT().
+					Notifications(user.ID, gomock.Any()).
+					Return(nil, 0, testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, testCase.path, nil))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestNotificationRead(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "Success", status: http.StatusNoContent},
+		{name: "Not found", err: postgres.ErrNoRecord, status: http.StatusNotFound},
+		{name: "DB error", err: errors.New("intentional error"), status: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			notificationModel := fixtures.NewNotificationModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{NotificationModel: notificationModel})
+			notificationModel.EXPECT().
+				MarkNotificationRead(user.ID, "1").
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/notifications/1/read", nil))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestNotificationsRead(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "Success", status: http.StatusNoContent},
+		{name: "DB error", err: errors.New("intentional error"), status: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			notificationModel := fixtures.NewNotificationModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{NotificationModel: notificationModel})
+			notificationModel.EXPECT().
+				MarkAllNotificationsRead(user.ID).
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/notifications/read", nil))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestNotificationPreferencesGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notificationModel := fixtures.NewNotificationModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{NotificationModel: notificationModel})
+	notificationModel.EXPECT().
+		NotificationPreferences(user.ID).
+		Return(notificationPrefs, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/notifications/preferences", nil))
+
+	var res models.NotificationPreferences
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, *notificationPrefs, res)
+}
+
+func TestNotificationPreferencesGetError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notificationModel := fixtures.NewNotificationModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{NotificationModel: notificationModel})
+	notificationModel.EXPECT().
+		NotificationPreferences(user.ID).
+		Return(nil, errors.New("intentional error"))
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/notifications/preferences", nil))
+
+	gassert.StatusCode(t, w, http.StatusInternalServerError)
+}
+
+func TestNotificationPreferencesPut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notificationModel := fixtures.NewNotificationModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{NotificationModel: notificationModel})
+	notificationModel.EXPECT().
+		SetNotificationPreferences(user.ID, notificationPrefs).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/notifications/preferences", fixtures.Marshal(t, notificationPrefs)))
+
+	var res models.NotificationPreferences
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, *notificationPrefs, res)
+}
+
+func TestNotificationPreferencesPutError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		prefs  models.NotificationPreferences
+		err    error
+		status int
+	}{
+		{
+			name:   "No days",
+			prefs:  models.NotificationPreferences{StaleReminders: true, InApp: true},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Too many days",
+			prefs:  models.NotificationPreferences{StaleReminders: true, StaleAfterDays: 1000},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Relative webhook",
+			prefs:  models.NotificationPreferences{StaleAfterDays: 30, WebhookURL: "/hook"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "Webhook with another scheme",
+			prefs:  models.NotificationPreferences{StaleAfterDays: 30, WebhookURL: "ftp://example.com/hook"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "DB error",
+			prefs:  models.NotificationPreferences{StaleAfterDays: 30},
+			err:    errors.New("intentional error"),
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			notificationModel := fixtures.NewNotificationModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{NotificationModel: notificationModel})
+			if testCase.err != nil {
+				notificationModel.EXPECT().
+					SetNotificationPreferences(user.ID, gomock.Any()).
+					Return(testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/notifications/preferences", fixtures.Marshal(t, testCase.prefs)))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/gorilla/mux"
+)
+
+// maxPlaythroughName is the maximum length of the name of a playthrough
+const maxPlaythroughName = 255
+
+func (s *Server) handlePlaythroughsGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		playthroughs, err := s.PlaythroughModel.Playthroughs(user.ID, mux.Vars(r)["id"])
+		if err != nil {
+			s.playthroughError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, &models.PlaythroughsResponse{Playthroughs: playthroughs}, http.StatusOK)
+	}
+}
+
+func (s *Server) handlePlaythroughCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		playthrough, ok := s.decodePlaythrough(w, r)
+		if !ok {
+			return
+		}
+
+		playthrough, status, err := s.PlaythroughModel.InsertPlaythrough(user.ID, playthrough)
+		if err != nil {
+			s.playthroughError(w, r, err)
+			return
+		}
+		// the game gets the status of the playthrough
+		s.playthroughStatusChanged(user, playthrough.GameID, status)
+
+		s.respond(w, r, playthrough, http.StatusCreated)
+	}
+}
+
+func (s *Server) handlePlaythroughUpdate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		playthrough, ok := s.decodePlaythrough(w, r)
+		if !ok {
+			return
+		}
+		playthrough.ID = mux.Vars(r)["playthroughId"]
+
+		playthrough, status, err := s.PlaythroughModel.UpdatePlaythrough(user.ID, playthrough)
+		if err != nil {
+			s.playthroughError(w, r, err)
+			return
+		}
+		// the game gets the status of the playthrough
+		s.playthroughStatusChanged(user, playthrough.GameID, status)
+
+		s.respond(w, r, playthrough, http.StatusOK)
+	}
+}
+
+func (s *Server) handlePlaythroughDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		vars := mux.Vars(r)
+		status, err := s.PlaythroughModel.DeletePlaythrough(user.ID, vars["id"], vars["playthroughId"])
+		if err != nil {
+			s.playthroughError(w, r, err)
+			return
+		}
+		// the game gets the status of the playthrough before it
+		s.playthroughStatusChanged(user, vars["id"], status)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// decodePlaythrough decodes and validates the playthrough from the body of the request.
+// The playthrough gets the defaults of the fields that are not set,
+// and the start or the end date, if it is started or finished and they are not set.
+func (s *Server) decodePlaythrough(w http.ResponseWriter, r *http.Request) (*models.Playthrough, bool) {
+	var playthrough models.Playthrough
+	if err := json.NewDecoder(r.Body).Decode(&playthrough); err != nil {
+		s.respondError(w, r, "error decoding body", http.StatusBadRequest)
+		return nil, false
+	}
+	playthrough.GameID = mux.Vars(r)["id"]
+
+	playthrough.Name = strings.TrimSpace(playthrough.Name)
+	if playthrough.Name == "" {
+		s.respondError(w, r, "'name' is required", http.StatusBadRequest)
+		return nil, false
+	}
+	if len(playthrough.Name) > maxPlaythroughName {
+		s.respondError(w, r, "'name' is too long", http.StatusBadRequest)
+		return nil, false
+	}
+
+	if playthrough.Status == "" {
+		playthrough.Status = models.StatusTODO
+	}
+	if err := playthrough.Status.Validate(); err != nil {
+		s.respondError(w, r, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+
+	if playthrough.Progress == nil {
+		playthrough.Progress = &models.GameProgress{}
+	}
+	if playthrough.Progress.Final == 0 {
+		playthrough.Progress.Final = 100
+	}
+	if playthrough.Progress.Current < 0 || playthrough.Progress.Current > playthrough.Progress.Final {
+		s.respondError(w, r, "'progress' is not valid", http.StatusBadRequest)
+		return nil, false
+	}
+
+	today := time.Now().UTC()
+	if playthrough.StartedAt == nil && (playthrough.Status == models.StatusInProgress || playthrough.Status == models.StatusDone) {
+		playthrough.StartedAt = &today
+	}
+	if playthrough.FinishedAt == nil && playthrough.Status == models.StatusDone {
+		playthrough.FinishedAt = &today
+	}
+	playthrough.StartedAt = releaseDate(playthrough.StartedAt)
+	playthrough.FinishedAt = releaseDate(playthrough.FinishedAt)
+	if playthrough.StartedAt != nil && playthrough.FinishedAt != nil && playthrough.FinishedAt.Before(*playthrough.StartedAt) {
+		s.respondError(w, r, "'finishedAt' is before 'startedAt'", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return &playthrough, true
+}
+
+// playthroughStatusChanged tracks the goals and announces the change of the status of the game, if a playthrough changed it.
+// An empty status means, that the status of the game did not change.
+func (s *Server) playthroughStatusChanged(user *models.User, gameID string, status models.Status) {
+	if status == "" {
+		return
+	}
+	s.trackGoals(user.ID)
+	s.gameChanged(user.ID, s.gameViewers(gameID), &models.Change{Type: models.ChangeGameUpdated, GameID: gameID, Status: status})
+}
+
+func (s *Server) playthroughError(w http.ResponseWriter, r *http.Request, err error) {
+	s.gameChangeError(w, r, "Game or playthrough not found", "changing playthroughs", err)
+}
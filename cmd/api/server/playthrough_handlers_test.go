@@ -0,0 +1,195 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	playthroughStart = time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	playthroughEnd   = time.Date(2021, time.April, 2, 0, 0, 0, 0, time.UTC)
+	playthrough      = &models.Playthrough{
+		ID:         "2",
+		GameID:     "1",
+		Name:       "NG+",
+		Status:     models.StatusDone,
+		Progress:   &models.GameProgress{Current: 100, Final: 100},
+		StartedAt:  &playthroughStart,
+		FinishedAt: &playthroughEnd,
+		Notes:      "Mage build",
+	}
+)
+
+func TestGetPlaythroughs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	playthroughModel := fixtures.NewPlaythroughModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{PlaythroughModel: playthroughModel, GoalModel: newTrackingGoalModel(ctrl, user.ID)})
+	playthroughModel.EXPECT().
+		Playthroughs(user.ID, "1").
+		Return([]*models.Playthrough{playthrough}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/games/1/playthroughs", nil))
+
+	gassert.StatusOK(t, w)
+
+	var res models.PlaythroughsResponse
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, []*models.Playthrough{playthrough}, res.Playthroughs)
+}
+
+func TestCreatePlaythrough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	playthroughModel := fixtures.NewPlaythroughModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{PlaythroughModel: playthroughModel, GoalModel: newTrackingGoalModel(ctrl, user.ID)})
+	playthroughModel.EXPECT().
+		InsertPlaythrough(user.ID, &models.Playthrough{
+			GameID:   "1",
+			Name:     "NG+",
+			Status:   models.StatusTODO,
+			Progress: &models.GameProgress{Current: 0, Final: 100},
+		}).
+		Return(playthrough, models.Status(""), nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/games/1/playthroughs", strings.NewReader(`{"name": " NG+ "}`)))
+
+	gassert.StatusCode(t, w, http.StatusCreated)
+
+	var res models.Playthrough
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, playthrough, &res)
+}
+
+func TestCreatePlaythroughFillsDates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var inserted *models.Playthrough
+	playthroughModel := fixtures.NewPlaythroughModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{PlaythroughModel: playthroughModel, GoalModel: newTrackingGoalModel(ctrl, user.ID)})
+	playthroughModel.EXPECT().
+		InsertPlaythrough(user.ID, gomock.Any()).
+		DoAndReturn(func(userID string, p *models.Playthrough) (*models.Playthrough, models.Status, error) {
+			inserted = p
+			return p, models.StatusDone, nil
+		})
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/games/1/playthroughs", strings.NewReader(`{"name": "NG+", "status": "Done", "startedAt": "2021-03-01T10:00:00Z"}`)))
+
+	gassert.StatusCode(t, w, http.StatusCreated)
+	require.NotNil(t, inserted)
+	assert.Equal(t, &playthroughStart, inserted.StartedAt)
+	require.NotNil(t, inserted.FinishedAt)
+	now := time.Now().UTC()
+	assert.Equal(t, releaseDate(&now), inserted.FinishedAt)
+}
+
+func TestUpdatePlaythrough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	playthroughModel := fixtures.NewPlaythroughModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{PlaythroughModel: playthroughModel, GoalModel: newTrackingGoalModel(ctrl, user.ID)})
+	playthroughModel.EXPECT().
+		UpdatePlaythrough(user.ID, playthrough).
+		Return(playthrough, models.StatusDone, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/games/1/playthroughs/2", fixtures.Marshal(t, playthrough)))
+
+	gassert.StatusOK(t, w)
+
+	var res models.Playthrough
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, playthrough, &res)
+}
+
+func TestDeletePlaythrough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	playthroughModel := fixtures.NewPlaythroughModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{PlaythroughModel: playthroughModel, GoalModel: newTrackingGoalModel(ctrl, user.ID)})
+	playthroughModel.EXPECT().
+		DeletePlaythrough(user.ID, "1", "2").
+		Return(models.Status(""), nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodDelete, "/games/1/playthroughs/2", nil))
+
+	gassert.StatusCode(t, w, http.StatusNoContent)
+}
+
+func TestPlaythroughBadRequest(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{name: "Invalid body", body: "{"},
+		{name: "No name", body: `{"name": "  "}`},
+		{name: "Too long name", body: `{"name": "` + strings.Repeat("a", 256) + `"}`},
+		{name: "Invalid status", body: `{"name": "NG+", "status": "Paused"}`},
+		{name: "Invalid progress", body: `{"name": "NG+", "progress": {"current": 101, "final": 100}}`},
+		{name: "Finished before started", body: `{"name": "NG+", "startedAt": "2021-04-02T00:00:00Z", "finishedAt": "2021-03-01T00:00:00Z"}`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := newAuthorizedServer(t, ctrl, &Options{PlaythroughModel: fixtures.NewPlaythroughModelMock(ctrl), GoalModel: newTrackingGoalModel(ctrl, user.ID)})
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/games/1/playthroughs", strings.NewReader(testCase.body)))
+
+			gassert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestPlaythroughError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "Not found", err: postgres.ErrNoRecord, expectedCode: http.StatusNotFound},
+		{name: "Board viewer", err: postgres.ErrForbidden, expectedCode: http.StatusForbidden},
+		{name: "DB error", err: errors.New("this is an intentional error"), expectedCode: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			playthroughModel := fixtures.NewPlaythroughModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{PlaythroughModel: playthroughModel, GoalModel: newTrackingGoalModel(ctrl, user.ID)})
+			playthroughModel.EXPECT().
+				DeletePlaythrough(user.ID, "1", "3").
+				Return(models.Status(""), testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodDelete, "/games/1/playthroughs/3", nil))
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/asankov/gira/internal/middleware"
+	"github.com/asankov/gira/pkg/models"
 
 	"github.com/justinas/alice"
 
@@ -11,7 +12,8 @@ import (
 )
 
 func (s *Server) routes() http.Handler {
-	standartMiddleware := alice.New(middleware.RecoverPanic(s.Log), middleware.LogRequest(s.Log))
+	standartMiddleware := alice.New(middleware.RecoverPanic(s.Log), middleware.LogRequest(s.Log), s.rateLimit("default", s.defaultRateLimit()))
+	loginMiddleware := alice.New(s.rateLimit("login", s.loginRateLimit()))
 
 	r := mux.NewRouter()
 
@@ -19,23 +21,166 @@ func (s *Server) routes() http.Handler {
 	r.Handle("/games", s.requireLogin(s.handleGamesGet())).Methods(http.MethodGet)
 	// POST /games creates a game for the authenticated user
 	r.Handle("/games", s.requireLogin(s.handleGamesCreate())).Methods(http.MethodPost)
+	// POST /games/import imports games from a CSV or JSON file for the authenticated user
+	r.Handle("/games/import", s.requireLogin(s.handleGamesImport())).Methods(http.MethodPost)
+	// POST /games/import/preview?source=steam|gog parses a library export of a storefront into games, that can be reviewed before they are imported
+	r.Handle("/games/import/preview", s.requireLogin(s.handleGamesImportPreview())).Methods(http.MethodPost)
+	// GET /games/upcoming?from=YYYY-MM-DD&wishlist=true returns the games, released on or after the given date (today by default), sorted by release date.
+	// ?wishlist=true returns only the games on the wishlist.
+	r.Handle("/games/upcoming", s.requireLogin(s.handleGamesUpcoming())).Methods(http.MethodGet)
+	// GET /games/next?weights=franchise:2,age:0&tags=RPG,Action&limit= suggests what to play next - the To Do games, ranked by the given heuristics.
+	// Every suggestion explains how each heuristic scored it.
+	r.Handle("/games/next", s.requireLogin(s.handleGamesNext())).Methods(http.MethodGet)
 	// GET /games/{id} returns the requested game for the authorized user
 	r.Handle("/games/{id}", s.requireLogin(s.handleGamesGetByID())).Methods(http.MethodGet)
-	// PATCH /games/{id} changes the status or progress of the given games for the authenticated user
+	// PATCH /games/{id} changes the status, progress, wishlist flag, release date, visibility or rating of the given games for the authenticated user
 	r.Handle("/games/{id}", s.requireLogin(s.handleUsersGamesPatch())).Methods(http.MethodPatch)
 	// DELETE /games/{id} deletes the given game for the authenticated user
 	r.Handle("/games/{id}", s.requireLogin(s.handleUsersGamesDelete())).Methods(http.MethodDelete)
+	// PUT /games/{id}/cover uploads the cover of the given game. The body is the image itself.
+	r.Handle("/games/{id}/cover", s.requireLogin(s.handleCoverPut(models.CoverKindGame))).Methods(http.MethodPut)
+	// GET /games/{id}/cover?size=full|thumbnail returns the cover of the given game as an image
+	r.Handle("/games/{id}/cover", s.requireLogin(s.handleCoverGet(models.CoverKindGame))).Methods(http.MethodGet)
+	// DELETE /games/{id}/cover deletes the cover of the given game
+	r.Handle("/games/{id}/cover", s.requireLogin(s.handleCoverDelete(models.CoverKindGame))).Methods(http.MethodDelete)
+	// GET /games/{id}/checklist returns the checklist of the given game
+	r.Handle("/games/{id}/checklist", s.requireLogin(s.handleChecklistGet())).Methods(http.MethodGet)
+	// PATCH /games/{id}/checklist changes the settings of the checklist, e.g. whether the progress of the game is computed from it
+	r.Handle("/games/{id}/checklist", s.requireLogin(s.handleChecklistPatch())).Methods(http.MethodPatch)
+	// POST /games/{id}/checklist/items adds an item to the checklist of the given game
+	r.Handle("/games/{id}/checklist/items", s.requireLogin(s.handleChecklistItemCreate())).Methods(http.MethodPost)
+	// PATCH /games/{id}/checklist/items/{itemId} renames the item or ticks it off
+	r.Handle("/games/{id}/checklist/items/{itemId}", s.requireLogin(s.handleChecklistItemPatch())).Methods(http.MethodPatch)
+	// DELETE /games/{id}/checklist/items/{itemId} deletes the item from the checklist
+	r.Handle("/games/{id}/checklist/items/{itemId}", s.requireLogin(s.handleChecklistItemDelete())).Methods(http.MethodDelete)
+	// GET /games/{id}/playthroughs returns the playthroughs of the given game, the oldest first
+	r.Handle("/games/{id}/playthroughs", s.requireLogin(s.handlePlaythroughsGet())).Methods(http.MethodGet)
+	// POST /games/{id}/playthroughs starts a new playthrough of the given game. The game gets its status.
+	r.Handle("/games/{id}/playthroughs", s.requireLogin(s.handlePlaythroughCreate())).Methods(http.MethodPost)
+	// PUT /games/{id}/playthroughs/{playthroughId} replaces the details of the playthrough
+	r.Handle("/games/{id}/playthroughs/{playthroughId}", s.requireLogin(s.handlePlaythroughUpdate())).Methods(http.MethodPut)
+	// DELETE /games/{id}/playthroughs/{playthroughId} deletes the playthrough
+	r.Handle("/games/{id}/playthroughs/{playthroughId}", s.requireLogin(s.handlePlaythroughDelete())).Methods(http.MethodDelete)
 
 	r.HandleFunc("/users", s.handleUserGet()).Methods(http.MethodGet)
 	r.HandleFunc("/users", s.handleUserCreate()).Methods(http.MethodPost)
-	r.HandleFunc("/users/login", s.handleUserLogin()).Methods(http.MethodPost)
+	r.Handle("/users/login", loginMiddleware.Then(s.handleUserLogin())).Methods(http.MethodPost)
+	// POST /users/login/oidc logs in the user with an ID token, issued by an OpenID Connect provider
+	r.Handle("/users/login/oidc", loginMiddleware.Then(s.handleUserLoginOIDC())).Methods(http.MethodPost)
 
 	r.Handle("/users/logout", s.requireLogin(s.handleUserLogout())).Methods(http.MethodPost)
+	// GET /users/me/export streams all data of the authenticated user as JSON, or as a ZIP of CSV files with ?format=csv
+	r.Handle("/users/me/export", s.requireLogin(s.handleUserExport())).Methods(http.MethodGet)
+	// GET /users/me/profile returns the profile of the authenticated user
+	r.Handle("/users/me/profile", s.requireLogin(s.handleProfileMeGet())).Methods(http.MethodGet)
+	// PATCH /users/me/profile makes the profile of the authenticated user public or private
+	r.Handle("/users/me/profile", s.requireLogin(s.handleProfileMePatch())).Methods(http.MethodPatch)
+	// GET /users/me/following returns the profiles, that the authenticated user follows
+	r.Handle("/users/me/following", s.requireLogin(s.handleFollowingGet())).Methods(http.MethodGet)
+	// GET /users/{username} returns the profile of the given user, if it is public
+	r.Handle("/users/{username}", s.requireLogin(s.handleProfileGet())).Methods(http.MethodGet)
+	// GET /users/{username}/games returns the games of the given user, that are not hidden, if the profile is public
+	r.Handle("/users/{username}/games", s.requireLogin(s.handleProfileGamesGet())).Methods(http.MethodGet)
+	// POST /users/{username}/follow follows the given user
+	r.Handle("/users/{username}/follow", s.requireLogin(s.handleFollow())).Methods(http.MethodPost)
+	// DELETE /users/{username}/follow unfollows the given user
+	r.Handle("/users/{username}/follow", s.requireLogin(s.handleUnfollow())).Methods(http.MethodDelete)
 
+	// GET /boards returns the shared boards, that the authenticated user is a member of
+	r.Handle("/boards", s.requireLogin(s.handleBoardsGet())).Methods(http.MethodGet)
+	// POST /boards creates a shared board. The authenticated user becomes its owner.
+	r.Handle("/boards", s.requireLogin(s.handleBoardCreate())).Methods(http.MethodPost)
+	// GET /boards/{id} returns the given board, together with its members
+	r.Handle("/boards/{id}", s.requireLogin(s.handleBoardGet())).Methods(http.MethodGet)
+	// DELETE /boards/{id} deletes the given board, together with its games. Only the owners of the board can delete it.
+	r.Handle("/boards/{id}", s.requireLogin(s.handleBoardDelete())).Methods(http.MethodDelete)
+	// GET /boards/{id}/games returns the games on the given board. Games are added to a board with POST /games and a boardId.
+	r.Handle("/boards/{id}/games", s.requireLogin(s.handleBoardGamesGet())).Methods(http.MethodGet)
+	// POST /boards/{id}/invitations invites a user to the given board by username. Only the owners of the board can invite users.
+	r.Handle("/boards/{id}/invitations", s.requireLogin(s.handleBoardInvitationCreate())).Methods(http.MethodPost)
+	// PATCH /boards/{id}/members/{username} changes the role of the given member of the board
+	r.Handle("/boards/{id}/members/{username}", s.requireLogin(s.handleBoardMemberPatch())).Methods(http.MethodPatch)
+	// DELETE /boards/{id}/members/{username} removes the given member from the board, or makes the authenticated user leave it
+	r.Handle("/boards/{id}/members/{username}", s.requireLogin(s.handleBoardMemberDelete())).Methods(http.MethodDelete)
+	// GET /invitations returns the pending invitations of the authenticated user to shared boards
+	r.Handle("/invitations", s.requireLogin(s.handleInvitationsGet())).Methods(http.MethodGet)
+	// POST /invitations/{id}/accept makes the authenticated user a member of the board, that they are invited to
+	r.Handle("/invitations/{id}/accept", s.requireLogin(s.handleInvitationAccept())).Methods(http.MethodPost)
+	// POST /invitations/{id}/decline declines the invitation
+	r.Handle("/invitations/{id}/decline", s.requireLogin(s.handleInvitationDecline())).Methods(http.MethodPost)
+
+	// GET /metadata/games?q= searches the metadata provider for games by name
+	r.Handle("/metadata/games", s.requireLogin(s.handleMetadataSearch())).Methods(http.MethodGet)
+	// GET /metadata/games/{id} returns the details of a game from the metadata provider
+	r.Handle("/metadata/games/{id}", s.requireLogin(s.handleMetadataGet())).Methods(http.MethodGet)
+
+	// GET /franchises returns the tree of franchises, with their sub-series as children and the completion of their games
 	r.Handle("/franchises", s.requireLogin(s.handleFranchisesGet())).Methods(http.MethodGet)
+	// POST /franchises creates a franchise. If it has a parentId, it is a sub-series of that franchise.
 	r.Handle("/franchises", s.requireLogin(s.handleFranchisesCreate())).Methods(http.MethodPost)
+	// GET /franchises/{id} returns the franchise with its games in play order, with their release and play positions
+	r.Handle("/franchises/{id}", s.requireLogin(s.handleFranchiseGet())).Methods(http.MethodGet)
+	// PUT /franchises/{id}/order stores the release or play order of the games of the franchise. The body is models.FranchiseOrderRequest.
+	r.Handle("/franchises/{id}/order", s.requireLogin(s.handleFranchiseOrderPut())).Methods(http.MethodPut)
+	// PUT /franchises/{id}/parent makes the franchise a sub-series of another one, or a top-level franchise if parentId is empty
+	r.Handle("/franchises/{id}/parent", s.requireLogin(s.handleFranchiseParentPut())).Methods(http.MethodPut)
+	// PUT /franchises/{id}/cover uploads the cover of the given franchise. The body is the image itself.
+	r.Handle("/franchises/{id}/cover", s.requireLogin(s.handleCoverPut(models.CoverKindFranchise))).Methods(http.MethodPut)
+	// GET /franchises/{id}/cover?size=full|thumbnail returns the cover of the given franchise as an image
+	r.Handle("/franchises/{id}/cover", s.requireLogin(s.handleCoverGet(models.CoverKindFranchise))).Methods(http.MethodGet)
+	// DELETE /franchises/{id}/cover deletes the cover of the given franchise
+	r.Handle("/franchises/{id}/cover", s.requireLogin(s.handleCoverDelete(models.CoverKindFranchise))).Methods(http.MethodDelete)
 
 	r.Handle("/statuses", s.requireLogin(s.handleStatusesGet())).Methods(http.MethodGet)
 
+	// GET /feed?before=&limit= returns the activity of the users, that the authenticated user follows, the newest first.
+	// ?before= is the cursor of the next page, returned with the previous one.
+	r.Handle("/feed", s.requireLogin(s.handleFeedGet())).Methods(http.MethodGet)
+
+	// GET /goals returns the goals of the authenticated user with their progress
+	r.Handle("/goals", s.requireLogin(s.handleGoalsGet())).Methods(http.MethodGet)
+	// POST /goals creates a goal, e.g. to finish a number of games in a period, to finish a franchise or to clear the backlog
+	r.Handle("/goals", s.requireLogin(s.handleGoalCreate())).Methods(http.MethodPost)
+	// GET /goals/{id} returns the given goal with its progress
+	r.Handle("/goals/{id}", s.requireLogin(s.handleGoalGet())).Methods(http.MethodGet)
+	// DELETE /goals/{id} deletes the given goal
+	r.Handle("/goals/{id}", s.requireLogin(s.handleGoalDelete())).Methods(http.MethodDelete)
+
+	// GET /notifications returns the latest notifications of the authenticated user and the number of the unread ones
+	r.Handle("/notifications", s.requireLogin(s.handleNotificationsGet())).Methods(http.MethodGet)
+	// POST /notifications/read marks all notifications of the authenticated user as read
+	r.Handle("/notifications/read", s.requireLogin(s.handleNotificationsRead())).Methods(http.MethodPost)
+	// GET /notifications/preferences returns the notification preferences of the authenticated user
+	r.Handle("/notifications/preferences", s.requireLogin(s.handleNotificationPreferencesGet())).Methods(http.MethodGet)
+	// PUT /notifications/preferences replaces the notification preferences of the authenticated user
+	r.Handle("/notifications/preferences", s.requireLogin(s.handleNotificationPreferencesPut())).Methods(http.MethodPut)
+	// POST /notifications/{id}/read marks the given notification as read
+	r.Handle("/notifications/{id}/read", s.requireLogin(s.handleNotificationRead())).Methods(http.MethodPost)
+
+	// GET /webhooks returns the webhooks of the authenticated user, without their secrets
+	r.Handle("/webhooks", s.requireLogin(s.handleWebhooksGet())).Methods(http.MethodGet)
+	// POST /webhooks creates a webhook, that the given events of the games are posted to, and returns it with the secret, that signs the payloads
+	r.Handle("/webhooks", s.requireLogin(s.handleWebhookCreate())).Methods(http.MethodPost)
+	// GET /webhooks/{id} returns the given webhook, without its secret
+	r.Handle("/webhooks/{id}", s.requireLogin(s.handleWebhookGet())).Methods(http.MethodGet)
+	// PUT /webhooks/{id} replaces the URL, the events and the active flag of the given webhook
+	r.Handle("/webhooks/{id}", s.requireLogin(s.handleWebhookUpdate())).Methods(http.MethodPut)
+	// DELETE /webhooks/{id} deletes the given webhook, together with its deliveries
+	r.Handle("/webhooks/{id}", s.requireLogin(s.handleWebhookDelete())).Methods(http.MethodDelete)
+	// GET /webhooks/{id}/deliveries?limit= returns the latest deliveries of the given webhook, the newest first
+	r.Handle("/webhooks/{id}/deliveries", s.requireLogin(s.handleWebhookDeliveriesGet())).Methods(http.MethodGet)
+
+	// GET /changes streams the changes of the games, that the authenticated user can see, as Server-Sent Events
+	r.Handle("/changes", s.requireLogin(s.handleChangesStream())).Methods(http.MethodGet)
+
+	// GET /stats returns the statistics of the backlog of the authenticated user
+	r.Handle("/stats", s.requireLogin(s.handleStatsGet())).Methods(http.MethodGet)
+	// GET /stats/years/{year} returns the report of the given year for the authenticated user.
+	// ?format=csv returns it as a CSV file, instead of JSON.
+	r.Handle("/stats/years/{year}", s.requireLogin(s.handleStatsYearGet())).Methods(http.MethodGet)
+
+	// GET /debug/vars exposes the login metrics (failures and lockouts)
+	r.Handle("/debug/vars", s.handleLoginMetricsGet()).Methods(http.MethodGet)
+
 	return standartMiddleware.Then(r)
 }
@@ -1,9 +1,14 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 
+	"github.com/asankov/gira/internal/blob"
+	"github.com/asankov/gira/internal/middleware"
 	"github.com/asankov/gira/pkg/models"
 	"github.com/sirupsen/logrus"
 )
@@ -11,17 +16,26 @@ import (
 // GameModel is the interface to interact with the Games provider (DB, service, etc.)
 type GameModel interface {
 	AllForUser(userID string) ([]*models.Game, error)
+	AllForBoard(userID, boardID string) ([]*models.Game, error)
 	Get(id string) (*models.Game, error)
 	Insert(game *models.Game) (*models.Game, error)
+	InsertMany(userID string, franchises []string, games []*models.Game) ([]*models.Game, error)
 	DeleteGame(userID, gameID string) error
 	ChangeGameStatus(userID, gameID string, status models.Status) error
 	ChangeGameProgress(userID, gameID string, progress *models.GameProgress) error
+	ChangeGameWishlist(userID, gameID string, wishlist bool) error
+	ChangeGameReleaseDate(userID, gameID string, releaseDate *time.Time) error
+	ChangeGameHidden(userID, gameID string, hidden bool) error
+	ChangeGameRating(userID, gameID string, rating int) error
+	Upcoming(userID string, from time.Time, wishlistOnly bool) ([]*models.Game, error)
+	Viewers(gameID string) ([]string, error)
 }
 
 // UserModel is the interface to interact with the User provider (DB, service, etc.)
 type UserModel interface {
 	Insert(user *models.User) (*models.User, error)
 	Authenticate(email, password string) (*models.User, error)
+	AuthenticateIdentity(identity *models.Identity) (*models.User, error)
 	AssociateTokenWithUser(userID, token string) error
 	InvalidateToken(userID, token string) error
 	GetUserByToken(token string) (*models.User, error)
@@ -40,6 +54,130 @@ type UserGamesModel interface {
 type FranchiseModel interface {
 	Insert(franchise *models.Franchise) (*models.Franchise, error)
 	All(userID string) ([]*models.Franchise, error)
+	Get(userID, franchiseID string) (*models.Franchise, error)
+	Reorder(userID, franchiseID string, order models.FranchiseOrder, gameIDs []string) error
+	GamesBefore(userID, gameID string) ([]*models.Game, error)
+	SetParent(userID, franchiseID, parentID string) error
+}
+
+// StatsModel is the interface to compute the statistics of the backlog of a user (DB, service, etc.)
+type StatsModel interface {
+	StatsForUser(userID string, now time.Time) (*models.Stats, error)
+	YearReport(userID string, year int, now time.Time) (*models.YearReport, error)
+}
+
+// ExportModel is the interface to stream all data of a user (DB, service, etc.)
+type ExportModel interface {
+	Franchises(userID string, fn func(*models.Franchise) error) error
+	Games(userID string, fn func(*models.Game) error) error
+	StatusChanges(userID string, fn func(*models.StatusChange) error) error
+}
+
+// MetadataProvider is the interface to fetch the details of games (cover art, release date, etc.) from an external source (RAWG, IGDB, etc.)
+type MetadataProvider interface {
+	Search(ctx context.Context, query string) ([]*models.GameMetadata, error)
+	Details(ctx context.Context, id string) (*models.GameMetadata, error)
+}
+
+// CoverModel is the interface to keep track of the covers of games and franchises (DB, service, etc.)
+type CoverModel interface {
+	CoverUpdatedAt(userID string, kind models.CoverKind, id string, write bool) (*time.Time, error)
+	SetCoverUpdatedAt(userID string, kind models.CoverKind, id string, updatedAt *time.Time) error
+}
+
+// ChecklistModel is the interface to interact with the checklists of the games (DB, service, etc.)
+type ChecklistModel interface {
+	Checklist(userID, gameID string) (*models.Checklist, error)
+	AddChecklistItem(userID, gameID, name string) (*models.Checklist, error)
+	UpdateChecklistItem(userID, gameID, itemID, name string, done *bool) (*models.Checklist, error)
+	DeleteChecklistItem(userID, gameID, itemID string) (*models.Checklist, error)
+	SetChecklistAutoProgress(userID, gameID string, autoProgress bool) (*models.Checklist, error)
+}
+
+// PlaythroughModel is the interface to interact with the playthroughs of the games (DB, service, etc.)
+type PlaythroughModel interface {
+	Playthroughs(userID, gameID string) ([]*models.Playthrough, error)
+	InsertPlaythrough(userID string, playthrough *models.Playthrough) (*models.Playthrough, models.Status, error)
+	UpdatePlaythrough(userID string, playthrough *models.Playthrough) (*models.Playthrough, models.Status, error)
+	DeletePlaythrough(userID, gameID, playthroughID string) (models.Status, error)
+}
+
+// SocialModel is the interface to interact with the public profiles of the users and who follows whom (DB, service, etc.)
+type SocialModel interface {
+	Profile(viewerID, username string) (*models.Profile, error)
+	SetProfilePublic(userID string, public bool) error
+	Follow(followerID, username string) error
+	Unfollow(followerID, username string) error
+	Following(userID string) ([]*models.Profile, error)
+	ProfileGames(viewerID, username string) ([]*models.Game, error)
+}
+
+// EventModel is the interface to read the activity of the users (DB, service, etc.)
+type EventModel interface {
+	Feed(userID string, before int64, limit int) ([]*models.Event, error)
+}
+
+// BoardModel is the interface to interact with the shared boards, their members and the invitations to them (DB, service, etc.)
+type BoardModel interface {
+	CreateBoard(userID, name string) (*models.Board, error)
+	Boards(userID string) ([]*models.Board, error)
+	Board(userID, boardID string) (*models.Board, error)
+	DeleteBoard(userID, boardID string) error
+	InviteToBoard(userID, boardID, username string, role models.BoardRole) (*models.BoardInvitation, error)
+	Invitations(userID string) ([]*models.BoardInvitation, error)
+	AcceptInvitation(userID, invitationID string) (*models.Board, error)
+	DeclineInvitation(userID, invitationID string) error
+	ChangeMemberRole(userID, boardID, username string, role models.BoardRole) error
+	RemoveMember(userID, boardID, username string) error
+}
+
+// GoalModel is the interface to interact with the goals of the users and their progress (DB, service, etc.)
+type GoalModel interface {
+	InsertGoal(goal *models.Goal, now time.Time) (*models.Goal, error)
+	Goals(userID string, now time.Time) ([]*models.Goal, error)
+	Goal(userID, goalID string, now time.Time) (*models.Goal, error)
+	DeleteGoal(userID, goalID string) error
+	TrackGoals(userID string, now time.Time) error
+}
+
+// NotificationModel is the interface to interact with the notifications of the users and their preferences (DB, service, etc.)
+type NotificationModel interface {
+	NotificationPreferences(userID string) (*models.NotificationPreferences, error)
+	SetNotificationPreferences(userID string, prefs *models.NotificationPreferences) error
+	Notifications(userID string, limit int) ([]*models.Notification, int, error)
+	MarkNotificationRead(userID, notificationID string) error
+	MarkAllNotificationsRead(userID string) error
+}
+
+// WebhookModel is the interface to interact with the webhooks of the users and the log of their deliveries (DB, service, etc.)
+type WebhookModel interface {
+	InsertWebhook(webhook *models.Webhook) (*models.Webhook, error)
+	Webhooks(userID string) ([]*models.Webhook, error)
+	Webhook(userID, webhookID string) (*models.Webhook, error)
+	UpdateWebhook(webhook *models.Webhook) error
+	DeleteWebhook(userID, webhookID string) error
+	WebhookDeliveries(userID, webhookID string, limit int) ([]*models.WebhookDelivery, error)
+}
+
+// WebhookDispatcher posts the events of the games to the webhooks of the users.
+// Dispatch must not block, the deliveries should happen in the background.
+type WebhookDispatcher interface {
+	Dispatch(userID string, payload *models.WebhookPayload)
+}
+
+// ChangeBroker streams the changes of the games to the users, who are watching them live, e.g. have the list of games open.
+// Publish must not block, the changes should be dropped for the subscribers, that can not keep up.
+type ChangeBroker interface {
+	// Subscribe returns the changes for the given user and a function, that cancels the subscription and closes the channel.
+	Subscribe(userID string) (<-chan *models.Change, func())
+	Publish(userIDs []string, change *models.Change)
+}
+
+// BlobStore is the interface to store binary objects, like the covers of games (filesystem, S3, etc.)
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) (*blob.Object, error)
+	Delete(ctx context.Context, key string) error
 }
 
 // Authenticator is the interface to interact with the Authenticator (DB, OIDC provider, etc.)
@@ -48,25 +186,92 @@ type Authenticator interface {
 	NewTokenForUser(user *models.User) (string, error)
 }
 
+// IdentityVerifier is the interface to verify identities asserted by external identity providers (OIDC, etc.)
+type IdentityVerifier interface {
+	ExchangeIdentity(ctx context.Context, provider, code, codeVerifier, nonce string) (*models.Identity, error)
+}
+
+// LoginThrottler is the interface to track failed login attempts and lock out accounts and clients
+type LoginThrottler interface {
+	Check(email, ip string) time.Duration
+	Fail(email, ip string) time.Duration
+	Success(email, ip string)
+}
+
+// RateLimits are the limits of the requests that a single user or client can make.
+type RateLimits struct {
+	// Default applies to all requests.
+	Default middleware.Limit
+	// Login applies to the login endpoints, on top of Default.
+	Login middleware.Limit
+}
+
 // Server is the struct that holds all the dependencies
 // needed to run the application
 type Server struct {
 	Log *logrus.Logger
+	// TrustedProxies are the proxies (e.g. the front-end), whose X-Forwarded-For header is trusted
+	// to determine the IP of the client.
+	TrustedProxies []*net.IPNet
+	// RateLimitStore stores the rate limit buckets. If nil, the requests are not rate limited.
+	RateLimitStore middleware.Store
+	RateLimits     *RateLimits
 
 	Authenticator
+	IdentityVerifier
+	LoginThrottler
 	GameModel
 	UserModel
 	FranchiseModel
+	StatsModel
+	ExportModel
+	// MetadataProvider is optional. If nil, the metadata endpoints are disabled and games can not be linked to metadata.
+	MetadataProvider
+	CoverModel
+	// BlobStore is optional. If nil, the cover endpoints are disabled.
+	BlobStore
+	ChecklistModel
+	PlaythroughModel
+	SocialModel
+	EventModel
+	BoardModel
+	GoalModel
+	NotificationModel
+	WebhookModel
+	// WebhookDispatcher is optional. If nil, no events are posted to the webhooks.
+	WebhookDispatcher
+	// ChangeBroker is optional. If nil, the changes are not streamed live.
+	ChangeBroker
 }
 
 // Options is the struct used to construct a server
 type Options struct {
-	Log *logrus.Logger
+	Log            *logrus.Logger
+	TrustedProxies []*net.IPNet
+	RateLimitStore middleware.Store
+	RateLimits     *RateLimits
 
 	Authenticator
+	IdentityVerifier
+	LoginThrottler
 	GameModel
 	UserModel
 	FranchiseModel
+	StatsModel
+	ExportModel
+	MetadataProvider
+	CoverModel
+	BlobStore
+	ChecklistModel
+	PlaythroughModel
+	SocialModel
+	EventModel
+	BoardModel
+	GoalModel
+	NotificationModel
+	WebhookModel
+	WebhookDispatcher
+	ChangeBroker
 }
 
 // New returns a new Server, based on opts.
@@ -75,11 +280,31 @@ type Options struct {
 func New(opts *Options) (*Server, error) {
 	// TODO: validate args
 	return &Server{
-		Log:            opts.Log,
-		Authenticator:  opts.Authenticator,
-		GameModel:      opts.GameModel,
-		UserModel:      opts.UserModel,
-		FranchiseModel: opts.FranchiseModel,
+		Log:               opts.Log,
+		TrustedProxies:    opts.TrustedProxies,
+		RateLimitStore:    opts.RateLimitStore,
+		RateLimits:        opts.RateLimits,
+		Authenticator:     opts.Authenticator,
+		IdentityVerifier:  opts.IdentityVerifier,
+		LoginThrottler:    opts.LoginThrottler,
+		GameModel:         opts.GameModel,
+		UserModel:         opts.UserModel,
+		FranchiseModel:    opts.FranchiseModel,
+		StatsModel:        opts.StatsModel,
+		ExportModel:       opts.ExportModel,
+		MetadataProvider:  opts.MetadataProvider,
+		CoverModel:        opts.CoverModel,
+		BlobStore:         opts.BlobStore,
+		ChecklistModel:    opts.ChecklistModel,
+		PlaythroughModel:  opts.PlaythroughModel,
+		SocialModel:       opts.SocialModel,
+		EventModel:        opts.EventModel,
+		BoardModel:        opts.BoardModel,
+		GoalModel:         opts.GoalModel,
+		NotificationModel: opts.NotificationModel,
+		WebhookModel:      opts.WebhookModel,
+		WebhookDispatcher: opts.WebhookDispatcher,
+		ChangeBroker:      opts.ChangeBroker,
 	}, nil
 }
 
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/gorilla/mux"
+)
+
+func (s *Server) handleProfileMeGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		profile, err := s.SocialModel.Profile(user.ID, user.Username)
+		if err != nil {
+			s.profileError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, profile, http.StatusOK)
+	}
+}
+
+func (s *Server) handleProfileMePatch() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var req models.ProfileRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.respondError(w, r, "error decoding body", http.StatusBadRequest)
+			return
+		}
+		if req.Public == nil {
+			s.respondError(w, r, "'public' is required", http.StatusBadRequest)
+			return
+		}
+
+		if err := s.SocialModel.SetProfilePublic(user.ID, *req.Public); err != nil {
+			s.profileError(w, r, err)
+			return
+		}
+
+		profile, err := s.SocialModel.Profile(user.ID, user.Username)
+		if err != nil {
+			s.profileError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, profile, http.StatusOK)
+	}
+}
+
+func (s *Server) handleFollowingGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		profiles, err := s.SocialModel.Following(user.ID)
+		if err != nil {
+			s.profileError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, &models.ProfilesResponse{Profiles: profiles}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleProfileGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		profile, err := s.SocialModel.Profile(user.ID, mux.Vars(r)["username"])
+		if err != nil {
+			s.profileError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, profile, http.StatusOK)
+	}
+}
+
+func (s *Server) handleProfileGamesGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		username := mux.Vars(r)["username"]
+
+		games, err := s.SocialModel.ProfileGames(user.ID, username)
+		if err != nil {
+			s.profileError(w, r, err)
+			return
+		}
+
+		if username != user.Username {
+			// the uploaded covers are served only to the owner of the game, so the other users get only the covers from the metadata provider
+			for _, game := range games {
+				game.CoverUpdatedAt = nil
+			}
+		}
+
+		s.respond(w, r, &models.GamesResponse{Games: games}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleFollow() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		username := mux.Vars(r)["username"]
+
+		if err := s.SocialModel.Follow(user.ID, username); err != nil {
+			s.profileError(w, r, err)
+			return
+		}
+
+		profile, err := s.SocialModel.Profile(user.ID, username)
+		if err != nil {
+			s.profileError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, profile, http.StatusOK)
+	}
+}
+
+func (s *Server) handleUnfollow() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if err := s.SocialModel.Unfollow(user.ID, mux.Vars(r)["username"]); err != nil {
+			s.profileError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) profileError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, postgres.ErrNoRecord):
+		s.respondError(w, r, "User not found", http.StatusNotFound)
+	case errors.Is(err, postgres.ErrFollowSelf):
+		s.respondError(w, r, err.Error(), http.StatusBadRequest)
+	default:
+		s.Log.Errorf("Error while processing profile: %v", err)
+		s.internalError(w, r)
+	}
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var friendProfile = &models.Profile{Username: "ivan", Public: true, Following: true, Followers: 3, Follows: 1}
+
+func TestProfile(t *testing.T) {
+	ownProfile := &models.Profile{Username: user.Username, Public: true, Followers: 1}
+
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		expect   func(*fixtures.SocialModelMock)
+		expected *models.Profile
+	}{
+		{
+			name:   "Own profile",
+			method: http.MethodGet,
+			path:   "/users/me/profile",
+			expect: func(m *fixtures.SocialModelMock) {
+				m.EXPECT().Profile(user.ID, user.Username).Return(ownProfile, nil)
+			},
+			expected: ownProfile,
+		},
+		{
+			name:   "Make profile public",
+			method: http.MethodPatch,
+			path:   "/users/me/profile",
+			body:   `{"public": true}`,
+			expect: func(m *fixtures.SocialModelMock) {
+				m.EXPECT().SetProfilePublic(user.ID, true).Return(nil)
+				m.EXPECT().Profile(user.ID, user.Username).Return(ownProfile, nil)
+			},
+			expected: ownProfile,
+		},
+		{
+			name:   "Profile of another user",
+			method: http.MethodGet,
+			path:   "/users/ivan",
+			expect: func(m *fixtures.SocialModelMock) {
+				m.EXPECT().Profile(user.ID, "ivan").Return(friendProfile, nil)
+			},
+			expected: friendProfile,
+		},
+		{
+			name:   "Follow",
+			method: http.MethodPost,
+			path:   "/users/ivan/follow",
+			expect: func(m *fixtures.SocialModelMock) {
+				m.EXPECT().Follow(user.ID, "ivan").Return(nil)
+				m.EXPECT().Profile(user.ID, "ivan").Return(friendProfile, nil)
+			},
+			expected: friendProfile,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			socialModel := fixtures.NewSocialModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{SocialModel: socialModel})
+			testCase.expect(socialModel)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(testCase.method, testCase.path, strings.NewReader(testCase.body)))
+
+			gassert.StatusOK(t, w)
+
+			var res models.Profile
+			fixtures.Decode(t, w.Body, &res)
+			assert.Equal(t, testCase.expected, &res)
+		})
+	}
+}
+
+func TestProfileMePatchBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := newAuthorizedServer(t, ctrl, &Options{SocialModel: fixtures.NewSocialModelMock(ctrl)})
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPatch, "/users/me/profile", strings.NewReader(`{}`)))
+
+	gassert.StatusCode(t, w, http.StatusBadRequest)
+}
+
+func TestFollowingGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	socialModel := fixtures.NewSocialModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{SocialModel: socialModel})
+	socialModel.EXPECT().
+		Following(user.ID).
+		Return([]*models.Profile{friendProfile}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/users/me/following", nil))
+
+	gassert.StatusOK(t, w)
+
+	var res models.ProfilesResponse
+	fixtures.Decode(t, w.Body, &res)
+	assert.Equal(t, []*models.Profile{friendProfile}, res.Profiles)
+}
+
+func TestProfileGamesGet(t *testing.T) {
+	coverUpdatedAt := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		username string
+		expected *time.Time
+	}{
+		{name: "Games of another user", username: "ivan"},
+		{name: "Own games", username: user.Username, expected: &coverUpdatedAt},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			socialModel := fixtures.NewSocialModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{SocialModel: socialModel})
+			socialModel.EXPECT().
+				ProfileGames(user.ID, testCase.username).
+				Return([]*models.Game{{ID: "1", Name: "Hades", Status: models.StatusInProgress, CoverUpdatedAt: &coverUpdatedAt}}, nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/users/"+testCase.username+"/games", nil))
+
+			gassert.StatusOK(t, w)
+
+			var res models.GamesResponse
+			fixtures.Decode(t, w.Body, &res)
+			assert.Equal(t, []*models.Game{{ID: "1", Name: "Hades", Status: models.StatusInProgress, CoverUpdatedAt: testCase.expected}}, res.Games)
+		})
+	}
+}
+
+func TestUnfollow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	socialModel := fixtures.NewSocialModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{SocialModel: socialModel})
+	socialModel.EXPECT().
+		Unfollow(user.ID, "ivan").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodDelete, "/users/ivan/follow", nil))
+
+	gassert.StatusCode(t, w, http.StatusNoContent)
+}
+
+func TestProfileError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "Not found", err: postgres.ErrNoRecord, expectedCode: http.StatusNotFound},
+		{name: "Follow self", err: postgres.ErrFollowSelf, expectedCode: http.StatusBadRequest},
+		{name: "DB error", err: errors.New("this is an intentional error"), expectedCode: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			socialModel := fixtures.NewSocialModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{SocialModel: socialModel})
+			socialModel.EXPECT().
+				Follow(user.ID, "ivan").
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/users/ivan/follow", nil))
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/gorilla/mux"
+)
+
+// minReportYear is the earliest year for which a report can be requested.
+const minReportYear = 1970
+
+func (s *Server) handleStatsGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		stats, err := s.StatsModel.StatsForUser(user.ID, time.Now())
+		if err != nil {
+			s.Log.Errorf("Error while fetching stats for user %s: %v", user.ID, err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, stats, http.StatusOK)
+	}
+}
+
+func (s *Server) handleStatsYearGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		now := time.Now()
+
+		year, err := strconv.Atoi(mux.Vars(r)["year"])
+		if err != nil || year < minReportYear || year > now.Year() {
+			s.respondError(w, r, fmt.Sprintf("year must be between %d and %d", minReportYear, now.Year()), http.StatusBadRequest)
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "csv" {
+			s.respondError(w, r, "format must be one of json, csv", http.StatusBadRequest)
+			return
+		}
+
+		report, err := s.StatsModel.YearReport(user.ID, year, now)
+		if err != nil {
+			s.Log.Errorf("Error while fetching report for year %d for user %s: %v", year, user.ID, err)
+			s.internalError(w, r)
+			return
+		}
+
+		if format == "csv" {
+			s.respondYearReportCSV(w, report)
+			return
+		}
+		s.respond(w, r, report, http.StatusOK)
+	}
+}
+
+// respondYearReportCSV writes the report as a CSV attachment - one row per game, followed by a row with the totals.
+func (s *Server) respondYearReportCSV(w http.ResponseWriter, report *models.YearReport) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="gira-%d.csv"`, report.Year))
+
+	cw := csv.NewWriter(w)
+	records := [][]string{{"id", "name", "started", "finished", "dropped", "hours"}}
+	for _, game := range report.Games {
+		records = append(records, []string{
+			game.ID,
+			game.Name,
+			strconv.FormatBool(game.Started),
+			strconv.FormatBool(game.Finished),
+			strconv.FormatBool(game.Dropped),
+			formatHours(game.Hours),
+		})
+	}
+	records = append(records, []string{
+		"",
+		"Total",
+		strconv.Itoa(report.Started),
+		strconv.Itoa(report.Finished),
+		strconv.Itoa(report.Dropped),
+		formatHours(report.TotalHours),
+	})
+
+	if err := cw.WriteAll(records); err != nil {
+		s.Log.Errorf("Error while writing report for year %d: %v", report.Year, err)
+	}
+}
+
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(hours, 'f', 2, 64)
+}
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+	userModelMock := fixtures.NewUserModelMock(ctrl)
+	statsModelMock := fixtures.NewStatsModelMock(ctrl)
+	srv := newServer(t, &Options{
+		Authenticator: authenticatorMock,
+		UserModel:     userModelMock,
+		StatsModel:    statsModelMock,
+	})
+
+	stats := &models.Stats{
+		StatusCounts: map[models.Status]int{
+			models.StatusTODO:       2,
+			models.StatusInProgress: 1,
+			models.StatusDone:       3,
+		},
+		Total:             6,
+		FinishedThisMonth: 1,
+		FinishedThisYear:  3,
+		AverageProgress:   62.5,
+		TopFranchises: []*models.FranchiseStats{
+			{ID: "1", Name: "Assassin's Creed", Games: 4, Finished: 2},
+		},
+		RecentlyUpdated:  []*models.Game{{ID: "1", Name: "AC", Status: models.StatusInProgress}},
+		CurrentlyPlaying: []*models.Game{{ID: "1", Name: "AC", Status: models.StatusInProgress}},
+	}
+
+	authenticatorMock.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(nil, nil)
+	userModelMock.EXPECT().
+		GetUserByToken(gomock.Eq(token)).
+		Return(user, nil)
+	statsModelMock.EXPECT().
+		StatsForUser(user.ID, gomock.Any()).
+		Return(stats, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	r.Header.Add(models.XAuthToken, token)
+
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+
+	var statsResponse models.Stats
+	fixtures.Decode(t, w.Body, &statsResponse)
+	assert.Equal(t, stats, &statsResponse)
+}
+
+func TestGetStatsDBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+	userModelMock := fixtures.NewUserModelMock(ctrl)
+	statsModelMock := fixtures.NewStatsModelMock(ctrl)
+	srv := newServer(t, &Options{
+		Authenticator: authenticatorMock,
+		UserModel:     userModelMock,
+		StatsModel:    statsModelMock,
+	})
+
+	authenticatorMock.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(nil, nil)
+	userModelMock.EXPECT().
+		GetUserByToken(gomock.Eq(token)).
+		Return(user, nil)
+	statsModelMock.EXPECT().
+		StatsForUser(user.ID, gomock.Any()).
+		Return(nil, errors.New("intentional error"))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	r.Header.Add(models.XAuthToken, token)
+
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusInternalServerError)
+}
+
+var yearReport = &models.YearReport{
+	Year:       2020,
+	Started:    2,
+	Finished:   1,
+	Dropped:    1,
+	TotalHours: 150,
+	LongestRunning: &models.YearReportGame{
+		ID: "1", Name: "AC", Started: true, Finished: true, Hours: 100,
+	},
+	Games: []*models.YearReportGame{
+		{ID: "1", Name: "AC", Started: true, Finished: true, Hours: 100},
+		{ID: "2", Name: "ACII", Started: true, Dropped: true, Hours: 50},
+	},
+}
+
+func TestGetYearReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+	userModelMock := fixtures.NewUserModelMock(ctrl)
+	statsModelMock := fixtures.NewStatsModelMock(ctrl)
+	srv := newServer(t, &Options{
+		Authenticator: authenticatorMock,
+		UserModel:     userModelMock,
+		StatsModel:    statsModelMock,
+	})
+
+	authenticatorMock.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(nil, nil)
+	userModelMock.EXPECT().
+		GetUserByToken(gomock.Eq(token)).
+		Return(user, nil)
+	statsModelMock.EXPECT().
+		YearReport(user.ID, 2020, gomock.Any()).
+		Return(yearReport, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/stats/years/2020", nil)
+	r.Header.Add(models.XAuthToken, token)
+
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+
+	var reportResponse models.YearReport
+	fixtures.Decode(t, w.Body, &reportResponse)
+	assert.Equal(t, yearReport, &reportResponse)
+}
+
+func TestGetYearReportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+	userModelMock := fixtures.NewUserModelMock(ctrl)
+	statsModelMock := fixtures.NewStatsModelMock(ctrl)
+	srv := newServer(t, &Options{
+		Authenticator: authenticatorMock,
+		UserModel:     userModelMock,
+		StatsModel:    statsModelMock,
+	})
+
+	authenticatorMock.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(nil, nil)
+	userModelMock.EXPECT().
+		GetUserByToken(gomock.Eq(token)).
+		Return(user, nil)
+	statsModelMock.EXPECT().
+		YearReport(user.ID, 2020, gomock.Any()).
+		Return(yearReport, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/stats/years/2020?format=csv", nil)
+	r.Header.Add(models.XAuthToken, token)
+
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="gira-2020.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "id,name,started,finished,dropped,hours\n"+
+		"1,AC,true,true,false,100.00\n"+
+		"2,ACII,true,false,true,50.00\n"+
+		",Total,2,1,1,150.00\n", w.Body.String())
+}
+
+func TestGetYearReportBadRequest(t *testing.T) {
+	testCases := []struct {
+		name string
+		path string
+	}{
+		{name: "Not a number", path: "/stats/years/abc"},
+		{name: "Too early", path: "/stats/years/1900"},
+		{name: "In the future", path: "/stats/years/3000"},
+		{name: "Unknown format", path: "/stats/years/2020?format=xml"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+			userModelMock := fixtures.NewUserModelMock(ctrl)
+			srv := newServer(t, &Options{
+				Authenticator: authenticatorMock,
+				UserModel:     userModelMock,
+				StatsModel:    fixtures.NewStatsModelMock(ctrl),
+			})
+
+			authenticatorMock.EXPECT().
+				DecodeToken(gomock.Eq(token)).
+				Return(nil, nil)
+			userModelMock.EXPECT().
+				GetUserByToken(gomock.Eq(token)).
+				Return(user, nil)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+			r.Header.Add(models.XAuthToken, token)
+
+			srv.ServeHTTP(w, r)
+
+			gassert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestGetYearReportDBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+	userModelMock := fixtures.NewUserModelMock(ctrl)
+	statsModelMock := fixtures.NewStatsModelMock(ctrl)
+	srv := newServer(t, &Options{
+		Authenticator: authenticatorMock,
+		UserModel:     userModelMock,
+		StatsModel:    statsModelMock,
+	})
+
+	authenticatorMock.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(nil, nil)
+	userModelMock.EXPECT().
+		GetUserByToken(gomock.Eq(token)).
+		Return(user, nil)
+	statsModelMock.EXPECT().
+		YearReport(user.ID, 2020, gomock.Any()).
+		Return(nil, errors.New("intentional error"))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/stats/years/2020", nil)
+	r.Header.Add(models.XAuthToken, token)
+
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusInternalServerError)
+}
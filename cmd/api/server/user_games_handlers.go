@@ -2,9 +2,12 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
 	"github.com/gorilla/mux"
 )
 
@@ -25,28 +28,115 @@ func (s *Server) handleUsersGamesPatch() authorizedHandler {
 			return
 		}
 
+		// all fields are validated before any of them is changed,
+		// so that an invalid request does not change the game partially, and does not notify about the changes
 		if req.Status != "" {
 			if err := req.Status.Validate(); err != nil {
 				s.respondError(w, r, err.Error(), http.StatusBadRequest)
 				return
 			}
+		}
+		if req.Rating != nil && (*req.Rating < 0 || *req.Rating > models.MaxRating) {
+			s.respondError(w, r, fmt.Sprintf("'rating' should be between 1 and %d, or 0 to remove the rating", models.MaxRating), http.StatusBadRequest)
+			return
+		}
+
+		// the viewers are looked up once for the changes of the status and the progress
+		var viewers []string
+		if req.Status != "" || req.Progress != nil {
+			viewers = s.gameViewers(userGameID)
+		}
+
+		res := models.GameUpdateResponse{}
+		if req.Status != "" {
 			if err := s.GameModel.ChangeGameStatus(user.ID, userGameID, req.Status); err != nil {
-				s.Log.Errorf("Error while changing game status: %v", err)
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				s.gameUpdateError(w, r, "status", err)
 				return
 			}
+			s.trackGoals(user.ID)
+			s.gameChanged(user.ID, viewers, &models.Change{Type: models.ChangeGameUpdated, GameID: userGameID, Status: req.Status})
+			if req.Status == models.StatusInProgress {
+				res.Warnings = s.playOrderWarnings(user.ID, userGameID)
+			}
 		}
 
 		if req.Progress != nil {
 			if err := s.GameModel.ChangeGameProgress(user.ID, userGameID, req.Progress); err != nil {
-				s.Log.Errorf("Error while changing game progress: %v", err)
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				s.gameUpdateError(w, r, "progress", err)
 				return
 			}
+			s.gameChanged(user.ID, viewers, &models.Change{Type: models.ChangeGameUpdated, GameID: userGameID, Progress: req.Progress})
 		}
 
-		// TODO: better response
-		s.respond(w, r, nil, http.StatusOK)
+		if req.Wishlist != nil {
+			if err := s.GameModel.ChangeGameWishlist(user.ID, userGameID, *req.Wishlist); err != nil {
+				s.gameUpdateError(w, r, "wishlist", err)
+				return
+			}
+			s.trackGoals(user.ID)
+		}
+
+		if req.ReleaseDate != nil || req.ClearReleaseDate {
+			if err := s.GameModel.ChangeGameReleaseDate(user.ID, userGameID, releaseDate(req.ReleaseDate)); err != nil {
+				s.gameUpdateError(w, r, "release date", err)
+				return
+			}
+		}
+
+		if req.Hidden != nil {
+			if err := s.GameModel.ChangeGameHidden(user.ID, userGameID, *req.Hidden); err != nil {
+				s.gameUpdateError(w, r, "visibility", err)
+				return
+			}
+		}
+
+		if req.Rating != nil {
+			if err := s.GameModel.ChangeGameRating(user.ID, userGameID, *req.Rating); err != nil {
+				s.gameUpdateError(w, r, "rating", err)
+				return
+			}
+		}
+
+		s.respond(w, r, res, http.StatusOK)
+	}
+}
+
+// playOrderWarnings warns about the games, that come before the given game in the play order of its franchise,
+// but are still To Do.
+// The warnings are best-effort, so an error while computing them does not fail the update.
+func (s *Server) playOrderWarnings(userID, gameID string) []string {
+	games, err := s.FranchiseModel.GamesBefore(userID, gameID)
+	if err != nil {
+		if !errors.Is(err, postgres.ErrNoRecord) {
+			s.Log.Errorf("Error while fetching the games before %s: %v", gameID, err)
+		}
+		return nil
+	}
+
+	var warnings []string
+	for _, game := range games {
+		if game.Status == models.StatusTODO && !game.Wishlist {
+			warnings = append(warnings, fmt.Sprintf("%s comes before this game in the %s franchise and is still To Do", game.Name, game.Franchise))
+		}
+	}
+	return warnings
+}
+
+func (s *Server) gameUpdateError(w http.ResponseWriter, r *http.Request, field string, err error) {
+	s.gameChangeError(w, r, "Game not found", "changing game "+field, err)
+}
+
+// gameChangeError responds with the error returned while changing a game, or something that belongs to it, like its checklist.
+// notFound is the message for a game, that the user can not see, and action describes the change in the log.
+func (s *Server) gameChangeError(w http.ResponseWriter, r *http.Request, notFound, action string, err error) {
+	switch {
+	case errors.Is(err, postgres.ErrNoRecord):
+		s.respondError(w, r, notFound, http.StatusNotFound)
+	case errors.Is(err, postgres.ErrForbidden):
+		s.respondError(w, r, "Your role on the board does not allow changing the game", http.StatusForbidden)
+	default:
+		s.Log.Errorf("Error while %s: %v", action, err)
+		s.internalError(w, r)
 	}
 }
 
@@ -60,12 +150,28 @@ func (s *Server) handleUsersGamesDelete() authorizedHandler {
 			return
 		}
 
+		// the cover is looked up before the game is deleted, so that only the cover of a game, that the user can delete, is deleted
+		var hasCover bool
+		if s.BlobStore != nil {
+			updatedAt, err := s.CoverModel.CoverUpdatedAt(user.ID, models.CoverKindGame, gameID, true)
+			if err != nil && !errors.Is(err, postgres.ErrNoRecord) && !errors.Is(err, postgres.ErrForbidden) {
+				s.Log.Errorf("Error while fetching cover of game %s: %v", gameID, err)
+			}
+			hasCover = updatedAt != nil
+		}
+
+		// the viewers are looked up before the game is deleted, because they can not be found after that
+		viewers := s.gameViewers(gameID)
+
 		if err := s.GameModel.DeleteGame(user.ID, gameID); err != nil {
-			s.Log.Errorf("Error while deleting user game: %v", err)
-			// TODO: determine if user does not own the game or other error
-			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			s.gameUpdateError(w, r, "deletion", err)
 			return
 		}
+		if hasCover {
+			s.deleteCover(r.Context(), models.CoverKindGame, gameID)
+		}
+		s.trackGoals(user.ID)
+		s.gameChanged(user.ID, viewers, &models.Change{Type: models.ChangeGameDeleted, GameID: gameID})
 
 		s.respond(w, r, nil, http.StatusOK)
 	}
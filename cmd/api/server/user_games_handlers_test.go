@@ -2,16 +2,18 @@ package server
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	gassert "github.com/asankov/gira/internal/fixtures/assert"
 
 	"github.com/asankov/gira/internal/fixtures"
 	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestUsersGamesPatch(t *testing.T) {
@@ -21,10 +23,12 @@ func TestUsersGamesPatch(t *testing.T) {
 	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
 	gamesModelMock := fixtures.NewGameModelMock(ctrl)
 	userModelMock := fixtures.NewUserModelMock(ctrl)
+	goalModelMock := fixtures.NewGoalModelMock(ctrl)
 	srv := newServer(t, &Options{
 		Authenticator: authenticatorMock,
 		UserModel:     userModelMock,
 		GameModel:     gamesModelMock,
+		GoalModel:     goalModelMock,
 	})
 
 	authenticatorMock.EXPECT().
@@ -39,6 +43,9 @@ func TestUsersGamesPatch(t *testing.T) {
 		EXPECT().
 		ChangeGameStatus(gomock.Eq("12"), gomock.Eq("1"), gomock.Eq(models.StatusDone)).
 		Return(nil)
+	goalModelMock.EXPECT().
+		TrackGoals("12", gomock.Any()).
+		Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, models.ChangeGameStatusRequest{Status: models.StatusDone}))
@@ -114,14 +121,67 @@ func TestUsersGamesPatchServiceError(t *testing.T) {
 }
 
 func TestUsersGamesPatchInvalidInput(t *testing.T) {
+	wishlist, rating := true, models.MaxRating+1
+	testCases := []struct {
+		name string
+		req  models.ChangeGameStatusRequest
+	}{
+		{
+			name: "Invalid status",
+			req:  models.ChangeGameStatusRequest{Status: models.Status("some status")},
+		},
+		{
+			// the valid fields are not changed either, because the request is validated before any change
+			name: "Invalid rating",
+			req:  models.ChangeGameStatusRequest{Status: models.StatusDone, Progress: &models.GameProgress{Current: 100, Final: 100}, Wishlist: &wishlist, Rating: &rating},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+			userModelMock := fixtures.NewUserModelMock(ctrl)
+			// the game model mock fails the test, if anything is changed
+			srv := newServer(t, &Options{
+				Authenticator: authenticatorMock,
+				UserModel:     userModelMock,
+				GameModel:     fixtures.NewGameModelMock(ctrl),
+			})
+
+			authenticatorMock.EXPECT().
+				DecodeToken(gomock.Eq(token)).
+				Return(nil, nil)
+			userModelMock.EXPECT().
+				GetUserByToken(gomock.Eq(token)).
+				Return(&models.User{
+					ID: "12",
+				}, nil)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, testCase.req))
+			r.Header.Add(models.XAuthToken, token)
+
+			srv.ServeHTTP(w, r)
+
+			gassert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestUsersGamesDelete(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+	gamesModelMock := fixtures.NewGameModelMock(ctrl)
 	userModelMock := fixtures.NewUserModelMock(ctrl)
 	srv := newServer(t, &Options{
 		Authenticator: authenticatorMock,
 		UserModel:     userModelMock,
+		GameModel:     gamesModelMock,
+		GoalModel:     newTrackingGoalModel(ctrl, "12"),
 	})
 
 	authenticatorMock.EXPECT().
@@ -132,27 +192,36 @@ func TestUsersGamesPatchInvalidInput(t *testing.T) {
 		Return(&models.User{
 			ID: "12",
 		}, nil)
+	gamesModelMock.
+		EXPECT().
+		DeleteGame(gomock.Eq("12"), gomock.Eq("1")).
+		Return(nil)
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, models.ChangeGameStatusRequest{Status: models.Status("some status")}))
+	r := httptest.NewRequest(http.MethodDelete, "/games/1", nil)
 	r.Header.Add(models.XAuthToken, token)
 
 	srv.ServeHTTP(w, r)
 
-	gassert.StatusCode(t, w, http.StatusBadRequest)
+	gassert.StatusOK(t, w)
 }
 
-func TestUsersGamesDelete(t *testing.T) {
+func TestUsersGamesDeleteWithCover(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
 	gamesModelMock := fixtures.NewGameModelMock(ctrl)
 	userModelMock := fixtures.NewUserModelMock(ctrl)
+	coverModelMock := fixtures.NewCoverModelMock(ctrl)
+	blobStoreMock := fixtures.NewBlobStoreMock(ctrl)
 	srv := newServer(t, &Options{
 		Authenticator: authenticatorMock,
 		UserModel:     userModelMock,
 		GameModel:     gamesModelMock,
+		GoalModel:     newTrackingGoalModel(ctrl, "12"),
+		CoverModel:    coverModelMock,
+		BlobStore:     blobStoreMock,
 	})
 
 	authenticatorMock.EXPECT().
@@ -163,10 +232,19 @@ func TestUsersGamesDelete(t *testing.T) {
 		Return(&models.User{
 			ID: "12",
 		}, nil)
-	gamesModelMock.
-		EXPECT().
+	updatedAt := time.Now()
+	coverModelMock.EXPECT().
+		CoverUpdatedAt("12", models.CoverKindGame, "1", true).
+		Return(&updatedAt, nil)
+	gamesModelMock.EXPECT().
 		DeleteGame(gomock.Eq("12"), gomock.Eq("1")).
 		Return(nil)
+	blobStoreMock.EXPECT().
+		Delete(gomock.Any(), "covers/games/1/full.jpg").
+		Return(nil)
+	blobStoreMock.EXPECT().
+		Delete(gomock.Any(), "covers/games/1/thumbnail.jpg").
+		Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/games/1", nil)
@@ -200,7 +278,7 @@ func TestUsersGamesDeleteUserDoesNotOwnGame(t *testing.T) {
 		}, nil)
 	gamesModelMock.EXPECT().
 		DeleteGame(gomock.Eq("12"), gomock.Eq("1")).
-		Return(fmt.Errorf("user does not own game"))
+		Return(postgres.ErrNoRecord)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/games/1", nil)
@@ -208,5 +286,200 @@ func TestUsersGamesDeleteUserDoesNotOwnGame(t *testing.T) {
 
 	srv.ServeHTTP(w, r)
 
-	gassert.StatusCode(t, w, http.StatusBadRequest)
+	gassert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestUsersGamesPatchWishlistAndReleaseDate(t *testing.T) {
+	wishlist := true
+	hidden := true
+	rating, noRating := 4, 0
+	releaseDate := time.Date(2023, 10, 20, 0, 0, 0, 0, time.UTC)
+	requestDate := time.Date(2023, 10, 20, 15, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		req    models.ChangeGameStatusRequest
+		expect func(*fixtures.GameModelMock)
+	}{
+		{
+			name: "Wishlist and release date",
+			req:  models.ChangeGameStatusRequest{Wishlist: &wishlist, ReleaseDate: &requestDate},
+			expect: func(m *fixtures.GameModelMock) {
+				m.EXPECT().ChangeGameWishlist("12", "1", true).Return(nil)
+				m.EXPECT().ChangeGameReleaseDate("12", "1", &releaseDate).Return(nil)
+			},
+		},
+		{
+			name: "Clear release date",
+			req:  models.ChangeGameStatusRequest{ClearReleaseDate: true},
+			expect: func(m *fixtures.GameModelMock) {
+				m.EXPECT().ChangeGameReleaseDate("12", "1", nil).Return(nil)
+			},
+		},
+		{
+			name: "Hidden",
+			req:  models.ChangeGameStatusRequest{Hidden: &hidden},
+			expect: func(m *fixtures.GameModelMock) {
+				m.EXPECT().ChangeGameHidden("12", "1", true).Return(nil)
+			},
+		},
+		{
+			name: "Rating",
+			req:  models.ChangeGameStatusRequest{Rating: &rating},
+			expect: func(m *fixtures.GameModelMock) {
+				m.EXPECT().ChangeGameRating("12", "1", 4).Return(nil)
+			},
+		},
+		{
+			name: "Remove rating",
+			req:  models.ChangeGameStatusRequest{Rating: &noRating},
+			expect: func(m *fixtures.GameModelMock) {
+				m.EXPECT().ChangeGameRating("12", "1", 0).Return(nil)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+			gamesModelMock := fixtures.NewGameModelMock(ctrl)
+			userModelMock := fixtures.NewUserModelMock(ctrl)
+			srv := newServer(t, &Options{
+				Authenticator: authenticatorMock,
+				UserModel:     userModelMock,
+				GameModel:     gamesModelMock,
+				GoalModel:     newTrackingGoalModel(ctrl, "12"),
+			})
+
+			authenticatorMock.EXPECT().
+				DecodeToken(gomock.Eq(token)).
+				Return(nil, nil)
+			userModelMock.EXPECT().
+				GetUserByToken(gomock.Eq(token)).
+				Return(&models.User{
+					ID: "12",
+				}, nil)
+			testCase.expect(gamesModelMock)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, testCase.req))
+			r.Header.Add(models.XAuthToken, token)
+
+			srv.ServeHTTP(w, r)
+
+			gassert.StatusOK(t, w)
+		})
+	}
+}
+
+func TestUsersGamesPatchWishlistNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+	gamesModelMock := fixtures.NewGameModelMock(ctrl)
+	userModelMock := fixtures.NewUserModelMock(ctrl)
+	srv := newServer(t, &Options{
+		Authenticator: authenticatorMock,
+		UserModel:     userModelMock,
+		GameModel:     gamesModelMock,
+	})
+
+	authenticatorMock.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(nil, nil)
+	userModelMock.EXPECT().
+		GetUserByToken(gomock.Eq(token)).
+		Return(&models.User{
+			ID: "12",
+		}, nil)
+	gamesModelMock.EXPECT().
+		ChangeGameWishlist("12", "1", false).
+		Return(postgres.ErrNoRecord)
+
+	wishlist := false
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, models.ChangeGameStatusRequest{Wishlist: &wishlist}))
+	r.Header.Add(models.XAuthToken, token)
+
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestUsersGamesPatchPlayOrderWarnings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		before   []*models.Game
+		err      error
+		warnings []string
+	}{
+		{
+			name: "Earlier games To Do",
+			before: []*models.Game{
+				{ID: "2", Name: "Witcher 1", Franchise: "Witcher", Status: models.StatusDone},
+				{ID: "3", Name: "Witcher 2", Franchise: "Witcher", Status: models.StatusTODO},
+				{ID: "4", Name: "Witcher 2.5", Franchise: "Witcher", Status: models.StatusTODO, Wishlist: true},
+			},
+			warnings: []string{"Witcher 2 comes before this game in the Witcher franchise and is still To Do"},
+		},
+		{
+			name:   "No earlier games",
+			before: []*models.Game{},
+		},
+		{
+			name: "Error",
+			err:  errors.New("intentional error"),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+			gamesModelMock := fixtures.NewGameModelMock(ctrl)
+			franchiseModelMock := fixtures.NewFranchiseModelMock(ctrl)
+			userModelMock := fixtures.NewUserModelMock(ctrl)
+			goalModelMock := fixtures.NewGoalModelMock(ctrl)
+			srv := newServer(t, &Options{
+				Authenticator:  authenticatorMock,
+				UserModel:      userModelMock,
+				GameModel:      gamesModelMock,
+				FranchiseModel: franchiseModelMock,
+				GoalModel:      goalModelMock,
+			})
+
+			authenticatorMock.EXPECT().
+				DecodeToken(gomock.Eq(token)).
+				Return(nil, nil)
+			userModelMock.EXPECT().
+				GetUserByToken(gomock.Eq(token)).
+				Return(&models.User{
+					ID: "12",
+				}, nil)
+			gamesModelMock.EXPECT().
+				ChangeGameStatus("12", "1", models.StatusInProgress).
+				Return(nil)
+			goalModelMock.EXPECT().
+				TrackGoals("12", gomock.Any()).
+				Return(nil)
+			franchiseModelMock.EXPECT().
+				GamesBefore("12", "1").
+				Return(testCase.before, testCase.err)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, models.ChangeGameStatusRequest{Status: models.StatusInProgress}))
+			r.Header.Add(models.XAuthToken, token)
+
+			srv.ServeHTTP(w, r)
+
+			gassert.StatusOK(t, w)
+			var res models.GameUpdateResponse
+			fixtures.Decode(t, w.Body, &res)
+			assert.Equal(t, testCase.warnings, res.Warnings)
+		})
+	}
 }
@@ -3,13 +3,19 @@ package server
 import (
 	"encoding/json"
 	"errors"
+	"expvar"
+	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"time"
 
 	"github.com/asankov/gira/internal/auth"
+	"github.com/asankov/gira/internal/middleware"
 	"github.com/asankov/gira/pkg/models"
 	"github.com/asankov/gira/pkg/models/postgres"
 	"github.com/hashicorp/go-multierror"
+	"github.com/sirupsen/logrus"
 )
 
 var (
@@ -21,6 +27,21 @@ var (
 	errPasswordRequired         = errors.New("'password' is required field")
 	errParsingBody              = errors.New("error while parsing request body")
 	errHashedPasswordNotAllowed = errors.New("'hashedPassword' is not allowed field")
+	errProviderRequired         = errors.New("'provider' is required field")
+	errCodeRequired             = errors.New("'code' is required field")
+	errCodeVerifierRequired     = errors.New("'codeVerifier' is required field")
+	errNonceRequired            = errors.New("'nonce' is required field")
+	errInvalidIdentity          = errors.New("login with the provider could not be verified")
+	errWrongCredentials         = errors.New("Wrong email/password")
+	errTooManyLoginAttempts     = errors.New("Too many failed login attempts. Try again later.")
+
+	metricLoginFailures = expvar.NewInt(metricLoginFailuresName)
+	metricLoginLockouts = expvar.NewInt(metricLoginLockoutsName)
+)
+
+const (
+	metricLoginFailuresName = "login_failures"
+	metricLoginLockoutsName = "login_lockouts"
 )
 
 func (s *Server) handleUserCreate() http.HandlerFunc {
@@ -131,29 +152,135 @@ func (s *Server) handleUserLogin() http.HandlerFunc {
 			return
 		}
 
+		ip := middleware.ClientIP(r, s.TrustedProxies)
+		if s.LoginThrottler != nil {
+			if wait := s.LoginThrottler.Check(user.Email, ip); wait > 0 {
+				s.respondTooManyLoginAttempts(w, r, wait)
+				return
+			}
+		}
+
 		usr, err := s.UserModel.Authenticate(user.Email, user.Password)
 		if err != nil {
-			// TODO: JSON Error
-			http.Error(w, "Wrong email/password", http.StatusUnauthorized)
+			if !errors.Is(err, postgres.ErrInvalidCredentials) {
+				s.Log.Errorf("Error while authenticating user: %v", err)
+				s.internalError(w, r)
+				return
+			}
+
+			metricLoginFailures.Add(1)
+			if s.LoginThrottler != nil {
+				if wait := s.LoginThrottler.Fail(user.Email, ip); wait > 0 {
+					metricLoginLockouts.Add(1)
+					s.Log.WithFields(logrus.Fields{
+						"email":    user.Email,
+						"ip":       ip,
+						"duration": wait,
+					}).Warn("Login locked out after too many failed attempts")
+				}
+			}
+
+			s.respondError(w, r, errWrongCredentials.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		token, err := s.Authenticator.NewTokenForUser(usr)
+		if s.LoginThrottler != nil {
+			s.LoginThrottler.Success(user.Email, ip)
+		}
+
+		s.loginUser(w, r, usr)
+	}
+}
+
+func (s *Server) handleUserLoginOIDC() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.OIDCLoginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.respondError(w, r, errParsingBody.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if req.Provider == "" {
+			s.respondError(w, r, errProviderRequired.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if req.Code == "" {
+			s.respondError(w, r, errCodeRequired.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if req.CodeVerifier == "" {
+			s.respondError(w, r, errCodeVerifierRequired.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// the nonce binds the ID token to the login, that it was issued for, so a token without one could be replayed
+		if req.Nonce == "" {
+			s.respondError(w, r, errNonceRequired.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// the code is exchanged here, and not by the caller, so that an ID token cannot be replayed from another login
+		identity, err := s.IdentityVerifier.ExchangeIdentity(r.Context(), req.Provider, req.Code, req.CodeVerifier, req.Nonce)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			s.Log.Warnf("Error while exchanging code with provider %s: %v", req.Provider, err)
+			s.respondError(w, r, errInvalidIdentity.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		if err := s.UserModel.AssociateTokenWithUser(usr.ID, token); err != nil {
-			s.Log.Errorf("Error while associating token with user %s: %v", usr.ID, err)
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		usr, err := s.UserModel.AuthenticateIdentity(identity)
+		if err != nil {
+			if errors.Is(err, postgres.ErrIdentityEmailRequired) || errors.Is(err, postgres.ErrEmailAlreadyExists) {
+				s.respondError(w, r, err.Error(), http.StatusBadRequest)
+				return
+			}
+			s.Log.Errorf("Error while authenticating identity %s from provider %s: %v", identity.Subject, req.Provider, err)
+			s.internalError(w, r)
 			return
 		}
 
-		s.respond(w, r, &models.UserLoginResponse{Token: token}, http.StatusOK)
+		s.loginUser(w, r, usr)
+	}
+}
+
+// handleLoginMetricsGet responds with the login metrics only, in the format of expvar.
+// The rest of the expvar variables are not public, because they include the command line and the memory stats of the process.
+func (s *Server) handleLoginMetricsGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		s.respond(w, r, map[string]int64{
+			metricLoginFailuresName: metricLoginFailures.Value(),
+			metricLoginLockoutsName: metricLoginLockouts.Value(),
+		}, http.StatusOK)
 	}
 }
 
+// respondTooManyLoginAttempts responds with the same message, regardless of whether
+// the account or the client was locked out, and of whether such account exists.
+func (s *Server) respondTooManyLoginAttempts(w http.ResponseWriter, r *http.Request, wait time.Duration) {
+	w.Header().Set("Retry-After", fmt.Sprintf("%d", int(math.Ceil(wait.Seconds()))))
+	s.respondError(w, r, errTooManyLoginAttempts.Error(), http.StatusTooManyRequests)
+}
+
+// loginUser issues a new token for the already authenticated user
+// and responds with it.
+func (s *Server) loginUser(w http.ResponseWriter, r *http.Request, usr *models.User) {
+	token, err := s.Authenticator.NewTokenForUser(usr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := s.UserModel.AssociateTokenWithUser(usr.ID, token); err != nil {
+		s.Log.Errorf("Error while associating token with user %s: %v", usr.ID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	s.respond(w, r, &models.UserLoginResponse{Token: token}, http.StatusOK)
+}
+
 func (s *Server) handleUserLogout() authorizedHandler {
 	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
 
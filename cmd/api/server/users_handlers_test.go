@@ -2,9 +2,11 @@ package server
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	gassert "github.com/asankov/gira/internal/fixtures/assert"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/asankov/gira/internal/auth"
 	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/middleware"
 	"github.com/asankov/gira/pkg/models"
 	"github.com/asankov/gira/pkg/models/postgres"
 	"github.com/golang/mock/gomock"
@@ -39,6 +42,43 @@ func newServer(t *testing.T, opts *Options) *Server {
 	return srv
 }
 
+// newAuthorizedServer returns a server with the given options, that accepts the token of user.
+// The user model returns user for the token, unless it is set in the options.
+func newAuthorizedServer(t *testing.T, ctrl *gomock.Controller, opts *Options) *Server {
+	authenticator := fixtures.NewAuthenticatorMock(ctrl)
+	authenticator.EXPECT().
+		DecodeToken(gomock.Eq(token)).
+		Return(user, nil)
+	opts.Authenticator = authenticator
+
+	if opts.UserModel == nil {
+		userModel := fixtures.NewUserModelMock(ctrl)
+		userModel.EXPECT().
+			GetUserByToken(gomock.Eq(token)).
+			Return(user, nil)
+		opts.UserModel = userModel
+	}
+
+	return newServer(t, opts)
+}
+
+// newTrackingGoalModel returns a goal model, that tracks the goals of the given user after a change of their games
+func newTrackingGoalModel(ctrl *gomock.Controller, userID string) *fixtures.GoalModelMock {
+	goalModel := fixtures.NewGoalModelMock(ctrl)
+	goalModel.EXPECT().
+		TrackGoals(userID, gomock.Any()).
+		Return(nil).
+		AnyTimes()
+	return goalModel
+}
+
+// newTokenRequest returns a request with the token of user
+func newTokenRequest(method, path string, body io.Reader) *http.Request {
+	r := httptest.NewRequest(method, path, body)
+	r.Header.Set(models.XAuthToken, token)
+	return r
+}
+
 func TestUserCreate(t *testing.T) {
 	testCases := []struct {
 		Name          string
@@ -276,14 +316,23 @@ func TestUserLoginServiceError(t *testing.T) {
 		expectedCode int
 	}{
 		{
-			name: "UserModel.Authenticate fails",
+			name: "UserModel.Authenticate returns invalid credentials",
 			setup: func(u *fixtures.UserModelMock, a *fixtures.AuthenticatorMock) {
 				u.EXPECT().
 					Authenticate(expectedUser.Email, expectedUser.Password).
-					Return(nil, errors.New("user not found"))
+					Return(nil, postgres.ErrInvalidCredentials)
 			},
 			expectedCode: http.StatusUnauthorized,
 		},
+		{
+			name: "UserModel.Authenticate fails",
+			setup: func(u *fixtures.UserModelMock, a *fixtures.AuthenticatorMock) {
+				u.EXPECT().
+					Authenticate(expectedUser.Email, expectedUser.Password).
+					Return(nil, errors.New("intentional error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
 		{
 			name: "Authenticator.NewTokenForUser fails",
 			setup: func(u *fixtures.UserModelMock, a *fixtures.AuthenticatorMock) {
@@ -339,6 +388,259 @@ func TestUserLoginServiceError(t *testing.T) {
 	}
 }
 
+func TestUserLoginLockedOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userModel := fixtures.NewUserModelMock(ctrl)
+	throttler := fixtures.NewLoginThrottlerMock(ctrl)
+
+	srv := newServer(t, &Options{
+		UserModel:      userModel,
+		LoginThrottler: throttler,
+	})
+
+	throttler.EXPECT().
+		Check(expectedUser.Email, "192.0.2.1").
+		Return(90 * time.Second)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users/login", fixtures.Marshal(t, expectedUser))
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusTooManyRequests)
+	assert.Equal(t, "90", w.Header().Get("Retry-After"))
+	var errorResponse models.ErrorResponse
+	fixtures.Decode(t, w.Body, &errorResponse)
+	assert.Equal(t, errTooManyLoginAttempts.Error(), errorResponse.Error)
+}
+
+func TestUserLoginFailureIsThrottled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userModel := fixtures.NewUserModelMock(ctrl)
+	throttler := fixtures.NewLoginThrottlerMock(ctrl)
+
+	trustedProxies, err := middleware.ParseTrustedProxies([]string{"192.0.2.1"})
+	require.NoError(t, err)
+
+	srv := newServer(t, &Options{
+		UserModel:      userModel,
+		LoginThrottler: throttler,
+		TrustedProxies: trustedProxies,
+	})
+
+	throttler.EXPECT().
+		Check(expectedUser.Email, "5.6.7.8").
+		Return(time.Duration(0))
+	userModel.EXPECT().
+		Authenticate(expectedUser.Email, expectedUser.Password).
+		Return(nil, postgres.ErrInvalidCredentials)
+	throttler.EXPECT().
+		Fail(expectedUser.Email, "5.6.7.8").
+		Return(time.Minute)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users/login", fixtures.Marshal(t, expectedUser))
+	r.Header.Set("X-Forwarded-For", "5.6.7.8")
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusUnauthorized)
+	var errorResponse models.ErrorResponse
+	fixtures.Decode(t, w.Body, &errorResponse)
+	assert.Equal(t, errWrongCredentials.Error(), errorResponse.Error)
+}
+
+func TestUserLoginSuccessResetsThrottler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userModel := fixtures.NewUserModelMock(ctrl)
+	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+	throttler := fixtures.NewLoginThrottlerMock(ctrl)
+
+	srv := newServer(t, &Options{
+		UserModel:      userModel,
+		Authenticator:  authenticatorMock,
+		LoginThrottler: throttler,
+	})
+
+	throttler.EXPECT().
+		Check(expectedUser.Email, "192.0.2.1").
+		Return(time.Duration(0))
+	userModel.EXPECT().
+		Authenticate(expectedUser.Email, expectedUser.Password).
+		Return(&expectedUser, nil)
+	throttler.EXPECT().
+		Success(expectedUser.Email, "192.0.2.1")
+	authenticatorMock.EXPECT().
+		NewTokenForUser(&expectedUser).
+		Return(token, nil)
+	userModel.EXPECT().
+		AssociateTokenWithUser(expectedUser.ID, token).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users/login", fixtures.Marshal(t, expectedUser))
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+}
+
+func TestLoginMetrics(t *testing.T) {
+	srv := newServer(t, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+
+	gassert.StatusOK(t, w)
+	var metrics map[string]int64
+	fixtures.Decode(t, w.Body, &metrics)
+	// only the login metrics are public, and not the command line and the memory stats of the process
+	assert.Len(t, metrics, 2)
+	assert.Contains(t, metrics, "login_failures")
+	assert.Contains(t, metrics, "login_lockouts")
+}
+
+func TestUserLoginOIDC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userModel := fixtures.NewUserModelMock(ctrl)
+	authenticatorMock := fixtures.NewAuthenticatorMock(ctrl)
+	identityVerifier := fixtures.NewIdentityVerifierMock(ctrl)
+
+	srv := newServer(t, &Options{
+		UserModel:        userModel,
+		Authenticator:    authenticatorMock,
+		IdentityVerifier: identityVerifier,
+	})
+
+	identity := &models.Identity{Provider: "google", Subject: "123", Email: expectedUser.Email}
+	identityVerifier.EXPECT().
+		ExchangeIdentity(gomock.Any(), "google", "code", "verifier", "nonce").
+		Return(identity, nil)
+	userModel.EXPECT().
+		AuthenticateIdentity(identity).
+		Return(&expectedUser, nil)
+	authenticatorMock.EXPECT().
+		NewTokenForUser(&expectedUser).
+		Return(token, nil)
+	userModel.EXPECT().
+		AssociateTokenWithUser(expectedUser.ID, token).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users/login/oidc", fixtures.Marshal(t, models.OIDCLoginRequest{
+		Provider:     "google",
+		Code:         "code",
+		CodeVerifier: "verifier",
+		Nonce:        "nonce",
+	}))
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+	var userResponse models.UserLoginResponse
+	fixtures.Decode(t, w.Body, &userResponse)
+	assert.Equal(t, token, userResponse.Token)
+}
+
+func TestUserLoginOIDCError(t *testing.T) {
+	validRequest := models.OIDCLoginRequest{Provider: "google", Code: "code", CodeVerifier: "verifier", Nonce: "nonce"}
+	identity := &models.Identity{Provider: "google", Subject: "123"}
+
+	testCases := []struct {
+		name         string
+		request      models.OIDCLoginRequest
+		setup        func(v *fixtures.IdentityVerifierMock, u *fixtures.UserModelMock)
+		expectedCode int
+	}{
+		{
+			name:         "No provider",
+			request:      models.OIDCLoginRequest{Code: "code", CodeVerifier: "verifier", Nonce: "nonce"},
+			setup:        func(v *fixtures.IdentityVerifierMock, u *fixtures.UserModelMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "No code",
+			request:      models.OIDCLoginRequest{Provider: "google", CodeVerifier: "verifier", Nonce: "nonce"},
+			setup:        func(v *fixtures.IdentityVerifierMock, u *fixtures.UserModelMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "No code verifier",
+			request:      models.OIDCLoginRequest{Provider: "google", Code: "code", Nonce: "nonce"},
+			setup:        func(v *fixtures.IdentityVerifierMock, u *fixtures.UserModelMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "No nonce",
+			request:      models.OIDCLoginRequest{Provider: "google", Code: "code", CodeVerifier: "verifier"},
+			setup:        func(v *fixtures.IdentityVerifierMock, u *fixtures.UserModelMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "IdentityVerifier.ExchangeIdentity fails",
+			request: validRequest,
+			setup: func(v *fixtures.IdentityVerifierMock, u *fixtures.UserModelMock) {
+				v.EXPECT().
+					ExchangeIdentity(gomock.Any(), "google", "code", "verifier", "nonce").
+					Return(nil, errors.New("code was already exchanged"))
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:    "Identity has no email",
+			request: validRequest,
+			setup: func(v *fixtures.IdentityVerifierMock, u *fixtures.UserModelMock) {
+				v.EXPECT().
+					ExchangeIdentity(gomock.Any(), "google", "code", "verifier", "nonce").
+					Return(identity, nil)
+				u.EXPECT().
+					AuthenticateIdentity(identity).
+					Return(nil, postgres.ErrIdentityEmailRequired)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "UserModel.AuthenticateIdentity fails",
+			request: validRequest,
+			setup: func(v *fixtures.IdentityVerifierMock, u *fixtures.UserModelMock) {
+				v.EXPECT().
+					ExchangeIdentity(gomock.Any(), "google", "code", "verifier", "nonce").
+					Return(identity, nil)
+				u.EXPECT().
+					AuthenticateIdentity(identity).
+					Return(nil, errors.New("intentional error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userModel := fixtures.NewUserModelMock(ctrl)
+			identityVerifier := fixtures.NewIdentityVerifierMock(ctrl)
+
+			testCase.setup(identityVerifier, userModel)
+
+			srv := newServer(t, &Options{
+				UserModel:        userModel,
+				IdentityVerifier: identityVerifier,
+			})
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/users/login/oidc", fixtures.Marshal(t, testCase.request))
+			srv.ServeHTTP(w, r)
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
+
 func TestUserGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
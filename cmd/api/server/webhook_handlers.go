@@ -0,0 +1,192 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/asankov/gira/internal/webhooks"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// defaultDeliveriesLimit is the number of deliveries returned, if no limit is requested
+	defaultDeliveriesLimit = 20
+	// maxDeliveriesLimit is the maximum number of deliveries returned at once
+	maxDeliveriesLimit = 100
+)
+
+var (
+	errURL            = errors.New("'url' should be an absolute http or https URL")
+	errEventsRequired = errors.New("'events' should contain at least one event")
+)
+
+func (s *Server) handleWebhooksGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		hooks, err := s.WebhookModel.Webhooks(user.ID)
+		if err != nil {
+			s.Log.Errorf("Error while fetching webhooks from the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, models.WebhooksResponse{Webhooks: hooks}, http.StatusOK)
+	}
+}
+
+// handleWebhookCreate creates an active webhook and returns it with its secret. The secret is not returned after that.
+func (s *Server) handleWebhookCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var webhook models.Webhook
+		if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+			s.respondError(w, r, "Error decoding body", http.StatusBadRequest)
+			return
+		}
+
+		if err := validateWebhook(&webhook); err != nil {
+			s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		secret, err := webhooks.NewSecret()
+		if err != nil {
+			s.Log.Errorf("Error while generating webhook secret: %v", err)
+			s.internalError(w, r)
+			return
+		}
+		webhook.Secret = secret
+		webhook.Active = true
+		webhook.UserID = user.ID
+
+		created, err := s.WebhookModel.InsertWebhook(&webhook)
+		if err != nil {
+			s.Log.Errorf("Error while inserting webhook into the database: %v", err)
+			s.internalError(w, r)
+			return
+		}
+
+		s.respond(w, r, created, http.StatusCreated)
+	}
+}
+
+func (s *Server) handleWebhookGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		webhook, err := s.WebhookModel.Webhook(user.ID, mux.Vars(r)["id"])
+		if err != nil {
+			s.webhookError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, webhook, http.StatusOK)
+	}
+}
+
+// handleWebhookUpdate replaces the URL, the events and the active flag of the webhook. The secret stays the same.
+func (s *Server) handleWebhookUpdate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		var webhook models.Webhook
+		if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+			s.respondError(w, r, "Error decoding body", http.StatusBadRequest)
+			return
+		}
+
+		if err := validateWebhook(&webhook); err != nil {
+			s.respondError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		webhook.ID = mux.Vars(r)["id"]
+		webhook.UserID = user.ID
+		webhook.Secret = ""
+		if err := s.WebhookModel.UpdateWebhook(&webhook); err != nil {
+			s.webhookError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, webhook, http.StatusOK)
+	}
+}
+
+func (s *Server) handleWebhookDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		if err := s.WebhookModel.DeleteWebhook(user.ID, mux.Vars(r)["id"]); err != nil {
+			s.webhookError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleWebhookDeliveriesGet returns the latest deliveries of the webhook, the newest first
+func (s *Server) handleWebhookDeliveriesGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, user *models.User, token string) {
+		limit := defaultDeliveriesLimit
+		if l := r.URL.Query().Get("limit"); l != "" {
+			var err error
+			if limit, err = strconv.Atoi(l); err != nil || limit <= 0 || limit > maxDeliveriesLimit {
+				s.respondError(w, r, "'limit' should be a number between 1 and 100", http.StatusBadRequest)
+				return
+			}
+		}
+
+		deliveries, err := s.WebhookModel.WebhookDeliveries(user.ID, mux.Vars(r)["id"], limit)
+		if err != nil {
+			s.webhookError(w, r, err)
+			return
+		}
+
+		s.respond(w, r, models.WebhookDeliveriesResponse{Deliveries: deliveries}, http.StatusOK)
+	}
+}
+
+func (s *Server) webhookError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, postgres.ErrNoRecord) {
+		s.respondError(w, r, "Webhook not found", http.StatusNotFound)
+		return
+	}
+	s.Log.Errorf("Error while working with webhook: %v", err)
+	s.internalError(w, r)
+}
+
+// dispatchWebhook posts the event to the webhooks of the user, if the webhooks are enabled
+func (s *Server) dispatchWebhook(userID string, payload *models.WebhookPayload) {
+	if s.WebhookDispatcher == nil {
+		return
+	}
+	s.WebhookDispatcher.Dispatch(userID, payload)
+}
+
+// validateWebhook validates the webhook. The duplicate events are removed.
+func validateWebhook(webhook *models.Webhook) error {
+	var err *multierror.Error
+	if webhook.ID != "" {
+		err = multierror.Append(err, errIDNotAllowed)
+	}
+	if !isHTTPURL(webhook.URL) {
+		err = multierror.Append(err, errURL)
+	}
+	if len(webhook.Events) == 0 {
+		err = multierror.Append(err, errEventsRequired)
+	}
+
+	seen := map[models.WebhookEvent]bool{}
+	events := []models.WebhookEvent{}
+	for _, event := range webhook.Events {
+		if e := event.Validate(); e != nil {
+			err = multierror.Append(err, e)
+			continue
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+	webhook.Events = events
+
+	return err.ErrorOrNil()
+}
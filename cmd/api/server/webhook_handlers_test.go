@@ -0,0 +1,447 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	webhookCreatedAt = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	webhook          = &models.Webhook{
+		ID:        "1",
+		URL:       "https://example.com/hook",
+		Events:    []models.WebhookEvent{models.WebhookGameCreated, models.WebhookGameStatusChanged},
+		Active:    true,
+		CreatedAt: &webhookCreatedAt,
+	}
+)
+
+func TestWebhooksGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookModel := fixtures.NewWebhookModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{WebhookModel: webhookModel})
+	webhookModel.EXPECT().
+		Webhooks(user.ID).
+		Return([]*models.Webhook{webhook}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/webhooks", nil))
+
+	var res models.WebhooksResponse
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, []*models.Webhook{webhook}, res.Webhooks)
+}
+
+func TestWebhookCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookModel := fixtures.NewWebhookModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{WebhookModel: webhookModel})
+
+	var inserted *models.Webhook
+	webhookModel.EXPECT().
+		InsertWebhook(gomock.Any()).
+		DoAndReturn(func(w *models.Webhook) (*models.Webhook, error) {
+			inserted = w
+			created := *w
+			created.ID = "1"
+			return &created, nil
+		})
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/webhooks", fixtures.Marshal(t, models.Webhook{
+		URL:    "https://example.com/hook",
+		Events: []models.WebhookEvent{models.WebhookGameCreated, models.WebhookGameDeleted, models.WebhookGameCreated},
+		// the secret is generated by the server
+		Secret: "chosen by the user",
+	})))
+
+	var res models.Webhook
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusCode(t, w, http.StatusCreated)
+	require.Equal(t, user.ID, inserted.UserID)
+	require.True(t, inserted.Active)
+	require.Len(t, inserted.Secret, 64)
+	require.Equal(t, models.Webhook{
+		ID:     "1",
+		URL:    "https://example.com/hook",
+		Events: []models.WebhookEvent{models.WebhookGameCreated, models.WebhookGameDeleted},
+		Active: true,
+		Secret: inserted.Secret,
+	}, res)
+}
+
+func TestWebhookCreateError(t *testing.T) {
+	testCases := []struct {
+		name    string
+		webhook models.Webhook
+		err     error
+		status  int
+	}{
+		{
+			name:    "ID",
+			webhook: models.Webhook{ID: "1", URL: "https://example.com/hook", Events: []models.WebhookEvent{models.WebhookGameCreated}},
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:    "No URL",
+			webhook: models.Webhook{Events: []models.WebhookEvent{models.WebhookGameCreated}},
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:    "Not an HTTP URL",
+			webhook: models.Webhook{URL: "file:///etc/passwd", Events: []models.WebhookEvent{models.WebhookGameCreated}},
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:    "No events",
+			webhook: models.Webhook{URL: "https://example.com/hook"},
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:    "Unknown event",
+			webhook: models.Webhook{URL: "https://example.com/hook", Events: []models.WebhookEvent{"game.rated"}},
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:    "DB error",
+			webhook: models.Webhook{URL: "https://example.com/hook", Events: []models.WebhookEvent{models.WebhookGameCreated}},
+			err:     errors.New("intentional error"),
+			status:  http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookModel := fixtures.NewWebhookModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{WebhookModel: webhookModel})
+			if testCase.err != nil {
+				webhookModel.EXPECT().
+					InsertWebhook(gomock.Any()).
+					Return(nil, testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPost, "/webhooks", fixtures.Marshal(t, testCase.webhook)))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestWebhookGet(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "Success", status: http.StatusOK},
+		{name: "Not found", err: postgres.ErrNoRecord, status: http.StatusNotFound},
+		{name: "DB error", err: errors.New("intentional error"), status: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookModel := fixtures.NewWebhookModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{WebhookModel: webhookModel})
+			var res *models.Webhook
+			if testCase.err == nil {
+				res = webhook
+			}
+			webhookModel.EXPECT().
+				Webhook(user.ID, "1").
+				Return(res, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/webhooks/1", nil))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "Success", status: http.StatusOK},
+		{name: "Not found", err: postgres.ErrNoRecord, status: http.StatusNotFound},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookModel := fixtures.NewWebhookModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{WebhookModel: webhookModel})
+			webhookModel.EXPECT().
+				UpdateWebhook(&models.Webhook{
+					ID:     "1",
+					URL:    "https://example.com/new-hook",
+					Events: []models.WebhookEvent{models.WebhookGameDeleted},
+					UserID: user.ID,
+				}).
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodPut, "/webhooks/1", fixtures.Marshal(t, models.Webhook{
+				URL:    "https://example.com/new-hook",
+				Events: []models.WebhookEvent{models.WebhookGameDeleted},
+				Secret: "chosen by the user",
+			})))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestWebhookDelete(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "Success", status: http.StatusNoContent},
+		{name: "Not found", err: postgres.ErrNoRecord, status: http.StatusNotFound},
+		{name: "DB error", err: errors.New("intentional error"), status: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookModel := fixtures.NewWebhookModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{WebhookModel: webhookModel})
+			webhookModel.EXPECT().
+				DeleteWebhook(user.ID, "1").
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodDelete, "/webhooks/1", nil))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+func TestWebhookDeliveriesGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookModel := fixtures.NewWebhookModelMock(ctrl)
+	srv := newAuthorizedServer(t, ctrl, &Options{WebhookModel: webhookModel})
+	deliveries := []*models.WebhookDelivery{
+		{ID: "2", WebhookID: "1", EventID: "abc", Event: models.WebhookGameCreated, Payload: "{}", Success: true, StatusCode: 200, Attempts: 1, CreatedAt: webhookCreatedAt},
+		{ID: "1", WebhookID: "1", EventID: "def", Event: models.WebhookGameDeleted, Payload: "{}", StatusCode: 500, Error: "webhook responded with 500", Attempts: 5, CreatedAt: webhookCreatedAt},
+	}
+	webhookModel.EXPECT().
+		WebhookDeliveries(user.ID, "1", 10).
+		Return(deliveries, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newTokenRequest(http.MethodGet, "/webhooks/1/deliveries?limit=10", nil))
+
+	var res models.WebhookDeliveriesResponse
+	fixtures.Decode(t, w.Body, &res)
+
+	gassert.StatusOK(t, w)
+	require.Equal(t, deliveries, res.Deliveries)
+}
+
+func TestWebhookDeliveriesGetError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		path   string
+		err    error
+		status int
+	}{
+		{name: "Invalid limit", path: "/webhooks/1/deliveries?limit=0", status: http.StatusBadRequest},
+		{name: "Not found", path: "/webhooks/1/deliveries", err: postgres.ErrNoRecord, status: http.StatusNotFound},
+		{name: "DB error", path: "/webhooks/1/deliveries", err: errors.New("intentional error"), status: http.StatusInternalServerError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookModel := fixtures.NewWebhookModelMock(ctrl)
+			srv := newAuthorizedServer(t, ctrl, &Options{WebhookModel: webhookModel})
+			if testCase.err != nil {
+				webhookModel.EXPECT().
+					WebhookDeliveries(user.ID, "1", 20).
+					Return(nil, testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newTokenRequest(http.MethodGet, testCase.path, nil))
+
+			gassert.StatusCode(t, w, testCase.status)
+		})
+	}
+}
+
+// dispatchMocks are the models, that the changes of the games go through
+type dispatchMocks struct {
+	gameModel        *fixtures.GameModelMock
+	goalModel        *fixtures.GoalModelMock
+	franchiseModel   *fixtures.FranchiseModelMock
+	playthroughModel *fixtures.PlaythroughModelMock
+	checklistModel   *fixtures.ChecklistModelMock
+}
+
+func TestWebhookDispatch(t *testing.T) {
+	progress := &models.GameProgress{Current: 10, Final: 100}
+	testCases := []struct {
+		name    string
+		request *http.Request
+		expect  func(m *dispatchMocks)
+		payload *models.WebhookPayload
+	}{
+		{
+			name:    "Game created",
+			request: newTokenRequest(http.MethodPost, "/games", fixtures.Marshal(t, models.Game{Name: "Hades"})),
+			expect: func(m *dispatchMocks) {
+				m.gameModel.EXPECT().
+					Insert(&models.Game{Name: "Hades", UserID: user.ID}).
+					Return(&models.Game{ID: "1", Name: "Hades"}, nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+			},
+			payload: &models.WebhookPayload{Event: models.WebhookGameCreated, GameID: "1", Game: &models.Game{ID: "1", Name: "Hades"}},
+		},
+		{
+			name:    "Status changed",
+			request: newTokenRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, models.ChangeGameStatusRequest{Status: models.StatusDone})),
+			expect: func(m *dispatchMocks) {
+				m.gameModel.EXPECT().
+					ChangeGameStatus(user.ID, "1", models.StatusDone).
+					Return(nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+			},
+			payload: &models.WebhookPayload{Event: models.WebhookGameStatusChanged, GameID: "1", Status: models.StatusDone},
+		},
+		{
+			name:    "Progress changed",
+			request: newTokenRequest(http.MethodPatch, "/games/1", fixtures.Marshal(t, models.ChangeGameStatusRequest{Progress: progress})),
+			expect: func(m *dispatchMocks) {
+				m.gameModel.EXPECT().
+					ChangeGameProgress(user.ID, "1", progress).
+					Return(nil)
+			},
+			payload: &models.WebhookPayload{Event: models.WebhookGameProgressChanged, GameID: "1", Progress: progress},
+		},
+		{
+			name:    "Deleted",
+			request: newTokenRequest(http.MethodDelete, "/games/1", nil),
+			expect: func(m *dispatchMocks) {
+				m.gameModel.EXPECT().
+					DeleteGame(user.ID, "1").
+					Return(nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+			},
+			payload: &models.WebhookPayload{Event: models.WebhookGameDeleted, GameID: "1"},
+		},
+		{
+			name:    "Game imported",
+			request: newImportRequest("/games/import", "text/csv", "name\nHades\n"),
+			expect: func(m *dispatchMocks) {
+				m.gameModel.EXPECT().
+					AllForUser(user.ID).
+					Return([]*models.Game{}, nil)
+				m.franchiseModel.EXPECT().
+					All(user.ID).
+					Return([]*models.Franchise{}, nil)
+				m.gameModel.EXPECT().
+					InsertMany(user.ID, []string{}, []*models.Game{{Name: "Hades"}}).
+					Return([]*models.Game{{ID: "1", Name: "Hades", Status: models.StatusTODO}}, nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+			},
+			payload: &models.WebhookPayload{Event: models.WebhookGameCreated, GameID: "1", Game: &models.Game{ID: "1", Name: "Hades", Status: models.StatusTODO}},
+		},
+		{
+			name:    "Status changed by playthrough",
+			request: newTokenRequest(http.MethodPut, "/games/1/playthroughs/2", fixtures.Marshal(t, playthrough)),
+			expect: func(m *dispatchMocks) {
+				m.playthroughModel.EXPECT().
+					UpdatePlaythrough(user.ID, playthrough).
+					Return(playthrough, models.StatusDone, nil)
+				m.goalModel.EXPECT().
+					TrackGoals(user.ID, gomock.Any()).
+					Return(nil)
+			},
+			payload: &models.WebhookPayload{Event: models.WebhookGameStatusChanged, GameID: "1", Status: models.StatusDone},
+		},
+		{
+			name:    "Progress changed by checklist",
+			request: newTokenRequest(http.MethodPatch, "/games/1/checklist/items/3", strings.NewReader(`{"done": true}`)),
+			expect: func(m *dispatchMocks) {
+				done := true
+				m.checklistModel.EXPECT().
+					UpdateChecklistItem(user.ID, "1", "3", "", &done).
+					Return(&models.Checklist{AutoProgress: true, Progress: progress, ProgressChanged: true}, nil)
+			},
+			payload: &models.WebhookPayload{Event: models.WebhookGameProgressChanged, GameID: "1", Progress: progress},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := newAuthorizedServer(t, ctrl, &Options{WebhookModel: fixtures.NewWebhookModelMock(ctrl)})
+			m := &dispatchMocks{
+				gameModel:        fixtures.NewGameModelMock(ctrl),
+				goalModel:        fixtures.NewGoalModelMock(ctrl),
+				franchiseModel:   fixtures.NewFranchiseModelMock(ctrl),
+				playthroughModel: fixtures.NewPlaythroughModelMock(ctrl),
+				checklistModel:   fixtures.NewChecklistModelMock(ctrl),
+			}
+			dispatcher := fixtures.NewWebhookDispatcherMock(ctrl)
+			srv.GameModel = m.gameModel
+			srv.GoalModel = m.goalModel
+			srv.FranchiseModel = m.franchiseModel
+			srv.PlaythroughModel = m.playthroughModel
+			srv.ChecklistModel = m.checklistModel
+			srv.WebhookDispatcher = dispatcher
+
+			testCase.expect(m)
+			dispatcher.EXPECT().
+				Dispatch(user.ID, testCase.payload)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, testCase.request)
+
+			gassert.StatusOK(t, w)
+		})
+	}
+}
@@ -1,13 +1,32 @@
 package config
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kelseyhightower/envconfig"
+)
 
 type Config struct {
-	Port          int    `required:"true" split_words:"true"`
-	LogLevel      string `default:"info" split_words:"true"`
-	APIAddress    string `required:"true" split_words:"true"`
-	SessionSecret string `required:"true" split_words:"true"`
-	EnforceHTTPS  bool   `required:"true" split_words:"true"`
+	Port          int      `required:"true" split_words:"true"`
+	LogLevel      string   `default:"info" split_words:"true"`
+	APIAddress    string   `required:"true" split_words:"true"`
+	SessionSecret string   `required:"true" split_words:"true"`
+	EnforceHTTPS  bool     `required:"true" split_words:"true"`
+	OIDCProviders []string `envconfig:"OIDC_PROVIDERS"`
+
+	// OIDC holds the configuration of each provider listed in OIDCProviders.
+	// Each provider is configured via the GIRA_OIDC_<NAME>_ prefix, e.g. GIRA_OIDC_GOOGLE_ISSUER.
+	OIDC []*OIDCConfig `ignored:"true"`
+}
+
+// OIDCConfig is the configuration of an OpenID Connect provider,
+// needed to login users via the authorization code flow.
+type OIDCConfig struct {
+	Name        string `ignored:"true"`
+	Issuer      string `required:"true" split_words:"true"`
+	ClientID    string `required:"true" split_words:"true"`
+	RedirectURL string `required:"true" split_words:"true"`
 }
 
 func NewFromEnv() (*Config, error) {
@@ -15,5 +34,14 @@ func NewFromEnv() (*Config, error) {
 	if err := envconfig.Process("GIRA", &config); err != nil {
 		return nil, err
 	}
+
+	for _, name := range config.OIDCProviders {
+		oidcConfig := OIDCConfig{Name: name}
+		if err := envconfig.Process(fmt.Sprintf("GIRA_OIDC_%s", strings.ToUpper(name)), &oidcConfig); err != nil {
+			return nil, fmt.Errorf("error while loading config for OIDC provider %s: %w", name, err)
+		}
+		config.OIDC = append(config.OIDC, &oidcConfig)
+	}
+
 	return &config, nil
 }
@@ -26,6 +26,26 @@ func TestNewConfig(t *testing.T) {
 	require.Equal(t, config.EnforceHTTPS, false)
 }
 
+func TestNewConfigOIDC(t *testing.T) {
+	setenv(t, "GIRA_PORT", "4000")
+	setenv(t, "GIRA_API_ADDRESS", "localhost:4000")
+	setenv(t, "GIRA_SESSION_SECRET", "sec")
+	setenv(t, "GIRA_ENFORCE_HTTPS", "false")
+	setenv(t, "GIRA_OIDC_PROVIDERS", "google")
+	setenv(t, "GIRA_OIDC_GOOGLE_ISSUER", "https://accounts.google.com")
+	setenv(t, "GIRA_OIDC_GOOGLE_CLIENT_ID", "google-client")
+	setenv(t, "GIRA_OIDC_GOOGLE_REDIRECT_URL", "http://localhost:4000/users/login/oidc/google/callback")
+
+	config, err := config.NewFromEnv()
+
+	require.NoError(t, err)
+	require.Len(t, config.OIDC, 1)
+	require.Equal(t, "google", config.OIDC[0].Name)
+	require.Equal(t, "https://accounts.google.com", config.OIDC[0].Issuer)
+	require.Equal(t, "google-client", config.OIDC[0].ClientID)
+	require.Equal(t, "http://localhost:4000/users/login/oidc/google/callback", config.OIDC[0].RedirectURL)
+}
+
 func TestRequiredValues(t *testing.T) {
 	config, err := config.NewFromEnv()
 
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/asankov/gira/internal/oidc"
 	"github.com/asankov/gira/pkg/client"
 
 	"github.com/asankov/gira/cmd/front-end/config"
@@ -31,6 +32,8 @@ func run() error {
 
 	session := sessions.New([]byte(config.SessionSecret))
 	session.Lifetime = 12 * time.Hour
+	session.Secure = config.EnforceHTTPS
+	session.SameSite = http.SameSiteLaxMode
 
 	cl, err := client.New(config.APIAddress)
 	if err != nil {
@@ -45,11 +48,24 @@ func run() error {
 	log.SetLevel(logLevel)
 	logrus.SetLevel(logLevel)
 
+	providers := []*oidc.Provider{}
+	for _, p := range config.OIDC {
+		providers = append(providers, oidc.NewProvider(&oidc.ProviderOptions{
+			Name:        p.Name,
+			Issuer:      p.Issuer,
+			ClientID:    p.ClientID,
+			RedirectURL: p.RedirectURL,
+		}))
+	}
+
 	s := &server.Server{
 		Log:      log,
 		Client:   cl,
 		Session:  session,
 		Renderer: templates.NewRenderer(),
+		OIDC:     oidc.NewRegistry(providers...),
+
+		EnforceHTTPS: config.EnforceHTTPS,
 	}
 
 	addr := fmt.Sprintf(":%d", config.Port)
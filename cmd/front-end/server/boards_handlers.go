@@ -0,0 +1,252 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// handleBoardsView renders the shared boards, that the user is a member of, and their pending invitations.
+func (s *Server) handleBoardsView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		boards, err := s.Client.GetBoards(r.Context(), &client.GetBoardsRequest{Token: token})
+		if err != nil {
+			s.boardViewError(w, r, err)
+			return
+		}
+
+		invitations, err := s.Client.GetInvitations(r.Context(), &client.GetInvitationsRequest{Token: token})
+		if err != nil {
+			s.boardViewError(w, r, err)
+			return
+		}
+
+		s.render(w, r, TemplateData{
+			Boards:      boards.Boards,
+			Invitations: invitations.Invitations,
+		}, boardsPage, token)
+	}
+}
+
+func (s *Server) handleBoardCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		name := r.PostForm.Get("name")
+		board, err := s.Client.CreateBoard(r.Context(), &client.CreateBoardRequest{Token: token, Name: name})
+		if err != nil {
+			s.redirectAfterBoardChange(w, r, "/boards", "", err)
+			return
+		}
+		s.redirectAfterBoardChange(w, r, boardURL(board.ID), fmt.Sprintf("Board %s created.", board.Name), nil)
+	}
+}
+
+// handleBoardView renders the given board with its games and members.
+func (s *Server) handleBoardView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		boardID := mux.Vars(r)["id"]
+
+		board, err := s.Client.GetBoard(r.Context(), &client.BoardRequest{Token: token, BoardID: boardID})
+		if err != nil {
+			s.boardViewError(w, r, err)
+			return
+		}
+
+		res, err := s.Client.GetBoardGames(r.Context(), &client.BoardRequest{Token: token, BoardID: boardID})
+		if err != nil {
+			s.boardViewError(w, r, err)
+			return
+		}
+
+		statuses, err := s.Client.GetStatuses(r.Context(), &client.GetStatusesRequest{Token: token})
+		if err != nil {
+			s.boardViewError(w, r, err)
+			return
+		}
+
+		games := []TemplateGame{}
+		for _, game := range res.Games {
+			games = append(games, TemplateGame{
+				ID:       game.ID,
+				Name:     game.Name,
+				Status:   game.Status,
+				Progress: game.Progress,
+				CoverURL: gameCoverURL(game),
+			})
+		}
+
+		s.render(w, r, TemplateData{
+			Board:    board,
+			Games:    games,
+			Statuses: statuses.Statuses,
+		}, boardPage, token)
+	}
+}
+
+func (s *Server) handleBoardDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		boardID := mux.Vars(r)["id"]
+
+		if err := s.Client.DeleteBoard(r.Context(), &client.BoardRequest{Token: token, BoardID: boardID}); err != nil {
+			s.redirectAfterBoardChange(w, r, boardURL(boardID), "", err)
+			return
+		}
+		s.redirectAfterBoardChange(w, r, "/boards", "The board is deleted.", nil)
+	}
+}
+
+// handleBoardGameCreate adds a game with the given name to the board.
+func (s *Server) handleBoardGameCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		boardID := mux.Vars(r)["id"]
+		name := r.PostForm.Get("name")
+		_, err := s.Client.CreateGame(r.Context(), &client.CreateGameRequest{Token: token, Game: &client.Game{Name: name, BoardID: boardID}})
+		s.redirectAfterBoardChange(w, r, boardURL(boardID), fmt.Sprintf("%s is added to the board.", name), err)
+	}
+}
+
+// handleBoardGameStatus changes the status of a game on the board.
+func (s *Server) handleBoardGameStatus() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		gameID, status := r.PostForm.Get("game"), r.PostForm.Get("status")
+		if gameID == "" || status == "" {
+			http.Error(w, "'game' and 'status' are required", http.StatusBadRequest)
+			return
+		}
+
+		_, err := s.Client.UpdateGameProgress(r.Context(), &client.UpdateGameProgressRequest{
+			GameID: gameID,
+			Token:  token,
+			Update: client.UpdateGameProgressChange{Status: client.Status(status)},
+		})
+		s.redirectAfterBoardChange(w, r, boardURL(mux.Vars(r)["id"]), "", err)
+	}
+}
+
+func (s *Server) handleBoardInvite() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		boardID := mux.Vars(r)["id"]
+		username := r.PostForm.Get("username")
+		_, err := s.Client.InviteToBoard(r.Context(), &client.InviteToBoardRequest{
+			Token:    token,
+			BoardID:  boardID,
+			Username: username,
+			Role:     client.BoardRole(r.PostForm.Get("role")),
+		})
+		s.redirectAfterBoardChange(w, r, boardURL(boardID), fmt.Sprintf("%s is invited to the board.", username), err)
+	}
+}
+
+func (s *Server) handleBoardMemberRole() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		args := mux.Vars(r)
+		role := client.BoardRole(r.PostForm.Get("role"))
+		_, err := s.Client.ChangeBoardMemberRole(r.Context(), &client.BoardMemberRequest{Token: token, BoardID: args["id"], Username: args["username"], Role: role})
+		s.redirectAfterBoardChange(w, r, boardURL(args["id"]), fmt.Sprintf("%s is %s now.", args["username"], role), err)
+	}
+}
+
+// handleBoardMemberRemove removes the member from the board.
+// If the form is sent with leave=true, the member is the user, who leaves the board, so they are sent back to the boards.
+func (s *Server) handleBoardMemberRemove() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		args := mux.Vars(r)
+		if err := s.Client.RemoveBoardMember(r.Context(), &client.BoardMemberRequest{Token: token, BoardID: args["id"], Username: args["username"]}); err != nil {
+			s.redirectAfterBoardChange(w, r, boardURL(args["id"]), "", err)
+			return
+		}
+
+		if r.PostForm.Get("leave") == "true" {
+			s.redirectAfterBoardChange(w, r, "/boards", "You left the board.", nil)
+			return
+		}
+		s.redirectAfterBoardChange(w, r, boardURL(args["id"]), fmt.Sprintf("%s is removed from the board.", args["username"]), nil)
+	}
+}
+
+func (s *Server) handleInvitationAccept() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		board, err := s.Client.AcceptInvitation(r.Context(), &client.InvitationRequest{Token: token, InvitationID: mux.Vars(r)["id"]})
+		if err != nil {
+			s.redirectAfterBoardChange(w, r, "/boards", "", err)
+			return
+		}
+		s.redirectAfterBoardChange(w, r, boardURL(board.ID), fmt.Sprintf("You joined %s.", board.Name), nil)
+	}
+}
+
+func (s *Server) handleInvitationDecline() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		err := s.Client.DeclineInvitation(r.Context(), &client.InvitationRequest{Token: token, InvitationID: mux.Vars(r)["id"]})
+		s.redirectAfterBoardChange(w, r, "/boards", "The invitation is declined.", err)
+	}
+}
+
+func boardURL(boardID string) string {
+	return "/boards/" + url.PathEscape(boardID)
+}
+
+// redirectAfterBoardChange redirects to the given location,
+// with the given flash message, if any, or with the error returned while changing the board.
+func (s *Server) redirectAfterBoardChange(w http.ResponseWriter, r *http.Request, location, flash string, err error) {
+	if err != nil {
+		if errors.Is(err, client.ErrNoAuthorization) {
+			w.Header().Add("Location", "/users/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		s.Log.Errorf("Error while changing board: %v", err)
+		s.Session.Put(r, "error", err.Error())
+	} else if flash != "" {
+		s.Session.Put(r, "flash", flash)
+	}
+
+	w.Header().Add("Location", location)
+	w.WriteHeader(http.StatusSeeOther)
+}
+
+func (s *Server) boardViewError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, client.ErrNoAuthorization):
+		w.Header().Add("Location", "/users/login")
+		w.WriteHeader(http.StatusSeeOther)
+	case errors.Is(err, client.ErrBoardNotFound):
+		http.NotFound(w, r)
+	default:
+		s.Log.Errorf("Error while fetching board: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,306 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+)
+
+var board = &client.Board{
+	ID:   "1",
+	Name: "Co-op",
+	Role: client.BoardRoleOwner,
+	Members: []*client.BoardMember{
+		{Username: user.Username, Role: client.BoardRoleOwner},
+		{Username: "ivan", Role: client.BoardRoleEditor},
+	},
+}
+
+func TestBoardsView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	boards := []*client.Board{{ID: "1", Name: "Co-op", Role: client.BoardRoleOwner}}
+	invitations := []*client.BoardInvitation{{ID: "3", BoardID: "2", BoardName: "Souls", Username: user.Username, Role: client.BoardRoleViewer, InvitedBy: "ivan"}}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetBoards(gomock.AssignableToTypeOf(ctxType), &client.GetBoardsRequest{Token: token}).
+		Return(&client.GetBoardsResponse{Boards: boards}, nil)
+	apiClient.EXPECT().
+		GetInvitations(gomock.AssignableToTypeOf(ctxType), &client.GetInvitationsRequest{Token: token}).
+		Return(&client.GetInvitationsResponse{Invitations: invitations}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:        user,
+			Boards:      boards,
+			Invitations: invitations,
+			CSRFToken:   csrfToken,
+		}), "boards.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/boards", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestBoardView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	progress := &client.GameProgress{Current: 3, Final: 10}
+	statuses := []client.Status{"To Do", "In Progress", "Done"}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetBoard(gomock.AssignableToTypeOf(ctxType), &client.BoardRequest{Token: token, BoardID: "1"}).
+		Return(board, nil)
+	apiClient.EXPECT().
+		GetBoardGames(gomock.AssignableToTypeOf(ctxType), &client.BoardRequest{Token: token, BoardID: "1"}).
+		Return(&client.GetGamesResponse{Games: []*client.Game{
+			{ID: "2", Name: "It Takes Two", Status: "In Progress", Progress: progress, BoardID: "1", CoverURL: "https://media.example.com/2.jpg"},
+		}}, nil)
+	apiClient.EXPECT().
+		GetStatuses(gomock.AssignableToTypeOf(ctxType), &client.GetStatusesRequest{Token: token}).
+		Return(&client.GetStatusesResponse{Statuses: statuses}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:  user,
+			Board: board,
+			Games: []server.TemplateGame{
+				{ID: "2", Name: "It Takes Two", Status: "In Progress", Progress: progress, CoverURL: "https://media.example.com/2.jpg"},
+			},
+			Statuses:  statuses,
+			CSRFToken: csrfToken,
+		}), "board.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/boards/1", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestBoardViewClientError(t *testing.T) {
+	testCases := []struct {
+		name  string
+		err   error
+		check func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "No authorization",
+			err:  client.ErrNoAuthorization,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Not a member",
+			err:  client.ErrBoardNotFound,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusNotFound)
+			},
+		},
+		{
+			name: "Other error",
+			err:  errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				GetBoard(gomock.AssignableToTypeOf(ctxType), &client.BoardRequest{Token: token, BoardID: "1"}).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/boards/1", nil))
+
+			testCase.check(t, w)
+		})
+	}
+}
+
+func TestBoardCreate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		location string
+	}{
+		{name: "Success", location: "/boards/1"},
+		{name: "Error", err: errors.New("'name' is required"), location: "/boards"},
+		{name: "No authorization", err: client.ErrNoAuthorization, location: "/users/login"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			var created *client.Board
+			if testCase.err == nil {
+				created = &client.Board{ID: "1", Name: "Co-op", Role: client.BoardRoleOwner}
+			}
+			apiClient.EXPECT().
+				CreateBoard(gomock.AssignableToTypeOf(ctxType), &client.CreateBoardRequest{Token: token, Name: "Co-op"}).
+				Return(created, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/boards", url.Values{"name": []string{"Co-op"}}))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
+
+func TestBoardChanges(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		form     url.Values
+		expect   func(*fixtures.APIClientMock)
+		location string
+	}{
+		{
+			name: "Add game",
+			path: "/boards/1/games",
+			form: url.Values{"name": []string{"It Takes Two"}},
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					CreateGame(gomock.AssignableToTypeOf(ctxType), &client.CreateGameRequest{Token: token, Game: &client.Game{Name: "It Takes Two", BoardID: "1"}}).
+					Return(&client.CreateGameResponse{Game: &client.Game{ID: "2", Name: "It Takes Two", BoardID: "1"}}, nil)
+			},
+			location: "/boards/1",
+		},
+		{
+			name: "Change status as a viewer",
+			path: "/boards/1/games/status",
+			form: url.Values{"game": []string{"2"}, "status": []string{"Done"}},
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					UpdateGameProgress(gomock.AssignableToTypeOf(ctxType), &client.UpdateGameProgressRequest{GameID: "2", Token: token, Update: client.UpdateGameProgressChange{Status: "Done"}}).
+					Return(nil, client.ErrBoardForbidden)
+			},
+			location: "/boards/1",
+		},
+		{
+			name: "Invite",
+			path: "/boards/1/invitations",
+			form: url.Values{"username": []string{"ivan"}, "role": []string{"viewer"}},
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					InviteToBoard(gomock.AssignableToTypeOf(ctxType), &client.InviteToBoardRequest{Token: token, BoardID: "1", Username: "ivan", Role: client.BoardRoleViewer}).
+					Return(&client.BoardInvitation{ID: "3"}, nil)
+			},
+			location: "/boards/1",
+		},
+		{
+			name: "Change role",
+			path: "/boards/1/members/ivan",
+			form: url.Values{"role": []string{"viewer"}},
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					ChangeBoardMemberRole(gomock.AssignableToTypeOf(ctxType), &client.BoardMemberRequest{Token: token, BoardID: "1", Username: "ivan", Role: client.BoardRoleViewer}).
+					Return(board, nil)
+			},
+			location: "/boards/1",
+		},
+		{
+			name: "Remove member",
+			path: "/boards/1/members/ivan/remove",
+			form: url.Values{},
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					RemoveBoardMember(gomock.AssignableToTypeOf(ctxType), &client.BoardMemberRequest{Token: token, BoardID: "1", Username: "ivan"}).
+					Return(nil)
+			},
+			location: "/boards/1",
+		},
+		{
+			name: "Leave",
+			path: "/boards/1/members/anton/remove",
+			form: url.Values{"leave": []string{"true"}},
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					RemoveBoardMember(gomock.AssignableToTypeOf(ctxType), &client.BoardMemberRequest{Token: token, BoardID: "1", Username: "anton"}).
+					Return(nil)
+			},
+			location: "/boards",
+		},
+		{
+			name: "Delete board",
+			path: "/boards/1/delete",
+			form: url.Values{},
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					DeleteBoard(gomock.AssignableToTypeOf(ctxType), &client.BoardRequest{Token: token, BoardID: "1"}).
+					Return(nil)
+			},
+			location: "/boards",
+		},
+		{
+			name: "Accept invitation",
+			path: "/invitations/3/accept",
+			form: url.Values{},
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					AcceptInvitation(gomock.AssignableToTypeOf(ctxType), &client.InvitationRequest{Token: token, InvitationID: "3"}).
+					Return(&client.Board{ID: "2", Name: "Souls", Role: client.BoardRoleViewer}, nil)
+			},
+			location: "/boards/2",
+		},
+		{
+			name: "Decline invitation",
+			path: "/invitations/3/decline",
+			form: url.Values{},
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					DeclineInvitation(gomock.AssignableToTypeOf(ctxType), &client.InvitationRequest{Token: token, InvitationID: "3"}).
+					Return(nil)
+			},
+			location: "/boards",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+			testCase.expect(apiClient)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, testCase.path, testCase.form))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
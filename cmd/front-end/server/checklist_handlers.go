@@ -0,0 +1,139 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// maxChecklistItemName is the maximum length of the name of a checklist item
+const maxChecklistItemName = 255
+
+// handleChecklistGet returns the checklist of the given game as JSON.
+// It backs the collapsible checklist of the All Games view, which is loaded when it is expanded.
+func (s *Server) handleChecklistGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		checklist, err := s.Client.GetChecklist(r.Context(), &client.GetChecklistRequest{Token: token, GameID: mux.Vars(r)["id"]})
+		s.respondChecklist(w, checklist, err)
+	}
+}
+
+// handleChecklistPatch turns on or off the computation of the progress of the game from its checklist.
+// The body is a JSON object with an autoProgress field.
+func (s *Server) handleChecklistPatch() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		var req struct {
+			AutoProgress *bool `json:"autoProgress"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AutoProgress == nil {
+			http.Error(w, "'autoProgress' is required", http.StatusBadRequest)
+			return
+		}
+
+		checklist, err := s.Client.SetChecklistAutoProgress(r.Context(), &client.SetChecklistAutoProgressRequest{
+			Token:        token,
+			GameID:       mux.Vars(r)["id"],
+			AutoProgress: *req.AutoProgress,
+		})
+		s.respondChecklist(w, checklist, err)
+	}
+}
+
+// handleChecklistItemCreate adds an item to the checklist of the game. The body is a JSON object with a name field.
+func (s *Server) handleChecklistItemCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		req, ok := decodeChecklistItem(w, r)
+		if !ok {
+			return
+		}
+		if req.Name == "" {
+			http.Error(w, "'name' is required", http.StatusBadRequest)
+			return
+		}
+
+		checklist, err := s.Client.AddChecklistItem(r.Context(), &client.AddChecklistItemRequest{
+			Token:  token,
+			GameID: mux.Vars(r)["id"],
+			Name:   req.Name,
+		})
+		s.respondChecklist(w, checklist, err)
+	}
+}
+
+// handleChecklistItemPatch renames or ticks off an item of the checklist. The body is a JSON object with name and/or done fields.
+func (s *Server) handleChecklistItemPatch() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		req, ok := decodeChecklistItem(w, r)
+		if !ok {
+			return
+		}
+		if req.Name == "" && req.Done == nil {
+			http.Error(w, "'name' or 'done' is required", http.StatusBadRequest)
+			return
+		}
+
+		vars := mux.Vars(r)
+		checklist, err := s.Client.UpdateChecklistItem(r.Context(), &client.UpdateChecklistItemRequest{
+			Token:  token,
+			GameID: vars["id"],
+			ItemID: vars["itemId"],
+			Name:   req.Name,
+			Done:   req.Done,
+		})
+		s.respondChecklist(w, checklist, err)
+	}
+}
+
+// handleChecklistItemDelete deletes an item from the checklist of the game.
+func (s *Server) handleChecklistItemDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		vars := mux.Vars(r)
+		checklist, err := s.Client.DeleteChecklistItem(r.Context(), &client.DeleteChecklistItemRequest{
+			Token:  token,
+			GameID: vars["id"],
+			ItemID: vars["itemId"],
+		})
+		s.respondChecklist(w, checklist, err)
+	}
+}
+
+type checklistItemRequest struct {
+	Name string `json:"name"`
+	Done *bool  `json:"done"`
+}
+
+func decodeChecklistItem(w http.ResponseWriter, r *http.Request) (*checklistItemRequest, bool) {
+	var req checklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return nil, false
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if len(req.Name) > maxChecklistItemName {
+		http.Error(w, "'name' is too long", http.StatusBadRequest)
+		return nil, false
+	}
+	return &req, true
+}
+
+// respondChecklist responds with the checklist as JSON, or with the status code, that matches the error of the client.
+func (s *Server) respondChecklist(w http.ResponseWriter, checklist *client.Checklist, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, client.ErrNoAuthorization):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case errors.Is(err, client.ErrChecklistNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			s.Log.Errorf("Error while changing checklist: %v", err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		}
+		return
+	}
+
+	s.respondJSON(w, checklist)
+}
@@ -0,0 +1,176 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+var checklist = &client.Checklist{
+	Items: []*client.ChecklistItem{
+		{ID: "1", Name: "Find all feathers", Done: true},
+		{ID: "2", Name: "Kill all templars"},
+	},
+	AutoProgress: true,
+	Progress:     &client.GameProgress{Current: 1, Final: 2},
+}
+
+func newChecklistRequest(t *testing.T, method, path, body string) *http.Request {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	addCSRFToken(t, r)
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	return r
+}
+
+func TestChecklist(t *testing.T) {
+	done := false
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		expect func(*fixtures.APIClientMock)
+	}{
+		{
+			name:   "Get",
+			method: http.MethodGet,
+			path:   "/games/1/checklist",
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					GetChecklist(gomock.AssignableToTypeOf(ctxType), &client.GetChecklistRequest{Token: token, GameID: "1"}).
+					Return(checklist, nil)
+			},
+		},
+		{
+			name:   "Set auto progress",
+			method: http.MethodPatch,
+			path:   "/games/1/checklist",
+			body:   `{"autoProgress": false}`,
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					SetChecklistAutoProgress(gomock.AssignableToTypeOf(ctxType), &client.SetChecklistAutoProgressRequest{Token: token, GameID: "1", AutoProgress: false}).
+					Return(checklist, nil)
+			},
+		},
+		{
+			name:   "Add item",
+			method: http.MethodPost,
+			path:   "/games/1/checklist/items",
+			body:   `{"name": " Kill all templars "}`,
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					AddChecklistItem(gomock.AssignableToTypeOf(ctxType), &client.AddChecklistItemRequest{Token: token, GameID: "1", Name: "Kill all templars"}).
+					Return(checklist, nil)
+			},
+		},
+		{
+			name:   "Untick item",
+			method: http.MethodPatch,
+			path:   "/games/1/checklist/items/1",
+			body:   `{"done": false}`,
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					UpdateChecklistItem(gomock.AssignableToTypeOf(ctxType), &client.UpdateChecklistItemRequest{Token: token, GameID: "1", ItemID: "1", Done: &done}).
+					Return(checklist, nil)
+			},
+		},
+		{
+			name:   "Delete item",
+			method: http.MethodDelete,
+			path:   "/games/1/checklist/items/1",
+			expect: func(m *fixtures.APIClientMock) {
+				m.EXPECT().
+					DeleteChecklistItem(gomock.AssignableToTypeOf(ctxType), &client.DeleteChecklistItemRequest{Token: token, GameID: "1", ItemID: "1"}).
+					Return(checklist, nil)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+			testCase.expect(apiClient)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newChecklistRequest(t, testCase.method, testCase.path, testCase.body))
+
+			assert.StatusOK(t, w)
+			tassert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var res client.Checklist
+			fixtures.Decode(t, w.Body, &res)
+			tassert.Equal(t, checklist, &res)
+		})
+	}
+}
+
+func TestChecklistBadRequest(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "Invalid body", method: http.MethodPost, path: "/games/1/checklist/items", body: "{"},
+		{name: "No name", method: http.MethodPost, path: "/games/1/checklist/items", body: `{"name": " "}`},
+		{name: "Too long name", method: http.MethodPost, path: "/games/1/checklist/items", body: `{"name": "` + strings.Repeat("a", 256) + `"}`},
+		{name: "No changes", method: http.MethodPatch, path: "/games/1/checklist/items/1", body: `{}`},
+		{name: "No auto progress", method: http.MethodPatch, path: "/games/1/checklist", body: `{}`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv := newServer(nil, nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newChecklistRequest(t, testCase.method, testCase.path, testCase.body))
+
+			assert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestChecklistClientError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "No authorization", err: client.ErrNoAuthorization, expectedCode: http.StatusUnauthorized},
+		{name: "Not found", err: client.ErrChecklistNotFound, expectedCode: http.StatusNotFound},
+		{name: "Other error", err: errors.New("intentional error"), expectedCode: http.StatusBadGateway},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				GetChecklist(gomock.AssignableToTypeOf(ctxType), &client.GetChecklistRequest{Token: token, GameID: "1"}).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newChecklistRequest(t, http.MethodGet, "/games/1/checklist", ""))
+
+			assert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// maxCoverFileSize is the maximum size of an uploaded cover, including the rest of the form
+const maxCoverFileSize = 11 << 20
+
+// coverURL returns the URL of the uploaded cover of the given game or franchise in the given size.
+// The time the cover was uploaded is part of the URL, so that browsers do not show a replaced cover from their cache.
+func coverURL(kind client.CoverKind, id, size string, updatedAt *time.Time) string {
+	if updatedAt == nil {
+		return ""
+	}
+	return fmt.Sprintf("/%s/%s/cover?%s", kind, url.PathEscape(id), url.Values{
+		"size": []string{size},
+		"v":    []string{fmt.Sprint(updatedAt.Unix())},
+	}.Encode())
+}
+
+// gameCoverURL returns the thumbnail of the uploaded cover of the game, or the cover from the metadata provider, if there is no uploaded one
+func gameCoverURL(game *client.Game) string {
+	if url := coverURL(client.CoverKindGame, game.ID, client.CoverSizeThumbnail, game.CoverUpdatedAt); url != "" {
+		return url
+	}
+	return game.CoverURL
+}
+
+// handleCoverGet returns the cover image of the given game or franchise, fetched from the API.
+func (s *Server) handleCoverGet(kind client.CoverKind) authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		size := r.URL.Query().Get("size")
+		if size != client.CoverSizeThumbnail {
+			size = client.CoverSizeFull
+		}
+
+		cover, err := s.Client.GetCover(r.Context(), &client.GetCoverRequest{
+			Token: token,
+			Kind:  kind,
+			ID:    mux.Vars(r)["id"],
+			Size:  size,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, client.ErrNoAuthorization):
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+			case errors.Is(err, client.ErrCoverNotFound), errors.Is(err, client.ErrCoversNotConfigured):
+				http.Error(w, err.Error(), http.StatusNotFound)
+			default:
+				s.Log.Errorf("Error while fetching cover: %v", err)
+				http.Error(w, err.Error(), http.StatusBadGateway)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", cover.ContentType)
+		w.Header().Set("Cache-Control", "private, max-age=86400")
+		if _, err := w.Write(cover.Data); err != nil {
+			s.Log.Errorf("Error while writing cover: %v", err)
+		}
+	}
+}
+
+// handleCoverEditView renders the view for uploading and deleting the cover of the given game or franchise.
+func (s *Server) handleCoverEditView(kind client.CoverKind) authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		cover, err := s.templateCover(r, kind, mux.Vars(r)["id"], token)
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if cover == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		s.render(w, r, TemplateData{Cover: cover}, coverPage, token)
+	}
+}
+
+// handleCoverUpload uploads the cover of the given game or franchise and redirects back to the list of games.
+func (s *Server) handleCoverUpload(kind client.CoverKind) authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		id := mux.Vars(r)["id"]
+		editURL := fmt.Sprintf("/%s/%s/cover/edit", kind, url.PathEscape(id))
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxCoverFileSize)
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			s.Session.Put(r, "error", "Please choose an image of at most 10 MB.")
+			w.Header().Add("Location", editURL)
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		defer file.Close()
+
+		if _, err := s.Client.UploadCover(r.Context(), &client.UploadCoverRequest{Token: token, Kind: kind, ID: id, Body: file}); err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.Session.Put(r, "error", err.Error())
+			w.Header().Add("Location", editURL)
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+
+		s.Session.Put(r, "flash", "Cover uploaded.")
+		w.Header().Add("Location", "/games")
+		w.WriteHeader(http.StatusSeeOther)
+	}
+}
+
+// handleCoverDelete deletes the cover of the given game or franchise and redirects back to the list of games.
+func (s *Server) handleCoverDelete(kind client.CoverKind) authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := s.Client.DeleteCover(r.Context(), &client.DeleteCoverRequest{Token: token, Kind: kind, ID: mux.Vars(r)["id"]}); err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.Log.Errorf("Error while deleting cover: %v", err)
+			s.Session.Put(r, "error", "The cover could not be deleted.")
+		} else {
+			s.Session.Put(r, "flash", "Cover deleted.")
+		}
+
+		w.Header().Add("Location", "/games")
+		w.WriteHeader(http.StatusSeeOther)
+	}
+}
+
+// templateCover returns the cover of the given game or franchise, along with its name.
+// It returns nil, if the user has no such game or franchise.
+func (s *Server) templateCover(r *http.Request, kind client.CoverKind, id, token string) (*TemplateCover, error) {
+	if kind == client.CoverKindFranchise {
+		resp, err := s.Client.GetFranchises(r.Context(), &client.GetFranchisesRequest{Token: token})
+		if err != nil {
+			return nil, err
+		}
+		for _, franchise := range resp.All() {
+			if franchise.ID == id {
+				return &TemplateCover{Kind: kind, ID: id, Name: franchise.Name, URL: coverURL(kind, id, client.CoverSizeFull, franchise.CoverUpdatedAt)}, nil
+			}
+		}
+		return nil, nil
+	}
+
+	resp, err := s.Client.GetGames(r.Context(), &client.GetGamesRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	for _, game := range resp.Games {
+		if game.ID == id {
+			return &TemplateCover{Kind: kind, ID: id, Name: game.Name, URL: coverURL(kind, id, client.CoverSizeFull, game.CoverUpdatedAt)}, nil
+		}
+	}
+	return nil, nil
+}
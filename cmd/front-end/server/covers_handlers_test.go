@@ -0,0 +1,253 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCoverRequest(t *testing.T, method, path string) *http.Request {
+	r := httptest.NewRequest(method, path, nil)
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	addCSRFToken(t, r)
+	return r
+}
+
+func TestGamesGetCovers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	statuses := []client.Status{"To Do", "Done"}
+	updatedAt := time.Unix(1646128800, 0)
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetGames(gomock.AssignableToTypeOf(ctxType), &client.GetGamesRequest{Token: token}).
+		Return(&client.GetGamesResponse{Games: []*client.Game{
+			{ID: "1", Name: "Uploaded", FranchiseID: "1", CoverUpdatedAt: &updatedAt, CoverURL: "https://media.example.com/1.jpg"},
+			{ID: "2", Name: "From metadata", CoverURL: "https://media.example.com/2.jpg"},
+			{ID: "3", Name: "Without cover"},
+		}}, nil)
+	apiClient.EXPECT().
+		GetStatuses(gomock.AssignableToTypeOf(ctxType), &client.GetStatusesRequest{Token: token}).
+		Return(&client.GetStatusesResponse{Statuses: statuses}, nil)
+	apiClient.EXPECT().
+		GetFranchises(gomock.AssignableToTypeOf(ctxType), &client.GetFranchisesRequest{Token: token}).
+		Return(&client.GetFranchisesResponse{Franchises: []*client.Franchise{{ID: "1", Name: "Batman", CoverUpdatedAt: &updatedAt}}}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User: user,
+			Games: []server.TemplateGame{
+				{
+					ID:                "1",
+					Name:              "Uploaded",
+					FranchiseID:       "1",
+					FranchiseName:     "Batman",
+					CoverURL:          "/games/1/cover?size=thumbnail&v=1646128800",
+					FranchiseCoverURL: "/franchises/1/cover?size=thumbnail&v=1646128800",
+				},
+				{ID: "2", Name: "From metadata", CoverURL: "https://media.example.com/2.jpg"},
+				{ID: "3", Name: "Without cover"},
+			},
+			Statuses:  statuses,
+			CSRFToken: csrfToken,
+		}), "list.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newCoverRequest(t, http.MethodGet, "/games"))
+
+	assert.StatusOK(t, w)
+}
+
+func TestCoverGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		GetCover(gomock.AssignableToTypeOf(ctxType), &client.GetCoverRequest{Token: token, Kind: client.CoverKindFranchise, ID: "1", Size: client.CoverSizeThumbnail}).
+		Return(&client.GetCoverResponse{Data: []byte("thumbnail"), ContentType: "image/jpeg"}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newCoverRequest(t, http.MethodGet, "/franchises/1/cover?size=thumbnail&v=1"))
+
+	assert.StatusOK(t, w)
+	tassert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	tassert.Equal(t, "thumbnail", w.Body.String())
+}
+
+func TestCoverGetError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "Not found", err: client.ErrCoverNotFound, expectedCode: http.StatusNotFound},
+		{name: "Not configured", err: client.ErrCoversNotConfigured, expectedCode: http.StatusNotFound},
+		{name: "Unauthorized", err: client.ErrNoAuthorization, expectedCode: http.StatusUnauthorized},
+		{name: "API error", err: errors.New("intentional error"), expectedCode: http.StatusBadGateway},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				GetCover(gomock.AssignableToTypeOf(ctxType), &client.GetCoverRequest{Token: token, Kind: client.CoverKindGame, ID: "1", Size: client.CoverSizeFull}).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newCoverRequest(t, http.MethodGet, "/games/1/cover"))
+
+			assert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
+
+func TestCoverEditView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	updatedAt := time.Unix(1646128800, 0)
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetGames(gomock.AssignableToTypeOf(ctxType), &client.GetGamesRequest{Token: token}).
+		Return(&client.GetGamesResponse{Games: []*client.Game{{ID: game.ID, Name: game.Name, CoverUpdatedAt: &updatedAt}}}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User: user,
+			Cover: &server.TemplateCover{
+				Kind: client.CoverKindGame,
+				ID:   game.ID,
+				Name: game.Name,
+				URL:  "/games/1/cover?size=full&v=1646128800",
+			},
+			CSRFToken: csrfToken,
+		}), "cover.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newCoverRequest(t, http.MethodGet, "/games/1/cover/edit"))
+
+	assert.StatusOK(t, w)
+}
+
+func TestCoverEditViewNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		GetFranchises(gomock.AssignableToTypeOf(ctxType), &client.GetFranchisesRequest{Token: token}).
+		Return(&client.GetFranchisesResponse{Franchises: []*client.Franchise{{ID: "1", Name: "Batman"}}}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newCoverRequest(t, http.MethodGet, "/franchises/2/cover/edit"))
+
+	assert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestCoverUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		UploadCover(gomock.AssignableToTypeOf(ctxType), gomock.AssignableToTypeOf(&client.UploadCoverRequest{})).
+		DoAndReturn(func(_ context.Context, request *client.UploadCoverRequest) (*client.Cover, error) {
+			tassert.Equal(t, token, request.Token)
+			tassert.Equal(t, client.CoverKindGame, request.Kind)
+			tassert.Equal(t, game.ID, request.ID)
+			data, err := io.ReadAll(request.Body)
+			require.NoError(t, err)
+			tassert.Equal(t, "name\n"+game.Name+"\n", string(data))
+			return &client.Cover{}, nil
+		})
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest(t, "/games/1/cover", "cover.png", nil))
+
+	assert.Redirect(t, w, "/games")
+}
+
+func TestCoverUploadError(t *testing.T) {
+	testCases := []struct {
+		name             string
+		filename         string
+		err              error
+		expectedLocation string
+	}{
+		{name: "No file", expectedLocation: "/games/1/cover/edit"},
+		{name: "Invalid image", filename: "cover.png", err: errors.New("the image must be a JPEG, PNG or GIF"), expectedLocation: "/games/1/cover/edit"},
+		{name: "Unauthorized", filename: "cover.png", err: client.ErrNoAuthorization, expectedLocation: "/users/login"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			if testCase.err != nil {
+				apiClient.EXPECT().
+					UploadCover(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+					Return(nil, testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newImportRequest(t, "/games/1/cover", testCase.filename, nil))
+
+			assert.Redirect(t, w, testCase.expectedLocation)
+		})
+	}
+}
+
+func TestCoverDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		DeleteCover(gomock.AssignableToTypeOf(ctxType), &client.DeleteCoverRequest{Token: token, Kind: client.CoverKindFranchise, ID: "1"}).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newCoverRequest(t, http.MethodPost, "/franchises/1/cover/delete"))
+
+	assert.Redirect(t, w, "/games")
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 
 	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
 )
 
 func (s *Server) handleFranchisesAddPost() authorizedHandler {
@@ -23,7 +26,7 @@ func (s *Server) handleFranchisesAddPost() authorizedHandler {
 			return
 		}
 
-		resp, err := s.Client.CreateFranchise(context.Background(), &client.CreateFranchiseRequest{Name: franchiseName, Token: token})
+		resp, err := s.Client.CreateFranchise(context.Background(), &client.CreateFranchiseRequest{Name: franchiseName, Token: token, ParentID: r.PostForm.Get("parentId")})
 		if err != nil {
 			if errors.Is(err, client.ErrNoAuthorization) {
 				w.Header().Add("Location", "/users/login")
@@ -40,3 +43,179 @@ func (s *Server) handleFranchisesAddPost() authorizedHandler {
 		w.WriteHeader(http.StatusSeeOther)
 	}
 }
+
+// handleFranchiseView renders the games of the franchise in play and release order, and the game that is next up.
+func (s *Server) handleFranchiseView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		franchise, err := s.Client.GetFranchise(r.Context(), &client.GetFranchiseRequest{Token: token, FranchiseID: mux.Vars(r)["id"]})
+		if err != nil {
+			switch {
+			case errors.Is(err, client.ErrNoAuthorization):
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+			case errors.Is(err, client.ErrFranchiseNotFound):
+				http.NotFound(w, r)
+			default:
+				s.Log.Errorf("Error while fetching franchise: %v", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		var nextUp *client.Game
+		for _, game := range franchise.Games {
+			if game.Status != "Done" && game.Status != "Dropped" && !game.Wishlist {
+				nextUp = game
+				break
+			}
+		}
+
+		// the hierarchy is only needed for the sub-series and for changing the parent, so the franchise is shown without it on error
+		var subSeries []*client.Franchise
+		options := []TemplateFranchise{}
+		if resp, err := s.Client.GetFranchises(r.Context(), &client.GetFranchisesRequest{Token: token}); err != nil {
+			s.Log.Warnf("Error while fetching franchises: %v", err)
+		} else {
+			for _, f := range resp.All() {
+				if f.ID == franchise.ID {
+					subSeries = f.Children
+				}
+			}
+			options = franchiseOptions(resp.Franchises, franchise.ID)
+		}
+
+		s.render(w, r, TemplateData{
+			Franchise:        franchise,
+			Franchises:       subSeries,
+			ReleaseOrder:     gamesInOrder(franchise.Games, client.FranchiseOrderRelease),
+			NextUp:           nextUp,
+			FranchiseOptions: options,
+		}, franchisePage, token)
+	}
+}
+
+// handleFranchiseReorder moves the game one position up or down in the release or play order of the franchise.
+// The form contains the order, the game and move=up|down.
+func (s *Server) handleFranchiseReorder() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		order := client.FranchiseOrder(r.PostForm.Get("order"))
+		if order != client.FranchiseOrderRelease && order != client.FranchiseOrderPlay {
+			http.Error(w, "'order' should be release or play", http.StatusBadRequest)
+			return
+		}
+		gameID, move := r.PostForm.Get("game"), r.PostForm.Get("move")
+		if gameID == "" || (move != "up" && move != "down") {
+			http.Error(w, "'game' and 'move' (up or down) are required", http.StatusBadRequest)
+			return
+		}
+
+		franchiseID := mux.Vars(r)["id"]
+		location := "/franchises/" + url.PathEscape(franchiseID)
+		franchise, err := s.Client.GetFranchise(r.Context(), &client.GetFranchiseRequest{Token: token, FranchiseID: franchiseID})
+		if err != nil {
+			s.redirectAfterFranchiseChange(w, r, location, err)
+			return
+		}
+
+		games := gamesInOrder(franchise.Games, order)
+		gameIDs := make([]string, 0, len(games))
+		position := -1
+		for i, game := range games {
+			gameIDs = append(gameIDs, game.ID)
+			if game.ID == gameID {
+				position = i
+			}
+		}
+		other := position + 1
+		if move == "up" {
+			other = position - 1
+		}
+		// the game is not in the franchise, or it is already first or last
+		if position == -1 || other < 0 || other >= len(gameIDs) {
+			s.redirectAfterFranchiseChange(w, r, location, nil)
+			return
+		}
+		gameIDs[position], gameIDs[other] = gameIDs[other], gameIDs[position]
+
+		_, err = s.Client.ReorderFranchise(r.Context(), &client.ReorderFranchiseRequest{
+			Token:       token,
+			FranchiseID: franchiseID,
+			Order:       order,
+			GameIDs:     gameIDs,
+		})
+		s.redirectAfterFranchiseChange(w, r, location, err)
+	}
+}
+
+// handleFranchiseParent makes the franchise a sub-series of the franchise in the form, or a top-level franchise if it is empty.
+func (s *Server) handleFranchiseParent() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		franchiseID := mux.Vars(r)["id"]
+		_, err := s.Client.SetFranchiseParent(r.Context(), &client.SetFranchiseParentRequest{
+			Token:       token,
+			FranchiseID: franchiseID,
+			ParentID:    r.PostForm.Get("parentId"),
+		})
+		s.redirectAfterFranchiseChange(w, r, "/franchises/"+url.PathEscape(franchiseID), err)
+	}
+}
+
+// franchiseOptions returns the franchises in the tree, each one followed by its sub-series, with their names indented by their depth.
+// The franchise with the given ID and its sub-series are skipped, so that they are not offered as its parent.
+func franchiseOptions(franchises []*client.Franchise, skipID string) []TemplateFranchise {
+	options := []TemplateFranchise{}
+	var walk func(franchises []*client.Franchise, indent string)
+	walk = func(franchises []*client.Franchise, indent string) {
+		for _, franchise := range franchises {
+			if franchise.ID == skipID {
+				continue
+			}
+			options = append(options, TemplateFranchise{
+				ID:         franchise.ID,
+				Label:      indent + franchise.Name,
+				Completion: franchise.Completion,
+			})
+			walk(franchise.Children, indent+"\u00a0\u00a0\u00a0\u00a0")
+		}
+	}
+	walk(franchises, "")
+	return options
+}
+
+// gamesInOrder returns a copy of the games of a franchise, sorted by the given order
+func gamesInOrder(games []*client.Game, order client.FranchiseOrder) []*client.Game {
+	sorted := make([]*client.Game, len(games))
+	copy(sorted, games)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if order == client.FranchiseOrderRelease {
+			return sorted[i].ReleaseOrder < sorted[j].ReleaseOrder
+		}
+		return sorted[i].PlayOrder < sorted[j].PlayOrder
+	})
+	return sorted
+}
+
+func (s *Server) redirectAfterFranchiseChange(w http.ResponseWriter, r *http.Request, location string, err error) {
+	if err != nil {
+		if errors.Is(err, client.ErrNoAuthorization) {
+			w.Header().Add("Location", "/users/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		s.Log.Errorf("Error while changing the franchise: %v", err)
+		s.Session.Put(r, "error", err.Error())
+	}
+
+	w.Header().Add("Location", location)
+	w.WriteHeader(http.StatusSeeOther)
+}
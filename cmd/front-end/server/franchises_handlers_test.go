@@ -1,6 +1,7 @@
 package server_test
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/asankov/gira/cmd/front-end/server"
 	"github.com/asankov/gira/pkg/client"
 
 	"github.com/asankov/gira/internal/fixtures"
@@ -39,6 +41,7 @@ func TestAddFranchise(t *testing.T) {
 	form := url.Values{}
 	form.Add("franchise", franchise.Name)
 	r := httptest.NewRequest(http.MethodPost, "/franchises/add", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
@@ -57,6 +60,7 @@ func TestAddFranchiseEmptyFranchise(t *testing.T) {
 
 	form := url.Values{}
 	r := httptest.NewRequest(http.MethodPost, "/franchises/add", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
@@ -84,6 +88,7 @@ func TestAddFranchiseNoAuth(t *testing.T) {
 	form := url.Values{}
 	form.Add("franchise", franchise.Name)
 	r := httptest.NewRequest(http.MethodPost, "/franchises/add", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
@@ -93,3 +98,227 @@ func TestAddFranchiseNoAuth(t *testing.T) {
 
 	assert.Redirect(t, w, "/users/login")
 }
+
+func orderedFranchise() *client.Franchise {
+	return &client.Franchise{
+		ID:   "123",
+		Name: "Batman",
+		Games: []*client.Game{
+			{ID: "2", Name: "Arkham Origins", Status: "Done", ReleaseOrder: 3, PlayOrder: 1},
+			{ID: "1", Name: "Arkham Asylum", Status: "To Do", ReleaseOrder: 1, PlayOrder: 2},
+			{ID: "3", Name: "Arkham City", Status: "To Do", ReleaseOrder: 2, PlayOrder: 3},
+		},
+	}
+}
+
+func TestFranchiseView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	franchise := orderedFranchise()
+	arkham := &client.Franchise{ID: "123", Name: "Arkham", ParentID: "1", Completion: &client.FranchiseCompletion{Games: 4, Finished: 1}, Children: []*client.Franchise{
+		{ID: "124", Name: "Arkham VR", ParentID: "123", Completion: &client.FranchiseCompletion{Games: 1, Finished: 0}},
+	}}
+	tree := []*client.Franchise{
+		{ID: "1", Name: "Batman", Completion: &client.FranchiseCompletion{Games: 5, Finished: 2}, Children: []*client.Franchise{arkham}},
+		{ID: "2", Name: "Zelda", Completion: &client.FranchiseCompletion{Games: 0, Finished: 0}},
+	}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetFranchise(gomock.AssignableToTypeOf(ctxType), &client.GetFranchiseRequest{Token: token, FranchiseID: "123"}).
+		Return(franchise, nil)
+	apiClient.EXPECT().
+		GetFranchises(gomock.AssignableToTypeOf(ctxType), &client.GetFranchisesRequest{Token: token}).
+		Return(&client.GetFranchisesResponse{Franchises: tree}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:         user,
+			Franchise:    franchise,
+			Franchises:   arkham.Children,
+			ReleaseOrder: []*client.Game{franchise.Games[1], franchise.Games[2], franchise.Games[0]},
+			NextUp:       franchise.Games[1],
+			// the franchise and its sub-series can not be its parent
+			FranchiseOptions: []server.TemplateFranchise{
+				{ID: "1", Label: "Batman", Completion: tree[0].Completion},
+				{ID: "2", Label: "Zelda", Completion: tree[1].Completion},
+			},
+			CSRFToken: csrfToken,
+		}), "franchise.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/franchises/123", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestFranchiseViewNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		GetFranchise(gomock.AssignableToTypeOf(ctxType), &client.GetFranchiseRequest{Token: token, FranchiseID: "123"}).
+		Return(nil, client.ErrFranchiseNotFound)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/franchises/123", nil))
+
+	assert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestFranchiseReorder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		form    url.Values
+		gameIDs []string
+		order   client.FranchiseOrder
+	}{
+		{
+			name:    "Play order up",
+			form:    url.Values{"order": []string{"play"}, "game": []string{"1"}, "move": []string{"up"}},
+			order:   client.FranchiseOrderPlay,
+			gameIDs: []string{"1", "2", "3"},
+		},
+		{
+			name:    "Release order down",
+			form:    url.Values{"order": []string{"release"}, "game": []string{"1"}, "move": []string{"down"}},
+			order:   client.FranchiseOrderRelease,
+			gameIDs: []string{"3", "1", "2"},
+		},
+		{
+			name: "First game up",
+			form: url.Values{"order": []string{"play"}, "game": []string{"2"}, "move": []string{"up"}},
+		},
+		{
+			name: "Last game down",
+			form: url.Values{"order": []string{"release"}, "game": []string{"2"}, "move": []string{"down"}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				GetFranchise(gomock.AssignableToTypeOf(ctxType), &client.GetFranchiseRequest{Token: token, FranchiseID: "123"}).
+				Return(orderedFranchise(), nil)
+			if testCase.gameIDs != nil {
+				apiClient.EXPECT().
+					ReorderFranchise(gomock.AssignableToTypeOf(ctxType), &client.ReorderFranchiseRequest{
+						Token:       token,
+						FranchiseID: "123",
+						Order:       testCase.order,
+						GameIDs:     testCase.gameIDs,
+					}).
+					Return(orderedFranchise(), nil)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/franchises/123/order", testCase.form))
+
+			assert.Redirect(t, w, "/franchises/123")
+		})
+	}
+}
+
+func TestFranchiseReorderInvalidForm(t *testing.T) {
+	testCases := []struct {
+		name string
+		form url.Values
+	}{
+		{name: "Invalid order", form: url.Values{"order": []string{"name"}, "game": []string{"1"}, "move": []string{"up"}}},
+		{name: "Invalid move", form: url.Values{"order": []string{"play"}, "game": []string{"1"}, "move": []string{"left"}}},
+		{name: "No game", form: url.Values{"order": []string{"play"}, "move": []string{"up"}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := newServer(fixtures.NewAPIClientMock(ctrl), nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/franchises/123/order", testCase.form))
+
+			assert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestFranchiseParent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		location string
+	}{
+		{name: "Success", location: "/franchises/123"},
+		{name: "Cycle", err: errors.New("the parent franchise is a sub-series"), location: "/franchises/123"},
+		{name: "No authorization", err: client.ErrNoAuthorization, location: "/users/login"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				SetFranchiseParent(gomock.AssignableToTypeOf(ctxType), &client.SetFranchiseParentRequest{Token: token, FranchiseID: "123", ParentID: "1"}).
+				Return(&client.Franchise{ID: "123", Name: "Arkham", ParentID: "1"}, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/franchises/123/parent", url.Values{"parentId": []string{"1"}}))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
+
+func TestGameCreateViewFranchiseHierarchy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	completion := &client.FranchiseCompletion{Games: 2, Finished: 1}
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetFranchises(gomock.AssignableToTypeOf(ctxType), &client.GetFranchisesRequest{Token: token}).
+		Return(&client.GetFranchisesResponse{Franchises: []*client.Franchise{
+			{ID: "1", Name: "Batman", Completion: completion, Children: []*client.Franchise{
+				{ID: "2", Name: "Arkham", Completion: completion, Children: []*client.Franchise{
+					{ID: "3", Name: "Arkham VR", Completion: completion},
+				}},
+			}},
+		}}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User: user,
+			FranchiseOptions: []server.TemplateFranchise{
+				{ID: "1", Label: "Batman", Completion: completion},
+				{ID: "2", Label: "\u00a0\u00a0\u00a0\u00a0Arkham", Completion: completion},
+				{ID: "3", Label: "\u00a0\u00a0\u00a0\u00a0\u00a0\u00a0\u00a0\u00a0Arkham VR", Completion: completion},
+			},
+			CSRFToken: csrfToken,
+		}), "create.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/games/new", nil))
+
+	assert.StatusOK(t, w)
+}
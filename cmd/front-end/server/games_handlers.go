@@ -5,6 +5,8 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/asankov/gira/pkg/client"
 )
@@ -16,8 +18,70 @@ func (s *Server) handleHome() http.HandlerFunc {
 			token = cookie.Value
 		}
 
-		s.render(w, r, emptyTemplateData, homePage, token)
+		if token == "" {
+			s.render(w, r, emptyTemplateData, homePage, token)
+			return
+		}
+
+		// the dashboard is optional, so the home page is rendered even if the stats can not be fetched
+		stats, err := s.Client.GetStats(r.Context(), &client.GetStatsRequest{Token: token})
+		if err != nil {
+			s.Log.Errorf("Error while fetching stats: %v", err)
+		}
+
+		data := TemplateData{Stats: stats}
+
+		// the feed is optional as well
+		feed, err := s.Client.GetFeed(r.Context(), &client.GetFeedRequest{Token: token, Before: r.URL.Query().Get("before")})
+		if err != nil {
+			s.Log.Errorf("Error while fetching feed: %v", err)
+		} else {
+			data.Feed = templateEvents(feed.Events)
+			data.FeedCursor = feed.NextCursor
+		}
+
+		// and so are the goals
+		goals, err := s.Client.GetGoals(r.Context(), &client.GetGoalsRequest{Token: token})
+		if err != nil {
+			s.Log.Errorf("Error while fetching goals: %v", err)
+		} else {
+			data.Goals = templateGoals(goals.Goals)
+		}
+
+		s.render(w, r, data, homePage, token)
+	}
+}
+
+// templateEvents describes the events of the feed in words, e.g. "ivan finished Hades".
+// The events of unknown kinds are left out.
+func templateEvents(events []*client.Event) []TemplateEvent {
+	templateEvents := []TemplateEvent{}
+	for _, event := range events {
+		if event.Kind != client.EventStatusChanged {
+			continue
+		}
+
+		templateEvent := TemplateEvent{
+			Username:  event.Username,
+			GameName:  event.GameName,
+			CreatedAt: event.CreatedAt,
+		}
+		switch {
+		case event.To == "Done":
+			templateEvent.Action = "finished"
+		case event.To == "Dropped":
+			templateEvent.Action = "dropped"
+		case event.To == "In Progress" && event.From == "To Do":
+			templateEvent.Action = "started"
+		case event.To == "In Progress":
+			templateEvent.Action = "went back to"
+		default:
+			templateEvent.Action = "moved"
+			templateEvent.Details = "to " + string(event.To)
+		}
+		templateEvents = append(templateEvents, templateEvent)
 	}
+	return templateEvents
 }
 
 func (s *Server) handleGamesChangeStatus() authorizedHandler {
@@ -40,18 +104,22 @@ func (s *Server) handleGamesChangeStatus() authorizedHandler {
 			return
 		}
 
-		if err := s.Client.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
+		res, err := s.Client.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
 			GameID: gameID,
 			Token:  token,
 			Update: client.UpdateGameProgressChange{
 				Status: client.Status(status),
 			},
-		}); err != nil {
+		})
+		if err != nil {
 			s.Log.Errorln(err)
 			// TODO: render error page
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		if len(res.Warnings) > 0 {
+			s.Session.Put(r, "flash", strings.Join(res.Warnings, ". ")+".")
+		}
 
 		w.Header().Add("Location", "/games")
 		w.WriteHeader(http.StatusSeeOther)
@@ -88,7 +156,7 @@ func (s *Server) handleGamesChangeProgress() authorizedHandler {
 			http.Error(w, "'finalProgress' should be a valid integer", http.StatusBadRequest)
 			return
 		}
-		if err := s.Client.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
+		if _, err := s.Client.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
 			GameID: gameID,
 			Token:  token,
 			Update: client.UpdateGameProgressChange{
@@ -146,24 +214,33 @@ func (s *Server) handleGamesGetView() authorizedHandler {
 
 			s.Log.Warnf("Error while fetching franchises: %v", err)
 		} else {
-			for _, fr := range franchisesResponse.Franchises {
+			for _, fr := range franchisesResponse.All() {
 				franchisesMap[fr.ID] = fr
 			}
 		}
 
 		games := []TemplateGame{}
 		for _, game := range gamesResponse.Games {
-			var frName string
+			var frName, frCoverURL string
 			if fr, ok := franchisesMap[game.FranchiseID]; ok {
 				frName = fr.Name
+				frCoverURL = coverURL(client.CoverKindFranchise, fr.ID, client.CoverSizeThumbnail, fr.CoverUpdatedAt)
 			}
 			games = append(games, TemplateGame{
-				ID:            game.ID,
-				Name:          game.Name,
-				FranchiseID:   game.FranchiseID,
-				FranchiseName: frName,
-				Status:        game.Status,
-				Progress:      game.Progress,
+				ID:                game.ID,
+				Name:              game.Name,
+				FranchiseID:       game.FranchiseID,
+				FranchiseName:     frName,
+				Status:            game.Status,
+				Progress:          game.Progress,
+				Wishlist:          game.Wishlist,
+				ReleaseDate:       game.ReleaseDate,
+				AutoProgress:      game.AutoProgress,
+				Playthroughs:      game.Playthroughs,
+				Hidden:            game.Hidden,
+				Rating:            game.Rating,
+				CoverURL:          gameCoverURL(game),
+				FranchiseCoverURL: frCoverURL,
 			})
 		}
 		data := TemplateData{
@@ -178,12 +255,12 @@ func (s *Server) handleGamesGetView() authorizedHandler {
 func (s *Server) handleGameCreateView() authorizedHandler {
 	return func(w http.ResponseWriter, r *http.Request, token string) {
 
-		franchises := []*client.Franchise{}
+		options := []TemplateFranchise{}
 		resp, err := s.Client.GetFranchises(context.Background(), &client.GetFranchisesRequest{Token: token})
 		if err != nil {
 			s.Log.Warnf("Error while fetching franchises: %v", err)
 		} else {
-			franchises = resp.Franchises
+			options = franchiseOptions(resp.Franchises, "")
 		}
 
 		selectedFranchiseIDquery, ok := r.URL.Query()["selectedFranchise"]
@@ -193,7 +270,7 @@ func (s *Server) handleGameCreateView() authorizedHandler {
 		}
 
 		s.render(w, r, TemplateData{
-			Franchises:          franchises,
+			FranchiseOptions:    options,
 			SelectedFranchiseID: selectedFranchiseID,
 		}, createGamePage, token)
 	}
@@ -213,12 +290,27 @@ func (s *Server) handleGameCreate() authorizedHandler {
 		}
 
 		franchiseID := r.PostForm.Get("franchiseId")
+		// metadataId is set by the autocomplete, when the user picks a game from the metadata provider
+		metadataID := r.PostForm.Get("metadataId")
+		// the release date is optional, the one from the metadata provider is used if it is empty
+		var releaseDate *time.Time
+		if date := r.PostForm.Get("releaseDate"); date != "" {
+			d, err := time.Parse(dateLayout, date)
+			if err != nil {
+				http.Error(w, "'releaseDate' should be a valid date", http.StatusBadRequest)
+				return
+			}
+			releaseDate = &d
+		}
 
 		if _, err := s.Client.CreateGame(context.Background(), &client.CreateGameRequest{
 			Token: token,
 			Game: &client.Game{
 				Name:        name,
 				FranchiseID: franchiseID,
+				MetadataID:  metadataID,
+				ReleaseDate: releaseDate,
+				Wishlist:    r.PostForm.Get("wishlist") == "true",
 			},
 		}); err != nil {
 			s.Session.Put(r, "error", err.Error())
@@ -279,6 +371,15 @@ func (s *Server) render(w http.ResponseWriter, r *http.Request, data TemplateDat
 		data.Error = err
 	}
 
+	csrfToken, csrfErr := s.csrfToken(r)
+	if csrfErr != nil {
+		s.Log.Errorf("Error while generating CSRF token: %v", csrfErr)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	data.CSRFToken = csrfToken
+	data.CSPNonce = cspNonce(r)
+
 	if token != "" {
 		resp, err := s.Client.GetUser(context.Background(), &client.GetUserRequest{
 			Token: token,
@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/asankov/gira/pkg/client"
 
@@ -34,6 +35,8 @@ var (
 	}
 
 	ctxType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+	csrfToken = "my_csrf_token"
 )
 
 func newServer(a *fixtures.APIClientMock, r *fixtures.RendererMock) *server.Server {
@@ -47,6 +50,49 @@ func newServer(a *fixtures.APIClientMock, r *fixtures.RendererMock) *server.Serv
 	}
 }
 
+// addCSRFToken adds a session cookie, holding csrfToken,
+// and the X-CSRF-Token header to the request, so that it passes the CSRF protection.
+// The session is signed with the same secret as the one in newServer.
+func addCSRFToken(t *testing.T, r *http.Request) {
+	t.Helper()
+
+	session := sessions.New([]byte("secret"))
+	w := httptest.NewRecorder()
+	session.Enable(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session.Put(r, "csrf_token", csrfToken)
+	})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected one session cookie, got %d", len(cookies))
+	}
+	r.AddCookie(cookies[0])
+	r.Header.Set("X-CSRF-Token", csrfToken)
+}
+
+// templateDataMatcher matches TemplateData, that is equal to the expected one,
+// except for the CSP nonce, which is random for each request, and only needs to be present.
+type templateDataMatcher struct {
+	expected server.TemplateData
+}
+
+func templateData(expected server.TemplateData) gomock.Matcher {
+	return templateDataMatcher{expected: expected}
+}
+
+func (m templateDataMatcher) Matches(x interface{}) bool {
+	data, ok := x.(server.TemplateData)
+	if !ok || data.CSPNonce == "" {
+		return false
+	}
+	data.CSPNonce = ""
+	return gomock.Eq(m.expected).Matches(data)
+}
+
+func (m templateDataMatcher) String() string {
+	return fmt.Sprintf("is equal to %v with a CSP nonce", m.expected)
+}
+
 func TestHandleHome(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -82,6 +128,7 @@ func TestHandleHomeLoggedInUser(t *testing.T) {
 		Name:  "token",
 		Value: token,
 	})
+	addCSRFToken(t, r)
 
 	apiClient.EXPECT().
 		GetUser(gomock.AssignableToTypeOf(ctxType), &client.GetUserRequest{
@@ -92,9 +139,104 @@ func TestHandleHomeLoggedInUser(t *testing.T) {
 			Username: user.Username,
 			Email:    user.Email,
 		}, nil)
+	stats := &client.GetStatsResponse{
+		StatusCounts:     map[client.Status]int{client.Status("To Do"): 1},
+		Total:            1,
+		CurrentlyPlaying: []*client.Game{game},
+	}
+	apiClient.EXPECT().
+		GetStats(gomock.AssignableToTypeOf(ctxType), &client.GetStatsRequest{
+			Token: token,
+		}).
+		Return(stats, nil)
+	createdAt := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+	apiClient.EXPECT().
+		GetFeed(gomock.AssignableToTypeOf(ctxType), &client.GetFeedRequest{
+			Token: token,
+		}).
+		Return(&client.GetFeedResponse{
+			Events: []*client.Event{
+				{ID: "2", Kind: client.EventStatusChanged, Username: "ivan", GameName: "Hades", From: "In Progress", To: "Done", CreatedAt: createdAt},
+				{ID: "1", Kind: client.EventStatusChanged, Username: "ivan", GameName: "Hades", From: "To Do", To: "In Progress", CreatedAt: createdAt},
+			},
+			NextCursor: "1",
+		}, nil)
+	goalEnd := time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)
+	apiClient.EXPECT().
+		GetGoals(gomock.AssignableToTypeOf(ctxType), &client.GetGoalsRequest{
+			Token: token,
+		}).
+		Return(&client.GetGoalsResponse{
+			Goals: []*client.Goal{
+				{ID: "1", Kind: client.GoalBacklog, Target: 10, EndsAt: &goalEnd, Current: 15, Status: client.GoalInProgress},
+			},
+		}, nil)
 	renderer.EXPECT().
-		Render(gomock.Any(), gomock.Any(), gomock.Eq(server.TemplateData{
-			User: user,
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:  user,
+			Stats: stats,
+			Feed: []server.TemplateEvent{
+				{Username: "ivan", Action: "finished", GameName: "Hades", CreatedAt: createdAt},
+				{Username: "ivan", Action: "started", GameName: "Hades", CreatedAt: createdAt},
+			},
+			FeedCursor: "1",
+			Goals: []server.TemplateGoal{
+				{ID: "1", Description: "Clear the backlog to 10 games or less by 31 Dec 2021", Progress: "15 games To Do", Percent: 66, Status: client.GoalInProgress},
+			},
+			CSRFToken: csrfToken,
+		}), gomock.Any()).
+		Return(nil)
+
+	srv.ServeHTTP(w, r)
+
+	assert.StatusOK(t, w)
+}
+
+func TestHandleHomeStatsAndFeedError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+
+	srv := newServer(apiClient, renderer)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	addCSRFToken(t, r)
+
+	apiClient.EXPECT().
+		GetUser(gomock.AssignableToTypeOf(ctxType), &client.GetUserRequest{
+			Token: token,
+		}).
+		Return(&client.GetUserResponse{
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+		}, nil)
+	apiClient.EXPECT().
+		GetStats(gomock.AssignableToTypeOf(ctxType), &client.GetStatsRequest{
+			Token: token,
+		}).
+		Return(nil, errors.New("intentional error"))
+	apiClient.EXPECT().
+		GetFeed(gomock.AssignableToTypeOf(ctxType), &client.GetFeedRequest{
+			Token: token,
+		}).
+		Return(nil, errors.New("intentional error"))
+	apiClient.EXPECT().
+		GetGoals(gomock.AssignableToTypeOf(ctxType), &client.GetGoalsRequest{
+			Token: token,
+		}).
+		Return(nil, errors.New("intentional error"))
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:      user,
+			CSRFToken: csrfToken,
 		}), gomock.Any()).
 		Return(nil)
 
@@ -205,7 +347,7 @@ func TestGamesChangeStatus(t *testing.T) {
 				Status: client.Status("In Progress"),
 			},
 		}).
-		Return(nil)
+		Return(&client.UpdateGameProgressResponse{}, nil)
 
 	w := httptest.NewRecorder()
 
@@ -213,6 +355,7 @@ func TestGamesChangeStatus(t *testing.T) {
 	form.Add("game", game.ID)
 	form.Add("status", "In Progress")
 	r := httptest.NewRequest(http.MethodPost, "/games/status", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
@@ -239,7 +382,7 @@ func TestGamesChangeStatusServiceError(t *testing.T) {
 				Status: client.Status("In Progress"),
 			},
 		}).
-		Return(errors.New("error while changing status"))
+		Return(nil, errors.New("error while changing status"))
 
 	w := httptest.NewRecorder()
 
@@ -247,6 +390,7 @@ func TestGamesChangeStatusServiceError(t *testing.T) {
 	form.Add("game", game.ID)
 	form.Add("status", "In Progress")
 	r := httptest.NewRequest(http.MethodPost, "/games/status", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
@@ -307,6 +451,7 @@ func TestGamesChangeStatusPostError(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			r := testCase.request()
+			addCSRFToken(t, r)
 			r.AddCookie(&http.Cookie{
 				Name:  "token",
 				Value: token,
@@ -337,7 +482,7 @@ func TestGamesChangeProgress(t *testing.T) {
 				},
 			},
 		}).
-		Return(nil)
+		Return(&client.UpdateGameProgressResponse{}, nil)
 
 	w := httptest.NewRecorder()
 
@@ -346,6 +491,7 @@ func TestGamesChangeProgress(t *testing.T) {
 	form.Add("currentProgress", fmt.Sprintf("%d", 10))
 	form.Add("finalProgress", fmt.Sprintf("%d", 100))
 	r := httptest.NewRequest(http.MethodPost, "/games/progress", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
@@ -407,6 +553,7 @@ func TestGamesChangeProgressPostError(t *testing.T) {
 			form.Add("currentProgress", testCase.Current)
 			form.Add("finalProgress", testCase.Final)
 			r := httptest.NewRequest(http.MethodPost, "/games/progress", strings.NewReader(form.Encode()))
+			addCSRFToken(t, r)
 			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 			r.AddCookie(&http.Cookie{
 				Name:  "token",
@@ -443,6 +590,7 @@ func TestGamesCreate(t *testing.T) {
 	form := url.Values{}
 	form.Add("name", game.Name)
 	r := httptest.NewRequest(http.MethodPost, "/games/new", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
@@ -488,6 +636,7 @@ func TestGamesCreatePostError(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			r := testCase.request()
+			addCSRFToken(t, r)
 			r.AddCookie(&http.Cookie{
 				Name:  "token",
 				Value: token,
@@ -516,6 +665,7 @@ func TestGamesCreateServiceError(t *testing.T) {
 	form := url.Values{}
 	form.Add("name", game.Name)
 	r := httptest.NewRequest(http.MethodPost, "/games/new", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
@@ -667,6 +817,7 @@ func TestGamesDelete(t *testing.T) {
 	form := url.Values{}
 	form.Add("game", game.ID)
 	r := httptest.NewRequest(http.MethodPost, "/games/delete", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
@@ -729,6 +880,7 @@ func TestGamesDeleteClientError(t *testing.T) {
 			form := url.Values{}
 			form.Add("game", game.ID)
 			r := httptest.NewRequest(http.MethodPost, "/games/delete", strings.NewReader(form.Encode()))
+			addCSRFToken(t, r)
 			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 			r.AddCookie(&http.Cookie{
 				Name:  "token",
@@ -779,6 +931,7 @@ func TestGamesDeletePostError(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			r := testCase.request()
+			addCSRFToken(t, r)
 			r.AddCookie(&http.Cookie{
 				Name:  "token",
 				Value: token,
@@ -789,3 +942,63 @@ func TestGamesDeletePostError(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleHomeFeedPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+
+	srv := newServer(apiClient, renderer)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/?before=5", nil)
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	addCSRFToken(t, r)
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetStats(gomock.AssignableToTypeOf(ctxType), &client.GetStatsRequest{
+			Token: token,
+		}).
+		Return(nil, errors.New("intentional error"))
+	createdAt := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+	apiClient.EXPECT().
+		GetFeed(gomock.AssignableToTypeOf(ctxType), &client.GetFeedRequest{
+			Token:  token,
+			Before: "5",
+		}).
+		Return(&client.GetFeedResponse{
+			Events: []*client.Event{
+				{ID: "4", Kind: client.EventStatusChanged, Username: "ivan", GameName: "Hades", From: "Done", To: "To Do", CreatedAt: createdAt},
+				{ID: "3", Kind: client.EventStatusChanged, Username: "ivan", GameName: "Hades", From: "Dropped", To: "In Progress", CreatedAt: createdAt},
+				{ID: "2", Kind: client.EventStatusChanged, Username: "ivan", GameName: "Hades", From: "In Progress", To: "Dropped", CreatedAt: createdAt},
+				{ID: "1", Kind: client.EventKind("unknown"), Username: "ivan", GameName: "Hades", CreatedAt: createdAt},
+			},
+		}, nil)
+	apiClient.EXPECT().
+		GetGoals(gomock.AssignableToTypeOf(ctxType), &client.GetGoalsRequest{
+			Token: token,
+		}).
+		Return(&client.GetGoalsResponse{Goals: []*client.Goal{}}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User: user,
+			Feed: []server.TemplateEvent{
+				{Username: "ivan", Action: "moved", GameName: "Hades", Details: "to To Do", CreatedAt: createdAt},
+				{Username: "ivan", Action: "went back to", GameName: "Hades", CreatedAt: createdAt},
+				{Username: "ivan", Action: "dropped", GameName: "Hades", CreatedAt: createdAt},
+			},
+			Goals:     []server.TemplateGoal{},
+			CSRFToken: csrfToken,
+		}), gomock.Any()).
+		Return(nil)
+
+	srv.ServeHTTP(w, r)
+
+	assert.StatusOK(t, w)
+}
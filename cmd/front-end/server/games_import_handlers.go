@@ -0,0 +1,55 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/asankov/gira/pkg/client"
+)
+
+// maxImportFileSize is the maximum size of the file uploaded for import
+const maxImportFileSize = 5 << 20
+
+func (s *Server) handleGamesImportView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		s.render(w, r, emptyTemplateData, importPage, token)
+	}
+}
+
+func (s *Server) handleGamesImport() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			s.render(w, r, TemplateData{Error: "Please choose a CSV or JSON file to import."}, importPage, token)
+			return
+		}
+		defer file.Close()
+
+		contentType := "application/json"
+		if strings.EqualFold(filepath.Ext(header.Filename), ".csv") || header.Header.Get("Content-Type") == "text/csv" {
+			contentType = "text/csv"
+		}
+
+		result, err := s.Client.ImportGames(r.Context(), &client.ImportGamesRequest{
+			Token:       token,
+			ContentType: contentType,
+			Body:        file,
+			Mode:        r.PostFormValue("mode"),
+			DryRun:      r.PostFormValue("dryRun") != "",
+		})
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.render(w, r, TemplateData{Error: err.Error()}, importPage, token)
+			return
+		}
+
+		s.render(w, r, TemplateData{ImportResult: result}, importPage, token)
+	}
+}
@@ -0,0 +1,189 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var importResult = &client.ImportGamesResponse{
+	Mode:          "best-effort",
+	DryRun:        true,
+	NewFranchises: []string{},
+	Rows:          []*client.ImportRow{{Line: 1, Name: game.Name, Status: "ok"}},
+}
+
+// newImportRequest returns an import request to the given path with a multipart body,
+// that contains the given fields and a file with the given name, if it is not empty.
+func newImportRequest(t *testing.T, path, filename string, fields map[string]string) *http.Request {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for k, v := range fields {
+		require.NoError(t, mw.WriteField(k, v))
+	}
+	if filename != "" {
+		fw, err := mw.CreateFormFile("file", filename)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte("name\n" + game.Name + "\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+
+	r := httptest.NewRequest(http.MethodPost, path, &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	addCSRFToken(t, r)
+	return r
+}
+
+func expectGetUser(apiClient *fixtures.APIClientMock) {
+	apiClient.EXPECT().
+		GetUser(gomock.AssignableToTypeOf(ctxType), &client.GetUserRequest{Token: token}).
+		Return(&client.GetUserResponse{ID: user.ID, Username: user.Username}, nil)
+}
+
+func TestGamesImportView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	expectGetUser(apiClient)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), gomock.Any(), "import.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/games/import", nil)
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	srv.ServeHTTP(w, r)
+
+	assert.StatusOK(t, w)
+}
+
+func TestGamesImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	apiClient.EXPECT().
+		ImportGames(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *client.ImportGamesRequest) (*client.ImportGamesResponse, error) {
+			tassert.Equal(t, token, req.Token)
+			tassert.Equal(t, "text/csv", req.ContentType)
+			tassert.Equal(t, "best-effort", req.Mode)
+			tassert.True(t, req.DryRun)
+
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			tassert.Equal(t, "name\n"+game.Name+"\n", string(body))
+
+			return importResult, nil
+		})
+	expectGetUser(apiClient)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:         user,
+			ImportResult: importResult,
+			CSRFToken:    csrfToken,
+		}), "import.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest(t, "/games/import", "games.csv", map[string]string{
+		"mode":   "best-effort",
+		"dryRun": "true",
+	}))
+
+	assert.StatusOK(t, w)
+}
+
+func TestGamesImportError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		filename      string
+		clientErr     error
+		expectedError string
+	}{
+		{
+			name:          "No file",
+			expectedError: "Please choose a CSV or JSON file to import.",
+		},
+		{
+			name:          "Client error",
+			filename:      "games.json",
+			clientErr:     errors.New("the CSV header must contain a 'name' column"),
+			expectedError: "the CSV header must contain a 'name' column",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			renderer := fixtures.NewRendererMock(ctrl)
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, renderer)
+
+			if testCase.clientErr != nil {
+				apiClient.EXPECT().
+					ImportGames(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+					Return(nil, testCase.clientErr)
+			}
+			expectGetUser(apiClient)
+			renderer.EXPECT().
+				Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+					User:      user,
+					Error:     testCase.expectedError,
+					CSRFToken: csrfToken,
+				}), "import.page.tmpl").
+				Return(nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newImportRequest(t, "/games/import", testCase.filename, nil))
+
+			assert.StatusOK(t, w)
+		})
+	}
+}
+
+func TestGamesImportNoAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		ImportGames(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+		Return(nil, client.ErrNoAuthorization)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest(t, "/games/import", "games.csv", nil))
+
+	assert.Redirect(t, w, "/users/login")
+}
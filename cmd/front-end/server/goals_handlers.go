@@ -0,0 +1,177 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// handleGoalsView renders the goals of the user with their progress, and the form for creating a new one.
+func (s *Server) handleGoalsView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		res, err := s.Client.GetGoals(r.Context(), &client.GetGoalsRequest{Token: token})
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.Log.Errorf("Error while fetching goals: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		// the franchises are only needed for the finish-franchise goals, so the goals are shown without them on error
+		options := []TemplateFranchise{}
+		if franchises, err := s.Client.GetFranchises(r.Context(), &client.GetFranchisesRequest{Token: token}); err != nil {
+			s.Log.Warnf("Error while fetching franchises: %v", err)
+		} else {
+			options = franchiseOptions(franchises.Franchises, "")
+		}
+
+		s.render(w, r, TemplateData{
+			Goals:            templateGoals(res.Goals),
+			FranchiseOptions: options,
+		}, goalsPage, token)
+	}
+}
+
+// handleGoalCreate creates a goal from the form. The dates are optional, except for the finish-games goals, which is validated by the API.
+func (s *Server) handleGoalCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		goal := &client.Goal{
+			Kind:        client.GoalKind(r.PostForm.Get("kind")),
+			FranchiseID: r.PostForm.Get("franchiseId"),
+		}
+		if goal.Kind != client.GoalFinishFranchise {
+			goal.FranchiseID = ""
+		}
+		if target := r.PostForm.Get("target"); target != "" {
+			t, err := strconv.Atoi(target)
+			if err != nil {
+				s.redirectAfterGoalChange(w, r, "", errors.New("'target' should be a number"))
+				return
+			}
+			goal.Target = t
+		}
+		var err error
+		if goal.StartsAt, err = formDate(r, "startsAt"); err != nil {
+			s.redirectAfterGoalChange(w, r, "", err)
+			return
+		}
+		if goal.EndsAt, err = formDate(r, "endsAt"); err != nil {
+			s.redirectAfterGoalChange(w, r, "", err)
+			return
+		}
+
+		_, err = s.Client.CreateGoal(r.Context(), &client.CreateGoalRequest{Token: token, Goal: goal})
+		s.redirectAfterGoalChange(w, r, "Goal created.", err)
+	}
+}
+
+// formDate returns the date from the given field of the form, or nil if it is empty
+func formDate(r *http.Request, field string) (*time.Time, error) {
+	value := r.PostForm.Get(field)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' should be a date", field)
+	}
+	return &date, nil
+}
+
+func (s *Server) handleGoalDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		err := s.Client.DeleteGoal(r.Context(), &client.GoalRequest{Token: token, GoalID: mux.Vars(r)["id"]})
+		s.redirectAfterGoalChange(w, r, "The goal is deleted.", err)
+	}
+}
+
+func (s *Server) redirectAfterGoalChange(w http.ResponseWriter, r *http.Request, flash string, err error) {
+	if err != nil {
+		if errors.Is(err, client.ErrNoAuthorization) {
+			w.Header().Add("Location", "/users/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		s.Log.Errorf("Error while changing goal: %v", err)
+		s.Session.Put(r, "error", err.Error())
+	} else {
+		s.Session.Put(r, "flash", flash)
+	}
+
+	w.Header().Add("Location", "/goals")
+	w.WriteHeader(http.StatusSeeOther)
+}
+
+// templateGoals describes the goals and their progress in words, e.g. "Finish 12 games in 2021" and "3 of 12 finished"
+func templateGoals(goals []*client.Goal) []TemplateGoal {
+	templateGoals := []TemplateGoal{}
+	for _, goal := range goals {
+		templateGoal := TemplateGoal{ID: goal.ID, Status: goal.Status}
+
+		switch goal.Kind {
+		case client.GoalFinishGames:
+			templateGoal.Description = fmt.Sprintf("Finish %d games%s", goal.Target, period(goal.StartsAt, goal.EndsAt))
+			templateGoal.Progress = fmt.Sprintf("%d of %d finished", goal.Current, goal.Target)
+			templateGoal.Percent = percent(goal.Current, goal.Target)
+		case client.GoalFinishFranchise:
+			templateGoal.Description = fmt.Sprintf("Finish the %s franchise%s", goal.Franchise, period(nil, goal.EndsAt))
+			templateGoal.Progress = fmt.Sprintf("%d of %d finished", goal.Current, goal.Target)
+			templateGoal.Percent = percent(goal.Current, goal.Target)
+		case client.GoalBacklog:
+			templateGoal.Description = fmt.Sprintf("Clear the backlog to %d games or less%s", goal.Target, period(nil, goal.EndsAt))
+			templateGoal.Progress = fmt.Sprintf("%d games To Do", goal.Current)
+			// the backlog is cleared from the top down, so the progress is how close the backlog is to the target
+			if goal.Current <= goal.Target {
+				templateGoal.Percent = 100
+			} else {
+				templateGoal.Percent = percent(goal.Target, goal.Current)
+			}
+		default:
+			continue
+		}
+		if goal.Status == client.GoalAchieved {
+			templateGoal.Percent = 100
+		}
+
+		templateGoals = append(templateGoals, templateGoal)
+	}
+	return templateGoals
+}
+
+// period describes the period of a goal, e.g. " between 01 Jan 2021 and 31 Dec 2021", or " by 31 Dec 2021"
+func period(startsAt, endsAt *time.Time) string {
+	const layout = "02 Jan 2006"
+	switch {
+	case startsAt != nil && endsAt != nil:
+		return fmt.Sprintf(" between %s and %s", startsAt.Format(layout), endsAt.Format(layout))
+	case endsAt != nil:
+		return fmt.Sprintf(" by %s", endsAt.Format(layout))
+	default:
+		return ""
+	}
+}
+
+// percent returns current as a percentage of total, up to 100
+func percent(current, total int) int {
+	if total <= 0 {
+		return 0
+	}
+	if current >= total {
+		return 100
+	}
+	return current * 100 / total
+}
@@ -0,0 +1,192 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+)
+
+func TestGoalsView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)
+	goals := []*client.Goal{
+		{ID: "1", Kind: client.GoalFinishGames, Target: 12, StartsAt: &start, EndsAt: &end, Current: 3, Status: client.GoalInProgress},
+		{ID: "2", Kind: client.GoalFinishFranchise, FranchiseID: "1", Franchise: "Witcher", Target: 3, Current: 3, Status: client.GoalAchieved},
+		{ID: "3", Kind: client.GoalBacklog, Target: 10, Current: 20, Status: client.GoalFailed},
+		{ID: "4", Kind: client.GoalKind("unknown")},
+	}
+	franchises := []*client.Franchise{{ID: "1", Name: "Witcher"}}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetGoals(gomock.AssignableToTypeOf(ctxType), &client.GetGoalsRequest{Token: token}).
+		Return(&client.GetGoalsResponse{Goals: goals}, nil)
+	apiClient.EXPECT().
+		GetFranchises(gomock.AssignableToTypeOf(ctxType), &client.GetFranchisesRequest{Token: token}).
+		Return(&client.GetFranchisesResponse{Franchises: franchises}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User: user,
+			Goals: []server.TemplateGoal{
+				{ID: "1", Description: "Finish 12 games between 01 Jan 2021 and 31 Dec 2021", Progress: "3 of 12 finished", Percent: 25, Status: client.GoalInProgress},
+				{ID: "2", Description: "Finish the Witcher franchise", Progress: "3 of 3 finished", Percent: 100, Status: client.GoalAchieved},
+				{ID: "3", Description: "Clear the backlog to 10 games or less", Progress: "20 games To Do", Percent: 50, Status: client.GoalFailed},
+			},
+			FranchiseOptions: []server.TemplateFranchise{{ID: "1", Label: "Witcher"}},
+			CSRFToken:        csrfToken,
+		}), "goals.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/goals", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestGoalsViewClientError(t *testing.T) {
+	testCases := []struct {
+		name  string
+		err   error
+		check func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "No authorization",
+			err:  client.ErrNoAuthorization,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Other error",
+			err:  errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				GetGoals(gomock.AssignableToTypeOf(ctxType), &client.GetGoalsRequest{Token: token}).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/goals", nil))
+
+			testCase.check(t, w)
+		})
+	}
+}
+
+func TestGoalCreate(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		form     url.Values
+		goal     *client.Goal
+		err      error
+		location string
+	}{
+		{
+			name:     "Finish games",
+			form:     url.Values{"kind": []string{"finish-games"}, "target": []string{"12"}, "startsAt": []string{"2021-01-01"}, "endsAt": []string{"2021-12-31"}, "franchiseId": []string{"1"}},
+			goal:     &client.Goal{Kind: client.GoalFinishGames, Target: 12, StartsAt: &start, EndsAt: &end},
+			location: "/goals",
+		},
+		{
+			name:     "Finish franchise",
+			form:     url.Values{"kind": []string{"finish-franchise"}, "franchiseId": []string{"1"}},
+			goal:     &client.Goal{Kind: client.GoalFinishFranchise, FranchiseID: "1"},
+			location: "/goals",
+		},
+		{
+			name:     "Error",
+			form:     url.Values{"kind": []string{"backlog"}, "target": []string{"-1"}},
+			goal:     &client.Goal{Kind: client.GoalBacklog, Target: -1},
+			err:      errors.New("'target' should not be negative"),
+			location: "/goals",
+		},
+		{
+			name:     "No authorization",
+			form:     url.Values{"kind": []string{"backlog"}, "target": []string{"10"}},
+			goal:     &client.Goal{Kind: client.GoalBacklog, Target: 10},
+			err:      client.ErrNoAuthorization,
+			location: "/users/login",
+		},
+		{
+			name:     "Invalid target",
+			form:     url.Values{"kind": []string{"backlog"}, "target": []string{"ten"}},
+			location: "/goals",
+		},
+		{
+			name:     "Invalid date",
+			form:     url.Values{"kind": []string{"backlog"}, "endsAt": []string{"tomorrow"}},
+			location: "/goals",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			if testCase.goal != nil {
+				var created *client.Goal
+				if testCase.err == nil {
+					created = &client.Goal{ID: "1"}
+				}
+				apiClient.EXPECT().
+					CreateGoal(gomock.AssignableToTypeOf(ctxType), &client.CreateGoalRequest{Token: token, Goal: testCase.goal}).
+					Return(created, testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/goals", testCase.form))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
+
+func TestGoalDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		DeleteGoal(gomock.AssignableToTypeOf(ctxType), &client.GoalRequest{Token: token, GoalID: "1"}).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/goals/1/delete", url.Values{}))
+
+	assert.Redirect(t, w, "/goals")
+}
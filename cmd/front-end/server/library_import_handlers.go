@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/asankov/gira/pkg/client"
+)
+
+// maxLibraryGames is the maximum number of games that can be selected for import at once
+const maxLibraryGames = 5000
+
+func (s *Server) handleLibraryImportView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		s.render(w, r, emptyTemplateData, libraryPage, token)
+	}
+}
+
+// handleLibraryImportPreview reads the uploaded library export and renders the games in it,
+// so that the user can review them, before they are imported.
+func (s *Server) handleLibraryImportPreview() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			s.render(w, r, TemplateData{Error: "Please choose the library export to import."}, libraryPage, token)
+			return
+		}
+		defer file.Close()
+
+		preview, err := s.Client.PreviewLibraryImport(r.Context(), &client.PreviewLibraryImportRequest{
+			Token:  token,
+			Source: r.PostFormValue("source"),
+			Body:   file,
+		})
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.render(w, r, TemplateData{Error: err.Error()}, libraryPage, token)
+			return
+		}
+		if len(preview.Games) == 0 {
+			s.render(w, r, TemplateData{Error: "No games were found in the library export."}, libraryPage, token)
+			return
+		}
+
+		statusesResponse, err := s.Client.GetStatuses(r.Context(), &client.GetStatusesRequest{Token: token})
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		s.render(w, r, TemplateData{LibraryPreview: preview, Statuses: statusesResponse.Statuses}, libraryPage, token)
+	}
+}
+
+// libraryGame is a game, selected during the review of a library export, in the format accepted by the import of games
+type libraryGame struct {
+	Name      string `json:"name"`
+	Franchise string `json:"franchise,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
+
+// handleLibraryImportCommit imports the games that were selected during the review.
+// The form contains the number of games as count, and the include-N, name-N, franchise-N and status-N fields of each of them.
+func (s *Server) handleLibraryImportCommit() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "error while parsing form", http.StatusBadRequest)
+			return
+		}
+
+		count, err := strconv.Atoi(r.PostForm.Get("count"))
+		if err != nil || count < 0 || count > maxLibraryGames {
+			http.Error(w, "invalid number of games", http.StatusBadRequest)
+			return
+		}
+
+		games := []*libraryGame{}
+		for i := 0; i < count; i++ {
+			n := strconv.Itoa(i)
+			if r.PostForm.Get("include-"+n) == "" {
+				continue
+			}
+			name := strings.TrimSpace(r.PostForm.Get("name-" + n))
+			if name == "" {
+				continue
+			}
+			games = append(games, &libraryGame{
+				Name:      name,
+				Franchise: strings.TrimSpace(r.PostForm.Get("franchise-" + n)),
+				Status:    r.PostForm.Get("status-" + n),
+			})
+		}
+		if len(games) == 0 {
+			s.render(w, r, TemplateData{Error: "No games were selected for import."}, libraryPage, token)
+			return
+		}
+
+		body, err := json.Marshal(games)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		result, err := s.Client.ImportGames(r.Context(), &client.ImportGamesRequest{
+			Token:       token,
+			ContentType: "application/json",
+			Body:        bytes.NewReader(body),
+			Mode:        "best-effort",
+		})
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.render(w, r, TemplateData{Error: err.Error()}, libraryPage, token)
+			return
+		}
+
+		s.render(w, r, TemplateData{ImportResult: result}, importPage, token)
+	}
+}
@@ -0,0 +1,263 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var libraryPreview = &client.PreviewLibraryImportResponse{
+	Source: "steam",
+	Games: []*client.LibraryCandidate{
+		{Name: "Far Cry 3", Franchise: "Far Cry", Status: "In Progress", Playtime: 120},
+		{Name: game.Name, Status: "To Do", DuplicateOf: game.Name},
+	},
+}
+
+func newLibraryCommitRequest(t *testing.T, form url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/games/import/library/commit", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	return r
+}
+
+func TestLibraryImportView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	expectGetUser(apiClient)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), gomock.Any(), "library.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/games/import/library", nil)
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	srv.ServeHTTP(w, r)
+
+	assert.StatusOK(t, w)
+}
+
+func TestLibraryImportPreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	statuses := []client.Status{"To Do", "In Progress", "Done"}
+	apiClient.EXPECT().
+		PreviewLibraryImport(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *client.PreviewLibraryImportRequest) (*client.PreviewLibraryImportResponse, error) {
+			tassert.Equal(t, token, req.Token)
+			tassert.Equal(t, "steam", req.Source)
+			return libraryPreview, nil
+		})
+	apiClient.EXPECT().
+		GetStatuses(gomock.AssignableToTypeOf(ctxType), &client.GetStatusesRequest{Token: token}).
+		Return(&client.GetStatusesResponse{Statuses: statuses}, nil)
+	expectGetUser(apiClient)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:           user,
+			LibraryPreview: libraryPreview,
+			Statuses:       statuses,
+			CSRFToken:      csrfToken,
+		}), "library.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newImportRequest(t, "/games/import/library", "games.json", map[string]string{"source": "steam"}))
+
+	assert.StatusOK(t, w)
+}
+
+func TestLibraryImportPreviewError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		filename      string
+		response      *client.PreviewLibraryImportResponse
+		clientErr     error
+		expectedError string
+	}{
+		{
+			name:          "No file",
+			expectedError: "Please choose the library export to import.",
+		},
+		{
+			name:          "Client error",
+			filename:      "games.json",
+			clientErr:     errors.New("source must be one of steam, gog"),
+			expectedError: "source must be one of steam, gog",
+		},
+		{
+			name:          "No games",
+			filename:      "games.json",
+			response:      &client.PreviewLibraryImportResponse{Source: "steam", Games: []*client.LibraryCandidate{}},
+			expectedError: "No games were found in the library export.",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			renderer := fixtures.NewRendererMock(ctrl)
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, renderer)
+
+			if testCase.filename != "" {
+				apiClient.EXPECT().
+					PreviewLibraryImport(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+					Return(testCase.response, testCase.clientErr)
+			}
+			expectGetUser(apiClient)
+			renderer.EXPECT().
+				Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+					User:      user,
+					Error:     testCase.expectedError,
+					CSRFToken: csrfToken,
+				}), "library.page.tmpl").
+				Return(nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newImportRequest(t, "/games/import/library", testCase.filename, nil))
+
+			assert.StatusOK(t, w)
+		})
+	}
+}
+
+func TestLibraryImportCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	apiClient.EXPECT().
+		ImportGames(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *client.ImportGamesRequest) (*client.ImportGamesResponse, error) {
+			tassert.Equal(t, token, req.Token)
+			tassert.Equal(t, "application/json", req.ContentType)
+			tassert.Equal(t, "best-effort", req.Mode)
+			tassert.False(t, req.DryRun)
+
+			var games []map[string]string
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&games))
+			tassert.Equal(t, []map[string]string{
+				{"name": "Far Cry 3", "franchise": "Far Cry", "status": "Done"},
+				{"name": "Hades"},
+			}, games)
+
+			return importResult, nil
+		})
+	expectGetUser(apiClient)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:         user,
+			ImportResult: importResult,
+			CSRFToken:    csrfToken,
+		}), "import.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newLibraryCommitRequest(t, url.Values{
+		"count":       {"4"},
+		"include-0":   {"true"},
+		"name-0":      {" Far Cry 3 "},
+		"franchise-0": {"Far Cry"},
+		"status-0":    {"Done"},
+		"name-1":      {game.Name},
+		"include-2":   {"true"},
+		"name-2":      {""},
+		"include-3":   {"true"},
+		"name-3":      {"Hades"},
+		"include-4":   {"true"},
+		"name-4":      {"Out of range"},
+	}))
+
+	assert.StatusOK(t, w)
+}
+
+func TestLibraryImportCommitNothingSelected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	expectGetUser(apiClient)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:      user,
+			Error:     "No games were selected for import.",
+			CSRFToken: csrfToken,
+		}), "library.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newLibraryCommitRequest(t, url.Values{
+		"count":  {"1"},
+		"name-0": {game.Name},
+	}))
+
+	assert.StatusOK(t, w)
+}
+
+func TestLibraryImportCommitInvalidCount(t *testing.T) {
+	srv := newServer(nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newLibraryCommitRequest(t, url.Values{"count": {"abc"}}))
+
+	assert.StatusCode(t, w, http.StatusBadRequest)
+}
+
+func TestLibraryImportCommitNoAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		ImportGames(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+		Return(nil, client.ErrNoAuthorization)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newLibraryCommitRequest(t, url.Values{
+		"count":     {"1"},
+		"include-0": {"true"},
+		"name-0":    {"Hades"},
+	}))
+
+	assert.Redirect(t, w, "/users/login")
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/asankov/gira/pkg/client"
+)
+
+// handleChangesStream proxies the stream of the changes of the games from the API to the browser,
+// so that the open pages are updated live. The events are passed as they are, the browser reads them via EventSource.
+func (s *Server) handleChangesStream() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		// the stream ends, when the browser disconnects, because that cancels the context of the request to the API
+		stream, err := s.Client.StreamChanges(r.Context(), token)
+		if err != nil {
+			switch {
+			// EventSource does not reconnect on these, so the browser stops trying
+			case errors.Is(err, client.ErrNoAuthorization):
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			case errors.Is(err, client.ErrLiveUpdatesDisabled):
+				http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			default:
+				s.Log.Errorf("Error while streaming changes: %v", err)
+				http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			}
+			return
+		}
+		defer stream.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		// the headers are sent right away, so that the browser knows, that the stream is open, before the first change
+		rc := http.NewResponseController(w)
+		if err := rc.Flush(); err != nil {
+			s.Log.Errorf("Error while flushing the stream of changes: %v", err)
+			return
+		}
+
+		buf := make([]byte, 4096)
+		for {
+			n, err := stream.Read(buf)
+			if n > 0 {
+				if _, err := w.Write(buf[:n]); err != nil {
+					return
+				}
+				if err := rc.Flush(); err != nil {
+					s.Log.Errorf("Error while flushing the stream of changes: %v", err)
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+}
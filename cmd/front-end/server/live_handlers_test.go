@@ -0,0 +1,93 @@
+package server_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangesStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	ts := httptest.NewServer(newServer(apiClient, nil))
+	defer ts.Close()
+
+	// the stream from the API stays open, until the test closes it
+	stream, api := io.Pipe()
+	apiClient.EXPECT().
+		StreamChanges(gomock.AssignableToTypeOf(ctxType), token).
+		Return(stream, nil)
+
+	r, err := http.NewRequest(http.MethodGet, ts.URL+"/changes", nil)
+	require.NoError(t, err)
+	r.AddCookie(&http.Cookie{Name: "token", Value: token})
+	res, err := ts.Client().Do(r)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	tassert.Equal(t, http.StatusOK, res.StatusCode)
+	tassert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	// the event reaches the browser while the stream is still open, so it is not buffered on the way
+	go api.Write([]byte("event: game.deleted\ndata: {\"type\":\"game.deleted\",\"gameId\":\"1\"}\n\n")) // nolint: errcheck
+	body := bufio.NewReader(res.Body)
+	line, err := body.ReadString('\n')
+	require.NoError(t, err)
+	tassert.Equal(t, "event: game.deleted\n", line)
+	line, err = body.ReadString('\n')
+	require.NoError(t, err)
+	tassert.Equal(t, "data: {\"type\":\"game.deleted\",\"gameId\":\"1\"}\n", line)
+
+	api.Close()
+}
+
+func TestChangesStreamError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "No authorization", err: client.ErrNoAuthorization, code: http.StatusUnauthorized},
+		{name: "Disabled", err: client.ErrLiveUpdatesDisabled, code: http.StatusNotFound},
+		{name: "Other error", err: errors.New("intentional error"), code: http.StatusBadGateway},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				StreamChanges(gomock.AssignableToTypeOf(ctxType), token).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/changes", nil))
+
+			assert.StatusCode(t, w, testCase.code)
+		})
+	}
+}
+
+func TestChangesStreamNotLoggedIn(t *testing.T) {
+	srv := newServer(nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/changes", nil))
+
+	assert.Redirect(t, w, "/users/login")
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// handleMetadataSearch returns the games from the metadata provider, whose names match the q query parameter, as JSON.
+// It backs the autocomplete of the New Game view.
+func (s *Server) handleMetadataSearch() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		query := strings.TrimSpace(r.URL.Query().Get("q"))
+		if query == "" {
+			http.Error(w, "'q' is required", http.StatusBadRequest)
+			return
+		}
+
+		games, err := s.Client.SearchGameMetadata(r.Context(), &client.SearchGameMetadataRequest{Token: token, Query: query})
+		if err != nil {
+			s.metadataError(w, err)
+			return
+		}
+
+		s.respondJSON(w, games)
+	}
+}
+
+// handleMetadataGet returns the details of the given game from the metadata provider as JSON,
+// along with the franchise, that the game most likely belongs to.
+func (s *Server) handleMetadataGet() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		game, err := s.Client.GetGameMetadata(r.Context(), &client.GetGameMetadataRequest{Token: token, ID: mux.Vars(r)["id"]})
+		if err != nil {
+			s.metadataError(w, err)
+			return
+		}
+
+		s.respondJSON(w, game)
+	}
+}
+
+// metadataError responds with the status code, that matches the error of the client.
+// A missing metadata provider is reported as 404, so that the autocomplete is just not shown.
+func (s *Server) metadataError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, client.ErrNoAuthorization):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, client.ErrMetadataNotConfigured), errors.Is(err, client.ErrMetadataNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		s.Log.Errorf("Error while fetching game metadata: %v", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+	}
+}
+
+func (s *Server) respondJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.Log.Errorf("Error while encoding response: %v", err)
+	}
+}
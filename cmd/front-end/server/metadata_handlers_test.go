@@ -0,0 +1,153 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+var gameMetadata = &client.GameMetadata{
+	ID:          "3328",
+	Name:        "The Witcher 3: Wild Hunt",
+	CoverURL:    "https://media.example.com/games/the-witcher-3.jpg",
+	Genres:      []string{"Action", "RPG"},
+	Developer:   "CD PROJEKT RED",
+	FranchiseID: "1",
+}
+
+func newMetadataRequest(path string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	return r
+}
+
+func TestMetadataSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		SearchGameMetadata(gomock.AssignableToTypeOf(ctxType), &client.SearchGameMetadataRequest{Token: token, Query: "witcher"}).
+		Return(&client.SearchGameMetadataResponse{Games: []*client.GameMetadata{gameMetadata}}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newMetadataRequest("/games/metadata?q=+witcher+"))
+
+	assert.StatusOK(t, w)
+	tassert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var res client.SearchGameMetadataResponse
+	fixtures.Decode(t, w.Body, &res)
+	tassert.Equal(t, []*client.GameMetadata{gameMetadata}, res.Games)
+}
+
+func TestMetadataSearchNoQuery(t *testing.T) {
+	srv := newServer(nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newMetadataRequest("/games/metadata"))
+
+	assert.StatusCode(t, w, http.StatusBadRequest)
+}
+
+func TestMetadataGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		GetGameMetadata(gomock.AssignableToTypeOf(ctxType), &client.GetGameMetadataRequest{Token: token, ID: "3328"}).
+		Return(gameMetadata, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newMetadataRequest("/games/metadata/3328"))
+
+	assert.StatusOK(t, w)
+
+	var res client.GameMetadata
+	fixtures.Decode(t, w.Body, &res)
+	tassert.Equal(t, gameMetadata, &res)
+}
+
+func TestMetadataGetError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		clientErr    error
+		expectedCode int
+	}{
+		{name: "No authorization", clientErr: client.ErrNoAuthorization, expectedCode: http.StatusUnauthorized},
+		{name: "Not configured", clientErr: client.ErrMetadataNotConfigured, expectedCode: http.StatusNotFound},
+		{name: "Not found", clientErr: client.ErrMetadataNotFound, expectedCode: http.StatusNotFound},
+		{name: "Provider error", clientErr: errors.New("intentional error"), expectedCode: http.StatusBadGateway},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				GetGameMetadata(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+				Return(nil, testCase.clientErr)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newMetadataRequest("/games/metadata/3328"))
+
+			assert.StatusCode(t, w, testCase.expectedCode)
+		})
+	}
+}
+
+func TestGamesCreateWithMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		CreateGame(gomock.AssignableToTypeOf(ctxType), &client.CreateGameRequest{
+			Token: token,
+			Game: &client.Game{
+				Name:        gameMetadata.Name,
+				FranchiseID: "1",
+				MetadataID:  gameMetadata.ID,
+			},
+		}).
+		Return(&client.CreateGameResponse{Game: game}, nil)
+
+	w := httptest.NewRecorder()
+
+	form := url.Values{}
+	form.Add("name", gameMetadata.Name)
+	form.Add("franchiseId", "1")
+	form.Add("metadataId", gameMetadata.ID)
+	r := httptest.NewRequest(http.MethodPost, "/games/new", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	srv.ServeHTTP(w, r)
+
+	assert.Redirect(t, w, "/games")
+}
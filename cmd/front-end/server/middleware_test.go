@@ -3,9 +3,13 @@ package server
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/golangcollege/sessions"
+	"github.com/sirupsen/logrus"
 
 	"github.com/stretchr/testify/require"
 
@@ -18,8 +22,10 @@ func TestSecureHeaders(t *testing.T) {
 	srv := &Server{}
 
 	called := false
+	var nonce string
 	h := srv.secureHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		called = true
+		nonce = cspNonce(r)
 	}))
 
 	w := httptest.NewRecorder()
@@ -29,6 +35,44 @@ func TestSecureHeaders(t *testing.T) {
 	assert.True(t, called)
 	assert.Equal(t, "deny", w.Header().Get("X-Frame-Options"))
 	assert.Equal(t, "1; mode-block", w.Header().Get("X-XSS-Protection"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "strict-origin-when-cross-origin", w.Header().Get("Referrer-Policy"))
+	assert.NotEmpty(t, w.Header().Get("Permissions-Policy"))
+	assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+
+	require.NotEmpty(t, nonce)
+	csp := w.Header().Get("Content-Security-Policy")
+	assert.Contains(t, csp, "default-src 'self'")
+	assert.Contains(t, csp, "script-src 'self' 'nonce-"+nonce+"'")
+	assert.Contains(t, csp, "style-src 'self' 'nonce-"+nonce+"'")
+	assert.Contains(t, csp, "img-src 'self' data: https:")
+}
+
+func TestSecureHeadersNonceIsUnique(t *testing.T) {
+	srv := &Server{}
+
+	nonces := map[string]bool{}
+	h := srv.secureHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonces[cspNonce(r)] = true
+	}))
+
+	for i := 0; i < 3; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Len(t, nonces, 3)
+}
+
+func TestSecureHeadersHSTS(t *testing.T) {
+	srv := &Server{EnforceHTTPS: true}
+
+	h := srv.secureHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, "max-age=63072000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
 }
 
 func TestRequireLogin(t *testing.T) {
@@ -66,3 +110,113 @@ func TestRequireLoginNoUser(t *testing.T) {
 	require.False(t, called)
 	gassert.Redirect(t, w, "/users/login")
 }
+
+// newCSRFSession returns a server with an enabled session
+// and a session cookie, holding the CSRF token, generated by the server.
+func newCSRFSession(t *testing.T) (*Server, *http.Cookie, string) {
+	srv := &Server{
+		Log:     logrus.StandardLogger(),
+		Session: sessions.New([]byte("secret")),
+	}
+
+	var csrfToken string
+	w := httptest.NewRecorder()
+	srv.Session.Enable(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := srv.csrfToken(r)
+		require.NoError(t, err)
+		csrfToken = token
+
+		// the token is generated only once per session
+		sameToken, err := srv.csrfToken(r)
+		require.NoError(t, err)
+		require.Equal(t, token, sameToken)
+	})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.NotEmpty(t, csrfToken)
+
+	return srv, cookies[0], csrfToken
+}
+
+func TestVerifyCSRF(t *testing.T) {
+	testCases := []struct {
+		name         string
+		request      func(cookie *http.Cookie, csrfToken string) *http.Request
+		expectedCode int
+	}{
+		{
+			name: "GET without token",
+			request: func(cookie *http.Cookie, csrfToken string) *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/", nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "POST with token in form",
+			request: func(cookie *http.Cookie, csrfToken string) *http.Request {
+				form := url.Values{"csrf_token": []string{csrfToken}}
+				r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				r.AddCookie(cookie)
+				return r
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "POST with token in header",
+			request: func(cookie *http.Cookie, csrfToken string) *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/", nil)
+				r.Header.Set("X-CSRF-Token", csrfToken)
+				r.AddCookie(cookie)
+				return r
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "POST without token",
+			request: func(cookie *http.Cookie, csrfToken string) *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/", nil)
+				r.AddCookie(cookie)
+				return r
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "POST with wrong token",
+			request: func(cookie *http.Cookie, csrfToken string) *http.Request {
+				form := url.Values{"csrf_token": []string{"wrong-token"}}
+				r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				r.AddCookie(cookie)
+				return r
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "POST without session",
+			request: func(cookie *http.Cookie, csrfToken string) *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/", nil)
+				r.Header.Set("X-CSRF-Token", csrfToken)
+				return r
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv, cookie, csrfToken := newCSRFSession(t)
+
+			called := false
+			h := srv.Session.Enable(srv.verifyCSRF(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})))
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, testCase.request(cookie, csrfToken))
+
+			gassert.StatusCode(t, w, testCase.expectedCode)
+			assert.Equal(t, testCase.expectedCode == http.StatusOK, called)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/asankov/gira/pkg/client"
+)
+
+// maxRating is the highest rating of a game
+const maxRating = 5
+
+// maxHeuristicWeight is the maximum weight of a heuristic, that the API accepts
+const maxHeuristicWeight = 100
+
+// handleGamesNextView renders the suggestions of what to play next.
+// The weight of each heuristic is configured with a query parameter with its name, e.g. ?franchise=2&age=0,
+// and the preferred genres with ?genres=RPG,Action. The heuristics, that are not configured, use the default weight.
+func (s *Server) handleGamesNextView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		query := r.URL.Query()
+		request := &client.GetNextGamesRequest{Token: token, Weights: map[client.Heuristic]float64{}}
+		heuristics := []TemplateHeuristic{}
+		for _, heuristic := range client.AllHeuristics {
+			h := TemplateHeuristic{Name: heuristic, Weight: 1}
+			if value := query.Get(string(heuristic)); value != "" {
+				weight, err := strconv.ParseFloat(value, 64)
+				if err != nil || math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 0 || weight > maxHeuristicWeight {
+					http.Error(w, fmt.Sprintf("the weight of %s should be a number between 0 and %d", heuristic, maxHeuristicWeight), http.StatusBadRequest)
+					return
+				}
+				h.Weight = weight
+				request.Weights[heuristic] = weight
+			}
+			heuristics = append(heuristics, h)
+		}
+		genres := query.Get("genres")
+		for _, genre := range strings.Split(genres, ",") {
+			if genre = strings.TrimSpace(genre); genre != "" {
+				request.Tags = append(request.Tags, genre)
+			}
+		}
+
+		res, err := s.Client.GetNextGames(r.Context(), request)
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.Log.Errorf("Error while fetching suggestions: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		suggestions := []TemplateSuggestion{}
+		for _, suggestion := range res.Suggestions {
+			suggestions = append(suggestions, TemplateSuggestion{
+				Game: TemplateGame{
+					ID:            suggestion.Game.ID,
+					Name:          suggestion.Game.Name,
+					FranchiseID:   suggestion.Game.FranchiseID,
+					FranchiseName: suggestion.Game.Franchise,
+					Status:        suggestion.Game.Status,
+					Progress:      suggestion.Game.Progress,
+					Rating:        suggestion.Game.Rating,
+					CoverURL:      gameCoverURL(suggestion.Game),
+				},
+				Score:   suggestion.Score,
+				Reasons: suggestion.Reasons,
+			})
+		}
+
+		s.render(w, r, TemplateData{
+			Suggestions: suggestions,
+			Heuristics:  heuristics,
+			Genres:      genres,
+		}, nextPage, token)
+	}
+}
+
+func (s *Server) handleGamesChangeRating() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		gameID := r.PostForm.Get("game")
+		if gameID == "" {
+			http.Error(w, "'game' is required", http.StatusBadRequest)
+			return
+		}
+
+		// an empty rating removes it
+		rating := 0
+		if value := r.PostForm.Get("rating"); value != "" {
+			var err error
+			if rating, err = strconv.Atoi(value); err != nil || rating < 0 || rating > maxRating {
+				http.Error(w, fmt.Sprintf("'rating' should be a number between 0 and %d", maxRating), http.StatusBadRequest)
+				return
+			}
+		}
+		s.updateGame(w, r, token, gameID, client.UpdateGameProgressChange{Rating: &rating})
+	}
+}
@@ -0,0 +1,185 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+)
+
+func TestGamesNextView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	reasons := []*client.SuggestionReason{
+		{Heuristic: client.HeuristicFranchise, Value: 1, Weight: 2, Points: 2, Explanation: "Comes next in the Witcher franchise, after Witcher 1"},
+	}
+	progress := &client.GameProgress{Current: 0, Final: 100}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetNextGames(gomock.AssignableToTypeOf(ctxType), &client.GetNextGamesRequest{
+			Token:   token,
+			Weights: map[client.Heuristic]float64{client.HeuristicFranchise: 2, client.HeuristicAge: 0},
+			Tags:    []string{"RPG", "Action"},
+		}).
+		Return(&client.GetNextGamesResponse{Suggestions: []*client.Suggestion{
+			{Game: &client.Game{ID: "2", Name: "Witcher 2", FranchiseID: "1", Franchise: "Witcher", Status: "To Do", Progress: progress}, Score: 2, Reasons: reasons},
+		}}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User: user,
+			Suggestions: []server.TemplateSuggestion{
+				{
+					Game:    server.TemplateGame{ID: "2", Name: "Witcher 2", FranchiseID: "1", FranchiseName: "Witcher", Status: "To Do", Progress: progress},
+					Score:   2,
+					Reasons: reasons,
+				},
+			},
+			Heuristics: []server.TemplateHeuristic{
+				{Name: client.HeuristicFranchise, Weight: 2},
+				{Name: client.HeuristicProgress, Weight: 1},
+				{Name: client.HeuristicAge, Weight: 0},
+				{Name: client.HeuristicTags, Weight: 1},
+				{Name: client.HeuristicRating, Weight: 1},
+			},
+			Genres:    "RPG, Action",
+			CSRFToken: csrfToken,
+		}), "next.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/games/next?franchise=2&age=0&genres=RPG,+Action", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestGamesNextViewError(t *testing.T) {
+	testCases := []struct {
+		name  string
+		path  string
+		err   error
+		check func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Invalid weight",
+			path: "/games/next?age=abc",
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "NaN weight",
+			path: "/games/next?age=NaN",
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "Too big weight",
+			path: "/games/next?age=101",
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "No authorization",
+			path: "/games/next",
+			err:  client.ErrNoAuthorization,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Other error",
+			path: "/games/next",
+			err:  errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			if testCase.err != nil {
+				apiClient.EXPECT().
+					GetNextGames(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+					Return(nil, testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, testCase.path, nil))
+
+			testCase.check(t, w)
+		})
+	}
+}
+
+func TestGamesChangeRating(t *testing.T) {
+	testCases := []struct {
+		name   string
+		value  string
+		rating int
+	}{
+		{name: "Rate", value: "4", rating: 4},
+		{name: "Remove rating", value: "", rating: 0},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			rating := testCase.rating
+			apiClient.EXPECT().
+				UpdateGameProgress(gomock.AssignableToTypeOf(ctxType), &client.UpdateGameProgressRequest{
+					GameID: game.ID,
+					Token:  token,
+					Update: client.UpdateGameProgressChange{Rating: &rating},
+				}).
+				Return(&client.UpdateGameProgressResponse{}, nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/rating", url.Values{
+				"game":   {game.ID},
+				"rating": {testCase.value},
+			}))
+
+			assert.Redirect(t, w, "/games")
+		})
+	}
+}
+
+func TestGamesChangeRatingInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := newServer(fixtures.NewAPIClientMock(ctrl), nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/rating", url.Values{
+		"game":   {game.ID},
+		"rating": {"6"},
+	}))
+
+	assert.StatusCode(t, w, http.StatusBadRequest)
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// inboxLimit is the number of the latest notifications, shown in the inbox
+const inboxLimit = 50
+
+// handleNotificationsView renders the inbox of the user and the form for changing the notification preferences.
+func (s *Server) handleNotificationsView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		res, err := s.Client.GetNotifications(r.Context(), &client.GetNotificationsRequest{Token: token, Limit: inboxLimit})
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.Log.Errorf("Error while fetching notifications: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		prefs, err := s.Client.GetNotificationPreferences(r.Context(), token)
+		if err != nil {
+			s.Log.Errorf("Error while fetching notification preferences: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		s.render(w, r, TemplateData{
+			Notifications:           res.Notifications,
+			UnreadNotifications:     res.Unread,
+			NotificationPreferences: prefs,
+		}, notificationsPage, token)
+	}
+}
+
+func (s *Server) handleNotificationRead() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		err := s.Client.MarkNotificationRead(r.Context(), &client.NotificationRequest{Token: token, NotificationID: mux.Vars(r)["id"]})
+		s.redirectAfterNotificationChange(w, r, "", err)
+	}
+}
+
+func (s *Server) handleNotificationsRead() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		err := s.Client.MarkAllNotificationsRead(r.Context(), token)
+		s.redirectAfterNotificationChange(w, r, "All notifications are marked as read.", err)
+	}
+}
+
+// handleNotificationPreferences saves the preferences from the form. The unchecked checkboxes are not sent, so they are false.
+func (s *Server) handleNotificationPreferences() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		staleAfterDays, err := strconv.Atoi(r.PostForm.Get("staleAfterDays"))
+		if err != nil {
+			s.redirectAfterNotificationChange(w, r, "", errors.New("'staleAfterDays' should be a number"))
+			return
+		}
+
+		_, err = s.Client.SetNotificationPreferences(r.Context(), &client.NotificationPreferencesRequest{
+			Token: token,
+			Preferences: &client.NotificationPreferences{
+				StaleReminders: r.PostForm.Get("staleReminders") == "true",
+				StaleAfterDays: staleAfterDays,
+				InApp:          r.PostForm.Get("inApp") == "true",
+				Email:          r.PostForm.Get("email") == "true",
+				WebhookURL:     strings.TrimSpace(r.PostForm.Get("webhookUrl")),
+			},
+		})
+		s.redirectAfterNotificationChange(w, r, "Notification preferences saved.", err)
+	}
+}
+
+func (s *Server) redirectAfterNotificationChange(w http.ResponseWriter, r *http.Request, flash string, err error) {
+	if err != nil {
+		if errors.Is(err, client.ErrNoAuthorization) {
+			w.Header().Add("Location", "/users/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		s.Log.Errorf("Error while changing notifications: %v", err)
+		s.Session.Put(r, "error", err.Error())
+	} else if flash != "" {
+		s.Session.Put(r, "flash", flash)
+	}
+
+	w.Header().Add("Location", "/notifications")
+	w.WriteHeader(http.StatusSeeOther)
+}
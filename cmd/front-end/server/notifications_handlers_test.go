@@ -0,0 +1,218 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+)
+
+func TestNotificationsView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	createdAt := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	notifications := []*client.Notification{
+		{ID: "2", Kind: client.NotificationStaleGame, GameID: "1", GameName: "Hades", Message: "Hello", CreatedAt: createdAt},
+		{ID: "1", Kind: client.NotificationStaleGame, GameID: "2", GameName: "Celeste", Message: "Hi", CreatedAt: createdAt, ReadAt: &createdAt},
+	}
+	prefs := &client.NotificationPreferences{StaleReminders: true, StaleAfterDays: 30, InApp: true}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetNotifications(gomock.AssignableToTypeOf(ctxType), &client.GetNotificationsRequest{Token: token, Limit: 50}).
+		Return(&client.GetNotificationsResponse{Notifications: notifications, Unread: 1}, nil)
+	apiClient.EXPECT().
+		GetNotificationPreferences(gomock.AssignableToTypeOf(ctxType), token).
+		Return(prefs, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:                    user,
+			Notifications:           notifications,
+			UnreadNotifications:     1,
+			NotificationPreferences: prefs,
+			CSRFToken:               csrfToken,
+		}), "notifications.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/notifications", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestNotificationsViewClientError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		prefsErr error
+		check    func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "No authorization",
+			err:  client.ErrNoAuthorization,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Other error",
+			err:  errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+		{
+			name:     "Preferences error",
+			prefsErr: errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			var res *client.GetNotificationsResponse
+			if testCase.err == nil {
+				res = &client.GetNotificationsResponse{}
+				apiClient.EXPECT().
+					GetNotificationPreferences(gomock.AssignableToTypeOf(ctxType), token).
+					Return(nil, testCase.prefsErr)
+			}
+			apiClient.EXPECT().
+				GetNotifications(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+				Return(res, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/notifications", nil))
+
+			testCase.check(t, w)
+		})
+	}
+}
+
+func TestNotificationRead(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		location string
+	}{
+		{name: "Success", location: "/notifications"},
+		{name: "Not found", err: client.ErrNotificationNotFound, location: "/notifications"},
+		{name: "No authorization", err: client.ErrNoAuthorization, location: "/users/login"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				MarkNotificationRead(gomock.AssignableToTypeOf(ctxType), &client.NotificationRequest{Token: token, NotificationID: "1"}).
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/notifications/1/read", url.Values{}))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
+
+func TestNotificationsRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		MarkAllNotificationsRead(gomock.AssignableToTypeOf(ctxType), token).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/notifications/read", url.Values{}))
+
+	assert.Redirect(t, w, "/notifications")
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		form     url.Values
+		prefs    *client.NotificationPreferences
+		err      error
+		location string
+	}{
+		{
+			name: "Success",
+			form: url.Values{
+				"staleReminders": []string{"true"},
+				"staleAfterDays": []string{"14"},
+				"email":          []string{"true"},
+				"webhookUrl":     []string{" https://example.com/hook "},
+			},
+			prefs:    &client.NotificationPreferences{StaleReminders: true, StaleAfterDays: 14, Email: true, WebhookURL: "https://example.com/hook"},
+			location: "/notifications",
+		},
+		{
+			name:     "Error",
+			form:     url.Values{"staleAfterDays": []string{"1000"}},
+			prefs:    &client.NotificationPreferences{StaleAfterDays: 1000},
+			err:      errors.New("'staleAfterDays' should be a number between 1 and 365"),
+			location: "/notifications",
+		},
+		{
+			name:     "No authorization",
+			form:     url.Values{"staleAfterDays": []string{"30"}},
+			prefs:    &client.NotificationPreferences{StaleAfterDays: 30},
+			err:      client.ErrNoAuthorization,
+			location: "/users/login",
+		},
+		{
+			name:     "Invalid days",
+			form:     url.Values{"staleAfterDays": []string{"month"}},
+			location: "/notifications",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			if testCase.prefs != nil {
+				apiClient.EXPECT().
+					SetNotificationPreferences(gomock.AssignableToTypeOf(ctxType), &client.NotificationPreferencesRequest{Token: token, Preferences: testCase.prefs}).
+					Return(testCase.prefs, testCase.err)
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/notifications/preferences", testCase.form))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// handlePlaythroughsView renders the playthroughs of the given game, along with the forms to change them.
+func (s *Server) handlePlaythroughsView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		gameID := mux.Vars(r)["id"]
+
+		gamesResponse, err := s.Client.GetGames(r.Context(), &client.GetGamesRequest{Token: token})
+		if err != nil {
+			s.playthroughsViewError(w, r, err)
+			return
+		}
+		var game *client.Game
+		for _, g := range gamesResponse.Games {
+			if g.ID == gameID {
+				game = g
+				break
+			}
+		}
+		if game == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		playthroughsResponse, err := s.Client.GetPlaythroughs(r.Context(), &client.GetPlaythroughsRequest{Token: token, GameID: gameID})
+		if err != nil {
+			s.playthroughsViewError(w, r, err)
+			return
+		}
+
+		statusesResponse, err := s.Client.GetStatuses(r.Context(), &client.GetStatusesRequest{Token: token})
+		if err != nil {
+			s.playthroughsViewError(w, r, err)
+			return
+		}
+
+		s.render(w, r, TemplateData{
+			Game:         game,
+			Playthroughs: playthroughsResponse.Playthroughs,
+			Statuses:     statusesResponse.Statuses,
+		}, playthroughsPage, token)
+	}
+}
+
+func (s *Server) handlePlaythroughCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		gameID := mux.Vars(r)["id"]
+
+		playthrough, err := parsePlaythrough(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, err = s.Client.CreatePlaythrough(r.Context(), &client.CreatePlaythroughRequest{Token: token, GameID: gameID, Playthrough: playthrough})
+		s.redirectToPlaythroughs(w, r, gameID, "Playthrough started.", err)
+	}
+}
+
+func (s *Server) handlePlaythroughUpdate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		vars := mux.Vars(r)
+
+		playthrough, err := parsePlaythrough(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, err = s.Client.UpdatePlaythrough(r.Context(), &client.UpdatePlaythroughRequest{
+			Token:         token,
+			GameID:        vars["id"],
+			PlaythroughID: vars["playthroughId"],
+			Playthrough:   playthrough,
+		})
+		s.redirectToPlaythroughs(w, r, vars["id"], "Playthrough saved.", err)
+	}
+}
+
+func (s *Server) handlePlaythroughDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		vars := mux.Vars(r)
+
+		err := s.Client.DeletePlaythrough(r.Context(), &client.DeletePlaythroughRequest{
+			Token:         token,
+			GameID:        vars["id"],
+			PlaythroughID: vars["playthroughId"],
+		})
+		s.redirectToPlaythroughs(w, r, vars["id"], "Playthrough deleted.", err)
+	}
+}
+
+// parsePlaythrough parses the playthrough from the submitted form.
+// The validation of the values is left to the API, which returns a message that can be shown to the user.
+func parsePlaythrough(r *http.Request) (*client.Playthrough, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	playthrough := &client.Playthrough{
+		Name:   r.PostForm.Get("name"),
+		Status: client.Status(r.PostForm.Get("status")),
+		Notes:  r.PostForm.Get("notes"),
+	}
+
+	if current, final := r.PostForm.Get("currentProgress"), r.PostForm.Get("finalProgress"); current != "" || final != "" {
+		playthrough.Progress = &client.GameProgress{}
+		if current != "" {
+			c, err := strconv.Atoi(current)
+			if err != nil {
+				return nil, errors.New("'currentProgress' should be a number")
+			}
+			playthrough.Progress.Current = c
+		}
+		if final != "" {
+			f, err := strconv.Atoi(final)
+			if err != nil {
+				return nil, errors.New("'finalProgress' should be a number")
+			}
+			playthrough.Progress.Final = f
+		}
+	}
+
+	for field, date := range map[string]**time.Time{"startedAt": &playthrough.StartedAt, "finishedAt": &playthrough.FinishedAt} {
+		value := r.PostForm.Get(field)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' should be a valid date", field)
+		}
+		*date = &t
+	}
+
+	return playthrough, nil
+}
+
+// redirectToPlaythroughs redirects back to the playthroughs of the game,
+// with the given flash message, or with the error returned while changing the playthroughs.
+func (s *Server) redirectToPlaythroughs(w http.ResponseWriter, r *http.Request, gameID, flash string, err error) {
+	if err != nil {
+		if errors.Is(err, client.ErrNoAuthorization) {
+			w.Header().Add("Location", "/users/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		s.Log.Errorf("Error while changing playthroughs of game %s: %v", gameID, err)
+		s.Session.Put(r, "error", err.Error())
+	} else {
+		s.Session.Put(r, "flash", flash)
+	}
+
+	w.Header().Add("Location", fmt.Sprintf("/games/%s/playthroughs", url.PathEscape(gameID)))
+	w.WriteHeader(http.StatusSeeOther)
+}
+
+func (s *Server) playthroughsViewError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, client.ErrNoAuthorization):
+		w.Header().Add("Location", "/users/login")
+		w.WriteHeader(http.StatusSeeOther)
+	case errors.Is(err, client.ErrPlaythroughNotFound):
+		http.NotFound(w, r)
+	default:
+		s.Log.Errorf("Error while fetching playthroughs: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
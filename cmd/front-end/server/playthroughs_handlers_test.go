@@ -0,0 +1,240 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+)
+
+func TestPlaythroughsView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	startedAt := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	playthroughs := []*client.Playthrough{
+		{ID: "2", GameID: game.ID, Name: "NG+", Status: "In Progress", Progress: &client.GameProgress{Current: 40, Final: 100}, StartedAt: &startedAt},
+	}
+	statuses := []client.Status{"To Do", "In Progress", "Done"}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetGames(gomock.AssignableToTypeOf(ctxType), &client.GetGamesRequest{Token: token}).
+		Return(&client.GetGamesResponse{Games: []*client.Game{game}}, nil)
+	apiClient.EXPECT().
+		GetPlaythroughs(gomock.AssignableToTypeOf(ctxType), &client.GetPlaythroughsRequest{Token: token, GameID: game.ID}).
+		Return(&client.GetPlaythroughsResponse{Playthroughs: playthroughs}, nil)
+	apiClient.EXPECT().
+		GetStatuses(gomock.AssignableToTypeOf(ctxType), &client.GetStatusesRequest{Token: token}).
+		Return(&client.GetStatusesResponse{Statuses: statuses}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:         user,
+			Game:         game,
+			Playthroughs: playthroughs,
+			Statuses:     statuses,
+			CSRFToken:    csrfToken,
+		}), "playthroughs.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/games/1/playthroughs", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestPlaythroughsViewGameNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		GetGames(gomock.AssignableToTypeOf(ctxType), &client.GetGamesRequest{Token: token}).
+		Return(&client.GetGamesResponse{Games: []*client.Game{game}}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/games/2/playthroughs", nil))
+
+	assert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestPlaythroughsViewClientError(t *testing.T) {
+	testCases := []struct {
+		name  string
+		err   error
+		check func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "No authorization",
+			err:  client.ErrNoAuthorization,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Not found",
+			err:  client.ErrPlaythroughNotFound,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusNotFound)
+			},
+		},
+		{
+			name: "Other error",
+			err:  errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				GetGames(gomock.AssignableToTypeOf(ctxType), &client.GetGamesRequest{Token: token}).
+				Return(&client.GetGamesResponse{Games: []*client.Game{game}}, nil)
+			apiClient.EXPECT().
+				GetPlaythroughs(gomock.AssignableToTypeOf(ctxType), &client.GetPlaythroughsRequest{Token: token, GameID: game.ID}).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/games/1/playthroughs", nil))
+
+			testCase.check(t, w)
+		})
+	}
+}
+
+func TestPlaythroughCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		CreatePlaythrough(gomock.AssignableToTypeOf(ctxType), &client.CreatePlaythroughRequest{
+			Token:       token,
+			GameID:      game.ID,
+			Playthrough: &client.Playthrough{Name: "NG+", Status: "In Progress"},
+		}).
+		Return(&client.Playthrough{ID: "2"}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/1/playthroughs", url.Values{
+		"name":   []string{"NG+"},
+		"status": []string{"In Progress"},
+	}))
+
+	assert.Redirect(t, w, "/games/1/playthroughs")
+}
+
+func TestPlaythroughUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	startedAt := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	finishedAt := time.Date(2021, time.April, 2, 0, 0, 0, 0, time.UTC)
+	apiClient.EXPECT().
+		UpdatePlaythrough(gomock.AssignableToTypeOf(ctxType), &client.UpdatePlaythroughRequest{
+			Token:         token,
+			GameID:        game.ID,
+			PlaythroughID: "2",
+			Playthrough: &client.Playthrough{
+				Name:       "NG+",
+				Status:     "Done",
+				Progress:   &client.GameProgress{Current: 100, Final: 100},
+				StartedAt:  &startedAt,
+				FinishedAt: &finishedAt,
+				Notes:      "Mage build",
+			},
+		}).
+		Return(&client.Playthrough{ID: "2"}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/1/playthroughs/2", url.Values{
+		"name":            []string{"NG+"},
+		"status":          []string{"Done"},
+		"currentProgress": []string{"100"},
+		"finalProgress":   []string{"100"},
+		"startedAt":       []string{"2021-03-01"},
+		"finishedAt":      []string{"2021-04-02"},
+		"notes":           []string{"Mage build"},
+	}))
+
+	assert.Redirect(t, w, "/games/1/playthroughs")
+}
+
+func TestPlaythroughUpdateBadRequest(t *testing.T) {
+	testCases := []struct {
+		name string
+		form url.Values
+	}{
+		{name: "Invalid progress", form: url.Values{"name": []string{"NG+"}, "currentProgress": []string{"a lot"}}},
+		{name: "Invalid date", form: url.Values{"name": []string{"NG+"}, "startedAt": []string{"yesterday"}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := newServer(fixtures.NewAPIClientMock(ctrl), nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/1/playthroughs/2", testCase.form))
+
+			assert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestPlaythroughDelete(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		location string
+	}{
+		{name: "Success", location: "/games/1/playthroughs"},
+		{name: "Error", err: client.ErrPlaythroughNotFound, location: "/games/1/playthroughs"},
+		{name: "No authorization", err: client.ErrNoAuthorization, location: "/users/login"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				DeletePlaythrough(gomock.AssignableToTypeOf(ctxType), &client.DeletePlaythroughRequest{Token: token, GameID: game.ID, PlaythroughID: "2"}).
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/1/playthroughs/2/delete", url.Values{}))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
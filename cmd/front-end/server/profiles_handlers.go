@@ -0,0 +1,167 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// handleFriendsView renders the profile settings of the user and the profiles they follow.
+// If a username is given, it redirects to the profile of that user instead.
+func (s *Server) handleFriendsView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if username := r.URL.Query().Get("username"); username != "" {
+			w.Header().Add("Location", profileURL(username))
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+
+		profile, err := s.Client.GetProfile(r.Context(), &client.GetProfileRequest{Token: token})
+		if err != nil {
+			s.profileViewError(w, r, err)
+			return
+		}
+
+		following, err := s.Client.GetFollowing(r.Context(), &client.GetFollowingRequest{Token: token})
+		if err != nil {
+			s.profileViewError(w, r, err)
+			return
+		}
+
+		s.render(w, r, TemplateData{
+			Profile:   profile,
+			Following: following.Profiles,
+		}, friendsPage, token)
+	}
+}
+
+func (s *Server) handleProfileChangePublic() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		public := r.PostForm.Get("public") == "true"
+		_, err := s.Client.SetProfilePublic(r.Context(), &client.SetProfilePublicRequest{Token: token, Public: public})
+		flash := "Your profile is private now."
+		if public {
+			flash = "Your profile is public now."
+		}
+		s.redirectAfterProfileChange(w, r, "/profiles", flash, err)
+	}
+}
+
+// handleProfileView renders the profile of the given user with their games, that are not hidden.
+func (s *Server) handleProfileView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		username := mux.Vars(r)["username"]
+
+		profile, err := s.Client.GetProfile(r.Context(), &client.GetProfileRequest{Token: token, Username: username})
+		if err != nil {
+			s.profileViewError(w, r, err)
+			return
+		}
+
+		res, err := s.Client.GetProfileGames(r.Context(), &client.GetProfileGamesRequest{Token: token, Username: username})
+		if err != nil {
+			s.profileViewError(w, r, err)
+			return
+		}
+
+		games := []TemplateGame{}
+		for _, game := range res.Games {
+			games = append(games, TemplateGame{
+				ID:       game.ID,
+				Name:     game.Name,
+				Status:   game.Status,
+				Progress: game.Progress,
+				Wishlist: game.Wishlist,
+				Hidden:   game.Hidden,
+				CoverURL: gameCoverURL(game),
+			})
+		}
+
+		s.render(w, r, TemplateData{
+			Profile: profile,
+			Games:   games,
+		}, profilePage, token)
+	}
+}
+
+func (s *Server) handleProfileFollow() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		username := mux.Vars(r)["username"]
+
+		_, err := s.Client.Follow(r.Context(), &client.FollowRequest{Token: token, Username: username})
+		s.redirectAfterProfileChange(w, r, profileURL(username), fmt.Sprintf("You follow %s now.", username), err)
+	}
+}
+
+func (s *Server) handleProfileUnfollow() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		username := mux.Vars(r)["username"]
+
+		// the profile may be private, so the user is sent back to the Friends view
+		err := s.Client.Unfollow(r.Context(), &client.FollowRequest{Token: token, Username: username})
+		s.redirectAfterProfileChange(w, r, "/profiles", fmt.Sprintf("You no longer follow %s.", username), err)
+	}
+}
+
+func (s *Server) handleGamesChangeHidden() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		gameID := r.PostForm.Get("game")
+		if gameID == "" {
+			http.Error(w, "'game' is required", http.StatusBadRequest)
+			return
+		}
+
+		hidden := r.PostForm.Get("hidden") == "true"
+		s.updateGame(w, r, token, gameID, client.UpdateGameProgressChange{Hidden: &hidden})
+	}
+}
+
+func profileURL(username string) string {
+	return "/profiles/" + url.PathEscape(username)
+}
+
+// redirectAfterProfileChange redirects to the given location,
+// with the given flash message, or with the error returned while changing the profile.
+func (s *Server) redirectAfterProfileChange(w http.ResponseWriter, r *http.Request, location, flash string, err error) {
+	if err != nil {
+		if errors.Is(err, client.ErrNoAuthorization) {
+			w.Header().Add("Location", "/users/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		s.Log.Errorf("Error while changing profile: %v", err)
+		s.Session.Put(r, "error", err.Error())
+	} else {
+		s.Session.Put(r, "flash", flash)
+	}
+
+	w.Header().Add("Location", location)
+	w.WriteHeader(http.StatusSeeOther)
+}
+
+func (s *Server) profileViewError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, client.ErrNoAuthorization):
+		w.Header().Add("Location", "/users/login")
+		w.WriteHeader(http.StatusSeeOther)
+	case errors.Is(err, client.ErrProfileNotFound):
+		http.NotFound(w, r)
+	default:
+		s.Log.Errorf("Error while fetching profile: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,231 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+)
+
+var friend = &client.Profile{Username: "ivan", Public: true, Following: true, Followers: 3, Follows: 1}
+
+func TestFriendsView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	profile := &client.Profile{Username: user.Username, Public: true, Followers: 1}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetProfile(gomock.AssignableToTypeOf(ctxType), &client.GetProfileRequest{Token: token}).
+		Return(profile, nil)
+	apiClient.EXPECT().
+		GetFollowing(gomock.AssignableToTypeOf(ctxType), &client.GetFollowingRequest{Token: token}).
+		Return(&client.GetFollowingResponse{Profiles: []*client.Profile{friend}}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:      user,
+			Profile:   profile,
+			Following: []*client.Profile{friend},
+			CSRFToken: csrfToken,
+		}), "friends.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/profiles", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestFriendsViewFindUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := newServer(fixtures.NewAPIClientMock(ctrl), nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/profiles?username=ivan", nil))
+
+	assert.Redirect(t, w, "/profiles/ivan")
+}
+
+func TestProfileView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	progress := &client.GameProgress{Current: 40, Final: 100}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetProfile(gomock.AssignableToTypeOf(ctxType), &client.GetProfileRequest{Token: token, Username: "ivan"}).
+		Return(friend, nil)
+	apiClient.EXPECT().
+		GetProfileGames(gomock.AssignableToTypeOf(ctxType), &client.GetProfileGamesRequest{Token: token, Username: "ivan"}).
+		Return(&client.GetGamesResponse{Games: []*client.Game{
+			{ID: "1", Name: "Hades", Status: "In Progress", Progress: progress, CoverURL: "https://media.example.com/1.jpg"},
+		}}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:    user,
+			Profile: friend,
+			Games: []server.TemplateGame{
+				{ID: "1", Name: "Hades", Status: "In Progress", Progress: progress, CoverURL: "https://media.example.com/1.jpg"},
+			},
+			CSRFToken: csrfToken,
+		}), "profile.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/profiles/ivan", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestProfileViewClientError(t *testing.T) {
+	testCases := []struct {
+		name  string
+		err   error
+		check func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "No authorization",
+			err:  client.ErrNoAuthorization,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Private or missing profile",
+			err:  client.ErrProfileNotFound,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusNotFound)
+			},
+		},
+		{
+			name: "Other error",
+			err:  errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				GetProfile(gomock.AssignableToTypeOf(ctxType), &client.GetProfileRequest{Token: token, Username: "ivan"}).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/profiles/ivan", nil))
+
+			testCase.check(t, w)
+		})
+	}
+}
+
+func TestProfileChangePublic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		SetProfilePublic(gomock.AssignableToTypeOf(ctxType), &client.SetProfilePublicRequest{Token: token, Public: true}).
+		Return(&client.Profile{Username: user.Username, Public: true}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/profiles/me", url.Values{"public": []string{"true"}}))
+
+	assert.Redirect(t, w, "/profiles")
+}
+
+func TestProfileFollow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		location string
+	}{
+		{name: "Success", location: "/profiles/ivan"},
+		{name: "Error", err: client.ErrProfileNotFound, location: "/profiles/ivan"},
+		{name: "No authorization", err: client.ErrNoAuthorization, location: "/users/login"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				Follow(gomock.AssignableToTypeOf(ctxType), &client.FollowRequest{Token: token, Username: "ivan"}).
+				Return(friend, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/profiles/ivan/follow", url.Values{}))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
+
+func TestProfileUnfollow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	apiClient.EXPECT().
+		Unfollow(gomock.AssignableToTypeOf(ctxType), &client.FollowRequest{Token: token, Username: "ivan"}).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/profiles/ivan/unfollow", url.Values{}))
+
+	assert.Redirect(t, w, "/profiles")
+}
+
+func TestGamesChangeHidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	hidden := true
+	apiClient.EXPECT().
+		UpdateGameProgress(gomock.AssignableToTypeOf(ctxType), &client.UpdateGameProgressRequest{
+			GameID: game.ID,
+			Token:  token,
+			Update: client.UpdateGameProgressChange{Hidden: &hidden},
+		}).
+		Return(&client.UpdateGameProgressResponse{}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/hidden", url.Values{"game": []string{game.ID}, "hidden": []string{"true"}}))
+
+	assert.Redirect(t, w, "/games")
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// minReportYear is the earliest year for which the API returns a report.
+const minReportYear = 1970
+
+func (s *Server) handleReportCurrentYear() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		w.Header().Add("Location", fmt.Sprintf("/reports/%d", time.Now().Year()))
+		w.WriteHeader(http.StatusSeeOther)
+	}
+}
+
+func (s *Server) handleReportView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		year, err := strconv.Atoi(mux.Vars(r)["year"])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		report, err := s.Client.GetYearReport(r.Context(), &client.GetYearReportRequest{
+			Token: token,
+			Year:  year,
+		})
+		if err != nil {
+			s.handleReportError(w, r, err)
+			return
+		}
+
+		data := &TemplateReport{GetYearReportResponse: report}
+		if year > minReportYear {
+			data.PreviousYear = year - 1
+		}
+		if year < time.Now().Year() {
+			data.NextYear = year + 1
+		}
+
+		s.render(w, r, TemplateData{Report: data}, reportPage, token)
+	}
+}
+
+func (s *Server) handleReportExport() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		year, err := strconv.Atoi(mux.Vars(r)["year"])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format != "json" && format != "csv" {
+			http.Error(w, "'format' must be one of json, csv", http.StatusBadRequest)
+			return
+		}
+
+		export, err := s.Client.ExportYearReport(r.Context(), &client.ExportYearReportRequest{
+			Token:  token,
+			Year:   year,
+			Format: format,
+		})
+		if err != nil {
+			s.handleReportError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", export.ContentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="gira-%d.%s"`, year, format))
+		if _, err := w.Write(export.Body); err != nil {
+			s.Log.Errorf("Error while writing report export: %v", err)
+		}
+	}
+}
+
+func (s *Server) handleReportError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, client.ErrNoAuthorization):
+		w.Header().Add("Location", "/users/login")
+		w.WriteHeader(http.StatusSeeOther)
+	case errors.Is(err, client.ErrInvalidReportRequest):
+		http.NotFound(w, r)
+	default:
+		s.Log.Errorf("Error while fetching report: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,193 @@
+package server_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+var report = &client.GetYearReportResponse{
+	Year:       2020,
+	Started:    1,
+	Finished:   1,
+	TotalHours: 10,
+	Games: []*client.YearReportGame{
+		{ID: "1", Name: "Game1", Started: true, Finished: true, Hours: 10},
+	},
+}
+
+func newReportRequest(path string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	return r
+}
+
+func TestReportCurrentYear(t *testing.T) {
+	srv := newServer(nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newReportRequest("/reports"))
+
+	assert.Redirect(t, w, fmt.Sprintf("/reports/%d", time.Now().Year()))
+}
+
+func TestReportView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+
+	srv := newServer(apiClient, renderer)
+
+	w := httptest.NewRecorder()
+	r := newReportRequest("/reports/2020")
+	addCSRFToken(t, r)
+
+	apiClient.EXPECT().
+		GetYearReport(gomock.AssignableToTypeOf(ctxType), &client.GetYearReportRequest{
+			Token: token,
+			Year:  2020,
+		}).
+		Return(report, nil)
+	apiClient.EXPECT().
+		GetUser(gomock.AssignableToTypeOf(ctxType), &client.GetUserRequest{
+			Token: token,
+		}).
+		Return(&client.GetUserResponse{
+			ID:       user.ID,
+			Username: user.Username,
+		}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User: user,
+			Report: &server.TemplateReport{
+				GetYearReportResponse: report,
+				PreviousYear:          2019,
+				NextYear:              2021,
+			},
+			CSRFToken: csrfToken,
+		}), "report.page.tmpl").
+		Return(nil)
+
+	srv.ServeHTTP(w, r)
+
+	assert.StatusOK(t, w)
+}
+
+func TestReportViewError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		path   string
+		setup  func(*fixtures.APIClientMock)
+		assert func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Invalid year",
+			path:  "/reports/abc",
+			setup: func(a *fixtures.APIClientMock) {},
+			assert: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusNotFound)
+			},
+		},
+		{
+			name: "Year rejected by the API",
+			path: "/reports/1900",
+			setup: func(a *fixtures.APIClientMock) {
+				a.EXPECT().
+					GetYearReport(gomock.AssignableToTypeOf(ctxType), &client.GetYearReportRequest{Token: token, Year: 1900}).
+					Return(nil, client.ErrInvalidReportRequest)
+			},
+			assert: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusNotFound)
+			},
+		},
+		{
+			name: "Auth error",
+			path: "/reports/2020",
+			setup: func(a *fixtures.APIClientMock) {
+				a.EXPECT().
+					GetYearReport(gomock.AssignableToTypeOf(ctxType), &client.GetYearReportRequest{Token: token, Year: 2020}).
+					Return(nil, client.ErrNoAuthorization)
+			},
+			assert: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Other error",
+			path: "/reports/2020",
+			setup: func(a *fixtures.APIClientMock) {
+				a.EXPECT().
+					GetYearReport(gomock.AssignableToTypeOf(ctxType), &client.GetYearReportRequest{Token: token, Year: 2020}).
+					Return(nil, errors.New("unknown error"))
+			},
+			assert: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClientMock := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClientMock, nil)
+
+			testCase.setup(apiClientMock)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newReportRequest(testCase.path))
+
+			testCase.assert(t, w)
+		})
+	}
+}
+
+func TestReportExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	body := []byte("id,name,started,finished,dropped,hours\n")
+	apiClient.EXPECT().
+		ExportYearReport(gomock.AssignableToTypeOf(ctxType), &client.ExportYearReportRequest{
+			Token:  token,
+			Year:   2020,
+			Format: "csv",
+		}).
+		Return(&client.ExportYearReportResponse{ContentType: "text/csv", Body: body}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newReportRequest("/reports/2020/export?format=csv"))
+
+	assert.StatusOK(t, w)
+	tassert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	tassert.Equal(t, `attachment; filename="gira-2020.csv"`, w.Header().Get("Content-Disposition"))
+	tassert.Equal(t, body, w.Body.Bytes())
+}
+
+func TestReportExportInvalidFormat(t *testing.T) {
+	srv := newServer(nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newReportRequest("/reports/2020/export?format=xml"))
+
+	assert.StatusCode(t, w, http.StatusBadRequest)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/asankov/gira/internal/middleware"
+	"github.com/asankov/gira/pkg/client"
 
 	"github.com/justinas/alice"
 
@@ -23,23 +24,174 @@ func (s *Server) routes() http.Handler {
 	r.Handle("/games/new", s.requireLogin(s.handleGameCreateView())).Methods(http.MethodGet)
 	// POST /games/new handles the creation of a new game
 	r.Handle("/games/new", s.requireLogin(s.handleGameCreate())).Methods(http.MethodPost)
+	// GET /games/metadata?q= searches the metadata provider for games by name and returns them as JSON
+	r.Handle("/games/metadata", s.requireLogin(s.handleMetadataSearch())).Methods(http.MethodGet)
+	// GET /games/metadata/{id} returns the details of a game from the metadata provider as JSON
+	r.Handle("/games/metadata/{id}", s.requireLogin(s.handleMetadataGet())).Methods(http.MethodGet)
+
+	// GET /games/import renders the Import Games view for the authenticated user
+	r.Handle("/games/import", s.requireLogin(s.handleGamesImportView())).Methods(http.MethodGet)
+	// POST /games/import handles the upload of a CSV or JSON file with games to import
+	r.Handle("/games/import", s.requireLogin(s.handleGamesImport())).Methods(http.MethodPost)
+	// GET /games/import/library renders the view for uploading the library export of a storefront (Steam, GOG)
+	r.Handle("/games/import/library", s.requireLogin(s.handleLibraryImportView())).Methods(http.MethodGet)
+	// POST /games/import/library handles the upload of a library export and renders the games in it for review
+	r.Handle("/games/import/library", s.requireLogin(s.handleLibraryImportPreview())).Methods(http.MethodPost)
+	// POST /games/import/library/commit imports the games, selected during the review
+	r.Handle("/games/import/library/commit", s.requireLogin(s.handleLibraryImportCommit())).Methods(http.MethodPost)
 
 	r.Handle("/games/status", s.requireLogin(s.handleGamesChangeStatus())).Methods(http.MethodPost)
 	r.Handle("/games/progress", s.requireLogin(s.handleGamesChangeProgress())).Methods(http.MethodPost)
 	r.Handle("/games/delete", s.requireLogin(s.handleGamesDelete())).Methods(http.MethodPost)
+	// POST /games/wishlist adds the game to the wishlist or removes it from it
+	r.Handle("/games/wishlist", s.requireLogin(s.handleGamesChangeWishlist())).Methods(http.MethodPost)
+	// POST /games/release-date sets the release date of the game, or clears it if it is empty
+	r.Handle("/games/release-date", s.requireLogin(s.handleGamesChangeReleaseDate())).Methods(http.MethodPost)
+	// POST /games/hidden hides the game from the public profile of the user, or shows it on it
+	r.Handle("/games/hidden", s.requireLogin(s.handleGamesChangeHidden())).Methods(http.MethodPost)
+	// POST /games/rating rates the game from 1 to 5, or removes its rating if it is empty
+	r.Handle("/games/rating", s.requireLogin(s.handleGamesChangeRating())).Methods(http.MethodPost)
+	// GET /games/next?franchise=&progress=&age=&tags=&rating=&genres= renders the suggestions of what to play next,
+	// ranked by the heuristics with the given weights
+	r.Handle("/games/next", s.requireLogin(s.handleGamesNextView())).Methods(http.MethodGet)
+	// GET /games/upcoming?wishlist=true renders the calendar of the upcoming releases
+	r.Handle("/games/upcoming", s.requireLogin(s.handleGamesUpcomingView())).Methods(http.MethodGet)
+
+	// GET /games/{id}/cover?size=full|thumbnail returns the cover image of the given game
+	r.Handle("/games/{id}/cover", s.requireLogin(s.handleCoverGet(client.CoverKindGame))).Methods(http.MethodGet)
+	// POST /games/{id}/cover handles the upload of the cover of the given game
+	r.Handle("/games/{id}/cover", s.requireLogin(s.handleCoverUpload(client.CoverKindGame))).Methods(http.MethodPost)
+	// GET /games/{id}/cover/edit renders the view for changing the cover of the given game
+	r.Handle("/games/{id}/cover/edit", s.requireLogin(s.handleCoverEditView(client.CoverKindGame))).Methods(http.MethodGet)
+	// POST /games/{id}/cover/delete deletes the cover of the given game
+	r.Handle("/games/{id}/cover/delete", s.requireLogin(s.handleCoverDelete(client.CoverKindGame))).Methods(http.MethodPost)
+
+	// GET /games/{id}/checklist returns the checklist of the given game as JSON
+	r.Handle("/games/{id}/checklist", s.requireLogin(s.handleChecklistGet())).Methods(http.MethodGet)
+	// PATCH /games/{id}/checklist turns on or off the computation of the progress of the game from its checklist
+	r.Handle("/games/{id}/checklist", s.requireLogin(s.handleChecklistPatch())).Methods(http.MethodPatch)
+	// POST /games/{id}/checklist/items adds an item to the checklist of the given game
+	r.Handle("/games/{id}/checklist/items", s.requireLogin(s.handleChecklistItemCreate())).Methods(http.MethodPost)
+	// PATCH /games/{id}/checklist/items/{itemId} renames or ticks off an item of the checklist
+	r.Handle("/games/{id}/checklist/items/{itemId}", s.requireLogin(s.handleChecklistItemPatch())).Methods(http.MethodPatch)
+	// DELETE /games/{id}/checklist/items/{itemId} deletes an item from the checklist
+	r.Handle("/games/{id}/checklist/items/{itemId}", s.requireLogin(s.handleChecklistItemDelete())).Methods(http.MethodDelete)
+
+	// GET /games/{id}/playthroughs renders the playthroughs of the given game
+	r.Handle("/games/{id}/playthroughs", s.requireLogin(s.handlePlaythroughsView())).Methods(http.MethodGet)
+	// POST /games/{id}/playthroughs starts a new playthrough of the given game
+	r.Handle("/games/{id}/playthroughs", s.requireLogin(s.handlePlaythroughCreate())).Methods(http.MethodPost)
+	// POST /games/{id}/playthroughs/{playthroughId} saves the changes of the playthrough
+	r.Handle("/games/{id}/playthroughs/{playthroughId}", s.requireLogin(s.handlePlaythroughUpdate())).Methods(http.MethodPost)
+	// POST /games/{id}/playthroughs/{playthroughId}/delete deletes the playthrough
+	r.Handle("/games/{id}/playthroughs/{playthroughId}/delete", s.requireLogin(s.handlePlaythroughDelete())).Methods(http.MethodPost)
+
+	// GET /reports redirects to the report of the current year
+	r.Handle("/reports", s.requireLogin(s.handleReportCurrentYear())).Methods(http.MethodGet)
+	// GET /reports/{year} renders the report of the given year for the authenticated user
+	r.Handle("/reports/{year}", s.requireLogin(s.handleReportView())).Methods(http.MethodGet)
+	// GET /reports/{year}/export?format=json|csv downloads the report of the given year
+	r.Handle("/reports/{year}/export", s.requireLogin(s.handleReportExport())).Methods(http.MethodGet)
+
+	// GET /profiles renders the Friends view with the profile settings of the user and the profiles they follow.
+	// ?username= redirects to the profile of the given user.
+	r.Handle("/profiles", s.requireLogin(s.handleFriendsView())).Methods(http.MethodGet)
+	// POST /profiles/me makes the profile of the user public or private
+	r.Handle("/profiles/me", s.requireLogin(s.handleProfileChangePublic())).Methods(http.MethodPost)
+	// GET /profiles/{username} renders the profile of the given user with their games
+	r.Handle("/profiles/{username}", s.requireLogin(s.handleProfileView())).Methods(http.MethodGet)
+	// POST /profiles/{username}/follow follows the given user
+	r.Handle("/profiles/{username}/follow", s.requireLogin(s.handleProfileFollow())).Methods(http.MethodPost)
+	// POST /profiles/{username}/unfollow unfollows the given user
+	r.Handle("/profiles/{username}/unfollow", s.requireLogin(s.handleProfileUnfollow())).Methods(http.MethodPost)
+
+	// GET /goals renders the goals of the user with their progress
+	r.Handle("/goals", s.requireLogin(s.handleGoalsView())).Methods(http.MethodGet)
+	// POST /goals creates a goal
+	r.Handle("/goals", s.requireLogin(s.handleGoalCreate())).Methods(http.MethodPost)
+	// POST /goals/{id}/delete deletes the given goal
+	r.Handle("/goals/{id}/delete", s.requireLogin(s.handleGoalDelete())).Methods(http.MethodPost)
+
+	// GET /notifications renders the inbox of the user and the notification preferences
+	r.Handle("/notifications", s.requireLogin(s.handleNotificationsView())).Methods(http.MethodGet)
+	// POST /notifications/read marks all notifications as read
+	r.Handle("/notifications/read", s.requireLogin(s.handleNotificationsRead())).Methods(http.MethodPost)
+	// POST /notifications/preferences saves the notification preferences
+	r.Handle("/notifications/preferences", s.requireLogin(s.handleNotificationPreferences())).Methods(http.MethodPost)
+	// POST /notifications/{id}/read marks the given notification as read
+	r.Handle("/notifications/{id}/read", s.requireLogin(s.handleNotificationRead())).Methods(http.MethodPost)
+
+	// GET /webhooks renders the webhooks of the user with their latest deliveries
+	r.Handle("/webhooks", s.requireLogin(s.handleWebhooksView())).Methods(http.MethodGet)
+	// POST /webhooks creates a webhook and shows its secret once
+	r.Handle("/webhooks", s.requireLogin(s.handleWebhookCreate())).Methods(http.MethodPost)
+	// POST /webhooks/{id} updates the URL, the events and the active flag of the given webhook
+	r.Handle("/webhooks/{id}", s.requireLogin(s.handleWebhookUpdate())).Methods(http.MethodPost)
+	// POST /webhooks/{id}/delete deletes the given webhook
+	r.Handle("/webhooks/{id}/delete", s.requireLogin(s.handleWebhookDelete())).Methods(http.MethodPost)
+
+	// GET /boards renders the shared boards of the user and their pending invitations
+	r.Handle("/boards", s.requireLogin(s.handleBoardsView())).Methods(http.MethodGet)
+	// POST /boards creates a shared board
+	r.Handle("/boards", s.requireLogin(s.handleBoardCreate())).Methods(http.MethodPost)
+	// GET /boards/{id} renders the given board with its games and members
+	r.Handle("/boards/{id}", s.requireLogin(s.handleBoardView())).Methods(http.MethodGet)
+	// POST /boards/{id}/delete deletes the given board
+	r.Handle("/boards/{id}/delete", s.requireLogin(s.handleBoardDelete())).Methods(http.MethodPost)
+	// POST /boards/{id}/games adds a game to the board
+	r.Handle("/boards/{id}/games", s.requireLogin(s.handleBoardGameCreate())).Methods(http.MethodPost)
+	// POST /boards/{id}/games/status changes the status of a game on the board
+	r.Handle("/boards/{id}/games/status", s.requireLogin(s.handleBoardGameStatus())).Methods(http.MethodPost)
+	// POST /boards/{id}/invitations invites a user to the board
+	r.Handle("/boards/{id}/invitations", s.requireLogin(s.handleBoardInvite())).Methods(http.MethodPost)
+	// POST /boards/{id}/members/{username} changes the role of the member
+	r.Handle("/boards/{id}/members/{username}", s.requireLogin(s.handleBoardMemberRole())).Methods(http.MethodPost)
+	// POST /boards/{id}/members/{username}/remove removes the member from the board, or makes the user leave it
+	r.Handle("/boards/{id}/members/{username}/remove", s.requireLogin(s.handleBoardMemberRemove())).Methods(http.MethodPost)
+	// POST /invitations/{id}/accept joins the board, that the user is invited to
+	r.Handle("/invitations/{id}/accept", s.requireLogin(s.handleInvitationAccept())).Methods(http.MethodPost)
+	// POST /invitations/{id}/decline declines the invitation
+	r.Handle("/invitations/{id}/decline", s.requireLogin(s.handleInvitationDecline())).Methods(http.MethodPost)
 
 	r.Handle("/franchises/add", s.requireLogin(s.handleFranchisesAddPost())).Methods(http.MethodPost)
+	// GET /franchises/{id} renders the games of the given franchise in play and release order
+	r.Handle("/franchises/{id}", s.requireLogin(s.handleFranchiseView())).Methods(http.MethodGet)
+	// POST /franchises/{id}/order moves a game one position up or down in the release or play order of the franchise
+	r.Handle("/franchises/{id}/order", s.requireLogin(s.handleFranchiseReorder())).Methods(http.MethodPost)
+	// POST /franchises/{id}/parent makes the franchise a sub-series of another one, or a top-level franchise if parentId is empty
+	r.Handle("/franchises/{id}/parent", s.requireLogin(s.handleFranchiseParent())).Methods(http.MethodPost)
+	// GET /franchises/{id}/cover?size=full|thumbnail returns the cover image of the given franchise
+	r.Handle("/franchises/{id}/cover", s.requireLogin(s.handleCoverGet(client.CoverKindFranchise))).Methods(http.MethodGet)
+	// POST /franchises/{id}/cover handles the upload of the cover of the given franchise
+	r.Handle("/franchises/{id}/cover", s.requireLogin(s.handleCoverUpload(client.CoverKindFranchise))).Methods(http.MethodPost)
+	// GET /franchises/{id}/cover/edit renders the view for changing the cover of the given franchise
+	r.Handle("/franchises/{id}/cover/edit", s.requireLogin(s.handleCoverEditView(client.CoverKindFranchise))).Methods(http.MethodGet)
+	// POST /franchises/{id}/cover/delete deletes the cover of the given franchise
+	r.Handle("/franchises/{id}/cover/delete", s.requireLogin(s.handleCoverDelete(client.CoverKindFranchise))).Methods(http.MethodPost)
 
 	r.Handle("/users/signup", s.handleUserSignupForm()).Methods(http.MethodGet)
 	r.Handle("/users/create", s.handleUserSignup()).Methods(http.MethodPost)
 
 	r.Handle("/users/login", s.handleUserLoginForm()).Methods(http.MethodGet)
 	r.Handle("/users/login", s.handleUserLogin()).Methods(http.MethodPost)
+	// GET /users/login/oidc/{provider} redirects the user to the given OpenID Connect provider to login
+	r.Handle("/users/login/oidc/{provider}", s.handleUserLoginOIDC()).Methods(http.MethodGet)
+	// GET /users/login/oidc/{provider}/callback handles the redirect back from the OpenID Connect provider
+	r.Handle("/users/login/oidc/{provider}/callback", s.handleUserLoginOIDCCallback()).Methods(http.MethodGet)
 	r.Handle("/users/logout", s.requireLogin(s.handleUserLogout())).Methods(http.MethodPost)
+	// GET /users/me/export?format=json|csv downloads all data of the authenticated user
+	r.Handle("/users/me/export", s.requireLogin(s.handleUserExport())).Methods(http.MethodGet)
 
 	fileServer := http.FileServer(http.Dir("./ui/static"))
 	r.PathPrefix("/static/").Handler(http.StripPrefix("/static", fileServer))
 
-	standartMiddleware := alice.New(middleware.RecoverPanic(s.Log), middleware.LogRequest(s.Log), s.secureHeaders, s.Session.Enable)
-	return standartMiddleware.Then(r)
+	// the stream of changes is routed around the session, because the session buffers the whole response,
+	// which would hold the events back until the stream ends
+	root := mux.NewRouter()
+	// GET /changes streams the changes of the games of the authenticated user as Server-Sent Events
+	root.Handle("/changes", s.requireLogin(s.handleChangesStream())).Methods(http.MethodGet)
+	root.PathPrefix("/").Handler(alice.New(s.Session.Enable, s.verifyCSRF).Then(r))
+
+	standartMiddleware := alice.New(middleware.RecoverPanic(s.Log), middleware.LogRequest(s.Log), s.secureHeaders)
+	return standartMiddleware.Then(root)
 }
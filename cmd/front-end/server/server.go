@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/asankov/gira/pkg/client"
 
@@ -11,11 +13,26 @@ import (
 )
 
 var (
-	homePage       = "home.page.tmpl"
-	listGamesPage  = "list.page.tmpl"
-	createGamePage = "create.page.tmpl"
-	signupUserPage = "signup.page.tmpl"
-	loginUserPage  = "login.page.tmpl"
+	homePage          = "home.page.tmpl"
+	listGamesPage     = "list.page.tmpl"
+	createGamePage    = "create.page.tmpl"
+	signupUserPage    = "signup.page.tmpl"
+	loginUserPage     = "login.page.tmpl"
+	reportPage        = "report.page.tmpl"
+	importPage        = "import.page.tmpl"
+	libraryPage       = "library.page.tmpl"
+	coverPage         = "cover.page.tmpl"
+	upcomingPage      = "upcoming.page.tmpl"
+	playthroughsPage  = "playthroughs.page.tmpl"
+	friendsPage       = "friends.page.tmpl"
+	profilePage       = "profile.page.tmpl"
+	boardsPage        = "boards.page.tmpl"
+	boardPage         = "board.page.tmpl"
+	nextPage          = "next.page.tmpl"
+	franchisePage     = "franchise.page.tmpl"
+	goalsPage         = "goals.page.tmpl"
+	notificationsPage = "notifications.page.tmpl"
+	webhooksPage      = "webhooks.page.tmpl"
 
 	emptyTemplateData = TemplateData{}
 )
@@ -27,10 +44,70 @@ type TemplateData struct {
 	Games      []TemplateGame
 	Statuses   []client.Status
 	Franchises []*client.Franchise
+	Stats      *client.GetStatsResponse
+	Report     *TemplateReport
+	// ImportResult is the outcome of the last import of games
+	ImportResult *client.ImportGamesResponse
+	// LibraryPreview is the content of an uploaded library export, that is reviewed before it is imported
+	LibraryPreview *client.PreviewLibraryImportResponse
+	// Cover is the cover of a game or a franchise, that is being edited
+	Cover *TemplateCover
+	// Upcoming are the upcoming releases, grouped by month and day
+	Upcoming []TemplateUpcomingMonth
+	// WishlistOnly is true if only the games on the wishlist are shown
+	WishlistOnly bool
+	// Playthroughs are the playthroughs of Game, the oldest first
+	Playthroughs []*client.Playthrough
+	// Profile is the profile that is shown, or the profile of the user on the Friends view
+	Profile *client.Profile
+	// Following are the profiles that the user follows
+	Following []*client.Profile
+	// Feed is a page of the activity of the users, that the user follows, the newest first
+	Feed []TemplateEvent
+	// FeedCursor is the cursor of the next page of Feed, or empty if there are no more events
+	FeedCursor string
+	// Boards are the shared boards, that the user is a member of
+	Boards []*client.Board
+	// Board is the shared board that is shown, together with its members
+	Board *client.Board
+	// Invitations are the pending invitations of the user to shared boards
+	Invitations []*client.BoardInvitation
+	// Suggestions are the suggestions of what to play next, the best one first
+	Suggestions []TemplateSuggestion
+	// Heuristics are the heuristics, that rank the suggestions, together with their weights
+	Heuristics []TemplateHeuristic
+	// Genres are the comma-separated genres, that are preferred by the suggestions
+	Genres string
+	// Franchise is the franchise that is shown, with its games in play order
+	Franchise *client.Franchise
+	// ReleaseOrder are the games of Franchise in release order
+	ReleaseOrder []*client.Game
+	// NextUp is the first game of Franchise in play order, that is not finished yet
+	NextUp *client.Game
+	// FranchiseOptions are the franchises in the franchise selectors, in the order of the hierarchy
+	FranchiseOptions []TemplateFranchise
+	// Goals are the goals of the user with their progress, the oldest first
+	Goals []TemplateGoal
+	// Notifications are the latest notifications of the user, the newest first
+	Notifications []*client.Notification
+	// UnreadNotifications is the number of all unread notifications of the user
+	UnreadNotifications int
+	// NotificationPreferences are the preferences of the user about the reminders of stale games
+	NotificationPreferences *client.NotificationPreferences
+	// Webhooks are the webhooks of the user with their latest deliveries
+	Webhooks []TemplateWebhook
+	// WebhookEvents are all events, that the webhooks can subscribe to
+	WebhookEvents []client.WebhookEvent
 
+	OIDCProviders       []string
 	SelectedFranchiseID string
 	Error               string
 	Flash               string
+
+	// CSRFToken must be included in every form as a hidden csrf_token input
+	CSRFToken string
+	// CSPNonce must be set as the nonce attribute of every inline <style> and <script>
+	CSPNonce string
 }
 
 // TemplateGame is the struct that holds all the game info that is passed to the template renderer to render
@@ -42,6 +119,115 @@ type TemplateGame struct {
 
 	Status   client.Status
 	Progress *client.GameProgress
+
+	Wishlist    bool
+	ReleaseDate *time.Time
+	// AutoProgress is true if the progress is computed from the checklist of the game, and can not be changed manually
+	AutoProgress bool
+	// Playthroughs is the number of playthroughs of the game
+	Playthroughs int
+	// Hidden games are not shown on the public profile of the user
+	Hidden bool
+	// Rating is the rating of the game, from 1 to 5, or 0 if it is not rated
+	Rating int
+
+	// CoverURL is the thumbnail of the uploaded cover, or the cover from the metadata provider, if there is no uploaded one
+	CoverURL string
+	// FranchiseCoverURL is the thumbnail of the cover of the franchise, if it has one
+	FranchiseCoverURL string
+}
+
+// TemplateSuggestion is a game, that is suggested to be played next, together with the reasons for its score
+type TemplateSuggestion struct {
+	Game    TemplateGame
+	Score   float64
+	Reasons []*client.SuggestionReason
+}
+
+// TemplateHeuristic is a heuristic, that ranks the suggestions, and its weight
+type TemplateHeuristic struct {
+	Name   client.Heuristic
+	Weight float64
+}
+
+// TemplateFranchise is a franchise in a franchise selector, which shows the hierarchy of the franchises
+type TemplateFranchise struct {
+	ID string
+	// Label is the name of the franchise, indented by its depth in the hierarchy
+	Label string
+	// Completion is the completion of the games of the franchise, including its sub-series
+	Completion *client.FranchiseCompletion
+}
+
+// TemplateGoal is a goal of the user with its progress, described in words
+type TemplateGoal struct {
+	ID string
+	// Description is what the goal is, e.g. "Finish 12 games between 01 Jan 2021 and 31 Dec 2021"
+	Description string
+	// Progress is how far the user is, e.g. "3 of 12 finished"
+	Progress string
+	// Percent is the progress from 0 to 100, for the progress bar
+	Percent int
+	Status  client.GoalStatus
+}
+
+// TemplateWebhook is a webhook of the user with its latest deliveries, the newest first
+type TemplateWebhook struct {
+	*client.Webhook
+	Deliveries []*client.WebhookDelivery
+}
+
+// Has returns whether the webhook is subscribed to the given event
+func (w TemplateWebhook) Has(event client.WebhookEvent) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// TemplateCover is the struct that holds the cover of a game or a franchise, that is passed to the template renderer to render
+type TemplateCover struct {
+	Kind client.CoverKind
+	ID   string
+	// Name is the name of the game or the franchise
+	Name string
+	// URL is the URL of the uploaded cover, or empty if there is no such
+	URL string
+}
+
+// TemplateUpcomingMonth is a month of the calendar of upcoming releases
+type TemplateUpcomingMonth struct {
+	// Month is the first day of the month
+	Month time.Time
+	Days  []TemplateUpcomingDay
+}
+
+// TemplateUpcomingDay is a day of the calendar of upcoming releases, with the games released on it
+type TemplateUpcomingDay struct {
+	Date  time.Time
+	Games []TemplateGame
+}
+
+// TemplateEvent is an activity of a user in the feed, e.g. "ivan finished Hades"
+type TemplateEvent struct {
+	Username string
+	// Action is what the user did with the game, e.g. "finished"
+	Action   string
+	GameName string
+	// Details are shown after the name of the game, e.g. "to To Do"
+	Details   string
+	CreatedAt time.Time
+}
+
+// TemplateReport is the struct that holds the report of a single year and the years around it, that is passed to the template renderer to render
+type TemplateReport struct {
+	*client.GetYearReportResponse
+
+	// PreviousYear and NextYear are the years of the neighbouring reports, or 0 if there is no such report
+	PreviousYear int
+	NextYear     int
 }
 
 // Renderer is the interface that will be used to interact with the part of the program
@@ -54,19 +240,86 @@ type Renderer interface {
 type APIClient interface {
 	GetFranchises(context.Context, *client.GetFranchisesRequest) (*client.GetFranchisesResponse, error)
 	CreateFranchise(context.Context, *client.CreateFranchiseRequest) (*client.CreateFranchiseResponse, error)
+	GetFranchise(context.Context, *client.GetFranchiseRequest) (*client.Franchise, error)
+	ReorderFranchise(context.Context, *client.ReorderFranchiseRequest) (*client.Franchise, error)
+	SetFranchiseParent(context.Context, *client.SetFranchiseParentRequest) (*client.Franchise, error)
 
 	GetGames(context.Context, *client.GetGamesRequest) (*client.GetGamesResponse, error)
+	GetUpcomingGames(context.Context, *client.GetUpcomingGamesRequest) (*client.GetGamesResponse, error)
+	GetNextGames(context.Context, *client.GetNextGamesRequest) (*client.GetNextGamesResponse, error)
 	CreateGame(context.Context, *client.CreateGameRequest) (*client.CreateGameResponse, error)
+	ImportGames(context.Context, *client.ImportGamesRequest) (*client.ImportGamesResponse, error)
+	PreviewLibraryImport(context.Context, *client.PreviewLibraryImportRequest) (*client.PreviewLibraryImportResponse, error)
+	SearchGameMetadata(context.Context, *client.SearchGameMetadataRequest) (*client.SearchGameMetadataResponse, error)
+	GetGameMetadata(context.Context, *client.GetGameMetadataRequest) (*client.GameMetadata, error)
+	UploadCover(context.Context, *client.UploadCoverRequest) (*client.Cover, error)
+	GetCover(context.Context, *client.GetCoverRequest) (*client.GetCoverResponse, error)
+	DeleteCover(context.Context, *client.DeleteCoverRequest) error
+	GetChecklist(context.Context, *client.GetChecklistRequest) (*client.Checklist, error)
+	SetChecklistAutoProgress(context.Context, *client.SetChecklistAutoProgressRequest) (*client.Checklist, error)
+	AddChecklistItem(context.Context, *client.AddChecklistItemRequest) (*client.Checklist, error)
+	UpdateChecklistItem(context.Context, *client.UpdateChecklistItemRequest) (*client.Checklist, error)
+	DeleteChecklistItem(context.Context, *client.DeleteChecklistItemRequest) (*client.Checklist, error)
+	GetPlaythroughs(context.Context, *client.GetPlaythroughsRequest) (*client.GetPlaythroughsResponse, error)
+	CreatePlaythrough(context.Context, *client.CreatePlaythroughRequest) (*client.Playthrough, error)
+	UpdatePlaythrough(context.Context, *client.UpdatePlaythroughRequest) (*client.Playthrough, error)
+	DeletePlaythrough(context.Context, *client.DeletePlaythroughRequest) error
+
+	GetProfile(context.Context, *client.GetProfileRequest) (*client.Profile, error)
+	SetProfilePublic(context.Context, *client.SetProfilePublicRequest) (*client.Profile, error)
+	GetFollowing(context.Context, *client.GetFollowingRequest) (*client.GetFollowingResponse, error)
+	GetProfileGames(context.Context, *client.GetProfileGamesRequest) (*client.GetGamesResponse, error)
+	Follow(context.Context, *client.FollowRequest) (*client.Profile, error)
+	Unfollow(context.Context, *client.FollowRequest) error
+
+	GetBoards(context.Context, *client.GetBoardsRequest) (*client.GetBoardsResponse, error)
+	CreateBoard(context.Context, *client.CreateBoardRequest) (*client.Board, error)
+	GetBoard(context.Context, *client.BoardRequest) (*client.Board, error)
+	DeleteBoard(context.Context, *client.BoardRequest) error
+	GetBoardGames(context.Context, *client.BoardRequest) (*client.GetGamesResponse, error)
+	InviteToBoard(context.Context, *client.InviteToBoardRequest) (*client.BoardInvitation, error)
+	ChangeBoardMemberRole(context.Context, *client.BoardMemberRequest) (*client.Board, error)
+	RemoveBoardMember(context.Context, *client.BoardMemberRequest) error
+	GetInvitations(context.Context, *client.GetInvitationsRequest) (*client.GetInvitationsResponse, error)
+	AcceptInvitation(context.Context, *client.InvitationRequest) (*client.Board, error)
+	DeclineInvitation(context.Context, *client.InvitationRequest) error
 
-	UpdateGameProgress(context.Context, *client.UpdateGameProgressRequest) error
+	GetGoals(context.Context, *client.GetGoalsRequest) (*client.GetGoalsResponse, error)
+	CreateGoal(context.Context, *client.CreateGoalRequest) (*client.Goal, error)
+	DeleteGoal(context.Context, *client.GoalRequest) error
+	GetNotifications(context.Context, *client.GetNotificationsRequest) (*client.GetNotificationsResponse, error)
+	MarkNotificationRead(context.Context, *client.NotificationRequest) error
+	MarkAllNotificationsRead(ctx context.Context, token string) error
+	GetNotificationPreferences(ctx context.Context, token string) (*client.NotificationPreferences, error)
+	SetNotificationPreferences(context.Context, *client.NotificationPreferencesRequest) (*client.NotificationPreferences, error)
+	GetWebhooks(ctx context.Context, token string) (*client.GetWebhooksResponse, error)
+	CreateWebhook(context.Context, *client.WebhookRequest) (*client.Webhook, error)
+	UpdateWebhook(context.Context, *client.WebhookRequest) (*client.Webhook, error)
+	DeleteWebhook(ctx context.Context, token, webhookID string) error
+	GetWebhookDeliveries(context.Context, *client.GetWebhookDeliveriesRequest) (*client.GetWebhookDeliveriesResponse, error)
+	StreamChanges(ctx context.Context, token string) (io.ReadCloser, error)
+
+	UpdateGameProgress(context.Context, *client.UpdateGameProgressRequest) (*client.UpdateGameProgressResponse, error)
 	DeleteUserGame(context.Context, *client.DeleteUserGameRequest) error
 
 	LoginUser(context.Context, *client.LoginUserRequest) (*client.UserLoginResponse, error)
+	LoginUserOIDC(context.Context, *client.LoginUserOIDCRequest) (*client.UserLoginResponse, error)
 	CreateUser(context.Context, *client.CreateUserRequest) (*client.CreateUserResponse, error)
 	GetUser(context.Context, *client.GetUserRequest) (*client.GetUserResponse, error)
+	ExportUserData(context.Context, *client.ExportUserDataRequest) (*client.ExportUserDataResponse, error)
 	LogoutUser(context.Context, *client.LogoutUserRequest) error
 
 	GetStatuses(ctx context.Context, request *client.GetStatusesRequest) (*client.GetStatusesResponse, error)
+	GetStats(ctx context.Context, request *client.GetStatsRequest) (*client.GetStatsResponse, error)
+	GetFeed(ctx context.Context, request *client.GetFeedRequest) (*client.GetFeedResponse, error)
+	GetYearReport(ctx context.Context, request *client.GetYearReportRequest) (*client.GetYearReportResponse, error)
+	ExportYearReport(ctx context.Context, request *client.ExportYearReportRequest) (*client.ExportYearReportResponse, error)
+}
+
+// OIDCClient is the interface that interacts with the configured OpenID Connect providers
+type OIDCClient interface {
+	Providers() []string
+	AuthCodeURL(ctx context.Context, provider, state, nonce, codeChallenge string) (string, error)
 }
 
 // Server is the struct that holds all the dependencies
@@ -76,6 +329,9 @@ type Server struct {
 	Session  *sessions.Session
 	Client   APIClient
 	Renderer Renderer
+	OIDC     OIDCClient
+	// EnforceHTTPS marks the cookies as Secure and enables HSTS
+	EnforceHTTPS bool
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
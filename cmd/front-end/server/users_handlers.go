@@ -2,9 +2,20 @@ package server
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 
+	"github.com/asankov/gira/internal/middleware"
+	"github.com/asankov/gira/internal/oidc"
 	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+var (
+	sessionKeyOIDCState    = "oidc_state"
+	sessionKeyOIDCNonce    = "oidc_nonce"
+	sessionKeyOIDCVerifier = "oidc_verifier"
 )
 
 func (s *Server) handleUserSignupForm() http.HandlerFunc {
@@ -15,7 +26,7 @@ func (s *Server) handleUserSignupForm() http.HandlerFunc {
 
 func (s *Server) handleUserLoginForm() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		s.render(w, r, emptyTemplateData, loginUserPage, "")
+		s.render(w, r, TemplateData{OIDCProviders: s.oidcProviders()}, loginUserPage, "")
 	}
 }
 
@@ -30,29 +41,142 @@ func (s *Server) handleUserLogin() http.HandlerFunc {
 		res, err := s.Client.LoginUser(context.Background(), &client.LoginUserRequest{
 			Email:    email,
 			Password: password,
+			ClientIP: middleware.ClientIP(r, nil),
 		})
 		if err != nil {
 			s.Log.Errorf("Error while logging in user: %v", err)
 			if errResponse, ok := err.(*client.ErrorResponse); ok {
-				s.render(w, r, TemplateData{Error: errResponse.Error()}, loginUserPage, "")
+				s.render(w, r, TemplateData{Error: errResponse.Error(), OIDCProviders: s.oidcProviders()}, loginUserPage, "")
 				return
 			}
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 
-		http.SetCookie(w, &http.Cookie{
-			Name:  "token",
-			Value: res.Token,
-			Path:  "/",
+		s.setTokenCookie(w, res.Token)
+		w.Header().Add("Location", "/")
+		w.WriteHeader(http.StatusSeeOther)
+	}
+}
+
+// handleUserLoginOIDC starts the authorization code flow with the requested provider.
+// The state, nonce and PKCE code verifier are kept in the session,
+// until the provider redirects the user back to handleUserLoginOIDCCallback.
+func (s *Server) handleUserLoginOIDC() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.OIDC == nil {
+			http.NotFound(w, r)
+			return
+		}
+		provider := mux.Vars(r)["provider"]
+
+		state, err := oidc.RandomString(16)
+		if err != nil {
+			s.Log.Errorf("Error while generating state: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		nonce, err := oidc.RandomString(16)
+		if err != nil {
+			s.Log.Errorf("Error while generating nonce: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		verifier, err := oidc.NewCodeVerifier()
+		if err != nil {
+			s.Log.Errorf("Error while generating code verifier: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		authCodeURL, err := s.OIDC.AuthCodeURL(r.Context(), provider, state, nonce, oidc.CodeChallenge(verifier))
+		if err != nil {
+			if errors.Is(err, oidc.ErrUnknownProvider) {
+				http.NotFound(w, r)
+				return
+			}
+			s.Log.Errorf("Error while building authorization URL for provider %s: %v", provider, err)
+			s.render(w, r, TemplateData{Error: "Login with this provider is currently unavailable.", OIDCProviders: s.oidcProviders()}, loginUserPage, "")
+			return
+		}
+
+		s.Session.Put(r, sessionKeyOIDCState, state)
+		s.Session.Put(r, sessionKeyOIDCNonce, nonce)
+		s.Session.Put(r, sessionKeyOIDCVerifier, verifier)
+
+		w.Header().Add("Location", authCodeURL)
+		w.WriteHeader(http.StatusSeeOther)
+	}
+}
+
+// handleUserLoginOIDCCallback completes the authorization code flow,
+// and exchanges the code issued by the provider for a Gira token.
+// The API exchanges the code with the provider itself, so the code verifier and the nonce are passed along.
+func (s *Server) handleUserLoginOIDCCallback() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.OIDC == nil {
+			http.NotFound(w, r)
+			return
+		}
+		provider := mux.Vars(r)["provider"]
+
+		state := s.Session.PopString(r, sessionKeyOIDCState)
+		nonce := s.Session.PopString(r, sessionKeyOIDCNonce)
+		verifier := s.Session.PopString(r, sessionKeyOIDCVerifier)
+
+		query := r.URL.Query()
+		if errCode := query.Get("error"); errCode != "" {
+			s.Log.Warnf("Provider %s returned error: %s - %s", provider, errCode, query.Get("error_description"))
+			s.render(w, r, TemplateData{Error: "Login was not completed.", OIDCProviders: s.oidcProviders()}, loginUserPage, "")
+			return
+		}
+
+		if state == "" || query.Get("state") != state {
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+
+		code := query.Get("code")
+		if code == "" {
+			http.Error(w, "'code' is required", http.StatusBadRequest)
+			return
+		}
+
+		res, err := s.Client.LoginUserOIDC(r.Context(), &client.LoginUserOIDCRequest{
+			Provider:     provider,
+			Code:         code,
+			CodeVerifier: verifier,
+			Nonce:        nonce,
 		})
+		if err != nil {
+			s.Log.Errorf("Error while logging in user: %v", err)
+			if errResponse, ok := err.(*client.ErrorResponse); ok {
+				s.render(w, r, TemplateData{Error: errResponse.Error(), OIDCProviders: s.oidcProviders()}, loginUserPage, "")
+				return
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		s.setTokenCookie(w, res.Token)
 		w.Header().Add("Location", "/")
 		w.WriteHeader(http.StatusSeeOther)
 	}
 }
 
+func (s *Server) oidcProviders() []string {
+	if s.OIDC == nil {
+		return nil
+	}
+	return s.OIDC.Providers()
+}
+
 func (s *Server) handleUserLogout() authorizedHandler {
 	return func(w http.ResponseWriter, r *http.Request, token string) {
+		// the cookie is cleared even if the API call fails,
+		// so that the user is logged out of the front-end, at least
+		s.clearTokenCookie(w)
+
 		if err := s.Client.LogoutUser(context.Background(), &client.LogoutUserRequest{Token: token}); err != nil {
 			// TODO: render error page
 			s.Log.Printf("Error while logging-out user: %v", err)
@@ -65,6 +189,40 @@ func (s *Server) handleUserLogout() authorizedHandler {
 	}
 }
 
+// handleUserExport streams the export of all data of the user from the API to the browser, as a download.
+func (s *Server) handleUserExport() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "csv" {
+			http.Error(w, "'format' must be one of json, csv", http.StatusBadRequest)
+			return
+		}
+
+		export, err := s.Client.ExportUserData(r.Context(), &client.ExportUserDataRequest{
+			Token:  token,
+			Format: format,
+		})
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.Log.Errorf("Error while exporting user data: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		defer export.Body.Close()
+
+		w.Header().Set("Content-Type", export.ContentType)
+		w.Header().Set("Content-Disposition", export.ContentDisposition)
+		w.Header().Set("Cache-Control", "no-store")
+		if _, err := io.Copy(w, export.Body); err != nil {
+			s.Log.Errorf("Error while streaming user data export: %v", err)
+		}
+	}
+}
+
 func (s *Server) handleUserSignup() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := r.ParseForm(); err != nil {
@@ -95,3 +253,27 @@ func (s *Server) handleUserSignup() http.HandlerFunc {
 		w.WriteHeader(http.StatusSeeOther)
 	}
 }
+
+// setTokenCookie sets the cookie that holds the API token of the logged in user.
+// It is not accessible from JavaScript and is not sent with cross-site subrequests.
+// If HTTPS is enforced, it is sent only over HTTPS.
+func (s *Server) setTokenCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, s.tokenCookie(token, 0))
+}
+
+// clearTokenCookie instructs the browser to delete the token cookie.
+func (s *Server) clearTokenCookie(w http.ResponseWriter) {
+	http.SetCookie(w, s.tokenCookie("", -1))
+}
+
+func (s *Server) tokenCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     "token",
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   s.EnforceHTTPS,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -1,13 +1,17 @@
 package server_test
 
 import (
+	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
 
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/oidc"
 	"github.com/asankov/gira/pkg/client"
 
 	"github.com/stretchr/testify/require"
@@ -67,6 +71,7 @@ func TestUserLogin(t *testing.T) {
 		LoginUser(gomock.AssignableToTypeOf(ctxType), &client.LoginUserRequest{
 			Email:    email,
 			Password: password,
+			ClientIP: "192.0.2.1",
 		}).
 		Return(&client.UserLoginResponse{Token: token}, nil)
 
@@ -75,6 +80,7 @@ func TestUserLogin(t *testing.T) {
 	form.Add("email", email)
 	form.Add("password", password)
 	r := httptest.NewRequest(http.MethodPost, "/users/login", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	srv.ServeHTTP(w, r)
 
@@ -87,6 +93,9 @@ func TestUserLogin(t *testing.T) {
 	assert.Equal(t, cookie.Name, gotCookie.Name)
 	assert.Equal(t, cookie.Value, gotCookie.Value)
 	assert.Equal(t, cookie.Path, gotCookie.Path)
+	assert.True(t, gotCookie.HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, gotCookie.SameSite)
+	assert.False(t, gotCookie.Secure)
 }
 
 func TestUserLoginFormError(t *testing.T) {
@@ -100,6 +109,7 @@ func TestUserLoginFormError(t *testing.T) {
 		LoginUser(gomock.AssignableToTypeOf(ctxType), gomock.Eq(&client.LoginUserRequest{
 			Email:    email,
 			Password: password,
+			ClientIP: "192.0.2.1",
 		})).
 		Return(nil, errors.New("error while logging in user"))
 
@@ -108,6 +118,7 @@ func TestUserLoginFormError(t *testing.T) {
 	form.Add("email", email)
 	form.Add("password", password)
 	r := httptest.NewRequest(http.MethodPost, "/users/login", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	srv.ServeHTTP(w, r)
 
@@ -122,6 +133,7 @@ func TestUserLoginClientError(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/users/login", nil)
+	addCSRFToken(t, r)
 	r.Body = nil
 	srv.ServeHTTP(w, r)
 
@@ -141,6 +153,7 @@ func TestUserLogout(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/users/logout", nil)
+	addCSRFToken(t, r)
 	r.AddCookie(&http.Cookie{
 		Name:  "token",
 		Value: token,
@@ -148,6 +161,11 @@ func TestUserLogout(t *testing.T) {
 	srv.ServeHTTP(w, r)
 
 	gassert.Redirect(t, w, "/")
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "token", cookies[0].Name)
+	assert.Empty(t, cookies[0].Value)
+	assert.True(t, cookies[0].MaxAge < 0)
 }
 
 func TestUserSignup(t *testing.T) {
@@ -169,6 +187,7 @@ func TestUserSignup(t *testing.T) {
 	form.Add("email", email)
 	form.Add("password", password)
 	r := httptest.NewRequest(http.MethodPost, "/users/create", strings.NewReader(form.Encode()))
+	addCSRFToken(t, r)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	srv.ServeHTTP(w, r)
 
@@ -203,6 +222,7 @@ func TestUserSignupNoCredentials(t *testing.T) {
 			form.Add("email", testCase.Email)
 			form.Add("password", testCase.Password)
 			r := httptest.NewRequest(http.MethodPost, "/users/create", strings.NewReader(form.Encode()))
+			addCSRFToken(t, r)
 			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 			srv.ServeHTTP(w, r)
 
@@ -210,3 +230,182 @@ func TestUserSignupNoCredentials(t *testing.T) {
 		})
 	}
 }
+
+func newOIDCServer(t *testing.T, a *fixtures.APIClientMock, r *fixtures.RendererMock) (*server.Server, *fixtures.OIDCProvider) {
+	idp := fixtures.NewOIDCProvider(t)
+
+	srv := newServer(a, r)
+	srv.OIDC = oidc.NewRegistry(oidc.NewProvider(&oidc.ProviderOptions{
+		Name:        "test",
+		Issuer:      idp.Issuer(),
+		ClientID:    idp.ClientID,
+		RedirectURL: "http://gira.test/users/login/oidc/test/callback",
+	}))
+
+	return srv, idp
+}
+
+func TestUserLoginOIDC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClientMock := fixtures.NewAPIClientMock(ctrl)
+	srv, idp := newOIDCServer(t, apiClientMock, nil)
+
+	// start the flow and get redirected to the provider
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/login/oidc/test", nil))
+
+	require.Equal(t, http.StatusSeeOther, w.Code)
+	authCodeURL, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(authCodeURL.String(), idp.Issuer()))
+	sessionCookies := w.Result().Cookies()
+
+	// the stub provider approves the request and redirects back with a code
+	httpClient := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	res, err := httpClient.Get(authCodeURL.String())
+	require.NoError(t, err)
+	callbackURL, err := url.Parse(res.Header.Get("Location"))
+	require.NoError(t, err)
+
+	apiClientMock.EXPECT().
+		LoginUserOIDC(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *client.LoginUserOIDCRequest) (*client.UserLoginResponse, error) {
+			assert.Equal(t, "test", req.Provider)
+			assert.Equal(t, authCodeURL.Query().Get("nonce"), req.Nonce)
+			assert.Equal(t, callbackURL.Query().Get("code"), req.Code)
+			assert.Equal(t, authCodeURL.Query().Get("code_challenge"), oidc.CodeChallenge(req.CodeVerifier))
+			return &client.UserLoginResponse{Token: token}, nil
+		})
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, callbackURL.RequestURI(), nil)
+	for _, c := range sessionCookies {
+		r.AddCookie(c)
+	}
+	srv.ServeHTTP(w, r)
+
+	gassert.Redirect(t, w, "/")
+	var tokenCookie *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == cookie.Name {
+			tokenCookie = c
+		}
+	}
+	require.NotNil(t, tokenCookie)
+	assert.Equal(t, token, tokenCookie.Value)
+}
+
+func TestUserLoginOIDCInvalidState(t *testing.T) {
+	srv, _ := newOIDCServer(t, nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/login/oidc/test", nil))
+	sessionCookies := w.Result().Cookies()
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/login/oidc/test/callback?code=code&state=forged", nil)
+	for _, c := range sessionCookies {
+		r.AddCookie(c)
+	}
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusBadRequest)
+}
+
+func TestUserLoginOIDCUnknownProvider(t *testing.T) {
+	srv, _ := newOIDCServer(t, nil, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/login/oidc/unknown", nil))
+
+	gassert.StatusCode(t, w, http.StatusNotFound)
+}
+
+func TestUserExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClientMock := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClientMock, nil)
+
+	apiClientMock.EXPECT().
+		ExportUserData(gomock.AssignableToTypeOf(ctxType), &client.ExportUserDataRequest{
+			Token:  token,
+			Format: "csv",
+		}).
+		Return(&client.ExportUserDataResponse{
+			ContentType:        "application/zip",
+			ContentDisposition: `attachment; filename="gira-export-2021-01-01.zip"`,
+			Body:               io.NopCloser(strings.NewReader("PK")),
+		}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/me/export?format=csv", nil)
+	r.AddCookie(cookie)
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="gira-export-2021-01-01.zip"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "PK", w.Body.String())
+}
+
+func TestUserExportError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		assert func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Auth error",
+			err:  client.ErrNoAuthorization,
+			assert: func(t *testing.T, w *httptest.ResponseRecorder) {
+				gassert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Other error",
+			err:  client.ErrExportingData,
+			assert: func(t *testing.T, w *httptest.ResponseRecorder) {
+				gassert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClientMock := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClientMock, nil)
+
+			apiClientMock.EXPECT().
+				ExportUserData(gomock.AssignableToTypeOf(ctxType), &client.ExportUserDataRequest{Token: token}).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/users/me/export", nil)
+			r.AddCookie(cookie)
+			srv.ServeHTTP(w, r)
+
+			testCase.assert(t, w)
+		})
+	}
+}
+
+func TestUserExportBadFormat(t *testing.T) {
+	srv := newServer(nil, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/me/export?format=xml", nil)
+	r.AddCookie(cookie)
+	srv.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusBadRequest)
+}
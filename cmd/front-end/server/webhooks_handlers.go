@@ -0,0 +1,114 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/asankov/gira/pkg/client"
+	"github.com/gorilla/mux"
+)
+
+// webhookDeliveriesLimit is the number of the latest deliveries, shown for each webhook
+const webhookDeliveriesLimit = 5
+
+// handleWebhooksView renders the webhooks of the user with their latest deliveries and the form for creating a webhook.
+func (s *Server) handleWebhooksView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		res, err := s.Client.GetWebhooks(r.Context(), token)
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.Log.Errorf("Error while fetching webhooks: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		hooks := make([]TemplateWebhook, 0, len(res.Webhooks))
+		for _, webhook := range res.Webhooks {
+			deliveries, err := s.Client.GetWebhookDeliveries(r.Context(), &client.GetWebhookDeliveriesRequest{Token: token, WebhookID: webhook.ID, Limit: webhookDeliveriesLimit})
+			if err != nil {
+				s.Log.Errorf("Error while fetching deliveries of webhook %s: %v", webhook.ID, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			hooks = append(hooks, TemplateWebhook{Webhook: webhook, Deliveries: deliveries.Deliveries})
+		}
+
+		s.render(w, r, TemplateData{
+			Webhooks:      hooks,
+			WebhookEvents: client.WebhookEvents,
+		}, webhooksPage, token)
+	}
+}
+
+// handleWebhookCreate creates a webhook and flashes its secret, because the secret is not shown after that.
+func (s *Server) handleWebhookCreate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		webhook, err := s.Client.CreateWebhook(r.Context(), &client.WebhookRequest{Token: token, Webhook: webhookFromForm(r)})
+		if err != nil {
+			s.redirectAfterWebhookChange(w, r, "", err)
+			return
+		}
+		s.redirectAfterWebhookChange(w, r, fmt.Sprintf("Webhook created. Its secret is %s - copy it now, it will not be shown again.", webhook.Secret), nil)
+	}
+}
+
+// handleWebhookUpdate saves the webhook from the form. The unchecked checkboxes are not sent, so they are false.
+func (s *Server) handleWebhookUpdate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		webhook := webhookFromForm(r)
+		webhook.Active = r.PostForm.Get("active") == "true"
+		_, err := s.Client.UpdateWebhook(r.Context(), &client.WebhookRequest{Token: token, WebhookID: mux.Vars(r)["id"], Webhook: webhook})
+		s.redirectAfterWebhookChange(w, r, "Webhook saved.", err)
+	}
+}
+
+func (s *Server) handleWebhookDelete() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		err := s.Client.DeleteWebhook(r.Context(), token, mux.Vars(r)["id"])
+		s.redirectAfterWebhookChange(w, r, "Webhook deleted.", err)
+	}
+}
+
+func webhookFromForm(r *http.Request) *client.Webhook {
+	events := []client.WebhookEvent{}
+	for _, event := range r.PostForm["events"] {
+		events = append(events, client.WebhookEvent(event))
+	}
+	return &client.Webhook{
+		URL:    strings.TrimSpace(r.PostForm.Get("url")),
+		Events: events,
+	}
+}
+
+func (s *Server) redirectAfterWebhookChange(w http.ResponseWriter, r *http.Request, flash string, err error) {
+	if err != nil {
+		if errors.Is(err, client.ErrNoAuthorization) {
+			w.Header().Add("Location", "/users/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		s.Log.Errorf("Error while changing webhook: %v", err)
+		s.Session.Put(r, "error", err.Error())
+	} else {
+		s.Session.Put(r, "flash", flash)
+	}
+
+	w.Header().Add("Location", "/webhooks")
+	w.WriteHeader(http.StatusSeeOther)
+}
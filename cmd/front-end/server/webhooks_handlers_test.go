@@ -0,0 +1,239 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+)
+
+func TestWebhooksView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer := fixtures.NewRendererMock(ctrl)
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, renderer)
+
+	webhook := &client.Webhook{ID: "1", URL: "https://example.com/hook", Events: []client.WebhookEvent{client.WebhookGameCreated}, Active: true}
+	deliveries := []*client.WebhookDelivery{
+		{ID: "1", WebhookID: "1", Event: client.WebhookGameCreated, Success: true, StatusCode: 200, Attempts: 1, CreatedAt: time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	expectGetUser(apiClient)
+	apiClient.EXPECT().
+		GetWebhooks(gomock.AssignableToTypeOf(ctxType), token).
+		Return(&client.GetWebhooksResponse{Webhooks: []*client.Webhook{webhook}}, nil)
+	apiClient.EXPECT().
+		GetWebhookDeliveries(gomock.AssignableToTypeOf(ctxType), &client.GetWebhookDeliveriesRequest{Token: token, WebhookID: "1", Limit: 5}).
+		Return(&client.GetWebhookDeliveriesResponse{Deliveries: deliveries}, nil)
+	renderer.EXPECT().
+		Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+			User:          user,
+			Webhooks:      []server.TemplateWebhook{{Webhook: webhook, Deliveries: deliveries}},
+			WebhookEvents: client.WebhookEvents,
+			CSRFToken:     csrfToken,
+		}), "webhooks.page.tmpl").
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/webhooks", nil))
+
+	assert.StatusOK(t, w)
+}
+
+func TestWebhooksViewClientError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		deliveriesErr error
+		check         func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "No authorization",
+			err:  client.ErrNoAuthorization,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Other error",
+			err:  errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+		{
+			name:          "Deliveries error",
+			deliveriesErr: errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			var res *client.GetWebhooksResponse
+			if testCase.err == nil {
+				res = &client.GetWebhooksResponse{Webhooks: []*client.Webhook{{ID: "1"}}}
+				apiClient.EXPECT().
+					GetWebhookDeliveries(gomock.AssignableToTypeOf(ctxType), gomock.Any()).
+					Return(nil, testCase.deliveriesErr)
+			}
+			apiClient.EXPECT().
+				GetWebhooks(gomock.AssignableToTypeOf(ctxType), token).
+				Return(res, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/webhooks", nil))
+
+			testCase.check(t, w)
+		})
+	}
+}
+
+func TestWebhookCreate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		location string
+	}{
+		{name: "Success", location: "/webhooks"},
+		{name: "Error", err: errors.New("'url' should be an absolute http or https URL"), location: "/webhooks"},
+		{name: "No authorization", err: client.ErrNoAuthorization, location: "/users/login"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			var created *client.Webhook
+			if testCase.err == nil {
+				created = &client.Webhook{ID: "1", Secret: "secret"}
+			}
+			apiClient.EXPECT().
+				CreateWebhook(gomock.AssignableToTypeOf(ctxType), &client.WebhookRequest{
+					Token: token,
+					Webhook: &client.Webhook{
+						URL:    "https://example.com/hook",
+						Events: []client.WebhookEvent{client.WebhookGameCreated, client.WebhookGameDeleted},
+					},
+				}).
+				Return(created, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/webhooks", url.Values{
+				"url":    []string{" https://example.com/hook "},
+				"events": []string{"game.created", "game.deleted"},
+			}))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		form     url.Values
+		webhook  *client.Webhook
+		err      error
+		location string
+	}{
+		{
+			name: "Success",
+			form: url.Values{
+				"url":    []string{"https://example.com/hook"},
+				"events": []string{"game.status_changed"},
+				"active": []string{"true"},
+			},
+			webhook:  &client.Webhook{URL: "https://example.com/hook", Events: []client.WebhookEvent{client.WebhookGameStatusChanged}, Active: true},
+			location: "/webhooks",
+		},
+		{
+			name:     "Deactivate",
+			form:     url.Values{"url": []string{"https://example.com/hook"}, "events": []string{"game.status_changed"}},
+			webhook:  &client.Webhook{URL: "https://example.com/hook", Events: []client.WebhookEvent{client.WebhookGameStatusChanged}},
+			location: "/webhooks",
+		},
+		{
+			name:     "Error",
+			form:     url.Values{"url": []string{"https://example.com/hook"}},
+			webhook:  &client.Webhook{URL: "https://example.com/hook", Events: []client.WebhookEvent{}},
+			err:      errors.New("'events' should contain at least one event"),
+			location: "/webhooks",
+		},
+		{
+			name:     "No authorization",
+			form:     url.Values{"url": []string{"https://example.com/hook"}},
+			webhook:  &client.Webhook{URL: "https://example.com/hook", Events: []client.WebhookEvent{}},
+			err:      client.ErrNoAuthorization,
+			location: "/users/login",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				UpdateWebhook(gomock.AssignableToTypeOf(ctxType), &client.WebhookRequest{Token: token, WebhookID: "1", Webhook: testCase.webhook}).
+				Return(testCase.webhook, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/webhooks/1", testCase.form))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
+
+func TestWebhookDelete(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		location string
+	}{
+		{name: "Success", location: "/webhooks"},
+		{name: "Not found", err: client.ErrWebhookNotFound, location: "/webhooks"},
+		{name: "No authorization", err: client.ErrNoAuthorization, location: "/users/login"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				DeleteWebhook(gomock.AssignableToTypeOf(ctxType), token, "1").
+				Return(testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/webhooks/1/delete", url.Values{}))
+
+			assert.Redirect(t, w, testCase.location)
+		})
+	}
+}
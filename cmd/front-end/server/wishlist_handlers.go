@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/asankov/gira/pkg/client"
+)
+
+const dateLayout = "2006-01-02"
+
+func (s *Server) handleGamesUpcomingView() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		wishlistOnly := r.URL.Query().Get("wishlist") == "true"
+
+		res, err := s.Client.GetUpcomingGames(r.Context(), &client.GetUpcomingGamesRequest{Token: token, WishlistOnly: wishlistOnly})
+		if err != nil {
+			if errors.Is(err, client.ErrNoAuthorization) {
+				w.Header().Add("Location", "/users/login")
+				w.WriteHeader(http.StatusSeeOther)
+				return
+			}
+			s.Log.Errorf("Error while fetching upcoming games: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		s.render(w, r, TemplateData{
+			Upcoming:     groupUpcoming(res.Games),
+			WishlistOnly: wishlistOnly,
+		}, upcomingPage, token)
+	}
+}
+
+// groupUpcoming groups the games by month and day of their release.
+// The games are expected to be sorted by release date, as they are returned by the API.
+func groupUpcoming(games []*client.Game) []TemplateUpcomingMonth {
+	months := []TemplateUpcomingMonth{}
+	for _, game := range games {
+		if game.ReleaseDate == nil {
+			continue
+		}
+		y, m, d := game.ReleaseDate.Date()
+		month := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+		if len(months) == 0 || !months[len(months)-1].Month.Equal(month) {
+			months = append(months, TemplateUpcomingMonth{Month: month})
+		}
+		current := &months[len(months)-1]
+		if len(current.Days) == 0 || !current.Days[len(current.Days)-1].Date.Equal(day) {
+			current.Days = append(current.Days, TemplateUpcomingDay{Date: day})
+		}
+		currentDay := &current.Days[len(current.Days)-1]
+		currentDay.Games = append(currentDay.Games, TemplateGame{
+			ID:          game.ID,
+			Name:        game.Name,
+			FranchiseID: game.FranchiseID,
+			Status:      game.Status,
+			Wishlist:    game.Wishlist,
+			ReleaseDate: game.ReleaseDate,
+			CoverURL:    gameCoverURL(game),
+		})
+	}
+	return months
+}
+
+func (s *Server) handleGamesChangeWishlist() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		gameID := r.PostForm.Get("game")
+		if gameID == "" {
+			http.Error(w, "'game' is required", http.StatusBadRequest)
+			return
+		}
+
+		wishlist := r.PostForm.Get("wishlist") == "true"
+		s.updateGame(w, r, token, gameID, client.UpdateGameProgressChange{Wishlist: &wishlist})
+	}
+}
+
+func (s *Server) handleGamesChangeReleaseDate() authorizedHandler {
+	return func(w http.ResponseWriter, r *http.Request, token string) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		gameID := r.PostForm.Get("game")
+		if gameID == "" {
+			http.Error(w, "'game' is required", http.StatusBadRequest)
+			return
+		}
+
+		// an empty release date clears it
+		update := client.UpdateGameProgressChange{ClearReleaseDate: true}
+		if date := r.PostForm.Get("releaseDate"); date != "" {
+			releaseDate, err := time.Parse(dateLayout, date)
+			if err != nil {
+				http.Error(w, "'releaseDate' should be a valid date", http.StatusBadRequest)
+				return
+			}
+			update = client.UpdateGameProgressChange{ReleaseDate: &releaseDate}
+		}
+		s.updateGame(w, r, token, gameID, update)
+	}
+}
+
+func (s *Server) updateGame(w http.ResponseWriter, r *http.Request, token, gameID string, update client.UpdateGameProgressChange) {
+	if _, err := s.Client.UpdateGameProgress(r.Context(), &client.UpdateGameProgressRequest{
+		GameID: gameID,
+		Token:  token,
+		Update: update,
+	}); err != nil {
+		if errors.Is(err, client.ErrNoAuthorization) {
+			w.Header().Add("Location", "/users/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		s.Log.Errorf("Error while updating game %s: %v", gameID, err)
+		s.Session.Put(r, "error", "Could not update the game.")
+	}
+
+	w.Header().Add("Location", "/games")
+	w.WriteHeader(http.StatusSeeOther)
+}
@@ -0,0 +1,267 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/cmd/front-end/server"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/golang/mock/gomock"
+)
+
+func newFormRequest(t *testing.T, method, path string, form url.Values) *http.Request {
+	var r *http.Request
+	if form != nil {
+		r = httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		r = httptest.NewRequest(method, path, nil)
+	}
+	addCSRFToken(t, r)
+	r.AddCookie(&http.Cookie{
+		Name:  "token",
+		Value: token,
+	})
+	return r
+}
+
+func TestGamesUpcomingView(t *testing.T) {
+	october20 := time.Date(2023, 10, 20, 0, 0, 0, 0, time.UTC)
+	october27 := time.Date(2023, 10, 27, 0, 0, 0, 0, time.UTC)
+	november17 := time.Date(2023, 11, 17, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		path         string
+		wishlistOnly bool
+	}{
+		{name: "All games", path: "/games/upcoming"},
+		{name: "Wishlist only", path: "/games/upcoming?wishlist=true", wishlistOnly: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			renderer := fixtures.NewRendererMock(ctrl)
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, renderer)
+
+			expectGetUser(apiClient)
+			apiClient.EXPECT().
+				GetUpcomingGames(gomock.AssignableToTypeOf(ctxType), &client.GetUpcomingGamesRequest{Token: token, WishlistOnly: testCase.wishlistOnly}).
+				Return(&client.GetGamesResponse{Games: []*client.Game{
+					{ID: "1", Name: "Spider-Man 2", Wishlist: true, ReleaseDate: &october20},
+					{ID: "3", Name: "Super Mario Bros. Wonder", ReleaseDate: &october20},
+					{ID: "2", Name: "Alan Wake 2", ReleaseDate: &october27},
+					{ID: "4", Name: "Persona 5 Tactica", Wishlist: true, ReleaseDate: &november17, CoverURL: "https://media.example.com/4.jpg"},
+				}}, nil)
+			renderer.EXPECT().
+				Render(gomock.Any(), gomock.Any(), templateData(server.TemplateData{
+					User: user,
+					Upcoming: []server.TemplateUpcomingMonth{
+						{
+							Month: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+							Days: []server.TemplateUpcomingDay{
+								{Date: october20, Games: []server.TemplateGame{
+									{ID: "1", Name: "Spider-Man 2", Wishlist: true, ReleaseDate: &october20},
+									{ID: "3", Name: "Super Mario Bros. Wonder", ReleaseDate: &october20},
+								}},
+								{Date: october27, Games: []server.TemplateGame{
+									{ID: "2", Name: "Alan Wake 2", ReleaseDate: &october27},
+								}},
+							},
+						},
+						{
+							Month: time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC),
+							Days: []server.TemplateUpcomingDay{
+								{Date: november17, Games: []server.TemplateGame{
+									{ID: "4", Name: "Persona 5 Tactica", Wishlist: true, ReleaseDate: &november17, CoverURL: "https://media.example.com/4.jpg"},
+								}},
+							},
+						},
+					},
+					WishlistOnly: testCase.wishlistOnly,
+					CSRFToken:    csrfToken,
+				}), "upcoming.page.tmpl").
+				Return(nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, testCase.path, nil))
+
+			assert.StatusOK(t, w)
+		})
+	}
+}
+
+func TestGamesUpcomingViewClientError(t *testing.T) {
+	testCases := []struct {
+		name  string
+		err   error
+		check func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "No authorization",
+			err:  client.ErrNoAuthorization,
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Redirect(t, w, "/users/login")
+			},
+		},
+		{
+			name: "Other error",
+			err:  errors.New("intentional error"),
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.StatusCode(t, w, http.StatusInternalServerError)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				GetUpcomingGames(gomock.AssignableToTypeOf(ctxType), &client.GetUpcomingGamesRequest{Token: token}).
+				Return(nil, testCase.err)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodGet, "/games/upcoming", nil))
+
+			testCase.check(t, w)
+		})
+	}
+}
+
+func TestGamesChangeWishlist(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		wishlist bool
+	}{
+		{name: "Add", value: "true", wishlist: true},
+		{name: "Remove", value: "false", wishlist: false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			wishlist := testCase.wishlist
+			apiClient.EXPECT().
+				UpdateGameProgress(gomock.AssignableToTypeOf(ctxType), &client.UpdateGameProgressRequest{
+					GameID: game.ID,
+					Token:  token,
+					Update: client.UpdateGameProgressChange{Wishlist: &wishlist},
+				}).
+				Return(&client.UpdateGameProgressResponse{}, nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/wishlist", url.Values{
+				"game":     {game.ID},
+				"wishlist": {testCase.value},
+			}))
+
+			assert.Redirect(t, w, "/games")
+		})
+	}
+}
+
+func TestGamesChangeReleaseDate(t *testing.T) {
+	releaseDate := time.Date(2023, 10, 20, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		releaseDate    string
+		expectedUpdate client.UpdateGameProgressChange
+	}{
+		{name: "Set", releaseDate: "2023-10-20", expectedUpdate: client.UpdateGameProgressChange{ReleaseDate: &releaseDate}},
+		{name: "Clear", releaseDate: "", expectedUpdate: client.UpdateGameProgressChange{ClearReleaseDate: true}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiClient := fixtures.NewAPIClientMock(ctrl)
+			srv := newServer(apiClient, nil)
+
+			apiClient.EXPECT().
+				UpdateGameProgress(gomock.AssignableToTypeOf(ctxType), &client.UpdateGameProgressRequest{
+					GameID: game.ID,
+					Token:  token,
+					Update: testCase.expectedUpdate,
+				}).
+				Return(&client.UpdateGameProgressResponse{}, nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/release-date", url.Values{
+				"game":        {game.ID},
+				"releaseDate": {testCase.releaseDate},
+			}))
+
+			assert.Redirect(t, w, "/games")
+		})
+	}
+}
+
+func TestGamesChangeReleaseDatePostError(t *testing.T) {
+	testCases := []struct {
+		name string
+		form url.Values
+	}{
+		{name: "No game", form: url.Values{"releaseDate": {"2023-10-20"}}},
+		{name: "Invalid date", form: url.Values{"game": {game.ID}, "releaseDate": {"20.10.2023"}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv := newServer(nil, nil)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/release-date", testCase.form))
+
+			assert.StatusCode(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestGamesCreateWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiClient := fixtures.NewAPIClientMock(ctrl)
+	srv := newServer(apiClient, nil)
+
+	releaseDate := time.Date(2023, 10, 20, 0, 0, 0, 0, time.UTC)
+	apiClient.EXPECT().
+		CreateGame(gomock.AssignableToTypeOf(ctxType), &client.CreateGameRequest{
+			Token: token,
+			Game: &client.Game{
+				Name:        game.Name,
+				ReleaseDate: &releaseDate,
+				Wishlist:    true,
+			},
+		}).
+		Return(&client.CreateGameResponse{Game: game}, nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, newFormRequest(t, http.MethodPost, "/games/new", url.Values{
+		"name":        {game.Name},
+		"releaseDate": {"2023-10-20"},
+		"wishlist":    {"true"},
+	}))
+
+	assert.Redirect(t, w, "/games")
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// ThrottlerOptions is the struct used to construct a Throttler
+type ThrottlerOptions struct {
+	// AccountAttempts is the number of failed attempts for a single account, before it is locked out.
+	AccountAttempts int
+	// ClientAttempts is the number of failed attempts from a single client IP, before it is locked out.
+	// It should be higher than AccountAttempts, since many users can share the same IP.
+	ClientAttempts int
+	// Lockout is the duration of the first lockout.
+	// Every subsequent failed attempt doubles it, up to MaxLockout.
+	Lockout    time.Duration
+	MaxLockout time.Duration
+	// ResetAfter is the duration after the last failed attempt, after which the failed attempts are forgotten.
+	ResetAfter time.Duration
+}
+
+// Throttler tracks the failed login attempts per account and per client IP
+// and locks them out with an exponential backoff.
+// It is safe for concurrent use.
+type Throttler struct {
+	opts *ThrottlerOptions
+	now  func() time.Time
+
+	mu        sync.Mutex
+	accounts  map[string]*attempts
+	clients   map[string]*attempts
+	lastPrune time.Time
+}
+
+type attempts struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// NewThrottler returns a new Throttler with the given options.
+func NewThrottler(opts *ThrottlerOptions) *Throttler {
+	return &Throttler{
+		opts:     opts,
+		now:      time.Now,
+		accounts: map[string]*attempts{},
+		clients:  map[string]*attempts{},
+	}
+}
+
+// Check returns for how long the account with the given email
+// or the client with the given IP are still locked out.
+// A zero duration means that the login attempt is allowed.
+func (t *Throttler) Check(email, ip string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	wait := t.remaining(t.accounts, normalizeEmail(email), now)
+	if clientWait := t.remaining(t.clients, ip, now); clientWait > wait {
+		wait = clientWait
+	}
+	return wait
+}
+
+// Fail records a failed login attempt for the account with the given email from the client with the given IP.
+// It returns for how long the login attempts are locked out as a result,
+// or a zero duration if they are not.
+func (t *Throttler) Fail(email, ip string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.prune(now)
+
+	wait := t.fail(t.accounts, normalizeEmail(email), t.opts.AccountAttempts, now)
+	if clientWait := t.fail(t.clients, ip, t.opts.ClientAttempts, now); clientWait > wait {
+		wait = clientWait
+	}
+	return wait
+}
+
+// Success forgets the failed login attempts for the account with the given email.
+// The failed attempts of the client are not forgotten, so that a client can not
+// reset them by logging in with an account of its own in between the attempts.
+func (t *Throttler) Success(email, ip string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.accounts, normalizeEmail(email))
+}
+
+func (t *Throttler) remaining(m map[string]*attempts, key string, now time.Time) time.Duration {
+	a, ok := m[key]
+	if !ok || !a.lockedUntil.After(now) {
+		return 0
+	}
+	return a.lockedUntil.Sub(now)
+}
+
+func (t *Throttler) fail(m map[string]*attempts, key string, maxAttempts int, now time.Time) time.Duration {
+	a, ok := m[key]
+	if !ok || now.Sub(a.lastFailure) > t.opts.ResetAfter {
+		a = &attempts{}
+		m[key] = a
+	}
+	a.failures++
+	a.lastFailure = now
+
+	if a.failures < maxAttempts {
+		return 0
+	}
+
+	lockout := t.opts.Lockout
+	for i := maxAttempts; i < a.failures && lockout < t.opts.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > t.opts.MaxLockout {
+		lockout = t.opts.MaxLockout
+	}
+	a.lockedUntil = now.Add(lockout)
+
+	return lockout
+}
+
+// prune removes the entries, whose failed attempts are already forgotten,
+// so that the memory does not grow unbounded.
+// It does so at most once per ResetAfter.
+func (t *Throttler) prune(now time.Time) {
+	if now.Sub(t.lastPrune) < t.opts.ResetAfter {
+		return
+	}
+	t.lastPrune = now
+
+	for _, m := range []map[string]*attempts{t.accounts, t.clients} {
+		for key, a := range m {
+			if now.Sub(a.lastFailure) > t.opts.ResetAfter && !a.lockedUntil.After(now) {
+				delete(m, key)
+			}
+		}
+	}
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	throttlerOptions = &ThrottlerOptions{
+		AccountAttempts: 3,
+		ClientAttempts:  5,
+		Lockout:         time.Minute,
+		MaxLockout:      5 * time.Minute,
+		ResetAfter:      time.Hour,
+	}
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newThrottler() (*Throttler, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	t := NewThrottler(throttlerOptions)
+	t.now = clock.Now
+	return t, clock
+}
+
+func TestThrottlerAccountLockout(t *testing.T) {
+	throttler, clock := newThrottler()
+
+	assert.Zero(t, throttler.Fail("test@mail.com", "1.1.1.1"))
+	assert.Zero(t, throttler.Fail("test@mail.com", "2.2.2.2"))
+	assert.Zero(t, throttler.Check("test@mail.com", "3.3.3.3"))
+
+	assert.Equal(t, time.Minute, throttler.Fail("TEST@mail.com", "3.3.3.3"))
+	assert.Equal(t, time.Minute, throttler.Check("test@mail.com", "4.4.4.4"))
+	assert.Zero(t, throttler.Check("other@mail.com", "4.4.4.4"))
+
+	clock.now = clock.now.Add(time.Minute)
+	assert.Zero(t, throttler.Check("test@mail.com", "4.4.4.4"))
+}
+
+func TestThrottlerExponentialBackoff(t *testing.T) {
+	throttler, clock := newThrottler()
+
+	expectedLockouts := []time.Duration{0, 0, time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for i, expected := range expectedLockouts {
+		assert.Equal(t, expected, throttler.Fail("test@mail.com", "1.1.1.1"), "attempt %d", i+1)
+		clock.now = clock.now.Add(expected)
+	}
+}
+
+func TestThrottlerClientLockout(t *testing.T) {
+	throttler, _ := newThrottler()
+
+	emails := []string{"a@mail.com", "b@mail.com", "c@mail.com", "d@mail.com"}
+	for _, email := range emails {
+		assert.Zero(t, throttler.Fail(email, "1.1.1.1"))
+	}
+
+	assert.Equal(t, time.Minute, throttler.Fail("e@mail.com", "1.1.1.1"))
+	assert.Equal(t, time.Minute, throttler.Check("f@mail.com", "1.1.1.1"))
+	assert.Zero(t, throttler.Check("f@mail.com", "2.2.2.2"))
+}
+
+func TestThrottlerSuccessResetsAccountOnly(t *testing.T) {
+	throttler, _ := newThrottler()
+
+	for i := 0; i < 4; i++ {
+		throttler.Fail("test@mail.com", "1.1.1.1")
+	}
+	throttler.Success("test@mail.com", "1.1.1.1")
+
+	assert.Zero(t, throttler.Check("test@mail.com", "2.2.2.2"))
+	assert.Equal(t, time.Minute, throttler.Fail("other@mail.com", "1.1.1.1"))
+}
+
+func TestThrottlerForgetsOldFailures(t *testing.T) {
+	throttler, clock := newThrottler()
+
+	throttler.Fail("test@mail.com", "1.1.1.1")
+	throttler.Fail("test@mail.com", "1.1.1.1")
+
+	clock.now = clock.now.Add(2 * time.Hour)
+
+	assert.Zero(t, throttler.Fail("test@mail.com", "1.1.1.1"))
+	assert.Len(t, throttler.accounts, 1)
+}
@@ -0,0 +1,35 @@
+// Package blob stores binary objects (cover art, etc.) by key,
+// either on the local filesystem or in an S3-compatible object storage (AWS S3, MinIO, etc.)
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when there is no object with the requested key
+	ErrNotFound = errors.New("object not found")
+	// ErrInvalidKey is returned when the key can not be used to store an object
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object is a stored object along with its content type.
+type Object struct {
+	Data        []byte
+	ContentType string
+}
+
+// validateKey checks that the key is a relative, slash-separated path, that does not escape its root.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// FileSystem is a store that keeps the objects as files in a directory.
+// The content type of an object is derived from the extension of its key.
+type FileSystem struct {
+	dir string
+}
+
+// NewFileSystem returns a new store, that keeps the objects in dir.
+// The directory is created when the first object is stored, if it does not exist.
+func NewFileSystem(dir string) *FileSystem {
+	return &FileSystem{dir: dir}
+}
+
+// Put stores data under key, replacing the existing object, if any.
+// The file is written to a temporary file first, so that readers never see a partially written object.
+func (s *FileSystem) Put(ctx context.Context, key, contentType string, data []byte) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("error while creating directory for %s: %w", key, err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error while creating file for %s: %w", key, err)
+	}
+	defer os.Remove(f.Name()) // nolint: errcheck
+
+	if _, err := f.Write(data); err != nil {
+		f.Close() // nolint: errcheck
+		return fmt.Errorf("error while writing %s: %w", key, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error while writing %s: %w", key, err)
+	}
+	if err := os.Rename(f.Name(), p); err != nil {
+		return fmt.Errorf("error while writing %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get returns the object stored under key, or ErrNotFound.
+func (s *FileSystem) Get(ctx context.Context, key string) (*Object, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error while reading %s: %w", key, err)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &Object{Data: data, ContentType: contentType}, nil
+}
+
+// Delete deletes the object stored under key. Deleting a missing object is not an error.
+func (s *FileSystem) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error while deleting %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *FileSystem) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,41 @@
+package blob_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/asankov/gira/internal/blob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystem(t *testing.T) {
+	ctx := context.Background()
+	store := blob.NewFileSystem(t.TempDir())
+
+	_, err := store.Get(ctx, "covers/games/1/full.jpg")
+	require.ErrorIs(t, err, blob.ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, "covers/games/1/full.jpg", "image/jpeg", []byte("first")))
+	require.NoError(t, store.Put(ctx, "covers/games/1/full.jpg", "image/jpeg", []byte("second")))
+
+	object, err := store.Get(ctx, "covers/games/1/full.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, &blob.Object{Data: []byte("second"), ContentType: "image/jpeg"}, object)
+
+	require.NoError(t, store.Delete(ctx, "covers/games/1/full.jpg"))
+	require.NoError(t, store.Delete(ctx, "covers/games/1/full.jpg"))
+	_, err = store.Get(ctx, "covers/games/1/full.jpg")
+	require.ErrorIs(t, err, blob.ErrNotFound)
+}
+
+func TestFileSystemInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store := blob.NewFileSystem(t.TempDir())
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "covers/../../secret", "covers//1", `covers\1`} {
+		err := store.Put(ctx, key, "image/jpeg", []byte("data"))
+		assert.True(t, errors.Is(err, blob.ErrInvalidKey), "key %q", key)
+	}
+}
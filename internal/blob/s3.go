@@ -0,0 +1,147 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultS3Region is the region used to sign the requests, if none is configured.
+// MinIO and most S3-compatible storages accept it regardless of their location.
+const DefaultS3Region = "us-east-1"
+
+// S3Options is the struct used to construct an S3 store
+type S3Options struct {
+	// Endpoint is the address of the storage, e.g. https://s3.eu-west-1.amazonaws.com or http://localhost:9000 for MinIO.
+	Endpoint string
+	// Region defaults to DefaultS3Region.
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	HTTPClient *http.Client
+}
+
+// S3 is a store that keeps the objects in a bucket of an S3-compatible object storage.
+// The objects are addressed path-style ({endpoint}/{bucket}/{key}), which is supported by both AWS and MinIO.
+// The bucket must already exist.
+type S3 struct {
+	endpoint   string
+	bucket     string
+	signer     *signer
+	httpClient *http.Client
+}
+
+// NewS3 returns a new S3 store from the given options.
+func NewS3(opts *S3Options) *S3 {
+	region := opts.Region
+	if region == "" {
+		region = DefaultS3Region
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &S3{
+		endpoint: strings.TrimSuffix(opts.Endpoint, "/"),
+		bucket:   opts.Bucket,
+		signer: &signer{
+			accessKey: opts.AccessKey,
+			secretKey: opts.SecretKey,
+			region:    region,
+			service:   "s3",
+		},
+		httpClient: httpClient,
+	}
+}
+
+// Put stores data under key, replacing the existing object, if any.
+func (s *S3) Put(ctx context.Context, key, contentType string, data []byte) error {
+	res, err := s.do(ctx, http.MethodPut, key, contentType, data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error while storing %s: %w", key, responseError(res))
+	}
+	return nil
+}
+
+// Get returns the object stored under key, or ErrNotFound.
+func (s *S3) Get(ctx context.Context, key string) (*Object, error) {
+	res, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("error while fetching %s: %w", key, responseError(res))
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading %s: %w", key, err)
+	}
+	return &Object{Data: data, ContentType: res.Header.Get("Content-Type")}, nil
+}
+
+// Delete deletes the object stored under key. Deleting a missing object is not an error.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	res, err := s.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("error while deleting %s: %w", key, responseError(res))
+	}
+	return nil
+}
+
+func (s *S3) do(ctx context.Context, method, key, contentType string, data []byte) (*http.Response, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s/%s", s.endpoint, s.bucket, key), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request: %w", err)
+	}
+	if data == nil {
+		req.Body = http.NoBody
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	payloadHash := hashHex(data)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	s.signer.sign(req, payloadHash, time.Now())
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error while calling object storage: %w", err)
+	}
+	return res, nil
+}
+
+// responseError returns an error with the status code and the beginning of the body of an unexpected response.
+// S3 returns an XML document with the code and the message of the error.
+func responseError(res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+	return fmt.Errorf("unexpected status code %d: %s", res.StatusCode, strings.TrimSpace(string(body)))
+}
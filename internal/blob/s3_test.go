@@ -0,0 +1,57 @@
+package blob_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asankov/gira/internal/blob"
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newS3(t *testing.T) (*blob.S3, *fixtures.S3Server) {
+	server := fixtures.NewS3Server(t)
+	return blob.NewS3(&blob.S3Options{
+		Endpoint:  server.URL(),
+		Bucket:    server.Bucket,
+		AccessKey: server.AccessKey,
+		SecretKey: "minio-secret-key",
+	}), server
+}
+
+func TestS3(t *testing.T) {
+	ctx := context.Background()
+	store, server := newS3(t)
+
+	_, err := store.Get(ctx, "covers/games/1/full.jpg")
+	require.ErrorIs(t, err, blob.ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, "covers/games/1/full.jpg", "image/jpeg", []byte("cover")))
+	data, ok := server.Object("covers/games/1/full.jpg")
+	require.True(t, ok)
+	assert.Equal(t, []byte("cover"), data)
+
+	object, err := store.Get(ctx, "covers/games/1/full.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, &blob.Object{Data: []byte("cover"), ContentType: "image/jpeg"}, object)
+
+	require.NoError(t, store.Delete(ctx, "covers/games/1/full.jpg"))
+	_, ok = server.Object("covers/games/1/full.jpg")
+	assert.False(t, ok)
+}
+
+func TestS3Error(t *testing.T) {
+	server := fixtures.NewS3Server(t)
+	store := blob.NewS3(&blob.S3Options{
+		Endpoint:  server.URL(),
+		Bucket:    server.Bucket,
+		AccessKey: "wrong-access-key",
+		SecretKey: "minio-secret-key",
+	})
+
+	err := store.Put(context.Background(), "covers/games/1/full.jpg", "image/jpeg", []byte("cover"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.Contains(t, err.Error(), "SignatureDoesNotMatch")
+}
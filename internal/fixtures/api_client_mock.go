@@ -6,6 +6,7 @@ package fixtures
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	client "github.com/asankov/gira/pkg/client"
@@ -35,6 +36,66 @@ func (m *APIClientMock) EXPECT() *APIClientMockMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *APIClientMock) AcceptInvitation(arg0 context.Context, arg1 *client.InvitationRequest) (*client.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", arg0, arg1)
+	ret0, _ := ret[0].(*client.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *APIClientMockMockRecorder) AcceptInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*APIClientMock)(nil).AcceptInvitation), arg0, arg1)
+}
+
+// AddChecklistItem mocks base method.
+func (m *APIClientMock) AddChecklistItem(arg0 context.Context, arg1 *client.AddChecklistItemRequest) (*client.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", arg0, arg1)
+	ret0, _ := ret[0].(*client.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *APIClientMockMockRecorder) AddChecklistItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*APIClientMock)(nil).AddChecklistItem), arg0, arg1)
+}
+
+// ChangeBoardMemberRole mocks base method.
+func (m *APIClientMock) ChangeBoardMemberRole(arg0 context.Context, arg1 *client.BoardMemberRequest) (*client.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeBoardMemberRole", arg0, arg1)
+	ret0, _ := ret[0].(*client.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeBoardMemberRole indicates an expected call of ChangeBoardMemberRole.
+func (mr *APIClientMockMockRecorder) ChangeBoardMemberRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeBoardMemberRole", reflect.TypeOf((*APIClientMock)(nil).ChangeBoardMemberRole), arg0, arg1)
+}
+
+// CreateBoard mocks base method.
+func (m *APIClientMock) CreateBoard(arg0 context.Context, arg1 *client.CreateBoardRequest) (*client.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoard", arg0, arg1)
+	ret0, _ := ret[0].(*client.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoard indicates an expected call of CreateBoard.
+func (mr *APIClientMockMockRecorder) CreateBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoard", reflect.TypeOf((*APIClientMock)(nil).CreateBoard), arg0, arg1)
+}
+
 // CreateFranchise mocks base method.
 func (m *APIClientMock) CreateFranchise(arg0 context.Context, arg1 *client.CreateFranchiseRequest) (*client.CreateFranchiseResponse, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +126,36 @@ func (mr *APIClientMockMockRecorder) CreateGame(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGame", reflect.TypeOf((*APIClientMock)(nil).CreateGame), arg0, arg1)
 }
 
+// CreateGoal mocks base method.
+func (m *APIClientMock) CreateGoal(arg0 context.Context, arg1 *client.CreateGoalRequest) (*client.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoal", arg0, arg1)
+	ret0, _ := ret[0].(*client.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoal indicates an expected call of CreateGoal.
+func (mr *APIClientMockMockRecorder) CreateGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*APIClientMock)(nil).CreateGoal), arg0, arg1)
+}
+
+// CreatePlaythrough mocks base method.
+func (m *APIClientMock) CreatePlaythrough(arg0 context.Context, arg1 *client.CreatePlaythroughRequest) (*client.Playthrough, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaythrough", arg0, arg1)
+	ret0, _ := ret[0].(*client.Playthrough)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlaythrough indicates an expected call of CreatePlaythrough.
+func (mr *APIClientMockMockRecorder) CreatePlaythrough(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaythrough", reflect.TypeOf((*APIClientMock)(nil).CreatePlaythrough), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *APIClientMock) CreateUser(arg0 context.Context, arg1 *client.CreateUserRequest) (*client.CreateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +171,106 @@ func (mr *APIClientMockMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*APIClientMock)(nil).CreateUser), arg0, arg1)
 }
 
+// CreateWebhook mocks base method.
+func (m *APIClientMock) CreateWebhook(arg0 context.Context, arg1 *client.WebhookRequest) (*client.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(*client.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *APIClientMockMockRecorder) CreateWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*APIClientMock)(nil).CreateWebhook), arg0, arg1)
+}
+
+// DeclineInvitation mocks base method.
+func (m *APIClientMock) DeclineInvitation(arg0 context.Context, arg1 *client.InvitationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *APIClientMockMockRecorder) DeclineInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*APIClientMock)(nil).DeclineInvitation), arg0, arg1)
+}
+
+// DeleteBoard mocks base method.
+func (m *APIClientMock) DeleteBoard(arg0 context.Context, arg1 *client.BoardRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoard", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoard indicates an expected call of DeleteBoard.
+func (mr *APIClientMockMockRecorder) DeleteBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*APIClientMock)(nil).DeleteBoard), arg0, arg1)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *APIClientMock) DeleteChecklistItem(arg0 context.Context, arg1 *client.DeleteChecklistItemRequest) (*client.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", arg0, arg1)
+	ret0, _ := ret[0].(*client.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *APIClientMockMockRecorder) DeleteChecklistItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*APIClientMock)(nil).DeleteChecklistItem), arg0, arg1)
+}
+
+// DeleteCover mocks base method.
+func (m *APIClientMock) DeleteCover(arg0 context.Context, arg1 *client.DeleteCoverRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCover", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCover indicates an expected call of DeleteCover.
+func (mr *APIClientMockMockRecorder) DeleteCover(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCover", reflect.TypeOf((*APIClientMock)(nil).DeleteCover), arg0, arg1)
+}
+
+// DeleteGoal mocks base method.
+func (m *APIClientMock) DeleteGoal(arg0 context.Context, arg1 *client.GoalRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoal", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *APIClientMockMockRecorder) DeleteGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*APIClientMock)(nil).DeleteGoal), arg0, arg1)
+}
+
+// DeletePlaythrough mocks base method.
+func (m *APIClientMock) DeletePlaythrough(arg0 context.Context, arg1 *client.DeletePlaythroughRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaythrough", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaythrough indicates an expected call of DeletePlaythrough.
+func (mr *APIClientMockMockRecorder) DeletePlaythrough(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaythrough", reflect.TypeOf((*APIClientMock)(nil).DeletePlaythrough), arg0, arg1)
+}
+
 // DeleteUserGame mocks base method.
 func (m *APIClientMock) DeleteUserGame(arg0 context.Context, arg1 *client.DeleteUserGameRequest) error {
 	m.ctrl.T.Helper()
@@ -94,6 +285,185 @@ func (mr *APIClientMockMockRecorder) DeleteUserGame(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserGame", reflect.TypeOf((*APIClientMock)(nil).DeleteUserGame), arg0, arg1)
 }
 
+// DeleteWebhook mocks base method.
+func (m *APIClientMock) DeleteWebhook(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *APIClientMockMockRecorder) DeleteWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*APIClientMock)(nil).DeleteWebhook), arg0, arg1, arg2)
+}
+
+// ExportUserData mocks base method.
+func (m *APIClientMock) ExportUserData(arg0 context.Context, arg1 *client.ExportUserDataRequest) (*client.ExportUserDataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserData", arg0, arg1)
+	ret0, _ := ret[0].(*client.ExportUserDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUserData indicates an expected call of ExportUserData.
+func (mr *APIClientMockMockRecorder) ExportUserData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserData", reflect.TypeOf((*APIClientMock)(nil).ExportUserData), arg0, arg1)
+}
+
+// ExportYearReport mocks base method.
+func (m *APIClientMock) ExportYearReport(arg0 context.Context, arg1 *client.ExportYearReportRequest) (*client.ExportYearReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportYearReport", arg0, arg1)
+	ret0, _ := ret[0].(*client.ExportYearReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportYearReport indicates an expected call of ExportYearReport.
+func (mr *APIClientMockMockRecorder) ExportYearReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportYearReport", reflect.TypeOf((*APIClientMock)(nil).ExportYearReport), arg0, arg1)
+}
+
+// Follow mocks base method.
+func (m *APIClientMock) Follow(arg0 context.Context, arg1 *client.FollowRequest) (*client.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", arg0, arg1)
+	ret0, _ := ret[0].(*client.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *APIClientMockMockRecorder) Follow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*APIClientMock)(nil).Follow), arg0, arg1)
+}
+
+// GetBoard mocks base method.
+func (m *APIClientMock) GetBoard(arg0 context.Context, arg1 *client.BoardRequest) (*client.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", arg0, arg1)
+	ret0, _ := ret[0].(*client.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *APIClientMockMockRecorder) GetBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*APIClientMock)(nil).GetBoard), arg0, arg1)
+}
+
+// GetBoardGames mocks base method.
+func (m *APIClientMock) GetBoardGames(arg0 context.Context, arg1 *client.BoardRequest) (*client.GetGamesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardGames", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetGamesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardGames indicates an expected call of GetBoardGames.
+func (mr *APIClientMockMockRecorder) GetBoardGames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardGames", reflect.TypeOf((*APIClientMock)(nil).GetBoardGames), arg0, arg1)
+}
+
+// GetBoards mocks base method.
+func (m *APIClientMock) GetBoards(arg0 context.Context, arg1 *client.GetBoardsRequest) (*client.GetBoardsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoards", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetBoardsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoards indicates an expected call of GetBoards.
+func (mr *APIClientMockMockRecorder) GetBoards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoards", reflect.TypeOf((*APIClientMock)(nil).GetBoards), arg0, arg1)
+}
+
+// GetChecklist mocks base method.
+func (m *APIClientMock) GetChecklist(arg0 context.Context, arg1 *client.GetChecklistRequest) (*client.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklist", arg0, arg1)
+	ret0, _ := ret[0].(*client.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecklist indicates an expected call of GetChecklist.
+func (mr *APIClientMockMockRecorder) GetChecklist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklist", reflect.TypeOf((*APIClientMock)(nil).GetChecklist), arg0, arg1)
+}
+
+// GetCover mocks base method.
+func (m *APIClientMock) GetCover(arg0 context.Context, arg1 *client.GetCoverRequest) (*client.GetCoverResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCover", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetCoverResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCover indicates an expected call of GetCover.
+func (mr *APIClientMockMockRecorder) GetCover(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCover", reflect.TypeOf((*APIClientMock)(nil).GetCover), arg0, arg1)
+}
+
+// GetFeed mocks base method.
+func (m *APIClientMock) GetFeed(arg0 context.Context, arg1 *client.GetFeedRequest) (*client.GetFeedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetFeedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *APIClientMockMockRecorder) GetFeed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*APIClientMock)(nil).GetFeed), arg0, arg1)
+}
+
+// GetFollowing mocks base method.
+func (m *APIClientMock) GetFollowing(arg0 context.Context, arg1 *client.GetFollowingRequest) (*client.GetFollowingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetFollowingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *APIClientMockMockRecorder) GetFollowing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*APIClientMock)(nil).GetFollowing), arg0, arg1)
+}
+
+// GetFranchise mocks base method.
+func (m *APIClientMock) GetFranchise(arg0 context.Context, arg1 *client.GetFranchiseRequest) (*client.Franchise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFranchise", arg0, arg1)
+	ret0, _ := ret[0].(*client.Franchise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFranchise indicates an expected call of GetFranchise.
+func (mr *APIClientMockMockRecorder) GetFranchise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFranchise", reflect.TypeOf((*APIClientMock)(nil).GetFranchise), arg0, arg1)
+}
+
 // GetFranchises mocks base method.
 func (m *APIClientMock) GetFranchises(arg0 context.Context, arg1 *client.GetFranchisesRequest) (*client.GetFranchisesResponse, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +479,21 @@ func (mr *APIClientMockMockRecorder) GetFranchises(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFranchises", reflect.TypeOf((*APIClientMock)(nil).GetFranchises), arg0, arg1)
 }
 
+// GetGameMetadata mocks base method.
+func (m *APIClientMock) GetGameMetadata(arg0 context.Context, arg1 *client.GetGameMetadataRequest) (*client.GameMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGameMetadata", arg0, arg1)
+	ret0, _ := ret[0].(*client.GameMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGameMetadata indicates an expected call of GetGameMetadata.
+func (mr *APIClientMockMockRecorder) GetGameMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGameMetadata", reflect.TypeOf((*APIClientMock)(nil).GetGameMetadata), arg0, arg1)
+}
+
 // GetGames mocks base method.
 func (m *APIClientMock) GetGames(arg0 context.Context, arg1 *client.GetGamesRequest) (*client.GetGamesResponse, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +509,141 @@ func (mr *APIClientMockMockRecorder) GetGames(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGames", reflect.TypeOf((*APIClientMock)(nil).GetGames), arg0, arg1)
 }
 
+// GetGoals mocks base method.
+func (m *APIClientMock) GetGoals(arg0 context.Context, arg1 *client.GetGoalsRequest) (*client.GetGoalsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoals", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetGoalsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoals indicates an expected call of GetGoals.
+func (mr *APIClientMockMockRecorder) GetGoals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoals", reflect.TypeOf((*APIClientMock)(nil).GetGoals), arg0, arg1)
+}
+
+// GetInvitations mocks base method.
+func (m *APIClientMock) GetInvitations(arg0 context.Context, arg1 *client.GetInvitationsRequest) (*client.GetInvitationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetInvitationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *APIClientMockMockRecorder) GetInvitations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*APIClientMock)(nil).GetInvitations), arg0, arg1)
+}
+
+// GetNextGames mocks base method.
+func (m *APIClientMock) GetNextGames(arg0 context.Context, arg1 *client.GetNextGamesRequest) (*client.GetNextGamesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextGames", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetNextGamesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextGames indicates an expected call of GetNextGames.
+func (mr *APIClientMockMockRecorder) GetNextGames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextGames", reflect.TypeOf((*APIClientMock)(nil).GetNextGames), arg0, arg1)
+}
+
+// GetNotificationPreferences mocks base method.
+func (m *APIClientMock) GetNotificationPreferences(arg0 context.Context, arg1 string) (*client.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferences", arg0, arg1)
+	ret0, _ := ret[0].(*client.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferences indicates an expected call of GetNotificationPreferences.
+func (mr *APIClientMockMockRecorder) GetNotificationPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferences", reflect.TypeOf((*APIClientMock)(nil).GetNotificationPreferences), arg0, arg1)
+}
+
+// GetNotifications mocks base method.
+func (m *APIClientMock) GetNotifications(arg0 context.Context, arg1 *client.GetNotificationsRequest) (*client.GetNotificationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetNotificationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *APIClientMockMockRecorder) GetNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*APIClientMock)(nil).GetNotifications), arg0, arg1)
+}
+
+// GetPlaythroughs mocks base method.
+func (m *APIClientMock) GetPlaythroughs(arg0 context.Context, arg1 *client.GetPlaythroughsRequest) (*client.GetPlaythroughsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaythroughs", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetPlaythroughsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaythroughs indicates an expected call of GetPlaythroughs.
+func (mr *APIClientMockMockRecorder) GetPlaythroughs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaythroughs", reflect.TypeOf((*APIClientMock)(nil).GetPlaythroughs), arg0, arg1)
+}
+
+// GetProfile mocks base method.
+func (m *APIClientMock) GetProfile(arg0 context.Context, arg1 *client.GetProfileRequest) (*client.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", arg0, arg1)
+	ret0, _ := ret[0].(*client.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *APIClientMockMockRecorder) GetProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*APIClientMock)(nil).GetProfile), arg0, arg1)
+}
+
+// GetProfileGames mocks base method.
+func (m *APIClientMock) GetProfileGames(arg0 context.Context, arg1 *client.GetProfileGamesRequest) (*client.GetGamesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileGames", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetGamesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileGames indicates an expected call of GetProfileGames.
+func (mr *APIClientMockMockRecorder) GetProfileGames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileGames", reflect.TypeOf((*APIClientMock)(nil).GetProfileGames), arg0, arg1)
+}
+
+// GetStats mocks base method.
+func (m *APIClientMock) GetStats(arg0 context.Context, arg1 *client.GetStatsRequest) (*client.GetStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *APIClientMockMockRecorder) GetStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*APIClientMock)(nil).GetStats), arg0, arg1)
+}
+
 // GetStatuses mocks base method.
 func (m *APIClientMock) GetStatuses(arg0 context.Context, arg1 *client.GetStatusesRequest) (*client.GetStatusesResponse, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +659,21 @@ func (mr *APIClientMockMockRecorder) GetStatuses(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatuses", reflect.TypeOf((*APIClientMock)(nil).GetStatuses), arg0, arg1)
 }
 
+// GetUpcomingGames mocks base method.
+func (m *APIClientMock) GetUpcomingGames(arg0 context.Context, arg1 *client.GetUpcomingGamesRequest) (*client.GetGamesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingGames", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetGamesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcomingGames indicates an expected call of GetUpcomingGames.
+func (mr *APIClientMockMockRecorder) GetUpcomingGames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingGames", reflect.TypeOf((*APIClientMock)(nil).GetUpcomingGames), arg0, arg1)
+}
+
 // GetUser mocks base method.
 func (m *APIClientMock) GetUser(arg0 context.Context, arg1 *client.GetUserRequest) (*client.GetUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +689,81 @@ func (mr *APIClientMockMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*APIClientMock)(nil).GetUser), arg0, arg1)
 }
 
+// GetWebhookDeliveries mocks base method.
+func (m *APIClientMock) GetWebhookDeliveries(arg0 context.Context, arg1 *client.GetWebhookDeliveriesRequest) (*client.GetWebhookDeliveriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetWebhookDeliveriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *APIClientMockMockRecorder) GetWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*APIClientMock)(nil).GetWebhookDeliveries), arg0, arg1)
+}
+
+// GetWebhooks mocks base method.
+func (m *APIClientMock) GetWebhooks(arg0 context.Context, arg1 string) (*client.GetWebhooksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetWebhooksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *APIClientMockMockRecorder) GetWebhooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*APIClientMock)(nil).GetWebhooks), arg0, arg1)
+}
+
+// GetYearReport mocks base method.
+func (m *APIClientMock) GetYearReport(arg0 context.Context, arg1 *client.GetYearReportRequest) (*client.GetYearReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYearReport", arg0, arg1)
+	ret0, _ := ret[0].(*client.GetYearReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYearReport indicates an expected call of GetYearReport.
+func (mr *APIClientMockMockRecorder) GetYearReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYearReport", reflect.TypeOf((*APIClientMock)(nil).GetYearReport), arg0, arg1)
+}
+
+// ImportGames mocks base method.
+func (m *APIClientMock) ImportGames(arg0 context.Context, arg1 *client.ImportGamesRequest) (*client.ImportGamesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportGames", arg0, arg1)
+	ret0, _ := ret[0].(*client.ImportGamesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportGames indicates an expected call of ImportGames.
+func (mr *APIClientMockMockRecorder) ImportGames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportGames", reflect.TypeOf((*APIClientMock)(nil).ImportGames), arg0, arg1)
+}
+
+// InviteToBoard mocks base method.
+func (m *APIClientMock) InviteToBoard(arg0 context.Context, arg1 *client.InviteToBoardRequest) (*client.BoardInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteToBoard", arg0, arg1)
+	ret0, _ := ret[0].(*client.BoardInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteToBoard indicates an expected call of InviteToBoard.
+func (mr *APIClientMockMockRecorder) InviteToBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteToBoard", reflect.TypeOf((*APIClientMock)(nil).InviteToBoard), arg0, arg1)
+}
+
 // LoginUser mocks base method.
 func (m *APIClientMock) LoginUser(arg0 context.Context, arg1 *client.LoginUserRequest) (*client.UserLoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +779,21 @@ func (mr *APIClientMockMockRecorder) LoginUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*APIClientMock)(nil).LoginUser), arg0, arg1)
 }
 
+// LoginUserOIDC mocks base method.
+func (m *APIClientMock) LoginUserOIDC(arg0 context.Context, arg1 *client.LoginUserOIDCRequest) (*client.UserLoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUserOIDC", arg0, arg1)
+	ret0, _ := ret[0].(*client.UserLoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUserOIDC indicates an expected call of LoginUserOIDC.
+func (mr *APIClientMockMockRecorder) LoginUserOIDC(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUserOIDC", reflect.TypeOf((*APIClientMock)(nil).LoginUserOIDC), arg0, arg1)
+}
+
 // LogoutUser mocks base method.
 func (m *APIClientMock) LogoutUser(arg0 context.Context, arg1 *client.LogoutUserRequest) error {
 	m.ctrl.T.Helper()
@@ -183,16 +808,253 @@ func (mr *APIClientMockMockRecorder) LogoutUser(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutUser", reflect.TypeOf((*APIClientMock)(nil).LogoutUser), arg0, arg1)
 }
 
-// UpdateGameProgress mocks base method.
-func (m *APIClientMock) UpdateGameProgress(arg0 context.Context, arg1 *client.UpdateGameProgressRequest) error {
+// MarkAllNotificationsRead mocks base method.
+func (m *APIClientMock) MarkAllNotificationsRead(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGameProgress", arg0, arg1)
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *APIClientMockMockRecorder) MarkAllNotificationsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*APIClientMock)(nil).MarkAllNotificationsRead), arg0, arg1)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *APIClientMock) MarkNotificationRead(arg0 context.Context, arg1 *client.NotificationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *APIClientMockMockRecorder) MarkNotificationRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*APIClientMock)(nil).MarkNotificationRead), arg0, arg1)
+}
+
+// PreviewLibraryImport mocks base method.
+func (m *APIClientMock) PreviewLibraryImport(arg0 context.Context, arg1 *client.PreviewLibraryImportRequest) (*client.PreviewLibraryImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewLibraryImport", arg0, arg1)
+	ret0, _ := ret[0].(*client.PreviewLibraryImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewLibraryImport indicates an expected call of PreviewLibraryImport.
+func (mr *APIClientMockMockRecorder) PreviewLibraryImport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewLibraryImport", reflect.TypeOf((*APIClientMock)(nil).PreviewLibraryImport), arg0, arg1)
+}
+
+// RemoveBoardMember mocks base method.
+func (m *APIClientMock) RemoveBoardMember(arg0 context.Context, arg1 *client.BoardMemberRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBoardMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBoardMember indicates an expected call of RemoveBoardMember.
+func (mr *APIClientMockMockRecorder) RemoveBoardMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBoardMember", reflect.TypeOf((*APIClientMock)(nil).RemoveBoardMember), arg0, arg1)
+}
+
+// ReorderFranchise mocks base method.
+func (m *APIClientMock) ReorderFranchise(arg0 context.Context, arg1 *client.ReorderFranchiseRequest) (*client.Franchise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderFranchise", arg0, arg1)
+	ret0, _ := ret[0].(*client.Franchise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderFranchise indicates an expected call of ReorderFranchise.
+func (mr *APIClientMockMockRecorder) ReorderFranchise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderFranchise", reflect.TypeOf((*APIClientMock)(nil).ReorderFranchise), arg0, arg1)
+}
+
+// SearchGameMetadata mocks base method.
+func (m *APIClientMock) SearchGameMetadata(arg0 context.Context, arg1 *client.SearchGameMetadataRequest) (*client.SearchGameMetadataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchGameMetadata", arg0, arg1)
+	ret0, _ := ret[0].(*client.SearchGameMetadataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchGameMetadata indicates an expected call of SearchGameMetadata.
+func (mr *APIClientMockMockRecorder) SearchGameMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchGameMetadata", reflect.TypeOf((*APIClientMock)(nil).SearchGameMetadata), arg0, arg1)
+}
+
+// SetChecklistAutoProgress mocks base method.
+func (m *APIClientMock) SetChecklistAutoProgress(arg0 context.Context, arg1 *client.SetChecklistAutoProgressRequest) (*client.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChecklistAutoProgress", arg0, arg1)
+	ret0, _ := ret[0].(*client.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChecklistAutoProgress indicates an expected call of SetChecklistAutoProgress.
+func (mr *APIClientMockMockRecorder) SetChecklistAutoProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChecklistAutoProgress", reflect.TypeOf((*APIClientMock)(nil).SetChecklistAutoProgress), arg0, arg1)
+}
+
+// SetFranchiseParent mocks base method.
+func (m *APIClientMock) SetFranchiseParent(arg0 context.Context, arg1 *client.SetFranchiseParentRequest) (*client.Franchise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFranchiseParent", arg0, arg1)
+	ret0, _ := ret[0].(*client.Franchise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetFranchiseParent indicates an expected call of SetFranchiseParent.
+func (mr *APIClientMockMockRecorder) SetFranchiseParent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFranchiseParent", reflect.TypeOf((*APIClientMock)(nil).SetFranchiseParent), arg0, arg1)
+}
+
+// SetNotificationPreferences mocks base method.
+func (m *APIClientMock) SetNotificationPreferences(arg0 context.Context, arg1 *client.NotificationPreferencesRequest) (*client.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotificationPreferences", arg0, arg1)
+	ret0, _ := ret[0].(*client.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNotificationPreferences indicates an expected call of SetNotificationPreferences.
+func (mr *APIClientMockMockRecorder) SetNotificationPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotificationPreferences", reflect.TypeOf((*APIClientMock)(nil).SetNotificationPreferences), arg0, arg1)
+}
+
+// SetProfilePublic mocks base method.
+func (m *APIClientMock) SetProfilePublic(arg0 context.Context, arg1 *client.SetProfilePublicRequest) (*client.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProfilePublic", arg0, arg1)
+	ret0, _ := ret[0].(*client.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProfilePublic indicates an expected call of SetProfilePublic.
+func (mr *APIClientMockMockRecorder) SetProfilePublic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProfilePublic", reflect.TypeOf((*APIClientMock)(nil).SetProfilePublic), arg0, arg1)
+}
+
+// StreamChanges mocks base method.
+func (m *APIClientMock) StreamChanges(arg0 context.Context, arg1 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamChanges", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamChanges indicates an expected call of StreamChanges.
+func (mr *APIClientMockMockRecorder) StreamChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamChanges", reflect.TypeOf((*APIClientMock)(nil).StreamChanges), arg0, arg1)
+}
+
+// Unfollow mocks base method.
+func (m *APIClientMock) Unfollow(arg0 context.Context, arg1 *client.FollowRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Unfollow indicates an expected call of Unfollow.
+func (mr *APIClientMockMockRecorder) Unfollow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*APIClientMock)(nil).Unfollow), arg0, arg1)
+}
+
+// UpdateChecklistItem mocks base method.
+func (m *APIClientMock) UpdateChecklistItem(arg0 context.Context, arg1 *client.UpdateChecklistItemRequest) (*client.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistItem", arg0, arg1)
+	ret0, _ := ret[0].(*client.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklistItem indicates an expected call of UpdateChecklistItem.
+func (mr *APIClientMockMockRecorder) UpdateChecklistItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistItem", reflect.TypeOf((*APIClientMock)(nil).UpdateChecklistItem), arg0, arg1)
+}
+
+// UpdateGameProgress mocks base method.
+func (m *APIClientMock) UpdateGameProgress(arg0 context.Context, arg1 *client.UpdateGameProgressRequest) (*client.UpdateGameProgressResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGameProgress", arg0, arg1)
+	ret0, _ := ret[0].(*client.UpdateGameProgressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateGameProgress indicates an expected call of UpdateGameProgress.
 func (mr *APIClientMockMockRecorder) UpdateGameProgress(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGameProgress", reflect.TypeOf((*APIClientMock)(nil).UpdateGameProgress), arg0, arg1)
 }
+
+// UpdatePlaythrough mocks base method.
+func (m *APIClientMock) UpdatePlaythrough(arg0 context.Context, arg1 *client.UpdatePlaythroughRequest) (*client.Playthrough, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaythrough", arg0, arg1)
+	ret0, _ := ret[0].(*client.Playthrough)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePlaythrough indicates an expected call of UpdatePlaythrough.
+func (mr *APIClientMockMockRecorder) UpdatePlaythrough(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaythrough", reflect.TypeOf((*APIClientMock)(nil).UpdatePlaythrough), arg0, arg1)
+}
+
+// UpdateWebhook mocks base method.
+func (m *APIClientMock) UpdateWebhook(arg0 context.Context, arg1 *client.WebhookRequest) (*client.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(*client.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *APIClientMockMockRecorder) UpdateWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*APIClientMock)(nil).UpdateWebhook), arg0, arg1)
+}
+
+// UploadCover mocks base method.
+func (m *APIClientMock) UploadCover(arg0 context.Context, arg1 *client.UploadCoverRequest) (*client.Cover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadCover", arg0, arg1)
+	ret0, _ := ret[0].(*client.Cover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadCover indicates an expected call of UploadCover.
+func (mr *APIClientMockMockRecorder) UploadCover(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadCover", reflect.TypeOf((*APIClientMock)(nil).UploadCover), arg0, arg1)
+}
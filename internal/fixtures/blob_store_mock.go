@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: BlobStore)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	context "context"
+	reflect "reflect"
+
+	blob "github.com/asankov/gira/internal/blob"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// BlobStoreMock is a mock of BlobStore interface.
+type BlobStoreMock struct {
+	ctrl     *gomock.Controller
+	recorder *BlobStoreMockMockRecorder
+}
+
+// BlobStoreMockMockRecorder is the mock recorder for BlobStoreMock.
+type BlobStoreMockMockRecorder struct {
+	mock *BlobStoreMock
+}
+
+// NewBlobStoreMock creates a new mock instance.
+func NewBlobStoreMock(ctrl *gomock.Controller) *BlobStoreMock {
+	mock := &BlobStoreMock{ctrl: ctrl}
+	mock.recorder = &BlobStoreMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *BlobStoreMock) EXPECT() *BlobStoreMockMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *BlobStoreMock) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *BlobStoreMockMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*BlobStoreMock)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *BlobStoreMock) Get(arg0 context.Context, arg1 string) (*blob.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*blob.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *BlobStoreMockMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*BlobStoreMock)(nil).Get), arg0, arg1)
+}
+
+// Put mocks base method.
+func (m *BlobStoreMock) Put(arg0 context.Context, arg1, arg2 string, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *BlobStoreMockMockRecorder) Put(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*BlobStoreMock)(nil).Put), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: BoardModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// BoardModelMock is a mock of BoardModel interface.
+type BoardModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *BoardModelMockMockRecorder
+}
+
+// BoardModelMockMockRecorder is the mock recorder for BoardModelMock.
+type BoardModelMockMockRecorder struct {
+	mock *BoardModelMock
+}
+
+// NewBoardModelMock creates a new mock instance.
+func NewBoardModelMock(ctrl *gomock.Controller) *BoardModelMock {
+	mock := &BoardModelMock{ctrl: ctrl}
+	mock.recorder = &BoardModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *BoardModelMock) EXPECT() *BoardModelMockMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *BoardModelMock) AcceptInvitation(arg0, arg1 string) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", arg0, arg1)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *BoardModelMockMockRecorder) AcceptInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*BoardModelMock)(nil).AcceptInvitation), arg0, arg1)
+}
+
+// Board mocks base method.
+func (m *BoardModelMock) Board(arg0, arg1 string) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Board", arg0, arg1)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Board indicates an expected call of Board.
+func (mr *BoardModelMockMockRecorder) Board(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Board", reflect.TypeOf((*BoardModelMock)(nil).Board), arg0, arg1)
+}
+
+// Boards mocks base method.
+func (m *BoardModelMock) Boards(arg0 string) ([]*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Boards", arg0)
+	ret0, _ := ret[0].([]*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Boards indicates an expected call of Boards.
+func (mr *BoardModelMockMockRecorder) Boards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Boards", reflect.TypeOf((*BoardModelMock)(nil).Boards), arg0)
+}
+
+// ChangeMemberRole mocks base method.
+func (m *BoardModelMock) ChangeMemberRole(arg0, arg1, arg2 string, arg3 models.BoardRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeMemberRole", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeMemberRole indicates an expected call of ChangeMemberRole.
+func (mr *BoardModelMockMockRecorder) ChangeMemberRole(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeMemberRole", reflect.TypeOf((*BoardModelMock)(nil).ChangeMemberRole), arg0, arg1, arg2, arg3)
+}
+
+// CreateBoard mocks base method.
+func (m *BoardModelMock) CreateBoard(arg0, arg1 string) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoard", arg0, arg1)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoard indicates an expected call of CreateBoard.
+func (mr *BoardModelMockMockRecorder) CreateBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoard", reflect.TypeOf((*BoardModelMock)(nil).CreateBoard), arg0, arg1)
+}
+
+// DeclineInvitation mocks base method.
+func (m *BoardModelMock) DeclineInvitation(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *BoardModelMockMockRecorder) DeclineInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*BoardModelMock)(nil).DeclineInvitation), arg0, arg1)
+}
+
+// DeleteBoard mocks base method.
+func (m *BoardModelMock) DeleteBoard(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoard", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoard indicates an expected call of DeleteBoard.
+func (mr *BoardModelMockMockRecorder) DeleteBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*BoardModelMock)(nil).DeleteBoard), arg0, arg1)
+}
+
+// Invitations mocks base method.
+func (m *BoardModelMock) Invitations(arg0 string) ([]*models.BoardInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invitations", arg0)
+	ret0, _ := ret[0].([]*models.BoardInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invitations indicates an expected call of Invitations.
+func (mr *BoardModelMockMockRecorder) Invitations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invitations", reflect.TypeOf((*BoardModelMock)(nil).Invitations), arg0)
+}
+
+// InviteToBoard mocks base method.
+func (m *BoardModelMock) InviteToBoard(arg0, arg1, arg2 string, arg3 models.BoardRole) (*models.BoardInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteToBoard", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.BoardInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteToBoard indicates an expected call of InviteToBoard.
+func (mr *BoardModelMockMockRecorder) InviteToBoard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteToBoard", reflect.TypeOf((*BoardModelMock)(nil).InviteToBoard), arg0, arg1, arg2, arg3)
+}
+
+// RemoveMember mocks base method.
+func (m *BoardModelMock) RemoveMember(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *BoardModelMockMockRecorder) RemoveMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*BoardModelMock)(nil).RemoveMember), arg0, arg1, arg2)
+}
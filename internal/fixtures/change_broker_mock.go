@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: ChangeBroker)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// ChangeBrokerMock is a mock of ChangeBroker interface.
+type ChangeBrokerMock struct {
+	ctrl     *gomock.Controller
+	recorder *ChangeBrokerMockMockRecorder
+}
+
+// ChangeBrokerMockMockRecorder is the mock recorder for ChangeBrokerMock.
+type ChangeBrokerMockMockRecorder struct {
+	mock *ChangeBrokerMock
+}
+
+// NewChangeBrokerMock creates a new mock instance.
+func NewChangeBrokerMock(ctrl *gomock.Controller) *ChangeBrokerMock {
+	mock := &ChangeBrokerMock{ctrl: ctrl}
+	mock.recorder = &ChangeBrokerMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *ChangeBrokerMock) EXPECT() *ChangeBrokerMockMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *ChangeBrokerMock) Publish(arg0 []string, arg1 *models.Change) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", arg0, arg1)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *ChangeBrokerMockMockRecorder) Publish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*ChangeBrokerMock)(nil).Publish), arg0, arg1)
+}
+
+// Subscribe mocks base method.
+func (m *ChangeBrokerMock) Subscribe(arg0 string) (<-chan *models.Change, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0)
+	ret0, _ := ret[0].(<-chan *models.Change)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *ChangeBrokerMockMockRecorder) Subscribe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*ChangeBrokerMock)(nil).Subscribe), arg0)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: ChecklistModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// ChecklistModelMock is a mock of ChecklistModel interface.
+type ChecklistModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *ChecklistModelMockMockRecorder
+}
+
+// ChecklistModelMockMockRecorder is the mock recorder for ChecklistModelMock.
+type ChecklistModelMockMockRecorder struct {
+	mock *ChecklistModelMock
+}
+
+// NewChecklistModelMock creates a new mock instance.
+func NewChecklistModelMock(ctrl *gomock.Controller) *ChecklistModelMock {
+	mock := &ChecklistModelMock{ctrl: ctrl}
+	mock.recorder = &ChecklistModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *ChecklistModelMock) EXPECT() *ChecklistModelMockMockRecorder {
+	return m.recorder
+}
+
+// AddChecklistItem mocks base method.
+func (m *ChecklistModelMock) AddChecklistItem(arg0, arg1, arg2 string) (*models.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *ChecklistModelMockMockRecorder) AddChecklistItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*ChecklistModelMock)(nil).AddChecklistItem), arg0, arg1, arg2)
+}
+
+// Checklist mocks base method.
+func (m *ChecklistModelMock) Checklist(arg0, arg1 string) (*models.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checklist", arg0, arg1)
+	ret0, _ := ret[0].(*models.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checklist indicates an expected call of Checklist.
+func (mr *ChecklistModelMockMockRecorder) Checklist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checklist", reflect.TypeOf((*ChecklistModelMock)(nil).Checklist), arg0, arg1)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *ChecklistModelMock) DeleteChecklistItem(arg0, arg1, arg2 string) (*models.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *ChecklistModelMockMockRecorder) DeleteChecklistItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*ChecklistModelMock)(nil).DeleteChecklistItem), arg0, arg1, arg2)
+}
+
+// SetChecklistAutoProgress mocks base method.
+func (m *ChecklistModelMock) SetChecklistAutoProgress(arg0, arg1 string, arg2 bool) (*models.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChecklistAutoProgress", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChecklistAutoProgress indicates an expected call of SetChecklistAutoProgress.
+func (mr *ChecklistModelMockMockRecorder) SetChecklistAutoProgress(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChecklistAutoProgress", reflect.TypeOf((*ChecklistModelMock)(nil).SetChecklistAutoProgress), arg0, arg1, arg2)
+}
+
+// UpdateChecklistItem mocks base method.
+func (m *ChecklistModelMock) UpdateChecklistItem(arg0, arg1, arg2, arg3 string, arg4 *bool) (*models.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistItem", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*models.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklistItem indicates an expected call of UpdateChecklistItem.
+func (mr *ChecklistModelMockMockRecorder) UpdateChecklistItem(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistItem", reflect.TypeOf((*ChecklistModelMock)(nil).UpdateChecklistItem), arg0, arg1, arg2, arg3, arg4)
+}
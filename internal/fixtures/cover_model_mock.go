@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: CoverModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// CoverModelMock is a mock of CoverModel interface.
+type CoverModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *CoverModelMockMockRecorder
+}
+
+// CoverModelMockMockRecorder is the mock recorder for CoverModelMock.
+type CoverModelMockMockRecorder struct {
+	mock *CoverModelMock
+}
+
+// NewCoverModelMock creates a new mock instance.
+func NewCoverModelMock(ctrl *gomock.Controller) *CoverModelMock {
+	mock := &CoverModelMock{ctrl: ctrl}
+	mock.recorder = &CoverModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *CoverModelMock) EXPECT() *CoverModelMockMockRecorder {
+	return m.recorder
+}
+
+// CoverUpdatedAt mocks base method.
+func (m *CoverModelMock) CoverUpdatedAt(arg0 string, arg1 models.CoverKind, arg2 string, arg3 bool) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CoverUpdatedAt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CoverUpdatedAt indicates an expected call of CoverUpdatedAt.
+func (mr *CoverModelMockMockRecorder) CoverUpdatedAt(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CoverUpdatedAt", reflect.TypeOf((*CoverModelMock)(nil).CoverUpdatedAt), arg0, arg1, arg2, arg3)
+}
+
+// SetCoverUpdatedAt mocks base method.
+func (m *CoverModelMock) SetCoverUpdatedAt(arg0 string, arg1 models.CoverKind, arg2 string, arg3 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCoverUpdatedAt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCoverUpdatedAt indicates an expected call of SetCoverUpdatedAt.
+func (mr *CoverModelMockMockRecorder) SetCoverUpdatedAt(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCoverUpdatedAt", reflect.TypeOf((*CoverModelMock)(nil).SetCoverUpdatedAt), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: EventModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// EventModelMock is a mock of EventModel interface.
+type EventModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *EventModelMockMockRecorder
+}
+
+// EventModelMockMockRecorder is the mock recorder for EventModelMock.
+type EventModelMockMockRecorder struct {
+	mock *EventModelMock
+}
+
+// NewEventModelMock creates a new mock instance.
+func NewEventModelMock(ctrl *gomock.Controller) *EventModelMock {
+	mock := &EventModelMock{ctrl: ctrl}
+	mock.recorder = &EventModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *EventModelMock) EXPECT() *EventModelMockMockRecorder {
+	return m.recorder
+}
+
+// Feed mocks base method.
+func (m *EventModelMock) Feed(arg0 string, arg1 int64, arg2 int) ([]*models.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Feed", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Feed indicates an expected call of Feed.
+func (mr *EventModelMockMockRecorder) Feed(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Feed", reflect.TypeOf((*EventModelMock)(nil).Feed), arg0, arg1, arg2)
+}
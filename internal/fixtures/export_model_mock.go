@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: ExportModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// ExportModelMock is a mock of ExportModel interface.
+type ExportModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *ExportModelMockMockRecorder
+}
+
+// ExportModelMockMockRecorder is the mock recorder for ExportModelMock.
+type ExportModelMockMockRecorder struct {
+	mock *ExportModelMock
+}
+
+// NewExportModelMock creates a new mock instance.
+func NewExportModelMock(ctrl *gomock.Controller) *ExportModelMock {
+	mock := &ExportModelMock{ctrl: ctrl}
+	mock.recorder = &ExportModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *ExportModelMock) EXPECT() *ExportModelMockMockRecorder {
+	return m.recorder
+}
+
+// Franchises mocks base method.
+func (m *ExportModelMock) Franchises(arg0 string, arg1 func(*models.Franchise) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Franchises", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Franchises indicates an expected call of Franchises.
+func (mr *ExportModelMockMockRecorder) Franchises(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Franchises", reflect.TypeOf((*ExportModelMock)(nil).Franchises), arg0, arg1)
+}
+
+// Games mocks base method.
+func (m *ExportModelMock) Games(arg0 string, arg1 func(*models.Game) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Games", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Games indicates an expected call of Games.
+func (mr *ExportModelMockMockRecorder) Games(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Games", reflect.TypeOf((*ExportModelMock)(nil).Games), arg0, arg1)
+}
+
+// StatusChanges mocks base method.
+func (m *ExportModelMock) StatusChanges(arg0 string, arg1 func(*models.StatusChange) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatusChanges", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StatusChanges indicates an expected call of StatusChanges.
+func (mr *ExportModelMockMockRecorder) StatusChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusChanges", reflect.TypeOf((*ExportModelMock)(nil).StatusChanges), arg0, arg1)
+}
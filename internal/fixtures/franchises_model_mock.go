@@ -49,6 +49,36 @@ func (mr *FranchiseModelMockMockRecorder) All(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*FranchiseModelMock)(nil).All), arg0)
 }
 
+// GamesBefore mocks base method.
+func (m *FranchiseModelMock) GamesBefore(arg0, arg1 string) ([]*models.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GamesBefore", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GamesBefore indicates an expected call of GamesBefore.
+func (mr *FranchiseModelMockMockRecorder) GamesBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GamesBefore", reflect.TypeOf((*FranchiseModelMock)(nil).GamesBefore), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *FranchiseModelMock) Get(arg0, arg1 string) (*models.Franchise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*models.Franchise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *FranchiseModelMockMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*FranchiseModelMock)(nil).Get), arg0, arg1)
+}
+
 // Insert mocks base method.
 func (m *FranchiseModelMock) Insert(arg0 *models.Franchise) (*models.Franchise, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +93,31 @@ func (mr *FranchiseModelMockMockRecorder) Insert(arg0 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*FranchiseModelMock)(nil).Insert), arg0)
 }
+
+// Reorder mocks base method.
+func (m *FranchiseModelMock) Reorder(arg0, arg1 string, arg2 models.FranchiseOrder, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *FranchiseModelMockMockRecorder) Reorder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*FranchiseModelMock)(nil).Reorder), arg0, arg1, arg2, arg3)
+}
+
+// SetParent mocks base method.
+func (m *FranchiseModelMock) SetParent(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetParent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetParent indicates an expected call of SetParent.
+func (mr *FranchiseModelMockMockRecorder) SetParent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParent", reflect.TypeOf((*FranchiseModelMock)(nil).SetParent), arg0, arg1, arg2)
+}
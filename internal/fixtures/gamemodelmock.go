@@ -6,6 +6,7 @@ package fixtures
 
 import (
 	reflect "reflect"
+	time "time"
 
 	models "github.com/asankov/gira/pkg/models"
 	gomock "github.com/golang/mock/gomock"
@@ -34,6 +35,21 @@ func (m *GameModelMock) EXPECT() *GameModelMockMockRecorder {
 	return m.recorder
 }
 
+// AllForBoard mocks base method.
+func (m *GameModelMock) AllForBoard(arg0, arg1 string) ([]*models.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllForBoard indicates an expected call of AllForBoard.
+func (mr *GameModelMockMockRecorder) AllForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllForBoard", reflect.TypeOf((*GameModelMock)(nil).AllForBoard), arg0, arg1)
+}
+
 // AllForUser mocks base method.
 func (m *GameModelMock) AllForUser(arg0 string) ([]*models.Game, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +65,20 @@ func (mr *GameModelMockMockRecorder) AllForUser(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllForUser", reflect.TypeOf((*GameModelMock)(nil).AllForUser), arg0)
 }
 
+// ChangeGameHidden mocks base method.
+func (m *GameModelMock) ChangeGameHidden(arg0, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeGameHidden", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeGameHidden indicates an expected call of ChangeGameHidden.
+func (mr *GameModelMockMockRecorder) ChangeGameHidden(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeGameHidden", reflect.TypeOf((*GameModelMock)(nil).ChangeGameHidden), arg0, arg1, arg2)
+}
+
 // ChangeGameProgress mocks base method.
 func (m *GameModelMock) ChangeGameProgress(arg0, arg1 string, arg2 *models.GameProgress) error {
 	m.ctrl.T.Helper()
@@ -63,6 +93,34 @@ func (mr *GameModelMockMockRecorder) ChangeGameProgress(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeGameProgress", reflect.TypeOf((*GameModelMock)(nil).ChangeGameProgress), arg0, arg1, arg2)
 }
 
+// ChangeGameRating mocks base method.
+func (m *GameModelMock) ChangeGameRating(arg0, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeGameRating", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeGameRating indicates an expected call of ChangeGameRating.
+func (mr *GameModelMockMockRecorder) ChangeGameRating(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeGameRating", reflect.TypeOf((*GameModelMock)(nil).ChangeGameRating), arg0, arg1, arg2)
+}
+
+// ChangeGameReleaseDate mocks base method.
+func (m *GameModelMock) ChangeGameReleaseDate(arg0, arg1 string, arg2 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeGameReleaseDate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeGameReleaseDate indicates an expected call of ChangeGameReleaseDate.
+func (mr *GameModelMockMockRecorder) ChangeGameReleaseDate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeGameReleaseDate", reflect.TypeOf((*GameModelMock)(nil).ChangeGameReleaseDate), arg0, arg1, arg2)
+}
+
 // ChangeGameStatus mocks base method.
 func (m *GameModelMock) ChangeGameStatus(arg0, arg1 string, arg2 models.Status) error {
 	m.ctrl.T.Helper()
@@ -77,6 +135,20 @@ func (mr *GameModelMockMockRecorder) ChangeGameStatus(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeGameStatus", reflect.TypeOf((*GameModelMock)(nil).ChangeGameStatus), arg0, arg1, arg2)
 }
 
+// ChangeGameWishlist mocks base method.
+func (m *GameModelMock) ChangeGameWishlist(arg0, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeGameWishlist", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeGameWishlist indicates an expected call of ChangeGameWishlist.
+func (mr *GameModelMockMockRecorder) ChangeGameWishlist(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeGameWishlist", reflect.TypeOf((*GameModelMock)(nil).ChangeGameWishlist), arg0, arg1, arg2)
+}
+
 // DeleteGame mocks base method.
 func (m *GameModelMock) DeleteGame(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -120,3 +192,48 @@ func (mr *GameModelMockMockRecorder) Insert(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*GameModelMock)(nil).Insert), arg0)
 }
+
+// InsertMany mocks base method.
+func (m *GameModelMock) InsertMany(arg0 string, arg1 []string, arg2 []*models.Game) ([]*models.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMany", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertMany indicates an expected call of InsertMany.
+func (mr *GameModelMockMockRecorder) InsertMany(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMany", reflect.TypeOf((*GameModelMock)(nil).InsertMany), arg0, arg1, arg2)
+}
+
+// Upcoming mocks base method.
+func (m *GameModelMock) Upcoming(arg0 string, arg1 time.Time, arg2 bool) ([]*models.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upcoming", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upcoming indicates an expected call of Upcoming.
+func (mr *GameModelMockMockRecorder) Upcoming(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upcoming", reflect.TypeOf((*GameModelMock)(nil).Upcoming), arg0, arg1, arg2)
+}
+
+// Viewers mocks base method.
+func (m *GameModelMock) Viewers(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Viewers", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Viewers indicates an expected call of Viewers.
+func (mr *GameModelMockMockRecorder) Viewers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Viewers", reflect.TypeOf((*GameModelMock)(nil).Viewers), arg0)
+}
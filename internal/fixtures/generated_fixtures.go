@@ -4,6 +4,22 @@ package fixtures
 //go:generate mockgen -destination usermodelmock.go  -package fixtures -mock_names UserModel=UserModelMock github.com/asankov/gira/cmd/api/server UserModel
 //go:generate mockgen -destination user_games_model_mock.go  -package fixtures -mock_names UserGamesModel=UserGamesModelMock github.com/asankov/gira/cmd/api/server UserGamesModel
 //go:generate mockgen -destination franchises_model_mock.go  -package fixtures -mock_names FranchiseModel=FranchiseModelMock github.com/asankov/gira/cmd/api/server FranchiseModel
+//go:generate mockgen -destination stats_model_mock.go  -package fixtures -mock_names StatsModel=StatsModelMock github.com/asankov/gira/cmd/api/server StatsModel
+//go:generate mockgen -destination export_model_mock.go  -package fixtures -mock_names ExportModel=ExportModelMock github.com/asankov/gira/cmd/api/server ExportModel
+//go:generate mockgen -destination cover_model_mock.go  -package fixtures -mock_names CoverModel=CoverModelMock github.com/asankov/gira/cmd/api/server CoverModel
+//go:generate mockgen -destination blob_store_mock.go  -package fixtures -mock_names BlobStore=BlobStoreMock github.com/asankov/gira/cmd/api/server BlobStore
+//go:generate mockgen -destination checklist_model_mock.go  -package fixtures -mock_names ChecklistModel=ChecklistModelMock github.com/asankov/gira/cmd/api/server ChecklistModel
+//go:generate mockgen -destination playthrough_model_mock.go  -package fixtures -mock_names PlaythroughModel=PlaythroughModelMock github.com/asankov/gira/cmd/api/server PlaythroughModel
+//go:generate mockgen -destination social_model_mock.go  -package fixtures -mock_names SocialModel=SocialModelMock github.com/asankov/gira/cmd/api/server SocialModel
+//go:generate mockgen -destination event_model_mock.go  -package fixtures -mock_names EventModel=EventModelMock github.com/asankov/gira/cmd/api/server EventModel
+//go:generate mockgen -destination board_model_mock.go  -package fixtures -mock_names BoardModel=BoardModelMock github.com/asankov/gira/cmd/api/server BoardModel
+//go:generate mockgen -destination goal_model_mock.go  -package fixtures -mock_names GoalModel=GoalModelMock github.com/asankov/gira/cmd/api/server GoalModel
+//go:generate mockgen -destination notification_model_mock.go  -package fixtures -mock_names NotificationModel=NotificationModelMock github.com/asankov/gira/cmd/api/server NotificationModel
+//go:generate mockgen -destination webhook_model_mock.go  -package fixtures -mock_names WebhookModel=WebhookModelMock github.com/asankov/gira/cmd/api/server WebhookModel
+//go:generate mockgen -destination webhook_dispatcher_mock.go  -package fixtures -mock_names WebhookDispatcher=WebhookDispatcherMock github.com/asankov/gira/cmd/api/server WebhookDispatcher
+//go:generate mockgen -destination change_broker_mock.go  -package fixtures -mock_names ChangeBroker=ChangeBrokerMock github.com/asankov/gira/cmd/api/server ChangeBroker
 //go:generate mockgen -destination authenticatormock.go  -package fixtures -mock_names Authenticator=AuthenticatorMock github.com/asankov/gira/cmd/api/server Authenticator
+//go:generate mockgen -destination identity_verifier_mock.go  -package fixtures -mock_names IdentityVerifier=IdentityVerifierMock github.com/asankov/gira/cmd/api/server IdentityVerifier
+//go:generate mockgen -destination login_throttler_mock.go  -package fixtures -mock_names LoginThrottler=LoginThrottlerMock github.com/asankov/gira/cmd/api/server LoginThrottler
 //go:generate mockgen -destination renderer_mock.go  -package fixtures -mock_names Renderer=RendererMock github.com/asankov/gira/cmd/front-end/server Renderer
 //go:generate mockgen -destination api_client_mock.go  -package fixtures -mock_names APIClient=APIClientMock github.com/asankov/gira/cmd/front-end/server APIClient
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: GoalModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// GoalModelMock is a mock of GoalModel interface.
+type GoalModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *GoalModelMockMockRecorder
+}
+
+// GoalModelMockMockRecorder is the mock recorder for GoalModelMock.
+type GoalModelMockMockRecorder struct {
+	mock *GoalModelMock
+}
+
+// NewGoalModelMock creates a new mock instance.
+func NewGoalModelMock(ctrl *gomock.Controller) *GoalModelMock {
+	mock := &GoalModelMock{ctrl: ctrl}
+	mock.recorder = &GoalModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GoalModelMock) EXPECT() *GoalModelMockMockRecorder {
+	return m.recorder
+}
+
+// DeleteGoal mocks base method.
+func (m *GoalModelMock) DeleteGoal(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoal", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *GoalModelMockMockRecorder) DeleteGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*GoalModelMock)(nil).DeleteGoal), arg0, arg1)
+}
+
+// Goal mocks base method.
+func (m *GoalModelMock) Goal(arg0, arg1 string, arg2 time.Time) (*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Goal", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Goal indicates an expected call of Goal.
+func (mr *GoalModelMockMockRecorder) Goal(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Goal", reflect.TypeOf((*GoalModelMock)(nil).Goal), arg0, arg1, arg2)
+}
+
+// Goals mocks base method.
+func (m *GoalModelMock) Goals(arg0 string, arg1 time.Time) ([]*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Goals", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Goals indicates an expected call of Goals.
+func (mr *GoalModelMockMockRecorder) Goals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Goals", reflect.TypeOf((*GoalModelMock)(nil).Goals), arg0, arg1)
+}
+
+// InsertGoal mocks base method.
+func (m *GoalModelMock) InsertGoal(arg0 *models.Goal, arg1 time.Time) (*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertGoal", arg0, arg1)
+	ret0, _ := ret[0].(*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertGoal indicates an expected call of InsertGoal.
+func (mr *GoalModelMockMockRecorder) InsertGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGoal", reflect.TypeOf((*GoalModelMock)(nil).InsertGoal), arg0, arg1)
+}
+
+// TrackGoals mocks base method.
+func (m *GoalModelMock) TrackGoals(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackGoals", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackGoals indicates an expected call of TrackGoals.
+func (mr *GoalModelMockMockRecorder) TrackGoals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackGoals", reflect.TypeOf((*GoalModelMock)(nil).TrackGoals), arg0, arg1)
+}
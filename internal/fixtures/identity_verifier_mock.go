@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: IdentityVerifier)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// IdentityVerifierMock is a mock of IdentityVerifier interface.
+type IdentityVerifierMock struct {
+	ctrl     *gomock.Controller
+	recorder *IdentityVerifierMockMockRecorder
+}
+
+// IdentityVerifierMockMockRecorder is the mock recorder for IdentityVerifierMock.
+type IdentityVerifierMockMockRecorder struct {
+	mock *IdentityVerifierMock
+}
+
+// NewIdentityVerifierMock creates a new mock instance.
+func NewIdentityVerifierMock(ctrl *gomock.Controller) *IdentityVerifierMock {
+	mock := &IdentityVerifierMock{ctrl: ctrl}
+	mock.recorder = &IdentityVerifierMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *IdentityVerifierMock) EXPECT() *IdentityVerifierMockMockRecorder {
+	return m.recorder
+}
+
+// ExchangeIdentity mocks base method.
+func (m *IdentityVerifierMock) ExchangeIdentity(arg0 context.Context, arg1, arg2, arg3, arg4 string) (*models.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeIdentity", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*models.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeIdentity indicates an expected call of ExchangeIdentity.
+func (mr *IdentityVerifierMockMockRecorder) ExchangeIdentity(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeIdentity", reflect.TypeOf((*IdentityVerifierMock)(nil).ExchangeIdentity), arg0, arg1, arg2, arg3, arg4)
+}
@@ -0,0 +1,75 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: LoginThrottler)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// LoginThrottlerMock is a mock of LoginThrottler interface.
+type LoginThrottlerMock struct {
+	ctrl     *gomock.Controller
+	recorder *LoginThrottlerMockMockRecorder
+}
+
+// LoginThrottlerMockMockRecorder is the mock recorder for LoginThrottlerMock.
+type LoginThrottlerMockMockRecorder struct {
+	mock *LoginThrottlerMock
+}
+
+// NewLoginThrottlerMock creates a new mock instance.
+func NewLoginThrottlerMock(ctrl *gomock.Controller) *LoginThrottlerMock {
+	mock := &LoginThrottlerMock{ctrl: ctrl}
+	mock.recorder = &LoginThrottlerMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *LoginThrottlerMock) EXPECT() *LoginThrottlerMockMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *LoginThrottlerMock) Check(arg0, arg1 string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", arg0, arg1)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *LoginThrottlerMockMockRecorder) Check(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*LoginThrottlerMock)(nil).Check), arg0, arg1)
+}
+
+// Fail mocks base method.
+func (m *LoginThrottlerMock) Fail(arg0, arg1 string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", arg0, arg1)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *LoginThrottlerMockMockRecorder) Fail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*LoginThrottlerMock)(nil).Fail), arg0, arg1)
+}
+
+// Success mocks base method.
+func (m *LoginThrottlerMock) Success(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Success", arg0, arg1)
+}
+
+// Success indicates an expected call of Success.
+func (mr *LoginThrottlerMockMockRecorder) Success(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Success", reflect.TypeOf((*LoginThrottlerMock)(nil).Success), arg0, arg1)
+}
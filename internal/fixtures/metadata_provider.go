@@ -0,0 +1,114 @@
+package fixtures
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asankov/gira/internal/metadata"
+	"github.com/asankov/gira/pkg/models"
+)
+
+// MetadataProvider is a fake metadata provider, that serves a fixed set of games.
+// Search matches the games, whose names contain the query, regardless of the case.
+// It also counts the calls, so that tests can assert whether the provider was reached.
+type MetadataProvider struct {
+	Games []*models.GameMetadata
+	// Err, if set, is returned by all calls
+	Err error
+
+	mu       sync.Mutex
+	searches int
+	lookups  int
+}
+
+// NewMetadataProvider returns a fake metadata provider with a few well-known games.
+func NewMetadataProvider() *MetadataProvider {
+	released := func(year int, month time.Month, day int) *time.Time {
+		t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	return &MetadataProvider{
+		Games: []*models.GameMetadata{
+			{
+				ID:          "3328",
+				Name:        "The Witcher 3: Wild Hunt",
+				CoverURL:    "https://media.example.com/games/the-witcher-3.jpg",
+				ReleaseDate: released(2015, time.May, 18),
+				Genres:      []string{"Action", "RPG"},
+				Developer:   "CD PROJEKT RED",
+			},
+			{
+				ID:          "4200",
+				Name:        "Far Cry 3",
+				CoverURL:    "https://media.example.com/games/far-cry-3.jpg",
+				ReleaseDate: released(2012, time.November, 29),
+				Genres:      []string{"Action", "Shooter"},
+				Developer:   "Ubisoft Montreal",
+			},
+			{
+				ID:          "274755",
+				Name:        "Hades",
+				CoverURL:    "https://media.example.com/games/hades.jpg",
+				ReleaseDate: released(2020, time.September, 17),
+				Genres:      []string{"Action", "Indie", "RPG"},
+				Developer:   "Supergiant Games",
+			},
+		},
+	}
+}
+
+// Search returns copies of the games, whose names contain the query.
+func (p *MetadataProvider) Search(ctx context.Context, query string) ([]*models.GameMetadata, error) {
+	p.mu.Lock()
+	p.searches++
+	p.mu.Unlock()
+
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	games := []*models.GameMetadata{}
+	for _, game := range p.Games {
+		if strings.Contains(strings.ToLower(game.Name), strings.ToLower(query)) {
+			g := *game
+			games = append(games, &g)
+		}
+	}
+	return games, nil
+}
+
+// Details returns a copy of the game with the given ID, or metadata.ErrNotFound.
+func (p *MetadataProvider) Details(ctx context.Context, id string) (*models.GameMetadata, error) {
+	p.mu.Lock()
+	p.lookups++
+	p.mu.Unlock()
+
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	for _, game := range p.Games {
+		if game.ID == id {
+			g := *game
+			return &g, nil
+		}
+	}
+	return nil, metadata.ErrNotFound
+}
+
+// Searches returns the number of calls to Search
+func (p *MetadataProvider) Searches() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.searches
+}
+
+// Lookups returns the number of calls to Details
+func (p *MetadataProvider) Lookups() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lookups
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: NotificationModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NotificationModelMock is a mock of NotificationModel interface.
+type NotificationModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *NotificationModelMockMockRecorder
+}
+
+// NotificationModelMockMockRecorder is the mock recorder for NotificationModelMock.
+type NotificationModelMockMockRecorder struct {
+	mock *NotificationModelMock
+}
+
+// NewNotificationModelMock creates a new mock instance.
+func NewNotificationModelMock(ctrl *gomock.Controller) *NotificationModelMock {
+	mock := &NotificationModelMock{ctrl: ctrl}
+	mock.recorder = &NotificationModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *NotificationModelMock) EXPECT() *NotificationModelMockMockRecorder {
+	return m.recorder
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *NotificationModelMock) MarkAllNotificationsRead(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *NotificationModelMockMockRecorder) MarkAllNotificationsRead(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*NotificationModelMock)(nil).MarkAllNotificationsRead), arg0)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *NotificationModelMock) MarkNotificationRead(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *NotificationModelMockMockRecorder) MarkNotificationRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*NotificationModelMock)(nil).MarkNotificationRead), arg0, arg1)
+}
+
+// NotificationPreferences mocks base method.
+func (m *NotificationModelMock) NotificationPreferences(arg0 string) (*models.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotificationPreferences", arg0)
+	ret0, _ := ret[0].(*models.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotificationPreferences indicates an expected call of NotificationPreferences.
+func (mr *NotificationModelMockMockRecorder) NotificationPreferences(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationPreferences", reflect.TypeOf((*NotificationModelMock)(nil).NotificationPreferences), arg0)
+}
+
+// Notifications mocks base method.
+func (m *NotificationModelMock) Notifications(arg0 string, arg1 int) ([]*models.Notification, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notifications", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Notifications indicates an expected call of Notifications.
+func (mr *NotificationModelMockMockRecorder) Notifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notifications", reflect.TypeOf((*NotificationModelMock)(nil).Notifications), arg0, arg1)
+}
+
+// SetNotificationPreferences mocks base method.
+func (m *NotificationModelMock) SetNotificationPreferences(arg0 string, arg1 *models.NotificationPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotificationPreferences", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotificationPreferences indicates an expected call of SetNotificationPreferences.
+func (mr *NotificationModelMockMockRecorder) SetNotificationPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotificationPreferences", reflect.TypeOf((*NotificationModelMock)(nil).SetNotificationPreferences), arg0, arg1)
+}
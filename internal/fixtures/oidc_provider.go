@@ -0,0 +1,212 @@
+package fixtures
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// OIDCProvider is a stub OpenID Connect identity provider.
+// Its authorization endpoint approves every request immediately,
+// by redirecting back with a code that can be exchanged for an ID token
+// for Subject and Email.
+type OIDCProvider struct {
+	Server   *httptest.Server
+	ClientID string
+	Subject  string
+	Email    string
+
+	t            *testing.T
+	key          *rsa.PrivateKey
+	mu           sync.Mutex
+	codes        map[string]oidcAuthorization
+	jwksRequests int
+}
+
+type oidcAuthorization struct {
+	challenge   string
+	nonce       string
+	redirectURI string
+}
+
+// NewOIDCProvider starts a new stub provider, which is closed when the test finishes.
+func NewOIDCProvider(t *testing.T) *OIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Got unexpected error while generating RSA key - %v", err)
+	}
+
+	p := &OIDCProvider{
+		ClientID: "gira",
+		Subject:  "external-user-id",
+		Email:    "external@gira.com",
+		t:        t,
+		key:      key,
+		codes:    map[string]oidcAuthorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("/jwks", p.handleJWKS)
+	mux.HandleFunc("/authorize", p.handleAuthorize)
+	mux.HandleFunc("/token", p.handleToken)
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Server.Close)
+
+	return p
+}
+
+// Issuer returns the issuer URL of the provider
+func (p *OIDCProvider) Issuer() string {
+	return p.Server.URL
+}
+
+// IDToken returns a signed ID token for Subject and Email with the given nonce,
+// issued to ClientID and expiring after d.
+func (p *OIDCProvider) IDToken(nonce string, d time.Duration) string {
+	claims := map[string]interface{}{
+		"iss":            p.Issuer(),
+		"sub":            p.Subject,
+		"aud":            p.ClientID,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(d).Unix(),
+		"email":          p.Email,
+		"email_verified": true,
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	return p.Sign(claims)
+}
+
+// Sign returns a JWT with the given claims, signed with the key of the provider.
+func (p *OIDCProvider) Sign(claims map[string]interface{}) string {
+	return p.SignWithKeyID("test-key", claims)
+}
+
+// SignWithKeyID returns a JWT with the given claims, signed with the key of the provider,
+// but with the given key ID in its header, e.g. to simulate a key, that the provider does not publish.
+func (p *OIDCProvider) SignWithKeyID(keyID string, claims map[string]interface{}) string {
+	header := base64.RawURLEncoding.EncodeToString(MarshalBytes(p.t, map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID}))
+	payload := base64.RawURLEncoding.EncodeToString(MarshalBytes(p.t, claims))
+
+	digest := sha256.Sum256([]byte(header + "." + payload))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		p.t.Fatalf("Got unexpected error while signing token - %v", err)
+	}
+
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (p *OIDCProvider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	p.writeJSON(w, map[string]string{
+		"issuer":                 p.Issuer(),
+		"authorization_endpoint": p.Issuer() + "/authorize",
+		"token_endpoint":         p.Issuer() + "/token",
+		"jwks_uri":               p.Issuer() + "/jwks",
+	})
+}
+
+// JWKSRequests returns how many times the keys of the provider were fetched
+func (p *OIDCProvider) JWKSRequests() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.jwksRequests
+}
+
+func (p *OIDCProvider) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.jwksRequests++
+	p.mu.Unlock()
+
+	p.writeJSON(w, map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "test-key",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func (p *OIDCProvider) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != p.ClientID || q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	code := fmt.Sprintf("code-%d", time.Now().UnixNano())
+	p.mu.Lock()
+	p.codes[code] = oidcAuthorization{
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		redirectURI: q.Get("redirect_uri"),
+	}
+	p.mu.Unlock()
+
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	redirectQuery := redirect.Query()
+	redirectQuery.Set("code", code)
+	redirectQuery.Set("state", q.Get("state"))
+	redirect.RawQuery = redirectQuery.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *OIDCProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	authorization, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	if !ok || r.PostForm.Get("redirect_uri") != authorization.redirectURI {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	verifierHash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(verifierHash[:]) != authorization.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	p.writeJSON(w, map[string]interface{}{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     p.IDToken(authorization.nonce, time.Hour),
+	})
+}
+
+func (p *OIDCProvider) writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		p.t.Errorf("error while writing response - %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: PlaythroughModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// PlaythroughModelMock is a mock of PlaythroughModel interface.
+type PlaythroughModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *PlaythroughModelMockMockRecorder
+}
+
+// PlaythroughModelMockMockRecorder is the mock recorder for PlaythroughModelMock.
+type PlaythroughModelMockMockRecorder struct {
+	mock *PlaythroughModelMock
+}
+
+// NewPlaythroughModelMock creates a new mock instance.
+func NewPlaythroughModelMock(ctrl *gomock.Controller) *PlaythroughModelMock {
+	mock := &PlaythroughModelMock{ctrl: ctrl}
+	mock.recorder = &PlaythroughModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *PlaythroughModelMock) EXPECT() *PlaythroughModelMockMockRecorder {
+	return m.recorder
+}
+
+// DeletePlaythrough mocks base method.
+func (m *PlaythroughModelMock) DeletePlaythrough(arg0, arg1, arg2 string) (models.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaythrough", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePlaythrough indicates an expected call of DeletePlaythrough.
+func (mr *PlaythroughModelMockMockRecorder) DeletePlaythrough(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaythrough", reflect.TypeOf((*PlaythroughModelMock)(nil).DeletePlaythrough), arg0, arg1, arg2)
+}
+
+// InsertPlaythrough mocks base method.
+func (m *PlaythroughModelMock) InsertPlaythrough(arg0 string, arg1 *models.Playthrough) (*models.Playthrough, models.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPlaythrough", arg0, arg1)
+	ret0, _ := ret[0].(*models.Playthrough)
+	ret1, _ := ret[1].(models.Status)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// InsertPlaythrough indicates an expected call of InsertPlaythrough.
+func (mr *PlaythroughModelMockMockRecorder) InsertPlaythrough(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPlaythrough", reflect.TypeOf((*PlaythroughModelMock)(nil).InsertPlaythrough), arg0, arg1)
+}
+
+// Playthroughs mocks base method.
+func (m *PlaythroughModelMock) Playthroughs(arg0, arg1 string) ([]*models.Playthrough, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Playthroughs", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Playthrough)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Playthroughs indicates an expected call of Playthroughs.
+func (mr *PlaythroughModelMockMockRecorder) Playthroughs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Playthroughs", reflect.TypeOf((*PlaythroughModelMock)(nil).Playthroughs), arg0, arg1)
+}
+
+// UpdatePlaythrough mocks base method.
+func (m *PlaythroughModelMock) UpdatePlaythrough(arg0 string, arg1 *models.Playthrough) (*models.Playthrough, models.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaythrough", arg0, arg1)
+	ret0, _ := ret[0].(*models.Playthrough)
+	ret1, _ := ret[1].(models.Status)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdatePlaythrough indicates an expected call of UpdatePlaythrough.
+func (mr *PlaythroughModelMockMockRecorder) UpdatePlaythrough(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaythrough", reflect.TypeOf((*PlaythroughModelMock)(nil).UpdatePlaythrough), arg0, arg1)
+}
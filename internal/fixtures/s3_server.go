@@ -0,0 +1,114 @@
+package fixtures
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// S3Server is an in-memory stand-in for an S3-compatible object storage (like MinIO), with a single bucket.
+// It only checks that the requests are signed with AccessKey and that the payload hash matches the body,
+// without verifying the signature itself.
+type S3Server struct {
+	Server    *httptest.Server
+	Bucket    string
+	AccessKey string
+
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string]*s3Object
+}
+
+type s3Object struct {
+	data        []byte
+	contentType string
+}
+
+// NewS3Server starts a new stub object storage, which is closed when the test finishes.
+func NewS3Server(t *testing.T) *S3Server {
+	s := &S3Server{
+		Bucket:    "gira",
+		AccessKey: "minio-access-key",
+		t:         t,
+		objects:   map[string]*s3Object{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Server.Close)
+
+	return s
+}
+
+// URL returns the endpoint of the storage
+func (s *S3Server) URL() string {
+	return s.Server.URL
+}
+
+// Object returns the data of the object stored under key, and whether there is such.
+func (s *S3Server) Object(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.objects[key]
+	if !ok {
+		return nil, false
+	}
+	return o.data, true
+}
+
+func (s *S3Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	sum := sha256.Sum256(body)
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+s.AccessKey+"/") ||
+		r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) ||
+		r.Header.Get("X-Amz-Date") == "" {
+		s.writeError(w, http.StatusForbidden, "SignatureDoesNotMatch")
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/"+s.Bucket+"/")
+	if key == r.URL.Path || key == "" {
+		s.writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		s.objects[key] = &s3Object{data: body, contentType: r.Header.Get("Content-Type")}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		o, ok := s.objects[key]
+		if !ok {
+			s.writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Type", o.contentType)
+		if _, err := w.Write(o.data); err != nil {
+			s.t.Errorf("error while writing response - %v", err)
+		}
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *S3Server) writeError(w http.ResponseWriter, code int, s3Code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(code)
+	if _, err := io.WriteString(w, "<Error><Code>"+s3Code+"</Code></Error>"); err != nil {
+		s.t.Errorf("error while writing response - %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: SocialModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// SocialModelMock is a mock of SocialModel interface.
+type SocialModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *SocialModelMockMockRecorder
+}
+
+// SocialModelMockMockRecorder is the mock recorder for SocialModelMock.
+type SocialModelMockMockRecorder struct {
+	mock *SocialModelMock
+}
+
+// NewSocialModelMock creates a new mock instance.
+func NewSocialModelMock(ctrl *gomock.Controller) *SocialModelMock {
+	mock := &SocialModelMock{ctrl: ctrl}
+	mock.recorder = &SocialModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *SocialModelMock) EXPECT() *SocialModelMockMockRecorder {
+	return m.recorder
+}
+
+// Follow mocks base method.
+func (m *SocialModelMock) Follow(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *SocialModelMockMockRecorder) Follow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*SocialModelMock)(nil).Follow), arg0, arg1)
+}
+
+// Following mocks base method.
+func (m *SocialModelMock) Following(arg0 string) ([]*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Following", arg0)
+	ret0, _ := ret[0].([]*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Following indicates an expected call of Following.
+func (mr *SocialModelMockMockRecorder) Following(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Following", reflect.TypeOf((*SocialModelMock)(nil).Following), arg0)
+}
+
+// Profile mocks base method.
+func (m *SocialModelMock) Profile(arg0, arg1 string) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile", arg0, arg1)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *SocialModelMockMockRecorder) Profile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*SocialModelMock)(nil).Profile), arg0, arg1)
+}
+
+// ProfileGames mocks base method.
+func (m *SocialModelMock) ProfileGames(arg0, arg1 string) ([]*models.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProfileGames", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfileGames indicates an expected call of ProfileGames.
+func (mr *SocialModelMockMockRecorder) ProfileGames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfileGames", reflect.TypeOf((*SocialModelMock)(nil).ProfileGames), arg0, arg1)
+}
+
+// SetProfilePublic mocks base method.
+func (m *SocialModelMock) SetProfilePublic(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProfilePublic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProfilePublic indicates an expected call of SetProfilePublic.
+func (mr *SocialModelMockMockRecorder) SetProfilePublic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProfilePublic", reflect.TypeOf((*SocialModelMock)(nil).SetProfilePublic), arg0, arg1)
+}
+
+// Unfollow mocks base method.
+func (m *SocialModelMock) Unfollow(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *SocialModelMockMockRecorder) Unfollow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*SocialModelMock)(nil).Unfollow), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: StatsModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// StatsModelMock is a mock of StatsModel interface.
+type StatsModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *StatsModelMockMockRecorder
+}
+
+// StatsModelMockMockRecorder is the mock recorder for StatsModelMock.
+type StatsModelMockMockRecorder struct {
+	mock *StatsModelMock
+}
+
+// NewStatsModelMock creates a new mock instance.
+func NewStatsModelMock(ctrl *gomock.Controller) *StatsModelMock {
+	mock := &StatsModelMock{ctrl: ctrl}
+	mock.recorder = &StatsModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *StatsModelMock) EXPECT() *StatsModelMockMockRecorder {
+	return m.recorder
+}
+
+// StatsForUser mocks base method.
+func (m *StatsModelMock) StatsForUser(arg0 string, arg1 time.Time) (*models.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatsForUser", arg0, arg1)
+	ret0, _ := ret[0].(*models.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatsForUser indicates an expected call of StatsForUser.
+func (mr *StatsModelMockMockRecorder) StatsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatsForUser", reflect.TypeOf((*StatsModelMock)(nil).StatsForUser), arg0, arg1)
+}
+
+// YearReport mocks base method.
+func (m *StatsModelMock) YearReport(arg0 string, arg1 int, arg2 time.Time) (*models.YearReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "YearReport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.YearReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// YearReport indicates an expected call of YearReport.
+func (mr *StatsModelMockMockRecorder) YearReport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "YearReport", reflect.TypeOf((*StatsModelMock)(nil).YearReport), arg0, arg1, arg2)
+}
@@ -14,8 +14,11 @@ type ServerBuilder struct {
 	method       string
 	token        string
 	data         interface{}
+	body         []byte
+	contentType  string
 	responseCode int
 	query        string
+	headers      map[string]string
 	t            *testing.T
 }
 
@@ -24,6 +27,8 @@ func NewTestServer(t *testing.T) ServerBuilder {
 		path:         "/",
 		method:       http.MethodGet,
 		responseCode: http.StatusOK,
+		headers:      map[string]string{},
+		t:            t,
 	}
 }
 
@@ -47,6 +52,13 @@ func (s ServerBuilder) Data(data interface{}) ServerBuilder {
 	return s
 }
 
+// Body makes the server respond with the given raw body, instead of JSON.
+func (s ServerBuilder) Body(contentType string, body []byte) ServerBuilder {
+	s.contentType = contentType
+	s.body = body
+	return s
+}
+
 func (s ServerBuilder) Return(responseCode int) ServerBuilder {
 	s.responseCode = responseCode
 	return s
@@ -57,6 +69,16 @@ func (s ServerBuilder) Query(query string) ServerBuilder {
 	return s
 }
 
+// Header makes the server expect the given header value.
+func (s ServerBuilder) Header(key, value string) ServerBuilder {
+	headers := map[string]string{key: value}
+	for k, v := range s.headers {
+		headers[k] = v
+	}
+	s.headers = headers
+	return s
+}
+
 func (s ServerBuilder) Build() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != s.path || r.Method != s.method {
@@ -69,8 +91,14 @@ func (s ServerBuilder) Build() *httptest.Server {
 				return
 			}
 		}
+		for key, value := range s.headers {
+			if r.Header.Get(key) != value {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
 		if s.query != "" {
-			if !strings.Contains(r.URL.RawQuery, "excludeAssigned=true") {
+			if !strings.Contains(r.URL.RawQuery, s.query) {
 				w.WriteHeader(http.StatusBadRequest)
 				return
 			}
@@ -78,7 +106,15 @@ func (s ServerBuilder) Build() *httptest.Server {
 
 		// TODO: assert body
 
+		if s.contentType != "" {
+			w.Header().Set("Content-Type", s.contentType)
+		}
 		w.WriteHeader(s.responseCode)
+		if s.body != nil {
+			if _, err := w.Write(s.body); err != nil {
+				s.t.Fatalf("error while writing response - %v", err)
+			}
+		}
 		if s.data != nil {
 			if _, err := w.Write(MarshalBytes(s.t, s.data)); err != nil {
 				s.t.Fatalf("error while writing response - %v", err)
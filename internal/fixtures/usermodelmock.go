@@ -63,6 +63,21 @@ func (mr *UserModelMockMockRecorder) Authenticate(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*UserModelMock)(nil).Authenticate), arg0, arg1)
 }
 
+// AuthenticateIdentity mocks base method.
+func (m *UserModelMock) AuthenticateIdentity(arg0 *models.Identity) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateIdentity", arg0)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateIdentity indicates an expected call of AuthenticateIdentity.
+func (mr *UserModelMockMockRecorder) AuthenticateIdentity(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateIdentity", reflect.TypeOf((*UserModelMock)(nil).AuthenticateIdentity), arg0)
+}
+
 // GetUserByToken mocks base method.
 func (m *UserModelMock) GetUserByToken(arg0 string) (*models.User, error) {
 	m.ctrl.T.Helper()
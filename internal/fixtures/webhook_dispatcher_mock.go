@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: WebhookDispatcher)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// WebhookDispatcherMock is a mock of WebhookDispatcher interface.
+type WebhookDispatcherMock struct {
+	ctrl     *gomock.Controller
+	recorder *WebhookDispatcherMockMockRecorder
+}
+
+// WebhookDispatcherMockMockRecorder is the mock recorder for WebhookDispatcherMock.
+type WebhookDispatcherMockMockRecorder struct {
+	mock *WebhookDispatcherMock
+}
+
+// NewWebhookDispatcherMock creates a new mock instance.
+func NewWebhookDispatcherMock(ctrl *gomock.Controller) *WebhookDispatcherMock {
+	mock := &WebhookDispatcherMock{ctrl: ctrl}
+	mock.recorder = &WebhookDispatcherMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *WebhookDispatcherMock) EXPECT() *WebhookDispatcherMockMockRecorder {
+	return m.recorder
+}
+
+// Dispatch mocks base method.
+func (m *WebhookDispatcherMock) Dispatch(arg0 string, arg1 *models.WebhookPayload) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Dispatch", arg0, arg1)
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *WebhookDispatcherMockMockRecorder) Dispatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*WebhookDispatcherMock)(nil).Dispatch), arg0, arg1)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/asankov/gira/cmd/api/server (interfaces: WebhookModel)
+
+// Package fixtures is a generated GoMock package.
+package fixtures
+
+import (
+	reflect "reflect"
+
+	models "github.com/asankov/gira/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// WebhookModelMock is a mock of WebhookModel interface.
+type WebhookModelMock struct {
+	ctrl     *gomock.Controller
+	recorder *WebhookModelMockMockRecorder
+}
+
+// WebhookModelMockMockRecorder is the mock recorder for WebhookModelMock.
+type WebhookModelMockMockRecorder struct {
+	mock *WebhookModelMock
+}
+
+// NewWebhookModelMock creates a new mock instance.
+func NewWebhookModelMock(ctrl *gomock.Controller) *WebhookModelMock {
+	mock := &WebhookModelMock{ctrl: ctrl}
+	mock.recorder = &WebhookModelMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *WebhookModelMock) EXPECT() *WebhookModelMockMockRecorder {
+	return m.recorder
+}
+
+// DeleteWebhook mocks base method.
+func (m *WebhookModelMock) DeleteWebhook(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *WebhookModelMockMockRecorder) DeleteWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*WebhookModelMock)(nil).DeleteWebhook), arg0, arg1)
+}
+
+// InsertWebhook mocks base method.
+func (m *WebhookModelMock) InsertWebhook(arg0 *models.Webhook) (*models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhook", arg0)
+	ret0, _ := ret[0].(*models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWebhook indicates an expected call of InsertWebhook.
+func (mr *WebhookModelMockMockRecorder) InsertWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhook", reflect.TypeOf((*WebhookModelMock)(nil).InsertWebhook), arg0)
+}
+
+// UpdateWebhook mocks base method.
+func (m *WebhookModelMock) UpdateWebhook(arg0 *models.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *WebhookModelMockMockRecorder) UpdateWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*WebhookModelMock)(nil).UpdateWebhook), arg0)
+}
+
+// Webhook mocks base method.
+func (m *WebhookModelMock) Webhook(arg0, arg1 string) (*models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhook", arg0, arg1)
+	ret0, _ := ret[0].(*models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Webhook indicates an expected call of Webhook.
+func (mr *WebhookModelMockMockRecorder) Webhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhook", reflect.TypeOf((*WebhookModelMock)(nil).Webhook), arg0, arg1)
+}
+
+// WebhookDeliveries mocks base method.
+func (m *WebhookModelMock) WebhookDeliveries(arg0, arg1 string, arg2 int) ([]*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebhookDeliveries indicates an expected call of WebhookDeliveries.
+func (mr *WebhookModelMockMockRecorder) WebhookDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookDeliveries", reflect.TypeOf((*WebhookModelMock)(nil).WebhookDeliveries), arg0, arg1, arg2)
+}
+
+// Webhooks mocks base method.
+func (m *WebhookModelMock) Webhooks(arg0 string) ([]*models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhooks", arg0)
+	ret0, _ := ret[0].([]*models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Webhooks indicates an expected call of Webhooks.
+func (mr *WebhookModelMockMockRecorder) Webhooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhooks", reflect.TypeOf((*WebhookModelMock)(nil).Webhooks), arg0)
+}
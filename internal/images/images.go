@@ -0,0 +1,128 @@
+// Package images decodes uploaded images and scales them down to the sizes in which they are stored.
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+
+	// to register the decoders of the supported formats
+	_ "image/gif"
+	_ "image/png"
+)
+
+// maxPixels is the maximum number of pixels of an image that is decoded.
+// It prevents small files that decode to huge images from exhausting the memory.
+const maxPixels = 50_000_000
+
+// jpegQuality is the quality of the encoded images
+const jpegQuality = 85
+
+var (
+	// ErrUnsupportedFormat is returned when the data is not a JPEG, PNG or GIF image
+	ErrUnsupportedFormat = errors.New("the image must be a JPEG, PNG or GIF")
+	// ErrTooLarge is returned when the dimensions of the image are too large to be decoded
+	ErrTooLarge = errors.New("the image is too large")
+)
+
+// Decode decodes a JPEG, PNG or GIF image, after checking that its dimensions are reasonable.
+func Decode(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	return img, nil
+}
+
+// Fit returns the image scaled down, so that neither of its sides is longer than size, keeping its aspect ratio.
+// Smaller images are not scaled up. The transparent parts of the image are filled with white,
+// since the result is meant to be encoded as JPEG.
+func Fit(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Over)
+
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := sw, sh
+	if sw > size || sh > size {
+		if sw >= sh {
+			dw, dh = size, sh*size/sw
+		} else {
+			dw, dh = sw*size/sh, size
+		}
+		if dw < 1 {
+			dw = 1
+		}
+		if dh < 1 {
+			dh = 1
+		}
+	}
+	if dw == sw && dh == sh {
+		return src
+	}
+	return scaleDown(src, dw, dh)
+}
+
+// scaleDown scales the image down to w x h, by averaging the source pixels that fall in each of the destination pixels.
+func scaleDown(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		sy0, sy1 := y*sh/h, (y+1)*sh/h
+		if sy1 == sy0 {
+			sy1 = sy0 + 1
+		}
+		for x := 0; x < w; x++ {
+			sx0, sx1 := x*sw/w, (x+1)*sw/w
+			if sx1 == sx0 {
+				sx1 = sx0 + 1
+			}
+
+			var r, g, b, a, n int
+			for sy := sy0; sy < sy1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := sx0; sx < sx1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// EncodeJPEG encodes the image as JPEG.
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("error while encoding image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,93 @@
+package images_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/asankov/gira/internal/images"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+
+	decoded, err := images.Decode(encodePNG(t, img))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 40, 20), decoded.Bounds())
+}
+
+func TestDecodeError(t *testing.T) {
+	_, err := images.Decode([]byte("not an image"))
+	assert.ErrorIs(t, err, images.ErrUnsupportedFormat)
+
+	// the header claims 10000x10000 pixels, which is rejected before the pixels are decoded
+	huge := encodePNG(t, image.NewGray(image.Rect(0, 0, 10000, 10000)))
+	_, err = images.Decode(huge)
+	assert.ErrorIs(t, err, images.ErrTooLarge)
+}
+
+func TestFit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		width    int
+		height   int
+		size     int
+		expected image.Rectangle
+	}{
+		{name: "Landscape", width: 400, height: 200, size: 100, expected: image.Rect(0, 0, 100, 50)},
+		{name: "Portrait", width: 300, height: 600, size: 200, expected: image.Rect(0, 0, 100, 200)},
+		{name: "Smaller than size", width: 50, height: 80, size: 200, expected: image.Rect(0, 0, 50, 80)},
+		{name: "Very thin", width: 1000, height: 2, size: 100, expected: image.Rect(0, 0, 100, 1)},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			img := images.Fit(image.NewRGBA(image.Rect(0, 0, testCase.width, testCase.height)), testCase.size)
+
+			assert.Equal(t, testCase.expected, img.Bounds())
+		})
+	}
+}
+
+func TestFitAveragesPixels(t *testing.T) {
+	// black and white stripes, one pixel wide, average to gray
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if x%2 == 0 {
+				img.Set(x, y, color.Black)
+			} else {
+				img.Set(x, y, color.White)
+			}
+		}
+	}
+
+	scaled := images.Fit(img, 2)
+
+	assert.Equal(t, color.RGBA{R: 127, G: 127, B: 127, A: 255}, scaled.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{R: 127, G: 127, B: 127, A: 255}, scaled.RGBAAt(1, 1))
+}
+
+func TestFitFillsTransparency(t *testing.T) {
+	img := images.Fit(image.NewNRGBA(image.Rect(0, 0, 2, 2)), 10)
+
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, img.RGBAAt(0, 0))
+}
+
+func TestEncodeJPEG(t *testing.T) {
+	data, err := images.EncodeJPEG(image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	require.NoError(t, err)
+
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+}
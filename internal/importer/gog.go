@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrGOGNoTitleColumn is returned when the GOG export does not have a title column
+var ErrGOGNoTitleColumn = errors.New("the GOG export must have a 'title' column")
+
+// ParseGOG parses the CSV export of the GOG Galaxy library.
+// The games are read from the title column, the series from the series column, and the playtime from the gameMins column,
+// if they are present. The delimiter is detected from the header - comma, semicolon or tab.
+func ParseGOG(r io.Reader) ([]*LibraryGame, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(br.Size())
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("error while reading GOG export: %w", err)
+	}
+
+	cr := csv.NewReader(br)
+	cr.Comma = detectDelimiter(header)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	columns, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error while reading GOG export header: %w", err)
+	}
+	index := map[string]int{}
+	for i, column := range columns {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+	}
+	if _, ok := index["title"]; !ok {
+		return nil, ErrGOGNoTitleColumn
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := index[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	games := []*LibraryGame{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error while reading GOG export: %w", err)
+		}
+
+		name := field(record, "title")
+		if name == "" {
+			continue
+		}
+		// the minutes are not always present, in which case the game is considered not played
+		playtime, _ := strconv.Atoi(field(record, "gamemins"))
+
+		games = append(games, &LibraryGame{
+			Name:     name,
+			Series:   field(record, "series"),
+			Playtime: playtime,
+		})
+	}
+	return games, nil
+}
+
+// detectDelimiter returns the delimiter that occurs the most in the first line.
+func detectDelimiter(data []byte) rune {
+	line := string(data)
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+
+	delimiter, count := ',', strings.Count(line, ",")
+	for _, d := range []rune{';', '\t'} {
+		if c := strings.Count(line, string(d)); c > count {
+			delimiter, count = d, c
+		}
+	}
+	return delimiter
+}
@@ -0,0 +1,162 @@
+// Package importer parses the library exports of storefronts (Steam, GOG Galaxy)
+// into games, that can be reviewed and imported by the user.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// ErrUnknownSource is returned when the source of the export is not supported
+var ErrUnknownSource = errors.New("unknown import source")
+
+// LibraryGame is a single game, as read from the export of a storefront.
+type LibraryGame struct {
+	Name string
+	// Series is the series of the game, if the storefront provides it.
+	Series string
+	// Playtime is the time played, in minutes.
+	Playtime int
+}
+
+// Parse parses the export of the given source.
+func Parse(source models.ImportSource, r io.Reader) ([]*LibraryGame, error) {
+	switch source {
+	case models.ImportSourceSteam:
+		return ParseSteam(r)
+	case models.ImportSourceGOG:
+		return ParseGOG(r)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+}
+
+// Preview turns the games from the library into candidates for import.
+// It guesses the franchise and the status of each game
+// and marks the ones that are already in the backlog of the user, or repeated in the library, as duplicates.
+func Preview(source models.ImportSource, library []*LibraryGame, existing []*models.Game, franchises []*models.Franchise) *models.LibraryPreview {
+	preview := &models.LibraryPreview{
+		Source: source,
+		Games:  make([]*models.LibraryCandidate, 0, len(library)),
+	}
+
+	seen := map[string]string{}
+	for _, game := range existing {
+		seen[NormalizeName(game.Name)] = game.Name
+	}
+
+	guesser := newFranchiseGuesser(library, existing, franchises)
+	for _, game := range library {
+		candidate := &models.LibraryCandidate{
+			Name:      game.Name,
+			Franchise: guesser.guess(game),
+			Status:    models.StatusTODO,
+			Playtime:  game.Playtime,
+		}
+		if game.Playtime > 0 {
+			candidate.Status = models.StatusInProgress
+		}
+
+		normalized := NormalizeName(game.Name)
+		if name, ok := seen[normalized]; ok {
+			candidate.DuplicateOf = name
+		} else {
+			seen[normalized] = game.Name
+		}
+
+		preview.Games = append(preview.Games, candidate)
+	}
+	return preview
+}
+
+// franchiseGuesser guesses the franchise of a game by, in order:
+//   - the series, provided by the storefront
+//   - an existing franchise, whose name is the start of the name of the game
+//   - the series name of the game (e.g. Far Cry for Far Cry 3), if it is shared with other games in the library or the backlog
+type franchiseGuesser struct {
+	// franchises maps the normalized names of the existing franchises to their names
+	franchises map[string]string
+	// series counts the games of each normalized series name
+	series map[string]int
+}
+
+func newFranchiseGuesser(library []*LibraryGame, existing []*models.Game, franchises []*models.Franchise) *franchiseGuesser {
+	g := &franchiseGuesser{
+		franchises: map[string]string{},
+		series:     map[string]int{},
+	}
+	for _, f := range franchises {
+		g.franchises[NormalizeName(f.Name)] = f.Name
+	}
+
+	names := make([]string, 0, len(library)+len(existing))
+	for _, game := range library {
+		names = append(names, game.Name)
+	}
+	for _, game := range existing {
+		names = append(names, game.Name)
+	}
+	for _, name := range names {
+		if series := seriesName(name); series != "" {
+			g.series[NormalizeName(series)]++
+		}
+	}
+	return g
+}
+
+func (g *franchiseGuesser) guess(game *LibraryGame) string {
+	if game.Series != "" {
+		return g.existingOr(game.Series)
+	}
+
+	if franchise := matchFranchise(game.Name, g.franchises); franchise != "" {
+		return franchise
+	}
+
+	if series := seriesName(game.Name); series != "" && g.series[NormalizeName(series)] > 1 {
+		return g.existingOr(series)
+	}
+	return ""
+}
+
+// existingOr returns the existing franchise with the same normalized name, if any, or the given name otherwise.
+func (g *franchiseGuesser) existingOr(name string) string {
+	if existing, ok := g.franchises[NormalizeName(name)]; ok {
+		return existing
+	}
+	return name
+}
+
+// MatchFranchise returns the franchise, whose name is the start of the name of the game (e.g. Far Cry for Far Cry 3),
+// or nil if there is no such franchise.
+func MatchFranchise(name string, franchises []*models.Franchise) *models.Franchise {
+	byName := map[string]string{}
+	byID := map[string]*models.Franchise{}
+	for _, f := range franchises {
+		byName[NormalizeName(f.Name)] = f.ID
+		byID[f.ID] = f
+	}
+	if id := matchFranchise(name, byName); id != "" {
+		return byID[id]
+	}
+	return nil
+}
+
+// matchFranchise returns the value of the longest normalized franchise name in franchises, that is the start of the name.
+func matchFranchise(name string, franchises map[string]string) string {
+	normalized := NormalizeName(name)
+
+	var best, bestNormalized string
+	for franchise, value := range franchises {
+		if franchise == "" || len(franchise) <= len(bestNormalized) {
+			continue
+		}
+		if normalized == franchise || strings.HasPrefix(normalized, franchise+" ") {
+			best, bestNormalized = value, franchise
+		}
+	}
+	return best
+}
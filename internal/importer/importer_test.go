@@ -0,0 +1,161 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Hades", expected: "hades"},
+		{name: "The Witcher® 3: Wild Hunt - Game of the Year Edition", expected: "the witcher 3 wild hunt"},
+		{name: "Assassin’s Creed™ II", expected: "assassins creed ii"},
+		{name: "Ratchet & Clank", expected: "ratchet and clank"},
+		{name: "  DOOM   (2016) ", expected: "doom 2016"},
+		{name: "Remastered", expected: "remastered"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, NormalizeName(testCase.name))
+		})
+	}
+}
+
+func TestSeriesName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Far Cry 3", expected: "Far Cry"},
+		{name: "Assassin's Creed II", expected: "Assassin's Creed"},
+		{name: "Batman: Arkham City", expected: "Batman"},
+		{name: "Half-Life 2", expected: "Half-Life"},
+		{name: "Hades", expected: ""},
+		{name: "Portal", expected: ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, seriesName(testCase.name))
+		})
+	}
+}
+
+func TestParseSteam(t *testing.T) {
+	games, err := Parse(models.ImportSourceSteam, strings.NewReader(`{
+		"response": {
+			"game_count": 3,
+			"games": [
+				{"appid": 1, "name": "Far Cry 3", "playtime_forever": 120},
+				{"appid": 2, "name": "Hades", "playtime_forever": 0},
+				{"appid": 3, "playtime_forever": 5}
+			]
+		}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []*LibraryGame{
+		{Name: "Far Cry 3", Playtime: 120},
+		{Name: "Hades"},
+	}, games)
+}
+
+func TestParseSteamError(t *testing.T) {
+	_, err := ParseSteam(strings.NewReader(`{"response": {"games": [{"appid": 1}]}}`))
+	assert.ErrorIs(t, err, ErrSteamNoNames)
+
+	_, err = ParseSteam(strings.NewReader(`{`))
+	assert.Error(t, err)
+}
+
+func TestParseGOG(t *testing.T) {
+	testCases := []struct {
+		name   string
+		export string
+	}{
+		{
+			name:   "Comma",
+			export: "\ufefftitle,platformList,gameMins,series\n\"Batman: Arkham City\",\"gog,steam\",90,Batman Arkham\nHades,gog,,\n,gog,5,\n",
+		},
+		{
+			name:   "Tab",
+			export: "title\tplatformList\tgameMins\tseries\nBatman: Arkham City\tgog,steam\t90\tBatman Arkham\nHades\tgog\t\t\n",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			games, err := Parse(models.ImportSourceGOG, strings.NewReader(testCase.export))
+			require.NoError(t, err)
+			assert.Equal(t, []*LibraryGame{
+				{Name: "Batman: Arkham City", Series: "Batman Arkham", Playtime: 90},
+				{Name: "Hades"},
+			}, games)
+		})
+	}
+}
+
+func TestParseGOGError(t *testing.T) {
+	_, err := ParseGOG(strings.NewReader("name,gameMins\nHades,0\n"))
+	assert.ErrorIs(t, err, ErrGOGNoTitleColumn)
+}
+
+func TestParseUnknownSource(t *testing.T) {
+	_, err := Parse(models.ImportSource("epic"), strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrUnknownSource)
+}
+
+func TestPreview(t *testing.T) {
+	library := []*LibraryGame{
+		{Name: "Far Cry 3", Playtime: 120},
+		{Name: "Far Cry 4"},
+		{Name: "Assassin's Creed® II"},
+		{Name: "The Witcher 3: Wild Hunt - GOTY Edition", Playtime: 10},
+		{Name: "Batman: Arkham City", Series: "batman arkham"},
+		{Name: "Portal 2"},
+		{Name: "Hades"},
+		{Name: "HADES"},
+	}
+	existing := []*models.Game{
+		{Name: "The Witcher 3: Wild Hunt"},
+		{Name: "Assassin's Creed"},
+	}
+	franchises := []*models.Franchise{
+		{ID: "1", Name: "Assassin's Creed"},
+		{ID: "2", Name: "Batman Arkham"},
+	}
+
+	preview := Preview(models.ImportSourceSteam, library, existing, franchises)
+
+	assert.Equal(t, &models.LibraryPreview{
+		Source: models.ImportSourceSteam,
+		Games: []*models.LibraryCandidate{
+			{Name: "Far Cry 3", Franchise: "Far Cry", Status: models.StatusInProgress, Playtime: 120},
+			{Name: "Far Cry 4", Franchise: "Far Cry", Status: models.StatusTODO},
+			{Name: "Assassin's Creed® II", Franchise: "Assassin's Creed", Status: models.StatusTODO},
+			{Name: "The Witcher 3: Wild Hunt - GOTY Edition", Franchise: "The Witcher", Status: models.StatusInProgress, Playtime: 10, DuplicateOf: "The Witcher 3: Wild Hunt"},
+			{Name: "Batman: Arkham City", Franchise: "Batman Arkham", Status: models.StatusTODO},
+			{Name: "Portal 2", Status: models.StatusTODO},
+			{Name: "Hades", Status: models.StatusTODO},
+			{Name: "HADES", Status: models.StatusTODO, DuplicateOf: "Hades"},
+		},
+	}, preview)
+}
+
+func TestMatchFranchise(t *testing.T) {
+	franchises := []*models.Franchise{
+		{ID: "1", Name: "Far Cry"},
+		{ID: "2", Name: "Assassin's Creed"},
+		{ID: "3", Name: "Assassin's Creed Ezio Trilogy"},
+	}
+
+	assert.Equal(t, franchises[0], MatchFranchise("Far Cry 3", franchises))
+	assert.Equal(t, franchises[1], MatchFranchise("Assassin’s Creed® II", franchises))
+	assert.Equal(t, franchises[2], MatchFranchise("Assassin's Creed Ezio Trilogy: Revelations", franchises))
+	assert.Nil(t, MatchFranchise("Far Crysis", franchises))
+	assert.Nil(t, MatchFranchise("Hades", franchises))
+}
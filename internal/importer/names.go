@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"strings"
+	"unicode"
+)
+
+var (
+	// editionSuffixes are removed from the end of the names, so that the different editions of a game are considered the same game
+	editionSuffixes = []string{
+		"game of the year edition",
+		"goty edition",
+		"goty",
+		"definitive edition",
+		"complete edition",
+		"enhanced edition",
+		"deluxe edition",
+		"gold edition",
+		"ultimate edition",
+		"special edition",
+		"directors cut",
+		"remastered",
+	}
+
+	// romanNumerals can be the trailing word of a name that marks a part of a series, e.g. Assassin's Creed II
+	romanNumerals = map[string]bool{
+		"ii": true, "iii": true, "iv": true, "v": true, "vi": true, "vii": true, "viii": true, "ix": true, "x": true,
+	}
+)
+
+// NormalizeName returns the name in a form, in which it can be compared with the names of other games.
+// It is lowercased, trademark signs and punctuation are removed, and so are the edition suffixes (GOTY Edition, Remastered, etc.).
+func NormalizeName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer("&", " and ", "’", "", "'", "").Replace(name)
+
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '™' || r == '®' || r == '©':
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	normalized := strings.Join(strings.Fields(b.String()), " ")
+
+	for {
+		trimmed := normalized
+		for _, suffix := range editionSuffixes {
+			trimmed = strings.TrimSpace(strings.TrimSuffix(trimmed, suffix))
+		}
+		if trimmed == normalized || trimmed == "" {
+			return normalized
+		}
+		normalized = trimmed
+	}
+}
+
+// seriesName returns the name of the series, that the game is part of, judging only by its name, e.g.
+// Far Cry for Far Cry 3, and Batman for Batman: Arkham City.
+// It returns an empty string, if the name does not look like a part of a series.
+func seriesName(name string) string {
+	name = strings.TrimSpace(name)
+	base := name
+	// the subtitle is usually separated by a colon or a spaced dash
+	for _, separator := range []string{":", " - ", " – "} {
+		if i := strings.Index(base, separator); i > 0 {
+			base = base[:i]
+		}
+	}
+	base = strings.TrimSpace(base)
+
+	words := strings.Fields(base)
+	if len(words) > 1 && isSequelNumber(words[len(words)-1]) {
+		return strings.Join(words[:len(words)-1], " ")
+	}
+	if base != name {
+		return base
+	}
+	return ""
+}
+
+func isSequelNumber(word string) bool {
+	if romanNumerals[strings.ToLower(word)] {
+		return true
+	}
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrSteamNoNames is returned when the Steam export does not contain the names of the games
+var ErrSteamNoNames = errors.New("the Steam export does not contain the names of the games - request it with include_appinfo=1")
+
+// steamOwnedGames is the response of the IPlayerService/GetOwnedGames method of the Steam Web API
+type steamOwnedGames struct {
+	Response struct {
+		Games []struct {
+			AppID           int    `json:"appid"`
+			Name            string `json:"name"`
+			PlaytimeForever int    `json:"playtime_forever"`
+		} `json:"games"`
+	} `json:"response"`
+}
+
+// ParseSteam parses the owned games JSON, returned by the GetOwnedGames method of the Steam Web API.
+// The names of the games are included only if it was called with include_appinfo=1.
+func ParseSteam(r io.Reader) ([]*LibraryGame, error) {
+	var owned steamOwnedGames
+	if err := json.NewDecoder(r).Decode(&owned); err != nil {
+		return nil, fmt.Errorf("error while decoding Steam export: %w", err)
+	}
+
+	games := make([]*LibraryGame, 0, len(owned.Response.Games))
+	for _, g := range owned.Response.Games {
+		name := strings.TrimSpace(g.Name)
+		if name == "" {
+			continue
+		}
+		games = append(games, &LibraryGame{Name: name, Playtime: g.PlaytimeForever})
+	}
+
+	if len(games) == 0 && len(owned.Response.Games) > 0 {
+		return nil, ErrSteamNoNames
+	}
+	return games, nil
+}
@@ -0,0 +1,89 @@
+// Package live streams the changes of the games to the users, who are watching them, e.g. have the list of games open in a browser tab.
+//
+// The broker keeps the subscriptions in memory, so the changes reach only the users, that are connected to the same instance of the API.
+package live
+
+import (
+	"sync"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultBuffer is the number of changes, that are kept for a subscriber, that has not read them yet
+const defaultBuffer = 16
+
+// BrokerOptions is the struct used to construct a Broker
+type BrokerOptions struct {
+	// Buffer is the number of changes, that are kept for a subscriber, that has not read them yet.
+	// The changes above that are dropped for the subscriber.
+	Buffer int
+	Log    *logrus.Logger
+}
+
+// Broker publishes the changes to the subscribers of the users, that they concern.
+// A user can have many subscribers, e.g. one for each open tab.
+type Broker struct {
+	buffer int
+	log    *logrus.Logger
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan *models.Change]struct{}
+}
+
+// NewBroker returns a new Broker from the given options.
+func NewBroker(opts *BrokerOptions) *Broker {
+	b := &Broker{
+		buffer:      opts.Buffer,
+		log:         opts.Log,
+		subscribers: map[string]map[chan *models.Change]struct{}{},
+	}
+	if b.buffer < 1 {
+		b.buffer = defaultBuffer
+	}
+	return b
+}
+
+// Subscribe returns the changes for the given user and a function, that cancels the subscription and closes the channel.
+// The function can be called more than once.
+func (b *Broker) Subscribe(userID string) (<-chan *models.Change, func()) {
+	ch := make(chan *models.Change, b.buffer)
+
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = map[chan *models.Change]struct{}{}
+	}
+	b.subscribers[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subscribers[userID], ch)
+			if len(b.subscribers[userID]) == 0 {
+				delete(b.subscribers, userID)
+			}
+			close(ch)
+		})
+	}
+}
+
+// Publish sends the change to all subscribers of the given users.
+// It does not block - if a subscriber has not read the previous changes yet, and its buffer is full, the change is dropped for it.
+func (b *Broker) Publish(userIDs []string, change *models.Change) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, userID := range userIDs {
+		for ch := range b.subscribers[userID] {
+			select {
+			case ch <- change:
+			default:
+				b.log.Warnf("Dropping %s change of game %s for a slow subscriber of user %s", change.Type, change.GameID, userID)
+			}
+		}
+	}
+}
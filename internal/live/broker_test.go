@@ -0,0 +1,82 @@
+package live_test
+
+import (
+	"testing"
+
+	"github.com/asankov/gira/internal/live"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBroker(buffer int) *live.Broker {
+	return live.NewBroker(&live.BrokerOptions{Buffer: buffer, Log: logrus.StandardLogger()})
+}
+
+func TestPublish(t *testing.T) {
+	broker := newBroker(0)
+
+	first, cancelFirst := broker.Subscribe("1")
+	defer cancelFirst()
+	second, cancelSecond := broker.Subscribe("1")
+	defer cancelSecond()
+	other, cancelOther := broker.Subscribe("2")
+	defer cancelOther()
+
+	change := &models.Change{Type: models.ChangeGameUpdated, GameID: "10", Status: models.StatusDone}
+	broker.Publish([]string{"1"}, change)
+
+	// every tab of the user gets the change
+	assert.Equal(t, change, <-first)
+	assert.Equal(t, change, <-second)
+	// the other users do not
+	assert.Empty(t, other)
+}
+
+func TestPublishManyUsers(t *testing.T) {
+	broker := newBroker(0)
+
+	first, cancelFirst := broker.Subscribe("1")
+	defer cancelFirst()
+	second, cancelSecond := broker.Subscribe("2")
+	defer cancelSecond()
+
+	change := &models.Change{Type: models.ChangeGameDeleted, GameID: "10"}
+	// the users without subscribers are skipped
+	broker.Publish([]string{"1", "2", "3"}, change)
+
+	assert.Equal(t, change, <-first)
+	assert.Equal(t, change, <-second)
+}
+
+func TestPublishSlowSubscriber(t *testing.T) {
+	broker := newBroker(1)
+
+	changes, cancel := broker.Subscribe("1")
+	defer cancel()
+
+	first := &models.Change{Type: models.ChangeGameUpdated, GameID: "1"}
+	second := &models.Change{Type: models.ChangeGameUpdated, GameID: "2"}
+	// the second change does not fit in the buffer, so it is dropped, instead of blocking the publisher
+	broker.Publish([]string{"1"}, first)
+	broker.Publish([]string{"1"}, second)
+
+	assert.Equal(t, first, <-changes)
+	assert.Empty(t, changes)
+}
+
+func TestCancel(t *testing.T) {
+	broker := newBroker(0)
+
+	changes, cancel := broker.Subscribe("1")
+	cancel()
+	// cancelling twice is safe
+	cancel()
+
+	_, ok := <-changes
+	require.False(t, ok, "the channel should be closed")
+
+	// publishing after the subscription is cancelled does not panic
+	broker.Publish([]string{"1"}, &models.Change{Type: models.ChangeGameDeleted, GameID: "1"})
+}
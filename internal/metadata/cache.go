@@ -0,0 +1,112 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/sirupsen/logrus"
+)
+
+// Cache stores the responses of the providers (DB, in-memory, etc.)
+type Cache interface {
+	// Get returns the data stored under the key, if it is not older than maxAge, or postgres.ErrNoRecord.
+	Get(provider, key string, maxAge time.Duration) ([]byte, error)
+	Put(provider, key string, data []byte) error
+}
+
+// CachedOptions is the struct used to construct a Cached provider
+type CachedOptions struct {
+	Provider Provider
+	Cache    Cache
+	// Name is the name of the provider, under which its responses are cached
+	Name string
+	// TTL is how long the responses are cached for
+	TTL time.Duration
+	Log *logrus.Logger
+}
+
+// Cached is a Provider that caches the responses of another provider.
+// The errors of the cache are logged, and the provider is used as if the cache was empty.
+type Cached struct {
+	provider Provider
+	cache    Cache
+	name     string
+	ttl      time.Duration
+	log      *logrus.Logger
+}
+
+// NewCached returns a new Cached provider from the given options.
+func NewCached(opts *CachedOptions) *Cached {
+	return &Cached{
+		provider: opts.Provider,
+		cache:    opts.Cache,
+		name:     opts.Name,
+		ttl:      opts.TTL,
+		log:      opts.Log,
+	}
+}
+
+// Search returns the cached results of the query, or searches the provider, if there are none.
+func (c *Cached) Search(ctx context.Context, query string) ([]*models.GameMetadata, error) {
+	key := "search:" + strings.ToLower(strings.TrimSpace(query))
+
+	var games []*models.GameMetadata
+	if c.get(key, &games) {
+		return games, nil
+	}
+
+	games, err := c.provider.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, games)
+	return games, nil
+}
+
+// Details returns the cached details of the game, or fetches them from the provider, if there are none.
+func (c *Cached) Details(ctx context.Context, id string) (*models.GameMetadata, error) {
+	key := "game:" + id
+
+	var game *models.GameMetadata
+	if c.get(key, &game) && game != nil {
+		return game, nil
+	}
+
+	game, err := c.provider.Details(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, game)
+	return game, nil
+}
+
+func (c *Cached) get(key string, v interface{}) bool {
+	data, err := c.cache.Get(c.name, key, c.ttl)
+	if err != nil {
+		if !errors.Is(err, postgres.ErrNoRecord) {
+			c.log.Errorf("Error while reading %s from the metadata cache: %v", key, err)
+		}
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		c.log.Errorf("Error while decoding %s from the metadata cache: %v", key, err)
+		return false
+	}
+	return true
+}
+
+func (c *Cached) put(key string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		c.log.Errorf("Error while encoding %s for the metadata cache: %v", key, err)
+		return
+	}
+	if err := c.cache.Put(c.name, key, data); err != nil {
+		c.log.Errorf("Error while writing %s to the metadata cache: %v", key, err)
+	}
+}
@@ -0,0 +1,125 @@
+package metadata_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/internal/metadata"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is an in-memory Cache, whose entries never expire, unless expired is set
+type memoryCache struct {
+	data    map[string][]byte
+	expired bool
+	err     error
+}
+
+func (c *memoryCache) Get(provider, key string, maxAge time.Duration) ([]byte, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	data, ok := c.data[provider+"/"+key]
+	if !ok || c.expired {
+		return nil, postgres.ErrNoRecord
+	}
+	return data, nil
+}
+
+func (c *memoryCache) Put(provider, key string, data []byte) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.data[provider+"/"+key] = data
+	return nil
+}
+
+func newCached(provider metadata.Provider, cache metadata.Cache) *metadata.Cached {
+	return metadata.NewCached(&metadata.CachedOptions{
+		Provider: provider,
+		Cache:    cache,
+		Name:     "fake",
+		TTL:      time.Hour,
+		Log:      logrus.StandardLogger(),
+	})
+}
+
+func TestCachedSearch(t *testing.T) {
+	provider := fixtures.NewMetadataProvider()
+	cache := &memoryCache{data: map[string][]byte{}}
+	cached := newCached(provider, cache)
+
+	games, err := cached.Search(context.Background(), "Far Cry")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Far Cry 3"}, names(games))
+
+	games, err = cached.Search(context.Background(), " far cry")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Far Cry 3"}, names(games))
+	assert.Equal(t, 1, provider.Searches())
+	assert.Contains(t, cache.data, "fake/search:far cry")
+
+	cache.expired = true
+	_, err = cached.Search(context.Background(), "far cry")
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.Searches())
+}
+
+func TestCachedDetails(t *testing.T) {
+	provider := fixtures.NewMetadataProvider()
+	cached := newCached(provider, &memoryCache{data: map[string][]byte{}})
+
+	game, err := cached.Details(context.Background(), "274755")
+	require.NoError(t, err)
+	assert.Equal(t, provider.Games[2], game)
+
+	game, err = cached.Details(context.Background(), "274755")
+	require.NoError(t, err)
+	assert.Equal(t, provider.Games[2], game)
+	assert.Equal(t, 1, provider.Lookups())
+
+	// unknown games are not cached
+	for i := 0; i < 2; i++ {
+		_, err = cached.Details(context.Background(), "0")
+		assert.ErrorIs(t, err, metadata.ErrNotFound)
+	}
+	assert.Equal(t, 3, provider.Lookups())
+}
+
+func TestCachedCacheError(t *testing.T) {
+	provider := fixtures.NewMetadataProvider()
+	cached := newCached(provider, &memoryCache{err: errors.New("intentional error")})
+
+	for i := 0; i < 2; i++ {
+		game, err := cached.Details(context.Background(), "3328")
+		require.NoError(t, err)
+		assert.Equal(t, provider.Games[0], game)
+	}
+	assert.Equal(t, 2, provider.Lookups())
+}
+
+func TestCachedProviderError(t *testing.T) {
+	provider := fixtures.NewMetadataProvider()
+	provider.Err = metadata.ErrProvider
+	cache := &memoryCache{data: map[string][]byte{}}
+	cached := newCached(provider, cache)
+
+	_, err := cached.Search(context.Background(), "hades")
+	assert.ErrorIs(t, err, metadata.ErrProvider)
+	assert.Empty(t, cache.data)
+}
+
+func names(games []*models.GameMetadata) []string {
+	names := []string{}
+	for _, game := range games {
+		names = append(names, game.Name)
+	}
+	return names
+}
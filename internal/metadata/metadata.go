@@ -0,0 +1,25 @@
+// Package metadata fetches the details of games (cover art, release date, genres, developer)
+// from external metadata providers, and caches them.
+package metadata
+
+import (
+	"context"
+	"errors"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+var (
+	// ErrNotFound is returned when the provider does not know a game with the requested ID
+	ErrNotFound = errors.New("game not found in metadata provider")
+	// ErrProvider is returned when the provider could not be reached, or returned an unexpected response
+	ErrProvider = errors.New("error while fetching metadata from provider")
+)
+
+// Provider is a source of game metadata.
+type Provider interface {
+	// Search returns the games, whose names match the query, best matches first.
+	Search(ctx context.Context, query string) ([]*models.GameMetadata, error)
+	// Details returns the game with the given ID, or ErrNotFound.
+	Details(ctx context.Context, id string) (*models.GameMetadata, error)
+}
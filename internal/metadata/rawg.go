@@ -0,0 +1,154 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// DefaultRAWGURL is the address of the public RAWG API
+const DefaultRAWGURL = "https://api.rawg.io/api"
+
+// rawgSearchSize is the number of games returned by a search
+const rawgSearchSize = 10
+
+// RAWGOptions is the struct used to construct a RAWG provider
+type RAWGOptions struct {
+	// URL is the address of the API. Defaults to DefaultRAWGURL.
+	URL    string
+	APIKey string
+
+	HTTPClient *http.Client
+}
+
+// RAWG is a Provider backed by the RAWG video games database (https://rawg.io/apidocs).
+type RAWG struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+}
+
+type rawgGame struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	Released        string `json:"released"`
+	BackgroundImage string `json:"background_image"`
+	Genres          []struct {
+		Name string `json:"name"`
+	} `json:"genres"`
+	Developers []struct {
+		Name string `json:"name"`
+	} `json:"developers"`
+}
+
+// NewRAWG returns a new RAWG provider from the given options.
+func NewRAWG(opts *RAWGOptions) *RAWG {
+	u := opts.URL
+	if u == "" {
+		u = DefaultRAWGURL
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &RAWG{
+		url:        u,
+		apiKey:     opts.APIKey,
+		httpClient: httpClient,
+	}
+}
+
+// Search returns the games, whose names match the query.
+// The developers are not part of the search results of RAWG, so they are only returned by Details.
+func (p *RAWG) Search(ctx context.Context, query string) ([]*models.GameMetadata, error) {
+	params := url.Values{}
+	params.Set("search", query)
+	params.Set("page_size", strconv.Itoa(rawgSearchSize))
+
+	var resp struct {
+		Results []*rawgGame `json:"results"`
+	}
+	if err := p.get(ctx, "/games", params, &resp); err != nil {
+		return nil, err
+	}
+
+	games := make([]*models.GameMetadata, 0, len(resp.Results))
+	for _, game := range resp.Results {
+		games = append(games, game.metadata())
+	}
+	return games, nil
+}
+
+// Details returns the game with the given ID.
+func (p *RAWG) Details(ctx context.Context, id string) (*models.GameMetadata, error) {
+	var game rawgGame
+	if err := p.get(ctx, "/games/"+url.PathEscape(id), url.Values{}, &game); err != nil {
+		return nil, err
+	}
+	return game.metadata(), nil
+}
+
+func (p *RAWG) get(ctx context.Context, path string, params url.Values, v interface{}) error {
+	params.Set("key", p.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?%s", p.url, path, params.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("error while building HTTP request: %w", withoutURL(err))
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrProvider, withoutURL(err))
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return ErrNotFound
+	default:
+		return fmt.Errorf("%w: unexpected status code %d", ErrProvider, res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: error while decoding body: %v", ErrProvider, err)
+	}
+	return nil
+}
+
+// withoutURL strips the URL from the errors of building and sending a request,
+// because it contains the API key and the errors end up in the logs
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+func (g *rawgGame) metadata() *models.GameMetadata {
+	metadata := &models.GameMetadata{
+		ID:       strconv.Itoa(g.ID),
+		Name:     g.Name,
+		CoverURL: g.BackgroundImage,
+	}
+	// the release date is unknown for unreleased games
+	if released, err := time.Parse("2006-01-02", g.Released); err == nil {
+		metadata.ReleaseDate = &released
+	}
+	for _, genre := range g.Genres {
+		metadata.Genres = append(metadata.Genres, genre.Name)
+	}
+	if len(g.Developers) > 0 {
+		metadata.Developer = g.Developers[0].Name
+	}
+	return metadata
+}
@@ -0,0 +1,107 @@
+package metadata_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/metadata"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRAWGServer returns a RAWG provider, whose API responds to every request with the given code and body.
+// check is called with each request, before it is responded to.
+func newRAWGServer(t *testing.T, code int, body string, check func(r *http.Request)) *metadata.RAWG {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		check(r)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Errorf("Got unexpected error while writing response - %v", err)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	return metadata.NewRAWG(&metadata.RAWGOptions{URL: ts.URL, APIKey: "api-key"})
+}
+
+func TestRAWGSearch(t *testing.T) {
+	provider := newRAWGServer(t, http.StatusOK, `{"count": 2, "results": [
+		{"id": 3328, "name": "The Witcher 3: Wild Hunt", "released": "2015-05-18", "background_image": "https://media.rawg.io/witcher3.jpg", "genres": [{"name": "Action"}, {"name": "RPG"}]},
+		{"id": 1, "name": "The Witcher 4", "released": null, "background_image": null, "genres": []}
+	]}`, func(r *http.Request) {
+		assert.Equal(t, "/games", r.URL.Path)
+		assert.Equal(t, "api-key", r.URL.Query().Get("key"))
+		assert.Equal(t, "witcher", r.URL.Query().Get("search"))
+		assert.Equal(t, "10", r.URL.Query().Get("page_size"))
+	})
+
+	games, err := provider.Search(context.Background(), "witcher")
+	require.NoError(t, err)
+
+	released := time.Date(2015, time.May, 18, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []*models.GameMetadata{
+		{ID: "3328", Name: "The Witcher 3: Wild Hunt", CoverURL: "https://media.rawg.io/witcher3.jpg", ReleaseDate: &released, Genres: []string{"Action", "RPG"}},
+		{ID: "1", Name: "The Witcher 4"},
+	}, games)
+}
+
+func TestRAWGDetails(t *testing.T) {
+	provider := newRAWGServer(t, http.StatusOK, `{"id": 3328, "name": "The Witcher 3: Wild Hunt", "released": "2015-05-18", "background_image": "https://media.rawg.io/witcher3.jpg",
+		"genres": [{"name": "RPG"}], "developers": [{"name": "CD PROJEKT RED"}, {"name": "Other"}]}`, func(r *http.Request) {
+		assert.Equal(t, "/games/3328", r.URL.Path)
+		assert.Equal(t, "api-key", r.URL.Query().Get("key"))
+	})
+
+	game, err := provider.Details(context.Background(), "3328")
+	require.NoError(t, err)
+
+	released := time.Date(2015, time.May, 18, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, &models.GameMetadata{
+		ID:          "3328",
+		Name:        "The Witcher 3: Wild Hunt",
+		CoverURL:    "https://media.rawg.io/witcher3.jpg",
+		ReleaseDate: &released,
+		Genres:      []string{"RPG"},
+		Developer:   "CD PROJEKT RED",
+	}, game)
+}
+
+func TestRAWGError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		body        string
+		expectedErr error
+	}{
+		{name: "Not found", code: http.StatusNotFound, body: `{"detail": "Not found."}`, expectedErr: metadata.ErrNotFound},
+		{name: "Unauthorized", code: http.StatusUnauthorized, body: `{"error": "The key parameter is not provided"}`, expectedErr: metadata.ErrProvider},
+		{name: "Invalid body", code: http.StatusOK, body: `{`, expectedErr: metadata.ErrProvider},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			provider := newRAWGServer(t, testCase.code, testCase.body, func(r *http.Request) {})
+
+			game, err := provider.Details(context.Background(), "3328")
+			assert.Nil(t, game)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestRAWGErrorWithoutKey(t *testing.T) {
+	// the server is closed, so the request fails before there is a response
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+	provider := metadata.NewRAWG(&metadata.RAWGOptions{URL: ts.URL, APIKey: "secret-api-key"})
+
+	games, err := provider.Search(context.Background(), "witcher")
+	assert.Nil(t, games)
+	assert.ErrorIs(t, err, metadata.ErrProvider)
+	assert.NotContains(t, err.Error(), "secret-api-key")
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	trustedProxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		expectedIP   string
+	}{
+		{
+			name:       "No proxy",
+			remoteAddr: "1.2.3.4:1234",
+			expectedIP: "1.2.3.4",
+		},
+		{
+			name:         "Untrusted proxy",
+			remoteAddr:   "1.2.3.4:1234",
+			forwardedFor: "5.6.7.8",
+			expectedIP:   "1.2.3.4",
+		},
+		{
+			name:         "Trusted proxy",
+			remoteAddr:   "10.0.0.2:1234",
+			forwardedFor: "5.6.7.8",
+			expectedIP:   "5.6.7.8",
+		},
+		{
+			name:         "Chain of trusted proxies",
+			remoteAddr:   "10.0.0.2:1234",
+			forwardedFor: "6.6.6.6, 5.6.7.8, 192.168.1.1",
+			expectedIP:   "5.6.7.8",
+		},
+		{
+			name:       "Trusted proxy without header",
+			remoteAddr: "10.0.0.2:1234",
+			expectedIP: "10.0.0.2",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = testCase.remoteAddr
+			if testCase.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", testCase.forwardedFor)
+			}
+
+			assert.Equal(t, testCase.expectedIP, ClientIP(r, trustedProxies))
+		})
+	}
+}
+
+func TestParseTrustedProxiesError(t *testing.T) {
+	_, err := ParseTrustedProxies([]string{"not-an-ip"})
+
+	require.Error(t, err)
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Limit is the number of requests that can be made in the given period.
+// The requests are limited via a token bucket with capacity Requests,
+// that is fully refilled for Period.
+// This means that bursts of up to Requests are allowed,
+// but the sustained rate can not exceed Requests per Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Result is the result of taking a token from a bucket.
+type Result struct {
+	// Allowed is whether the request is allowed.
+	Allowed bool
+	// Limit is the capacity of the bucket.
+	Limit int
+	// Remaining is the number of requests that can still be made right away.
+	Remaining int
+	// Reset is the duration after which the bucket will be full again.
+	Reset time.Duration
+	// RetryAfter is the duration after which the next request will be allowed,
+	// if this one was not.
+	RetryAfter time.Duration
+}
+
+// Store is the interface that stores the token buckets.
+// The in-memory implementation is enough for a single instance of the API,
+// but multiple instances need a shared one.
+type Store interface {
+	// Take takes a token from the bucket with the given key,
+	// creating it with the given limit if it does not exist.
+	Take(key string, limit Limit) (*Result, error)
+}
+
+// RateLimitOptions is the struct used to construct a RateLimit middleware.
+type RateLimitOptions struct {
+	// Name is used to namespace the buckets, so that the same client
+	// has separate buckets for the different limits.
+	Name  string
+	Limit Limit
+	Store Store
+	// Key returns the key by which the requests are limited, e.g. the user or the client IP.
+	Key func(r *http.Request) string
+	Log *logrus.Logger
+}
+
+// RateLimit returns a middleware that limits the requests, according to the given options.
+// It sets the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers on every response,
+// and responds with 429 Too Many Requests and a Retry-After header, when the limit is exceeded.
+// If the store returns an error, the request is allowed.
+func RateLimit(opts *RateLimitOptions) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := fmt.Sprintf("%s:%s", opts.Name, opts.Key(r))
+			res, err := opts.Store.Take(key, opts.Limit)
+			if err != nil {
+				opts.Log.Errorf("Error while checking rate limit for %s: %v", key, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", fmt.Sprintf("%d", res.Limit))
+			w.Header().Set("RateLimit-Remaining", fmt.Sprintf("%d", res.Remaining))
+			w.Header().Set("RateLimit-Reset", fmt.Sprintf("%d", seconds(res.Reset)))
+
+			if !res.Allowed {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", seconds(res.RetryAfter)))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// MemoryStore is an in-memory Store.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is the time at which the bucket will be full again,
+	// after which it can be safely forgotten.
+	full time.Time
+}
+
+// pruneInterval is how often the full buckets are removed from a MemoryStore.
+const pruneInterval = time.Minute
+
+// NewMemoryStore returns a new, empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Take implements Store.
+func (s *MemoryStore) Take(key string, limit Limit) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.prune(now)
+
+	capacity := float64(limit.Requests)
+	// tokens per second
+	rate := capacity / limit.Period.Seconds()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity}
+		s.buckets[key] = b
+	} else {
+		b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	}
+	b.updated = now
+
+	res := &Result{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = duration((1 - b.tokens) / rate)
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = duration((capacity - b.tokens) / rate)
+	b.full = now.Add(res.Reset)
+
+	return res, nil
+}
+
+// prune removes the buckets that are already full,
+// so that the memory does not grow unbounded.
+func (s *MemoryStore) prune(now time.Time) {
+	if now.Sub(s.lastPrune) < pruneInterval {
+		return
+	}
+	s.lastPrune = now
+
+	for key, b := range s.buckets {
+		if !b.full.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func duration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
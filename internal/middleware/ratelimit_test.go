@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	limit = Limit{Requests: 2, Period: 10 * time.Second}
+)
+
+func newMemoryStore() (*MemoryStore, *time.Time) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	store, now := newMemoryStore()
+
+	res, err := store.Take("key", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Limit)
+	assert.Equal(t, 1, res.Remaining)
+	assert.Equal(t, 5*time.Second, res.Reset)
+
+	res, err = store.Take("key", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 10*time.Second, res.Reset)
+
+	res, err = store.Take("key", limit)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 5*time.Second, res.RetryAfter)
+
+	res, err = store.Take("other-key", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	*now = now.Add(5 * time.Second)
+	res, err = store.Take("key", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+}
+
+func TestMemoryStorePrunesFullBuckets(t *testing.T) {
+	store, now := newMemoryStore()
+
+	_, err := store.Take("key", limit)
+	require.NoError(t, err)
+
+	*now = now.Add(time.Hour)
+	_, err = store.Take("other-key", limit)
+	require.NoError(t, err)
+
+	assert.Len(t, store.buckets, 1)
+}
+
+type errStore struct{}
+
+func (errStore) Take(key string, limit Limit) (*Result, error) {
+	return nil, errors.New("intentional error")
+}
+
+func newRateLimitHandler(store Store) http.Handler {
+	return RateLimit(&RateLimitOptions{
+		Name:  "test",
+		Limit: Limit{Requests: 1, Period: time.Minute},
+		Store: store,
+		Key: func(r *http.Request) string {
+			return r.Header.Get("X-Key")
+		},
+		Log: logger,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+}
+
+func TestRateLimit(t *testing.T) {
+	h := newRateLimitHandler(NewMemoryStore())
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Key", "a")
+	h.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	gassert.StatusCode(t, w, http.StatusTooManyRequests)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Key", "b")
+	h.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+}
+
+func TestRateLimitStoreError(t *testing.T) {
+	h := newRateLimitHandler(errStore{})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.ServeHTTP(w, r)
+
+	gassert.StatusOK(t, w)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// SendMailFunc sends an email. It has the signature of smtp.SendMail.
+type SendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailOptions is the struct used to construct an Email notifier
+type EmailOptions struct {
+	// Host and Port are the address of the SMTP server
+	Host string
+	Port int
+	// Username and Password authenticate to the SMTP server. If Username is empty, no authentication is used.
+	Username string
+	Password string
+	// From is the sender of the emails
+	From string
+	// SendMail sends the emails. If nil, smtp.SendMail is used.
+	SendMail SendMailFunc
+}
+
+// Email is a Notifier, that sends the notifications to the emails of the users through an SMTP server.
+type Email struct {
+	addr     string
+	auth     smtp.Auth
+	from     string
+	sendMail SendMailFunc
+}
+
+// NewEmail returns a new Email notifier from the given options.
+func NewEmail(opts *EmailOptions) *Email {
+	e := &Email{
+		addr:     net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)),
+		from:     opts.From,
+		sendMail: opts.SendMail,
+	}
+	if opts.Username != "" {
+		e.auth = smtp.PlainAuth("", opts.Username, opts.Password, opts.Host)
+	}
+	if e.sendMail == nil {
+		e.sendMail = smtp.SendMail
+	}
+	return e
+}
+
+// Notify sends the notification to the email of the user.
+func (e *Email) Notify(ctx context.Context, message *Message) error {
+	if message.User.Email == "" {
+		return fmt.Errorf("%w: user %s has no email", ErrDelivery, message.User.ID)
+	}
+
+	if err := e.sendMail(e.addr, e.auth, e.from, []string{message.User.Email}, e.compose(message)); err != nil {
+		return fmt.Errorf("%w: %v", ErrDelivery, err)
+	}
+	return nil
+}
+
+// compose builds the email with the headers, as expected by smtp.SendMail
+func (e *Email) compose(message *Message) []byte {
+	subject := "Gira notification"
+	if message.Notification.GameName != "" {
+		subject = fmt.Sprintf("Gira: %s", message.Notification.GameName)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.User.Email)
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerValue(subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "Hello, %s,\r\n\r\n%s\r\n", message.User.Username, message.Notification.Message)
+	return []byte(b.String())
+}
+
+// headerValue removes the line breaks from the value, so that it can not add headers to the email
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,75 @@
+package notify_test
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"testing"
+
+	"github.com/asankov/gira/internal/notify"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmail(t *testing.T) {
+	var addr, from string
+	var to []string
+	var msg []byte
+	email := notify.NewEmail(&notify.EmailOptions{
+		Host: "smtp.example.com",
+		Port: 587,
+		From: "gira@example.com",
+		SendMail: func(a string, auth smtp.Auth, f string, t []string, m []byte) error {
+			addr, from, to, msg = a, f, t, m
+			return nil
+		},
+	})
+
+	err := email.Notify(context.Background(), &notify.Message{
+		User:         &models.User{ID: "1", Username: "anton", Email: "anton@example.com"},
+		Preferences:  &models.NotificationPreferences{Email: true},
+		Notification: &models.Notification{GameName: "Hades\r\nBcc: eve@example.com", Message: "You have not played Hades for 45 days."},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "smtp.example.com:587", addr)
+	assert.Equal(t, "gira@example.com", from)
+	assert.Equal(t, []string{"anton@example.com"}, to)
+	assert.Equal(t, "From: gira@example.com\r\n"+
+		"To: anton@example.com\r\n"+
+		"Subject: Gira: Hades  Bcc: eve@example.com\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n"+
+		"\r\n"+
+		"Hello, anton,\r\n\r\nYou have not played Hades for 45 days.\r\n", string(msg))
+}
+
+func TestEmailError(t *testing.T) {
+	testCases := []struct {
+		name  string
+		user  *models.User
+		error error
+	}{
+		{name: "No email", user: &models.User{ID: "1"}},
+		{name: "SMTP error", user: &models.User{ID: "1", Email: "anton@example.com"}, error: errors.New("intentional error")},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			email := notify.NewEmail(&notify.EmailOptions{
+				Host: "smtp.example.com",
+				Port: 587,
+				SendMail: func(string, smtp.Auth, string, []string, []byte) error {
+					return testCase.error
+				},
+			})
+
+			err := email.Notify(context.Background(), &notify.Message{
+				User:         testCase.user,
+				Preferences:  &models.NotificationPreferences{Email: true},
+				Notification: &models.Notification{Message: "Hello"},
+			})
+			assert.ErrorIs(t, err, notify.ErrDelivery)
+		})
+	}
+}
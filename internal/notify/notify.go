@@ -0,0 +1,46 @@
+// Package notify delivers notifications to the users through the channels they prefer (in-app, email, webhook),
+// and schedules the reminders about the games, that are In Progress, but were forgotten.
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// ErrDelivery is returned when a notification could not be delivered, e.g. the webhook of the user is not reachable
+var ErrDelivery = errors.New("error while delivering notification")
+
+// Message is a notification, together with its recipient and their preferences
+type Message struct {
+	User         *models.User
+	Preferences  *models.NotificationPreferences
+	Notification *models.Notification
+}
+
+// Notifier delivers notifications through a single channel.
+type Notifier interface {
+	Notify(ctx context.Context, message *Message) error
+}
+
+// Store stores the in-app notifications (DB, service, etc.)
+type Store interface {
+	InsertNotification(notification *models.Notification) (*models.Notification, error)
+}
+
+// InApp is a Notifier, that puts the notifications in the inbox of the user in the application.
+type InApp struct {
+	store Store
+}
+
+// NewInApp returns a new InApp notifier, that stores the notifications in the given store.
+func NewInApp(store Store) *InApp {
+	return &InApp{store: store}
+}
+
+// Notify stores the notification in the inbox of the user.
+func (n *InApp) Notify(ctx context.Context, message *Message) error {
+	_, err := n.store.InsertNotification(message.Notification)
+	return err
+}
@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// StaleGameStore finds the stale games and remembers the reminders about them (DB, service, etc.)
+type StaleGameStore interface {
+	// StaleGames returns the games, whose owners should be reminded about them now
+	StaleGames(now time.Time) ([]*models.StaleGame, error)
+	// MarkReminded records that the owner of the game was reminded about it
+	MarkReminded(gameID string, now time.Time) error
+}
+
+// SchedulerOptions is the struct used to construct a Scheduler
+type SchedulerOptions struct {
+	Store StaleGameStore
+	// Notifiers are the notifiers of the channels. The channels without a notifier are skipped, e.g. email, if there is no SMTP server.
+	Notifiers map[models.NotificationChannel]Notifier
+	// Interval is how often the stale games are checked
+	Interval time.Duration
+	Log      *logrus.Logger
+}
+
+// Scheduler periodically reminds the users about their stale games - the ones In Progress, whose status or progress did not change for a long time.
+type Scheduler struct {
+	store     StaleGameStore
+	notifiers map[models.NotificationChannel]Notifier
+	interval  time.Duration
+	log       *logrus.Logger
+}
+
+// NewScheduler returns a new Scheduler from the given options.
+func NewScheduler(opts *SchedulerOptions) *Scheduler {
+	return &Scheduler{
+		store:     opts.Store,
+		notifiers: opts.Notifiers,
+		interval:  opts.Interval,
+		log:       opts.Log,
+	}
+}
+
+// Run checks the stale games every interval, until the context is done.
+// The errors are logged, so that a single failed check does not stop the reminders.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Check(ctx, time.Now()); err != nil {
+			s.log.Errorf("Error while checking stale games: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check reminds the users about the games, that are stale at the given time, through their preferred channels.
+// A game is marked as reminded, if the reminder is delivered through at least one channel,
+// otherwise it is retried with the next check.
+func (s *Scheduler) Check(ctx context.Context, now time.Time) error {
+	games, err := s.store.StaleGames(now)
+	if err != nil {
+		return err
+	}
+
+	for _, game := range games {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		message := &Message{
+			User:         game.User,
+			Preferences:  game.Preferences,
+			Notification: staleGameNotification(game, now),
+		}
+
+		delivered := false
+		for _, channel := range game.Preferences.Channels() {
+			notifier, ok := s.notifiers[channel]
+			if !ok {
+				continue
+			}
+			if err := notifier.Notify(ctx, message); err != nil {
+				s.log.Warnf("Error while reminding user %s about game %s through %s: %v", game.User.ID, game.Game.ID, channel, err)
+				continue
+			}
+			delivered = true
+		}
+
+		if delivered {
+			if err := s.store.MarkReminded(game.Game.ID, now); err != nil {
+				s.log.Errorf("Error while marking game %s as reminded: %v", game.Game.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func staleGameNotification(game *models.StaleGame, now time.Time) *models.Notification {
+	days := game.Preferences.StaleAfterDays
+	if game.ProgressUpdatedAt != nil {
+		days = int(now.Sub(*game.ProgressUpdatedAt).Hours() / 24)
+	}
+	return &models.Notification{
+		Kind:      models.NotificationStaleGame,
+		GameID:    game.Game.ID,
+		GameName:  game.Game.Name,
+		Message:   fmt.Sprintf("You have not played %s for %d days. Pick it up again, or move it back to To Do.", game.Game.Name, days),
+		CreatedAt: now,
+		UserID:    game.User.ID,
+	}
+}
@@ -0,0 +1,169 @@
+package notify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/notify"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeStore returns the given stale games and remembers which ones were marked as reminded
+type fakeStore struct {
+	games    []*models.StaleGame
+	err      error
+	reminded []string
+}
+
+func (s *fakeStore) StaleGames(now time.Time) ([]*models.StaleGame, error) {
+	return s.games, s.err
+}
+
+func (s *fakeStore) MarkReminded(gameID string, now time.Time) error {
+	s.reminded = append(s.reminded, gameID)
+	return nil
+}
+
+// fakeNotifier remembers the messages, that it delivered, or fails with err
+type fakeNotifier struct {
+	messages []*notify.Message
+	err      error
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, message *notify.Message) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+// cancelNotifier cancels the context, when it delivers a message
+type cancelNotifier context.CancelFunc
+
+func (n cancelNotifier) Notify(ctx context.Context, message *notify.Message) error {
+	n()
+	return nil
+}
+
+func staleGame(id, name string, daysAgo int, prefs *models.NotificationPreferences) *models.StaleGame {
+	progressUpdatedAt := now.AddDate(0, 0, -daysAgo)
+	return &models.StaleGame{
+		Game:              &models.Game{ID: id, Name: name, Status: models.StatusInProgress},
+		User:              &models.User{ID: "1", Username: "anton", Email: "anton@example.com"},
+		Preferences:       prefs,
+		ProgressUpdatedAt: &progressUpdatedAt,
+	}
+}
+
+func TestSchedulerCheck(t *testing.T) {
+	store := &fakeStore{games: []*models.StaleGame{
+		staleGame("1", "Hades", 45, &models.NotificationPreferences{StaleReminders: true, StaleAfterDays: 30, InApp: true, Email: true}),
+		staleGame("2", "Celeste", 10, &models.NotificationPreferences{StaleReminders: true, StaleAfterDays: 7, WebhookURL: "https://example.com/hook"}),
+	}}
+	inApp, email, webhook := &fakeNotifier{}, &fakeNotifier{}, &fakeNotifier{}
+	scheduler := notify.NewScheduler(&notify.SchedulerOptions{
+		Store: store,
+		Notifiers: map[models.NotificationChannel]notify.Notifier{
+			models.NotificationInApp:   inApp,
+			models.NotificationEmail:   email,
+			models.NotificationWebhook: webhook,
+		},
+		Log: logrus.StandardLogger(),
+	})
+
+	require.NoError(t, scheduler.Check(context.Background(), now))
+
+	require.Len(t, inApp.messages, 1)
+	assert.Equal(t, &models.Notification{
+		Kind:      models.NotificationStaleGame,
+		GameID:    "1",
+		GameName:  "Hades",
+		Message:   "You have not played Hades for 45 days. Pick it up again, or move it back to To Do.",
+		CreatedAt: now,
+		UserID:    "1",
+	}, inApp.messages[0].Notification)
+	require.Len(t, email.messages, 1)
+	assert.Equal(t, "Hades", email.messages[0].Notification.GameName)
+	require.Len(t, webhook.messages, 1)
+	assert.Equal(t, "Celeste", webhook.messages[0].Notification.GameName)
+	assert.Equal(t, []string{"1", "2"}, store.reminded)
+}
+
+func TestSchedulerCheckDeliveryErrors(t *testing.T) {
+	store := &fakeStore{games: []*models.StaleGame{
+		// delivered through one of the channels
+		staleGame("1", "Hades", 45, &models.NotificationPreferences{InApp: true, Email: true}),
+		// not delivered at all, so it is retried with the next check
+		staleGame("2", "Celeste", 45, &models.NotificationPreferences{Email: true}),
+		// no configured notifier for the channel
+		staleGame("3", "Doom", 45, &models.NotificationPreferences{WebhookURL: "https://example.com/hook"}),
+	}}
+	inApp := &fakeNotifier{}
+	scheduler := notify.NewScheduler(&notify.SchedulerOptions{
+		Store: store,
+		Notifiers: map[models.NotificationChannel]notify.Notifier{
+			models.NotificationInApp: inApp,
+			models.NotificationEmail: &fakeNotifier{err: errors.New("intentional error")},
+		},
+		Log: logrus.StandardLogger(),
+	})
+
+	require.NoError(t, scheduler.Check(context.Background(), now))
+
+	assert.Len(t, inApp.messages, 1)
+	assert.Equal(t, []string{"1"}, store.reminded)
+}
+
+func TestSchedulerCheckStoreError(t *testing.T) {
+	scheduler := notify.NewScheduler(&notify.SchedulerOptions{
+		Store: &fakeStore{err: errors.New("intentional error")},
+		Log:   logrus.StandardLogger(),
+	})
+
+	require.Error(t, scheduler.Check(context.Background(), now))
+}
+
+func TestSchedulerRun(t *testing.T) {
+	store := &fakeStore{games: []*models.StaleGame{
+		staleGame("1", "Hades", 45, &models.NotificationPreferences{InApp: true}),
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheduler := notify.NewScheduler(&notify.SchedulerOptions{
+		Store:     store,
+		Notifiers: map[models.NotificationChannel]notify.Notifier{models.NotificationInApp: cancelNotifier(cancel)},
+		Interval:  time.Hour,
+		Log:       logrus.StandardLogger(),
+	})
+
+	// the games are checked once on start, and Run returns, because the context is done after the first reminder
+	scheduler.Run(ctx)
+
+	assert.Equal(t, []string{"1"}, store.reminded)
+}
+
+func TestInApp(t *testing.T) {
+	store := &fakeInAppStore{}
+	notification := &models.Notification{Kind: models.NotificationStaleGame, Message: "Hello", UserID: "1"}
+
+	err := notify.NewInApp(store).Notify(context.Background(), &notify.Message{Notification: notification})
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Notification{notification}, store.notifications)
+}
+
+type fakeInAppStore struct {
+	notifications []*models.Notification
+}
+
+func (s *fakeInAppStore) InsertNotification(notification *models.Notification) (*models.Notification, error) {
+	s.notifications = append(s.notifications, notification)
+	return notification, nil
+}
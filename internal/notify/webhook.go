@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/asankov/gira/internal/webhooks"
+)
+
+// Webhook is a Notifier, that posts the notifications as JSON to the webhook URLs of the users.
+type Webhook struct {
+	client *http.Client
+}
+
+// NewWebhook returns a new Webhook notifier, that posts with the given client.
+// If client is nil, webhooks.NewClient(nil) is used, which refuses to connect to the internal network.
+func NewWebhook(client *http.Client) *Webhook {
+	if client == nil {
+		client = webhooks.NewClient(nil)
+	}
+	return &Webhook{client: client}
+}
+
+// Notify posts the notification to the webhook URL of the user. Any 2xx response is a successful delivery.
+func (w *Webhook) Notify(ctx context.Context, message *Message) error {
+	if message.Preferences.WebhookURL == "" {
+		return fmt.Errorf("%w: user %s has no webhook", ErrDelivery, message.User.ID)
+	}
+
+	data, err := json.Marshal(message.Notification)
+	if err != nil {
+		return fmt.Errorf("error while marshalling notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, message.Preferences.WebhookURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDelivery, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDelivery, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("%w: webhook responded with %d", ErrDelivery, res.StatusCode)
+	}
+	return nil
+}
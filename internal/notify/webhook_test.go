@@ -0,0 +1,78 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/asankov/gira/internal/notify"
+	"github.com/asankov/gira/internal/webhooks"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	notification := &models.Notification{Kind: models.NotificationStaleGame, GameID: "1", GameName: "Hades", Message: "Hello", CreatedAt: now}
+
+	var received models.Notification
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	err := notify.NewWebhook(ts.Client()).Notify(context.Background(), &notify.Message{
+		User:         &models.User{ID: "1"},
+		Preferences:  &models.NotificationPreferences{WebhookURL: ts.URL},
+		Notification: notification,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, *notification, received)
+}
+
+func TestWebhookError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	testCases := []struct {
+		name string
+		url  string
+	}{
+		{name: "No webhook"},
+		{name: "Error response", url: ts.URL},
+		{name: "Invalid URL", url: "://example"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := notify.NewWebhook(ts.Client()).Notify(context.Background(), &notify.Message{
+				User:         &models.User{ID: "1"},
+				Preferences:  &models.NotificationPreferences{WebhookURL: testCase.url},
+				Notification: &models.Notification{Message: "Hello"},
+			})
+			assert.ErrorIs(t, err, notify.ErrDelivery)
+		})
+	}
+}
+
+func TestWebhookInternalAddress(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the webhook on the loopback should not be called")
+	}))
+	defer ts.Close()
+
+	// the default client refuses the internal addresses, like the one of the test server
+	err := notify.NewWebhook(nil).Notify(context.Background(), &notify.Message{
+		User:         &models.User{ID: "1"},
+		Preferences:  &models.NotificationPreferences{WebhookURL: ts.URL},
+		Notification: &models.Notification{Message: "Hello"},
+	})
+	assert.ErrorIs(t, err, notify.ErrDelivery)
+	assert.Contains(t, err.Error(), webhooks.ErrForbiddenAddress.Error())
+}
@@ -0,0 +1,222 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnknownProvider is returned when an operation is requested for a provider that is not configured
+	ErrUnknownProvider = errors.New("unknown OIDC provider")
+	// ErrDiscovery is returned when the provider metadata could not be fetched or is invalid
+	ErrDiscovery = errors.New("error while discovering OIDC provider metadata")
+	// ErrExchange is returned when the authorization code could not be exchanged for tokens
+	ErrExchange = errors.New("error while exchanging authorization code")
+)
+
+// ProviderOptions is the struct used to construct a Provider
+type ProviderOptions struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// LinkByEmail marks the provider as trusted to link its identities to the existing users with the same verified email
+	LinkByEmail bool
+
+	HTTPClient *http.Client
+}
+
+// Provider is an OpenID Connect identity provider.
+// The provider metadata and signing keys are discovered lazily,
+// on first use, and are cached afterwards.
+// mu guards only the cache, and is never held during a request to the provider,
+// so that a slow provider does not block the logins, that do not need to call it.
+type Provider struct {
+	name         string
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	linkByEmail  bool
+	httpClient   *http.Client
+
+	mu       sync.Mutex
+	metadata *metadata
+	keys     map[string]*rsa.PublicKey
+	// keysFetchedAt is the last time, when the keys were fetched
+	keysFetchedAt time.Time
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Token is the response of the token endpoint of the provider
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// NewProvider returns a new Provider from the given options.
+func NewProvider(opts *ProviderOptions) *Provider {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	scopes := opts.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &Provider{
+		name:         opts.Name,
+		issuer:       strings.TrimSuffix(opts.Issuer, "/"),
+		clientID:     opts.ClientID,
+		clientSecret: opts.ClientSecret,
+		redirectURL:  opts.RedirectURL,
+		scopes:       scopes,
+		linkByEmail:  opts.LinkByEmail,
+		httpClient:   httpClient,
+	}
+}
+
+// Name returns the name under which the provider is configured
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint of the provider
+// to which the user should be redirected to start the authorization code flow.
+// codeChallenge is the S256 PKCE challenge of the verifier that will be passed to Exchange.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.clientID)
+	q.Set("redirect_uri", p.redirectURL)
+	q.Set("scope", strings.Join(p.scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(md.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return md.AuthorizationEndpoint + separator + q.Encode(), nil
+}
+
+// Exchange exchanges the authorization code for tokens,
+// proving possession of the PKCE code verifier.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("client_id", p.clientID)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+	}
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: token endpoint returned %d", ErrExchange, res.StatusCode)
+	}
+
+	var token Token
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("%w: error while decoding body: %v", ErrExchange, err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: no id_token in response", ErrExchange)
+	}
+
+	return &token, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	cached := p.metadata
+	p.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	var md metadata
+	if err := p.getJSON(ctx, p.issuer+"/.well-known/openid-configuration", &md); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("%w: issuer %q does not match the configured issuer %q", ErrDiscovery, md.Issuer, p.issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: incomplete provider metadata", ErrDiscovery)
+	}
+
+	p.mu.Lock()
+	p.metadata = &md
+	p.mu.Unlock()
+	return &md, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, into interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error while building HTTP request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error while calling %s: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error response from %s: %d", url, res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(into); err != nil {
+		return fmt.Errorf("error while decoding body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asankov/gira/internal/fixtures"
+	gassert "github.com/asankov/gira/internal/fixtures/assert"
+	"github.com/asankov/gira/internal/oidc"
+)
+
+var (
+	redirectURL = "http://localhost:4000/users/login/oidc/test/callback"
+)
+
+func newProvider(idp *fixtures.OIDCProvider) *oidc.Provider {
+	return oidc.NewProvider(&oidc.ProviderOptions{
+		Name:        "test",
+		Issuer:      idp.Issuer(),
+		ClientID:    idp.ClientID,
+		RedirectURL: redirectURL,
+	})
+}
+
+// authorize follows the auth code URL and returns the code the provider redirected back with.
+func authorize(t *testing.T, authCodeURL string) (code, state string) {
+	httpClient := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	res, err := httpClient.Get(authCodeURL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusFound, res.StatusCode)
+
+	location, err := url.Parse(res.Header.Get("Location"))
+	require.NoError(t, err)
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// test vector from RFC 7636, Appendix B
+	challenge := oidc.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", challenge)
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	idp := fixtures.NewOIDCProvider(t)
+	provider := newProvider(idp)
+
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+
+	authCodeURL, err := provider.AuthCodeURL(context.Background(), "my-state", "my-nonce", oidc.CodeChallenge(verifier))
+	require.NoError(t, err)
+
+	code, state := authorize(t, authCodeURL)
+	assert.Equal(t, "my-state", state)
+
+	token, err := provider.Exchange(context.Background(), code, verifier)
+	require.NoError(t, err)
+
+	claims, err := provider.VerifyIDToken(context.Background(), token.IDToken, "my-nonce")
+	require.NoError(t, err)
+	assert.Equal(t, idp.Subject, claims.Subject)
+	assert.Equal(t, idp.Email, claims.Email)
+	assert.True(t, claims.EmailVerified)
+}
+
+func TestExchangeWrongVerifier(t *testing.T) {
+	idp := fixtures.NewOIDCProvider(t)
+	provider := newProvider(idp)
+
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+
+	authCodeURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", oidc.CodeChallenge(verifier))
+	require.NoError(t, err)
+
+	code, _ := authorize(t, authCodeURL)
+
+	token, err := provider.Exchange(context.Background(), code, "not-the-verifier")
+	assert.Nil(t, token)
+	gassert.Error(t, err, oidc.ErrExchange)
+}
+
+func TestVerifyIDTokenError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		token       func(*fixtures.OIDCProvider) string
+		nonce       string
+		expectedErr error
+	}{
+		{
+			name: "Expired token",
+			token: func(idp *fixtures.OIDCProvider) string {
+				return idp.IDToken("nonce", -time.Hour)
+			},
+			nonce:       "nonce",
+			expectedErr: oidc.ErrIDTokenExpired,
+		},
+		{
+			name: "Wrong nonce",
+			token: func(idp *fixtures.OIDCProvider) string {
+				return idp.IDToken("other-nonce", time.Hour)
+			},
+			nonce:       "nonce",
+			expectedErr: oidc.ErrClaimMismatch,
+		},
+		{
+			name: "Missing nonce claim",
+			token: func(idp *fixtures.OIDCProvider) string {
+				return idp.IDToken("", time.Hour)
+			},
+			nonce:       "nonce",
+			expectedErr: oidc.ErrClaimMismatch,
+		},
+		{
+			name: "No expected nonce",
+			token: func(idp *fixtures.OIDCProvider) string {
+				return idp.IDToken("", time.Hour)
+			},
+			expectedErr: oidc.ErrClaimMismatch,
+		},
+		{
+			name: "Wrong audience",
+			token: func(idp *fixtures.OIDCProvider) string {
+				return idp.Sign(map[string]interface{}{
+					"iss": idp.Issuer(),
+					"sub": idp.Subject,
+					"aud": "another-client",
+					"exp": time.Now().Add(time.Hour).Unix(),
+				})
+			},
+			expectedErr: oidc.ErrClaimMismatch,
+		},
+		{
+			name: "Wrong issuer",
+			token: func(idp *fixtures.OIDCProvider) string {
+				return idp.Sign(map[string]interface{}{
+					"iss": "https://evil.example.com",
+					"sub": idp.Subject,
+					"aud": idp.ClientID,
+					"exp": time.Now().Add(time.Hour).Unix(),
+				})
+			},
+			expectedErr: oidc.ErrClaimMismatch,
+		},
+		{
+			name: "Tampered signature",
+			token: func(idp *fixtures.OIDCProvider) string {
+				return idp.IDToken("", time.Hour) + "A"
+			},
+			expectedErr: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "Not a JWT",
+			token: func(idp *fixtures.OIDCProvider) string {
+				return "not-a-jwt"
+			},
+			expectedErr: oidc.ErrInvalidIDToken,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			idp := fixtures.NewOIDCProvider(t)
+			provider := newProvider(idp)
+
+			claims, err := provider.VerifyIDToken(context.Background(), testCase.token(idp), testCase.nonce)
+
+			assert.Nil(t, claims)
+			gassert.Error(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestVerifyIDTokenUnknownKeyThrottled(t *testing.T) {
+	idp := fixtures.NewOIDCProvider(t)
+	provider := newProvider(idp)
+
+	_, err := provider.VerifyIDToken(context.Background(), idp.IDToken("nonce", time.Hour), "nonce")
+	require.NoError(t, err)
+	require.Equal(t, 1, idp.JWKSRequests())
+
+	// the keys were just fetched, so the tokens with unknown keys do not make the provider fetch them again
+	for i := 0; i < 3; i++ {
+		claims, err := provider.VerifyIDToken(context.Background(), idp.SignWithKeyID("unknown-key", map[string]interface{}{
+			"iss":   idp.Issuer(),
+			"sub":   idp.Subject,
+			"aud":   idp.ClientID,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"nonce": "nonce",
+		}), "nonce")
+		assert.Nil(t, claims)
+		gassert.Error(t, err, oidc.ErrInvalidIDToken)
+	}
+	assert.Equal(t, 1, idp.JWKSRequests())
+}
+
+func TestRegistryExchangeIdentity(t *testing.T) {
+	idp := fixtures.NewOIDCProvider(t)
+	registry := oidc.NewRegistry(newProvider(idp))
+
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+	authCodeURL, err := registry.AuthCodeURL(context.Background(), "test", "state", "nonce", oidc.CodeChallenge(verifier))
+	require.NoError(t, err)
+	code, _ := authorize(t, authCodeURL)
+
+	identity, err := registry.ExchangeIdentity(context.Background(), "test", code, verifier, "nonce")
+
+	require.NoError(t, err)
+	assert.Equal(t, "test", identity.Provider)
+	assert.Equal(t, idp.Issuer(), identity.Issuer)
+	assert.Equal(t, idp.Subject, identity.Subject)
+	assert.Equal(t, idp.Email, identity.Username)
+	assert.False(t, identity.LinkByEmail)
+	assert.Equal(t, []string{"test"}, registry.Providers())
+
+	// the code can be exchanged only once, so the login cannot be replayed
+	identity, err = registry.ExchangeIdentity(context.Background(), "test", code, verifier, "nonce")
+	assert.Nil(t, identity)
+	gassert.Error(t, err, oidc.ErrExchange)
+}
+
+func TestRegistryExchangeIdentityWrongNonce(t *testing.T) {
+	idp := fixtures.NewOIDCProvider(t)
+	registry := oidc.NewRegistry(newProvider(idp))
+
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+	authCodeURL, err := registry.AuthCodeURL(context.Background(), "test", "state", "nonce", oidc.CodeChallenge(verifier))
+	require.NoError(t, err)
+	code, _ := authorize(t, authCodeURL)
+
+	identity, err := registry.ExchangeIdentity(context.Background(), "test", code, verifier, "another-nonce")
+
+	assert.Nil(t, identity)
+	gassert.Error(t, err, oidc.ErrClaimMismatch)
+}
+
+func TestRegistryUnknownProvider(t *testing.T) {
+	registry := oidc.NewRegistry()
+
+	identity, err := registry.ExchangeIdentity(context.Background(), "unknown", "code", "verifier", "nonce")
+
+	assert.Nil(t, identity)
+	gassert.Error(t, err, oidc.ErrUnknownProvider)
+}
+
+func TestProviderNotBlockedBySlowKeys(t *testing.T) {
+	idp := fixtures.NewOIDCProvider(t)
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 ts.URL,
+				"authorization_endpoint": ts.URL + "/authorize",
+				"token_endpoint":         ts.URL + "/token",
+				"jwks_uri":               ts.URL + "/jwks",
+			})
+		case "/jwks":
+			close(entered)
+			<-release
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	provider := oidc.NewProvider(&oidc.ProviderOptions{
+		Name:        "test",
+		Issuer:      ts.URL,
+		ClientID:    idp.ClientID,
+		RedirectURL: redirectURL,
+	})
+
+	go provider.VerifyIDToken(context.Background(), idp.IDToken("nonce", time.Hour), "nonce") // nolint: errcheck
+	<-entered
+
+	// the keys are still being fetched, but the logins, that do not need them, are not blocked
+	done := make(chan error)
+	go func() {
+		_, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("AuthCodeURL is blocked by the fetch of the keys")
+	}
+}
@@ -0,0 +1,86 @@
+package oidc
+
+import (
+	"context"
+	"sort"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// Registry holds all configured providers, by name.
+type Registry struct {
+	providers map[string]*Provider
+}
+
+// NewRegistry returns a new Registry with the given providers.
+func NewRegistry(providers ...*Provider) *Registry {
+	r := &Registry{providers: map[string]*Provider{}}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+// Providers returns the sorted names of all configured providers
+func (r *Registry) Providers() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Provider returns the provider with the given name,
+// or ErrUnknownProvider if no such provider is configured.
+func (r *Registry) Provider(name string) (*Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+// AuthCodeURL calls AuthCodeURL on the provider with the given name.
+func (r *Registry) AuthCodeURL(ctx context.Context, provider, state, nonce, codeChallenge string) (string, error) {
+	p, err := r.Provider(provider)
+	if err != nil {
+		return "", err
+	}
+	return p.AuthCodeURL(ctx, state, nonce, codeChallenge)
+}
+
+// ExchangeIdentity exchanges the authorization code with the provider with the given name,
+// verifies the ID token, that the provider issued for it, and returns the external identity the token asserts.
+// The ID token is received directly from the provider, so a token issued for another login cannot be replayed.
+func (r *Registry) ExchangeIdentity(ctx context.Context, provider, code, codeVerifier, nonce string) (*models.Identity, error) {
+	p, err := r.Provider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.Exchange(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := p.VerifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	username := claims.PreferredUsername
+	if username == "" {
+		username = claims.Email
+	}
+
+	return &models.Identity{
+		Provider:      provider,
+		Issuer:        p.issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Username:      username,
+		LinkByEmail:   p.linkByEmail,
+	}, nil
+}
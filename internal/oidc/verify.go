@@ -0,0 +1,217 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidIDToken is returned when the ID token is malformed or its signature is not valid
+	ErrInvalidIDToken = errors.New("invalid ID token")
+	// ErrIDTokenExpired is returned when the ID token is valid, but it has expired
+	ErrIDTokenExpired = errors.New("ID token has expired")
+	// ErrClaimMismatch is returned when the issuer, audience or nonce of the ID token do not match the expected ones
+	ErrClaimMismatch = errors.New("ID token claims do not match")
+
+	// clockSkew is the tolerance applied when validating the expiration of ID tokens
+	clockSkew = time.Minute
+	// keysRefreshInterval is the minimum time between two fetches of the keys of a provider,
+	// so that the tokens with unknown key IDs cannot make Gira flood the provider with requests
+	keysRefreshInterval = time.Minute
+)
+
+// Claims are the claims of an ID token that Gira cares about
+type Claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	ExpiresAt         int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce,omitempty"`
+	Email             string   `json:"email,omitempty"`
+	EmailVerified     bool     `json:"email_verified,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+}
+
+// audience is the "aud" claim, which can be either a string or an array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type jwks struct {
+	Keys []struct {
+		KeyType string `json:"kty"`
+		KeyID   string `json:"kid"`
+		N       string `json:"n"`
+		E       string `json:"e"`
+	} `json:"keys"`
+}
+
+// VerifyIDToken validates the signature of the raw ID token against the keys published by the provider,
+// checks that it was issued by the provider for this client and that it has not expired,
+// and returns its claims. The nonce claim of the token must match the nonce, that was sent with the authorization request,
+// so that a token issued for another login cannot be replayed. An empty nonce never matches.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	components := strings.Split(rawIDToken, ".")
+	if len(components) != 3 {
+		return nil, ErrInvalidIDToken
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(components[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: error decoding header: %v", ErrInvalidIDToken, err)
+	}
+	if header.Algorithm != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidIDToken, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(components[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: error decoding signature: %v", ErrInvalidIDToken, err)
+	}
+
+	key, err := p.signingKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(components[0] + "." + components[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: invalid signature", ErrInvalidIDToken)
+	}
+
+	var claims Claims
+	if err := decodeSegment(components[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: error decoding claims: %v", ErrInvalidIDToken, err)
+	}
+
+	if strings.TrimSuffix(claims.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrClaimMismatch, claims.Issuer)
+	}
+	if !claims.Audience.contains(p.clientID) {
+		return nil, fmt.Errorf("%w: token was not issued for this client", ErrClaimMismatch)
+	}
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: unexpected nonce", ErrClaimMismatch)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	if time.Now().Add(-clockSkew).Unix() > claims.ExpiresAt {
+		return nil, ErrIDTokenExpired
+	}
+
+	return &claims, nil
+}
+
+// signingKey returns the key with the given ID.
+// If the key is not known, the keys of the provider are fetched again,
+// to support key rotation, but not more often than keysRefreshInterval.
+func (p *Provider) signingKey(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	if key, ok := p.lookupKey(keyID); ok {
+		p.mu.Unlock()
+		return key, nil
+	}
+	if !p.keysFetchedAt.IsZero() && time.Since(p.keysFetchedAt) < keysRefreshInterval {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidIDToken, keyID)
+	}
+	// the failed fetches are throttled too, so that an unreachable provider is not retried on every login.
+	// Marking the fetch before it is made also makes it the only one, while it is in progress.
+	p.keysFetchedAt = time.Now()
+	p.mu.Unlock()
+
+	var set jwks
+	if err := p.getJSON(ctx, md.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("error while fetching provider keys: %w", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		if k.KeyType != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = keys
+
+	if key, ok := p.lookupKey(keyID); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidIDToken, keyID)
+}
+
+// lookupKey must be called with p.mu held.
+// An empty keyID matches the only key of the provider, if it has only one.
+func (p *Provider) lookupKey(keyID string) (*rsa.PublicKey, bool) {
+	if keyID == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[keyID]
+	return key, ok
+}
+
+func decodeSegment(segment string, into interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, into)
+}
@@ -0,0 +1,335 @@
+// Package recommend ranks the games in the backlog of a user,
+// to suggest what to play next.
+// Each heuristic scores a game from 0 to 1 and explains the score,
+// and the score of the game is the weighted sum of these.
+package recommend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// neutral is the value of a heuristic, that does not apply to a game, e.g. the franchise heuristic for a game without a franchise.
+// It is in the middle, so that such games are neither preferred, nor penalized.
+const neutral = 0.5
+
+// DefaultLimit is the number of suggestions, that are returned, if no limit is configured
+const DefaultLimit = 10
+
+// Config configures the ranking of the games.
+type Config struct {
+	// Weights are the weights of the heuristics. The heuristics, that are not in the map, have a weight of 1.
+	// A weight of 0 disables the heuristic.
+	Weights map[models.Heuristic]float64
+	// Tags are the preferred genres. If empty, the genres of the finished games are preferred.
+	Tags []string
+	// Limit is the maximum number of suggestions. If 0, DefaultLimit is used.
+	Limit int
+	// Now is the time, relative to which the age of the games is computed
+	Now time.Time
+}
+
+// Weight returns the weight of the given heuristic.
+func (c *Config) Weight(heuristic models.Heuristic) float64 {
+	if weight, ok := c.Weights[heuristic]; ok {
+		return weight
+	}
+	return 1
+}
+
+// scorer scores a single game by a single heuristic
+type scorer func(game *models.Game) (float64, string)
+
+// Suggest returns the suggestions of what to play next, the best one first.
+// Only the games, that are To Do, released and not on the wishlist, are suggested,
+// but all games are taken into account by the heuristics, e.g. the finished ones for the preferred genres.
+func Suggest(games []*models.Game, config Config) []*models.Suggestion {
+	if config.Now.IsZero() {
+		config.Now = time.Now()
+	}
+	limit := config.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	candidates := []*models.Game{}
+	for _, game := range games {
+		if isCandidate(game, config.Now) {
+			candidates = append(candidates, game)
+		}
+	}
+
+	scorers := map[models.Heuristic]scorer{
+		models.HeuristicFranchise: franchiseScorer(games),
+		models.HeuristicProgress:  progressScorer(candidates),
+		models.HeuristicAge:       ageScorer(candidates, config.Now),
+		models.HeuristicTags:      tagsScorer(games, config.Tags),
+		models.HeuristicRating:    ratingScorer(games),
+	}
+
+	suggestions := make([]*models.Suggestion, 0, len(candidates))
+	for _, game := range candidates {
+		suggestion := &models.Suggestion{Game: game, Reasons: []*models.SuggestionReason{}}
+		for _, heuristic := range models.AllHeuristics {
+			weight := config.Weight(heuristic)
+			if weight == 0 {
+				continue
+			}
+			value, explanation := scorers[heuristic](game)
+			reason := &models.SuggestionReason{
+				Heuristic:   heuristic,
+				Value:       round(value),
+				Weight:      weight,
+				Points:      round(value * weight),
+				Explanation: explanation,
+			}
+			suggestion.Reasons = append(suggestion.Reasons, reason)
+			suggestion.Score += value * weight
+		}
+		suggestion.Score = round(suggestion.Score)
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Game.Name < suggestions[j].Game.Name
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func isCandidate(game *models.Game, now time.Time) bool {
+	if game.Status != models.StatusTODO || game.Wishlist {
+		return false
+	}
+	return game.ReleaseDate == nil || !game.ReleaseDate.After(now)
+}
+
+// franchiseScorer prefers the game, that comes next in its franchise, after the finished ones.
+// The games of a franchise are in play order.
+func franchiseScorer(games []*models.Game) scorer {
+	franchises := map[string][]*models.Game{}
+	for _, game := range games {
+		if game.FranchiseID != "" {
+			franchises[game.FranchiseID] = append(franchises[game.FranchiseID], game)
+		}
+	}
+	for _, siblings := range franchises {
+		models.SortByPlayOrder(siblings)
+	}
+
+	return func(game *models.Game) (float64, string) {
+		if game.FranchiseID == "" {
+			return neutral, "Not part of a franchise"
+		}
+
+		var finished []*models.Game
+		for _, sibling := range franchises[game.FranchiseID] {
+			if sibling.ID == game.ID {
+				break
+			}
+			switch sibling.Status {
+			case models.StatusDone, models.StatusDropped:
+				finished = append(finished, sibling)
+			default:
+				return 0, fmt.Sprintf("Play %s first", sibling.Name)
+			}
+		}
+		if len(finished) == 0 {
+			return neutral, fmt.Sprintf("Starts the %s franchise", game.Franchise)
+		}
+		return 1, fmt.Sprintf("Comes next in the %s franchise, after %s", game.Franchise, finished[len(finished)-1].Name)
+	}
+}
+
+// progressScorer prefers the games with the least remaining progress.
+// The values are relative to the rest of the candidates, so the shortest game gets 1 and the longest one - 0.
+func progressScorer(candidates []*models.Game) scorer {
+	shortest, longest := -1, -1
+	for _, game := range candidates {
+		remaining, ok := remainingProgress(game)
+		if !ok {
+			continue
+		}
+		if shortest == -1 || remaining < shortest {
+			shortest = remaining
+		}
+		if remaining > longest {
+			longest = remaining
+		}
+	}
+
+	return func(game *models.Game) (float64, string) {
+		remaining, ok := remainingProgress(game)
+		if !ok {
+			return neutral, "The length of the game is unknown"
+		}
+		explanation := fmt.Sprintf("%d of %d left", remaining, game.Progress.Final)
+		if longest == shortest {
+			return neutral, explanation
+		}
+		return float64(longest-remaining) / float64(longest-shortest), explanation
+	}
+}
+
+func remainingProgress(game *models.Game) (int, bool) {
+	if game.Progress == nil || game.Progress.Final <= 0 {
+		return 0, false
+	}
+	remaining := game.Progress.Final - game.Progress.Current
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// ageScorer prefers the games, that were added the longest time ago.
+// The values are relative to the rest of the candidates, so the oldest game gets 1 and the newest one - 0.
+func ageScorer(candidates []*models.Game, now time.Time) scorer {
+	var oldest, newest *time.Time
+	for _, game := range candidates {
+		if game.CreatedAt == nil {
+			continue
+		}
+		if oldest == nil || game.CreatedAt.Before(*oldest) {
+			oldest = game.CreatedAt
+		}
+		if newest == nil || game.CreatedAt.After(*newest) {
+			newest = game.CreatedAt
+		}
+	}
+
+	return func(game *models.Game) (float64, string) {
+		if game.CreatedAt == nil {
+			return neutral, "It is unknown when the game was added"
+		}
+		explanation := fmt.Sprintf("Added %s", age(now.Sub(*game.CreatedAt)))
+		if oldest.Equal(*newest) {
+			return neutral, explanation
+		}
+		return float64(newest.Sub(*game.CreatedAt)) / float64(newest.Sub(*oldest)), explanation
+	}
+}
+
+func age(d time.Duration) string {
+	switch days := int(d.Hours() / 24); {
+	case days < 1:
+		return "today"
+	case days == 1:
+		return "yesterday"
+	default:
+		return fmt.Sprintf("%d days ago", days)
+	}
+}
+
+// tagsScorer prefers the games with the preferred genres.
+// The explicitly preferred genres are equally preferred. Otherwise, the genres of the finished games are preferred
+// by the number of finished games with each of them, so the most finished genre gets 1.
+// A game gets the value of its most preferred genre.
+func tagsScorer(games []*models.Game, tags []string) scorer {
+	preferred := map[string]float64{}
+	names := map[string]string{}
+	if len(tags) > 0 {
+		for _, tag := range tags {
+			preferred[strings.ToLower(tag)] = 1
+		}
+	} else {
+		counts, most := map[string]int{}, 0
+		for _, game := range games {
+			if game.Status != models.StatusDone {
+				continue
+			}
+			for _, genre := range game.Genres {
+				key := strings.ToLower(genre)
+				counts[key]++
+				if counts[key] > most {
+					most = counts[key]
+				}
+			}
+		}
+		for genre, count := range counts {
+			preferred[genre] = float64(count) / float64(most)
+		}
+	}
+	for _, tag := range tags {
+		names[strings.ToLower(tag)] = tag
+	}
+
+	return func(game *models.Game) (float64, string) {
+		if len(preferred) == 0 {
+			return neutral, "No preferred genres yet"
+		}
+		if len(game.Genres) == 0 {
+			return neutral, "The genres of the game are unknown"
+		}
+
+		value, matches := 0.0, []string{}
+		for _, genre := range game.Genres {
+			if v, ok := preferred[strings.ToLower(genre)]; ok {
+				matches = append(matches, genre)
+				if v > value {
+					value = v
+				}
+			}
+		}
+		if len(matches) == 0 {
+			return 0, "None of the preferred genres"
+		}
+		if len(tags) > 0 {
+			return value, fmt.Sprintf("Matches %s", strings.Join(matches, ", "))
+		}
+		return value, fmt.Sprintf("Matches %s, like the games you finished", strings.Join(matches, ", "))
+	}
+}
+
+// ratingScorer prefers the games, whose siblings in the franchise are rated the highest.
+// The value is the average rating of the siblings, scaled from 0 (1 star) to 1 (5 stars).
+func ratingScorer(games []*models.Game) scorer {
+	type ratings struct {
+		sum, count int
+	}
+	franchises := map[string]*ratings{}
+	for _, game := range games {
+		if game.FranchiseID == "" || game.Rating == 0 {
+			continue
+		}
+		if franchises[game.FranchiseID] == nil {
+			franchises[game.FranchiseID] = &ratings{}
+		}
+		franchises[game.FranchiseID].sum += game.Rating
+		franchises[game.FranchiseID].count++
+	}
+
+	return func(game *models.Game) (float64, string) {
+		if game.FranchiseID == "" {
+			return neutral, "Not part of a franchise"
+		}
+		r := franchises[game.FranchiseID]
+		sum, count := 0, 0
+		if r != nil {
+			sum, count = r.sum, r.count
+		}
+		// the game itself is not its own sibling
+		if game.Rating != 0 {
+			sum, count = sum-game.Rating, count-1
+		}
+		if count == 0 {
+			return neutral, fmt.Sprintf("No rated games in the %s franchise", game.Franchise)
+		}
+		average := float64(sum) / float64(count)
+		return (average - 1) / (models.MaxRating - 1), fmt.Sprintf("The %s franchise is rated %.1f of %d", game.Franchise, average, models.MaxRating)
+	}
+}
+
+// round rounds the value to 2 decimal places, so that the scores are readable
+func round(value float64) float64 {
+	return float64(int(value*100+0.5)) / 100
+}
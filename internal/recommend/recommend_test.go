@@ -0,0 +1,199 @@
+package recommend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+func daysAgo(days int) *time.Time {
+	t := now.AddDate(0, 0, -days)
+	return &t
+}
+
+func ids(suggestions []*models.Suggestion) []string {
+	result := []string{}
+	for _, suggestion := range suggestions {
+		result = append(result, suggestion.Game.ID)
+	}
+	return result
+}
+
+func reason(suggestion *models.Suggestion, heuristic models.Heuristic) *models.SuggestionReason {
+	for _, reason := range suggestion.Reasons {
+		if reason.Heuristic == heuristic {
+			return reason
+		}
+	}
+	return nil
+}
+
+func only(heuristic models.Heuristic) map[models.Heuristic]float64 {
+	weights := map[models.Heuristic]float64{}
+	for _, h := range models.AllHeuristics {
+		weights[h] = 0
+	}
+	weights[heuristic] = 1
+	return weights
+}
+
+func TestSuggestCandidates(t *testing.T) {
+	games := []*models.Game{
+		{ID: "1", Name: "To Do", Status: models.StatusTODO},
+		{ID: "2", Name: "In Progress", Status: models.StatusInProgress},
+		{ID: "3", Name: "Done", Status: models.StatusDone},
+		{ID: "4", Name: "Wishlist", Status: models.StatusTODO, Wishlist: true},
+		{ID: "5", Name: "Unreleased", Status: models.StatusTODO, ReleaseDate: daysAgo(-10)},
+		{ID: "6", Name: "Released", Status: models.StatusTODO, ReleaseDate: daysAgo(10)},
+	}
+
+	suggestions := Suggest(games, Config{Now: now})
+	assert.ElementsMatch(t, []string{"1", "6"}, ids(suggestions))
+}
+
+func TestSuggestFranchise(t *testing.T) {
+	games := []*models.Game{
+		{ID: "1", Name: "Witcher 1", Franchise: "Witcher", FranchiseID: "1", Status: models.StatusDone, ReleaseDate: daysAgo(3000)},
+		{ID: "2", Name: "Witcher 3", Franchise: "Witcher", FranchiseID: "1", Status: models.StatusTODO, ReleaseDate: daysAgo(1000)},
+		{ID: "3", Name: "Witcher 2", Franchise: "Witcher", FranchiseID: "1", Status: models.StatusTODO, ReleaseDate: daysAgo(2000)},
+		{ID: "4", Name: "Mass Effect", Franchise: "Mass Effect", FranchiseID: "2", Status: models.StatusTODO},
+		{ID: "5", Name: "Hades", Status: models.StatusTODO},
+	}
+
+	suggestions := Suggest(games, Config{Now: now, Weights: only(models.HeuristicFranchise)})
+	require.Equal(t, []string{"3", "5", "4", "2"}, ids(suggestions))
+
+	assert.Equal(t, &models.SuggestionReason{
+		Heuristic:   models.HeuristicFranchise,
+		Value:       1,
+		Weight:      1,
+		Points:      1,
+		Explanation: "Comes next in the Witcher franchise, after Witcher 1",
+	}, reason(suggestions[0], models.HeuristicFranchise))
+	assert.Equal(t, "Not part of a franchise", reason(suggestions[1], models.HeuristicFranchise).Explanation)
+	assert.Equal(t, "Starts the Mass Effect franchise", reason(suggestions[2], models.HeuristicFranchise).Explanation)
+	assert.Equal(t, "Play Witcher 2 first", reason(suggestions[3], models.HeuristicFranchise).Explanation)
+	assert.Equal(t, 0.0, suggestions[3].Score)
+	assert.Len(t, suggestions[0].Reasons, 1)
+}
+
+func TestSuggestFranchisePlayOrder(t *testing.T) {
+	// the prequel was released last, but it is played first
+	games := []*models.Game{
+		{ID: "1", Name: "Prequel", Franchise: "Saga", FranchiseID: "1", Status: models.StatusTODO, ReleaseDate: daysAgo(100), PlayOrder: 1},
+		{ID: "2", Name: "First", Franchise: "Saga", FranchiseID: "1", Status: models.StatusTODO, ReleaseDate: daysAgo(2000), PlayOrder: 2},
+		{ID: "3", Name: "Second", Franchise: "Saga", FranchiseID: "1", Status: models.StatusTODO, ReleaseDate: daysAgo(1000)},
+	}
+
+	suggestions := Suggest(games, Config{Now: now, Weights: only(models.HeuristicFranchise)})
+	require.Equal(t, []string{"1", "2", "3"}, ids(suggestions))
+	assert.Equal(t, "Starts the Saga franchise", reason(suggestions[0], models.HeuristicFranchise).Explanation)
+	assert.Equal(t, "Play Prequel first", reason(suggestions[1], models.HeuristicFranchise).Explanation)
+	assert.Equal(t, "Play Prequel first", reason(suggestions[2], models.HeuristicFranchise).Explanation)
+}
+
+func TestSuggestProgress(t *testing.T) {
+	games := []*models.Game{
+		{ID: "1", Name: "Long", Status: models.StatusTODO, Progress: &models.GameProgress{Current: 0, Final: 100}},
+		{ID: "2", Name: "Short", Status: models.StatusTODO, Progress: &models.GameProgress{Current: 10, Final: 20}},
+		{ID: "3", Name: "Medium", Status: models.StatusTODO, Progress: &models.GameProgress{Current: 45, Final: 100}},
+		{ID: "4", Name: "Unknown", Status: models.StatusTODO, Progress: &models.GameProgress{}},
+	}
+
+	suggestions := Suggest(games, Config{Now: now, Weights: only(models.HeuristicProgress)})
+	require.Equal(t, []string{"2", "3", "4", "1"}, ids(suggestions))
+	assert.Equal(t, "10 of 20 left", reason(suggestions[0], models.HeuristicProgress).Explanation)
+	assert.Equal(t, 0.5, suggestions[1].Score)
+	assert.Equal(t, "The length of the game is unknown", reason(suggestions[2], models.HeuristicProgress).Explanation)
+	assert.Equal(t, 0.0, suggestions[3].Score)
+}
+
+func TestSuggestAge(t *testing.T) {
+	games := []*models.Game{
+		{ID: "1", Name: "New", Status: models.StatusTODO, CreatedAt: daysAgo(0)},
+		{ID: "2", Name: "Old", Status: models.StatusTODO, CreatedAt: daysAgo(100)},
+		{ID: "3", Name: "Middle", Status: models.StatusTODO, CreatedAt: daysAgo(25)},
+	}
+
+	suggestions := Suggest(games, Config{Now: now, Weights: only(models.HeuristicAge)})
+	require.Equal(t, []string{"2", "3", "1"}, ids(suggestions))
+	assert.Equal(t, "Added 100 days ago", reason(suggestions[0], models.HeuristicAge).Explanation)
+	assert.Equal(t, 0.25, suggestions[1].Score)
+	assert.Equal(t, "Added today", reason(suggestions[2], models.HeuristicAge).Explanation)
+}
+
+func TestSuggestTags(t *testing.T) {
+	games := []*models.Game{
+		{ID: "1", Name: "Finished RPG", Status: models.StatusDone, Genres: []string{"RPG"}},
+		{ID: "2", Name: "Finished RPG 2", Status: models.StatusDone, Genres: []string{"RPG", "Action"}},
+		{ID: "3", Name: "Shooter", Status: models.StatusTODO, Genres: []string{"Shooter"}},
+		{ID: "4", Name: "Action", Status: models.StatusTODO, Genres: []string{"Action"}},
+		{ID: "5", Name: "RPG", Status: models.StatusTODO, Genres: []string{"rpg", "Strategy"}},
+	}
+
+	t.Run("Genres of the finished games", func(t *testing.T) {
+		suggestions := Suggest(games, Config{Now: now, Weights: only(models.HeuristicTags)})
+		require.Equal(t, []string{"5", "4", "3"}, ids(suggestions))
+		assert.Equal(t, "Matches rpg, like the games you finished", reason(suggestions[0], models.HeuristicTags).Explanation)
+		assert.Equal(t, 0.5, suggestions[1].Score)
+		assert.Equal(t, "None of the preferred genres", reason(suggestions[2], models.HeuristicTags).Explanation)
+	})
+
+	t.Run("Explicit tags", func(t *testing.T) {
+		suggestions := Suggest(games, Config{Now: now, Weights: only(models.HeuristicTags), Tags: []string{"shooter", "Action"}})
+		require.Equal(t, []string{"4", "3", "5"}, ids(suggestions))
+		assert.Equal(t, "Matches Shooter", reason(suggestions[1], models.HeuristicTags).Explanation)
+		assert.Equal(t, 0.0, suggestions[2].Score)
+	})
+}
+
+func TestSuggestRating(t *testing.T) {
+	games := []*models.Game{
+		{ID: "1", Name: "Witcher 1", Franchise: "Witcher", FranchiseID: "1", Status: models.StatusDone, Rating: 5},
+		{ID: "2", Name: "Witcher 2", Franchise: "Witcher", FranchiseID: "1", Status: models.StatusDone, Rating: 4},
+		{ID: "3", Name: "Witcher 3", Franchise: "Witcher", FranchiseID: "1", Status: models.StatusTODO},
+		{ID: "4", Name: "Dragon Age 1", Franchise: "Dragon Age", FranchiseID: "2", Status: models.StatusDone, Rating: 1},
+		{ID: "5", Name: "Dragon Age 2", Franchise: "Dragon Age", FranchiseID: "2", Status: models.StatusTODO, Rating: 5},
+		{ID: "6", Name: "Hades", Status: models.StatusTODO},
+	}
+
+	suggestions := Suggest(games, Config{Now: now, Weights: only(models.HeuristicRating)})
+	require.Equal(t, []string{"3", "6", "5"}, ids(suggestions))
+	assert.Equal(t, 0.88, suggestions[0].Score)
+	assert.Equal(t, "The Witcher franchise is rated 4.5 of 5", reason(suggestions[0], models.HeuristicRating).Explanation)
+	assert.Equal(t, 0.0, suggestions[2].Score)
+}
+
+func TestSuggestWeightsAndLimit(t *testing.T) {
+	games := []*models.Game{
+		{ID: "1", Name: "Old and long", Status: models.StatusTODO, CreatedAt: daysAgo(100), Progress: &models.GameProgress{Final: 100}},
+		{ID: "2", Name: "New and short", Status: models.StatusTODO, CreatedAt: daysAgo(1), Progress: &models.GameProgress{Final: 10}},
+		{ID: "3", Name: "Middle", Status: models.StatusTODO, CreatedAt: daysAgo(50), Progress: &models.GameProgress{Final: 55}},
+	}
+
+	weights := only(models.HeuristicAge)
+	weights[models.HeuristicProgress] = 2
+	suggestions := Suggest(games, Config{Now: now, Weights: weights, Limit: 2})
+	require.Equal(t, []string{"2", "3"}, ids(suggestions))
+
+	first := suggestions[0]
+	assert.Equal(t, 2.0, first.Score)
+	assert.Equal(t, &models.SuggestionReason{Heuristic: models.HeuristicProgress, Value: 1, Weight: 2, Points: 2, Explanation: "10 of 10 left"}, reason(first, models.HeuristicProgress))
+	assert.Equal(t, &models.SuggestionReason{Heuristic: models.HeuristicAge, Value: 0, Weight: 1, Points: 0, Explanation: "Added yesterday"}, reason(first, models.HeuristicAge))
+}
+
+func TestSuggestDefaults(t *testing.T) {
+	games := []*models.Game{}
+	for i := 0; i < DefaultLimit+5; i++ {
+		games = append(games, &models.Game{ID: "id", Name: "Game", Status: models.StatusTODO})
+	}
+
+	suggestions := Suggest(games, Config{Now: now})
+	require.Len(t, suggestions, DefaultLimit)
+	assert.Len(t, suggestions[0].Reasons, len(models.AllHeuristics))
+}
@@ -0,0 +1,86 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned, when a webhook resolves to an address of the internal network of the API
+var ErrForbiddenAddress = errors.New("the address of the webhook is not allowed")
+
+// ClientOptions is the struct used to construct a client for the webhooks
+type ClientOptions struct {
+	// AllowedNetworks are the networks, that are allowed even though they are internal,
+	// e.g. for self-hosted setups, where the webhooks run next to the API
+	AllowedNetworks []*net.IPNet
+}
+
+// NewClient returns a client for the URLs, that the users configure, e.g. webhooks.
+// It refuses to connect to loopback, private, link-local and unspecified addresses, unless they are in the allowed networks,
+// so that the users cannot make the API send requests to its internal network.
+// The address is checked when connecting, so the redirects and the DNS names, that resolve to different addresses over time, are covered too.
+func NewClient(opts *ClientOptions) *http.Client {
+	if opts == nil {
+		opts = &ClientOptions{}
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			return checkAddress(address, opts.AllowedNetworks)
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// the requests go directly to the webhooks, because through a proxy only the address of the proxy could be checked
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: requestTimeout, Transport: transport}
+}
+
+// ParseNetworks parses the given IPs and CIDRs into networks, that can be allowed in ClientOptions.
+func ParseNetworks(networks []string) ([]*net.IPNet, error) {
+	parsed := []*net.IPNet{}
+	for _, network := range networks {
+		if !strings.Contains(network, "/") {
+			if ip := net.ParseIP(network); ip != nil && ip.To4() != nil {
+				network += "/32"
+			} else {
+				network += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", network, err)
+		}
+		parsed = append(parsed, n)
+	}
+	return parsed, nil
+}
+
+// checkAddress returns ErrForbiddenAddress, if the resolved address, that is about to be connected to, is internal and not allowed
+func checkAddress(address string, allowed []*net.IPNet) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrForbiddenAddress, err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: %s is not an IP", ErrForbiddenAddress, host)
+	}
+	for _, network := range allowed {
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package webhooks_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/asankov/gira/internal/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loopback returns the IPv4 loopback address as the only allowed network, because the test servers listen on it
+func loopback(t *testing.T) []*net.IPNet {
+	networks, err := webhooks.ParseNetworks([]string{"127.0.0.1"})
+	require.NoError(t, err)
+	return networks
+}
+
+func TestClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	res, err := webhooks.NewClient(&webhooks.ClientOptions{AllowedNetworks: loopback(t)}).Get(ts.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestClientForbiddenAddress(t *testing.T) {
+	testCases := []struct {
+		name string
+		url  string
+	}{
+		{name: "Loopback", url: "http://127.0.0.1:1"},
+		{name: "Loopback name", url: "http://localhost:1"},
+		{name: "IPv6 loopback", url: "http://[::1]:1"},
+		{name: "Private", url: "http://10.0.0.1:1"},
+		{name: "Private IPv6", url: "http://[fd00::1]:1"},
+		{name: "Link-local", url: "http://169.254.169.254"},
+		{name: "Unspecified", url: "http://0.0.0.0:1"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// the address is checked before connecting, so nothing needs to listen on it
+			_, err := webhooks.NewClient(nil).Get(testCase.url)
+			assert.ErrorIs(t, err, webhooks.ErrForbiddenAddress)
+		})
+	}
+}
+
+func TestClientForbiddenRedirect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://10.0.0.1:1", http.StatusFound)
+	}))
+	defer ts.Close()
+
+	_, err := webhooks.NewClient(&webhooks.ClientOptions{AllowedNetworks: loopback(t)}).Get(ts.URL)
+	assert.ErrorIs(t, err, webhooks.ErrForbiddenAddress)
+}
+
+func TestParseNetworks(t *testing.T) {
+	networks, err := webhooks.ParseNetworks([]string{"10.0.0.0/8", "192.168.1.10", "fd00::1"})
+	require.NoError(t, err)
+	require.Len(t, networks, 3)
+	assert.Equal(t, "10.0.0.0/8", networks[0].String())
+	assert.Equal(t, "192.168.1.10/32", networks[1].String())
+	assert.Equal(t, "fd00::1/128", networks[2].String())
+
+	_, err = webhooks.ParseNetworks([]string{"not-a-network"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// requestTimeout is how long a webhook has to respond to a single attempt
+	requestTimeout = 10 * time.Second
+	// maxResponseSize is how much of the response of a webhook is read, before the connection is reused
+	maxResponseSize = 64 << 10
+)
+
+// Store finds the webhooks of the users and records their deliveries (DB, service, etc.)
+type Store interface {
+	// ActiveWebhooks returns the active webhooks of the user, that are subscribed to the event, together with their secrets
+	ActiveWebhooks(userID string, event models.WebhookEvent) ([]*models.Webhook, error)
+	InsertDelivery(delivery *models.WebhookDelivery) error
+}
+
+// DispatcherOptions is the struct used to construct a Dispatcher
+type DispatcherOptions struct {
+	Store Store
+	// Client sends the requests. If nil, NewClient(nil) is used, which refuses to connect to the internal network.
+	Client *http.Client
+	// MaxAttempts is the number of times an event is sent to a webhook, before the delivery fails
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It is doubled for each next retry.
+	Backoff time.Duration
+	Log     *logrus.Logger
+}
+
+// Dispatcher posts the events to the webhooks in the background, so that the requests of the users are not slowed down by them.
+type Dispatcher struct {
+	store       Store
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	log         *logrus.Logger
+
+	wg sync.WaitGroup
+}
+
+// NewDispatcher returns a new Dispatcher from the given options.
+func NewDispatcher(opts *DispatcherOptions) *Dispatcher {
+	d := &Dispatcher{
+		store:       opts.Store,
+		client:      opts.Client,
+		maxAttempts: opts.MaxAttempts,
+		backoff:     opts.Backoff,
+		log:         opts.Log,
+	}
+	if d.client == nil {
+		d.client = NewClient(nil)
+	}
+	if d.maxAttempts < 1 {
+		d.maxAttempts = 1
+	}
+	return d
+}
+
+// Dispatch posts the payload to the active webhooks of the user, that are subscribed to its event.
+// It returns immediately, the deliveries happen in the background.
+// The ID and the time of the payload are set, if they are empty.
+func (d *Dispatcher) Dispatch(userID string, payload *models.WebhookPayload) {
+	if payload.ID == "" {
+		id, err := randomHex(16)
+		if err != nil {
+			d.log.Errorf("Error while generating ID of %s event: %v", payload.Event, err)
+			return
+		}
+		payload.ID = id
+	}
+	if payload.CreatedAt.IsZero() {
+		payload.CreatedAt = time.Now()
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.dispatch(context.Background(), userID, payload)
+	}()
+}
+
+// Wait waits for the deliveries, that are in progress, including their retries.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, userID string, payload *models.WebhookPayload) {
+	webhooks, err := d.store.ActiveWebhooks(userID, payload.Event)
+	if err != nil {
+		d.log.Errorf("Error while fetching the webhooks of user %s: %v", userID, err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		d.log.Errorf("Error while marshalling %s event: %v", payload.Event, err)
+		return
+	}
+
+	// each webhook is delivered separately, so that a slow one does not delay the rest
+	for _, webhook := range webhooks {
+		d.wg.Add(1)
+		go func(webhook *models.Webhook) {
+			defer d.wg.Done()
+
+			delivery := d.deliver(ctx, webhook, payload, body)
+			if !delivery.Success {
+				d.log.Warnf("Error while delivering %s event %s to webhook %s: %s", payload.Event, payload.ID, webhook.ID, delivery.Error)
+			}
+			if err := d.store.InsertDelivery(delivery); err != nil {
+				d.log.Errorf("Error while recording delivery to webhook %s: %v", webhook.ID, err)
+			}
+		}(webhook)
+	}
+}
+
+// deliver sends the body to the webhook, until it succeeds or the attempts run out.
+// Only the network errors, the server errors and 429 Too Many Requests are retried -
+// the rest of the responses will not change, if the same payload is sent again.
+func (d *Dispatcher) deliver(ctx context.Context, webhook *models.Webhook, payload *models.WebhookPayload, body []byte) *models.WebhookDelivery {
+	delivery := &models.WebhookDelivery{
+		WebhookID: webhook.ID,
+		EventID:   payload.ID,
+		Event:     payload.Event,
+		Payload:   string(body),
+		CreatedAt: time.Now(),
+	}
+
+	delay := d.backoff
+	for attempt := 1; ; attempt++ {
+		delivery.Attempts = attempt
+		retry := d.send(ctx, webhook, payload, body, delivery)
+		if delivery.Success || !retry || attempt == d.maxAttempts {
+			return delivery
+		}
+
+		select {
+		case <-ctx.Done():
+			delivery.Error = ctx.Err().Error()
+			return delivery
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// send makes a single attempt to deliver the body and records its outcome in the delivery.
+// It returns whether the attempt can be retried.
+func (d *Dispatcher) send(ctx context.Context, webhook *models.Webhook, payload *models.WebhookPayload, body []byte, delivery *models.WebhookDelivery) bool {
+	delivery.StatusCode, delivery.Error = 0, ""
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = fmt.Sprintf("error while building HTTP request: %v", err)
+		return false
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Gira-Webhooks")
+	req.Header.Set(HeaderEvent, string(payload.Event))
+	req.Header.Set(HeaderDelivery, payload.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(webhook.Secret, timestamp, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+		// the address of the webhook will not be allowed on the next attempt either
+		return !errors.Is(err, ErrForbiddenAddress)
+	}
+	defer res.Body.Close()
+	// the response is drained, so that the connection can be reused for the next attempt
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseSize))
+
+	delivery.StatusCode = res.StatusCode
+	if res.StatusCode >= 200 && res.StatusCode <= 299 {
+		delivery.Success = true
+		return false
+	}
+	delivery.Error = fmt.Sprintf("webhook responded with %d", res.StatusCode)
+	return res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,227 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/webhooks"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var createdAt = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeStore returns the given webhooks and remembers the deliveries
+type fakeStore struct {
+	webhooks []*models.Webhook
+	err      error
+
+	mu         sync.Mutex
+	deliveries []*models.WebhookDelivery
+}
+
+func (s *fakeStore) ActiveWebhooks(userID string, event models.WebhookEvent) ([]*models.Webhook, error) {
+	return s.webhooks, s.err
+}
+
+func (s *fakeStore) InsertDelivery(delivery *models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, delivery)
+	return nil
+}
+
+func newDispatcher(t *testing.T, store webhooks.Store) *webhooks.Dispatcher {
+	return webhooks.NewDispatcher(&webhooks.DispatcherOptions{
+		Store: store,
+		// the webhooks of the tests listen on the loopback
+		Client:      webhooks.NewClient(&webhooks.ClientOptions{AllowedNetworks: loopback(t)}),
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+		Log:         logrus.StandardLogger(),
+	})
+}
+
+func TestDispatch(t *testing.T) {
+	var received models.WebhookPayload
+	var header http.Header
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		var err error
+		body, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	store := &fakeStore{webhooks: []*models.Webhook{{ID: "1", URL: ts.URL, Secret: "secret"}}}
+	dispatcher := newDispatcher(t, store)
+
+	payload := &models.WebhookPayload{
+		Event:     models.WebhookGameStatusChanged,
+		GameID:    "2",
+		Status:    models.StatusDone,
+		CreatedAt: createdAt,
+	}
+	dispatcher.Dispatch("1", payload)
+	dispatcher.Wait()
+
+	require.NotEmpty(t, payload.ID)
+	assert.Equal(t, *payload, received)
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, "game.status_changed", header.Get(webhooks.HeaderEvent))
+	assert.Equal(t, payload.ID, header.Get(webhooks.HeaderDelivery))
+
+	// the receiver verifies the payload with the secret of the webhook
+	timestamp, err := strconv.ParseInt(header.Get(webhooks.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, "sha256="+webhooks.Sign("secret", timestamp, body), header.Get(webhooks.HeaderSignature))
+	assert.NotEqual(t, "sha256="+webhooks.Sign("another secret", timestamp, body), header.Get(webhooks.HeaderSignature))
+
+	require.Len(t, store.deliveries, 1)
+	delivery := store.deliveries[0]
+	assert.Equal(t, "1", delivery.WebhookID)
+	assert.Equal(t, payload.ID, delivery.EventID)
+	assert.Equal(t, models.WebhookGameStatusChanged, delivery.Event)
+	assert.Equal(t, string(body), delivery.Payload)
+	assert.True(t, delivery.Success)
+	assert.Equal(t, http.StatusNoContent, delivery.StatusCode)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Empty(t, delivery.Error)
+}
+
+func TestDispatchRetries(t *testing.T) {
+	testCases := []struct {
+		name             string
+		responses        []int
+		expectedSuccess  bool
+		expectedAttempts int
+		expectedStatus   int
+	}{
+		{
+			name:             "Succeeds after retries",
+			responses:        []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK},
+			expectedSuccess:  true,
+			expectedAttempts: 3,
+			expectedStatus:   http.StatusOK,
+		},
+		{
+			name:             "Fails after all attempts",
+			responses:        []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			expectedAttempts: 3,
+			expectedStatus:   http.StatusBadGateway,
+		},
+		{
+			name:             "Client errors are not retried",
+			responses:        []int{http.StatusNotFound},
+			expectedAttempts: 1,
+			expectedStatus:   http.StatusNotFound,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var mu sync.Mutex
+			attempts := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				w.WriteHeader(testCase.responses[attempts])
+				attempts++
+			}))
+			defer ts.Close()
+
+			store := &fakeStore{webhooks: []*models.Webhook{{ID: "1", URL: ts.URL, Secret: "secret"}}}
+			dispatcher := newDispatcher(t, store)
+
+			dispatcher.Dispatch("1", &models.WebhookPayload{Event: models.WebhookGameDeleted, GameID: "2"})
+			dispatcher.Wait()
+
+			assert.Equal(t, len(testCase.responses), attempts)
+			require.Len(t, store.deliveries, 1)
+			delivery := store.deliveries[0]
+			assert.Equal(t, testCase.expectedSuccess, delivery.Success)
+			assert.Equal(t, testCase.expectedAttempts, delivery.Attempts)
+			assert.Equal(t, testCase.expectedStatus, delivery.StatusCode)
+			if !testCase.expectedSuccess {
+				assert.Equal(t, "webhook responded with "+strconv.Itoa(testCase.expectedStatus), delivery.Error)
+			}
+		})
+	}
+}
+
+func TestDispatchUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	url := ts.URL
+	ts.Close()
+
+	store := &fakeStore{webhooks: []*models.Webhook{{ID: "1", URL: url, Secret: "secret"}}}
+	dispatcher := newDispatcher(t, store)
+
+	dispatcher.Dispatch("1", &models.WebhookPayload{Event: models.WebhookGameCreated, GameID: "2"})
+	dispatcher.Wait()
+
+	require.Len(t, store.deliveries, 1)
+	delivery := store.deliveries[0]
+	assert.False(t, delivery.Success)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, 0, delivery.StatusCode)
+	assert.NotEmpty(t, delivery.Error)
+}
+
+func TestDispatchForbiddenAddress(t *testing.T) {
+	store := &fakeStore{webhooks: []*models.Webhook{{ID: "1", URL: "http://10.0.0.1:1", Secret: "secret"}}}
+	dispatcher := newDispatcher(t, store)
+
+	dispatcher.Dispatch("1", &models.WebhookPayload{Event: models.WebhookGameCreated, GameID: "2"})
+	dispatcher.Wait()
+
+	require.Len(t, store.deliveries, 1)
+	delivery := store.deliveries[0]
+	assert.False(t, delivery.Success)
+	assert.Equal(t, 1, delivery.Attempts, "the forbidden address should not be retried")
+	assert.Contains(t, delivery.Error, webhooks.ErrForbiddenAddress.Error())
+}
+
+func TestDispatchMultipleWebhooks(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		received[r.URL.Path]++
+	}))
+	defer ts.Close()
+
+	store := &fakeStore{webhooks: []*models.Webhook{
+		{ID: "1", URL: ts.URL + "/discord", Secret: "secret"},
+		{ID: "2", URL: ts.URL + "/dashboard", Secret: "another secret"},
+	}}
+	dispatcher := newDispatcher(t, store)
+
+	dispatcher.Dispatch("1", &models.WebhookPayload{Event: models.WebhookGameCreated, GameID: "2"})
+	dispatcher.Wait()
+
+	assert.Equal(t, map[string]int{"/discord": 1, "/dashboard": 1}, received)
+	assert.Len(t, store.deliveries, 2)
+}
+
+func TestDispatchStoreError(t *testing.T) {
+	store := &fakeStore{err: errors.New("intentional error")}
+	dispatcher := newDispatcher(t, store)
+
+	dispatcher.Dispatch("1", &models.WebhookPayload{Event: models.WebhookGameCreated, GameID: "2"})
+	dispatcher.Wait()
+
+	assert.Empty(t, store.deliveries)
+}
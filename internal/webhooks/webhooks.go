@@ -0,0 +1,46 @@
+// Package webhooks posts the events of the games of the users to the webhooks they configured, e.g. Discord bots or dashboards.
+// The payloads are signed with the secret of the webhook, the failed deliveries are retried with backoff,
+// and every delivery is recorded in a log, that the users can inspect.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// HeaderEvent is the header with the event of the payload, e.g. game.created
+	HeaderEvent = "X-Gira-Event"
+	// HeaderDelivery is the header with the ID of the event. It is the same for the retries of the event.
+	HeaderDelivery = "X-Gira-Delivery"
+	// HeaderTimestamp is the header with the Unix time, when the payload was signed
+	HeaderTimestamp = "X-Gira-Timestamp"
+	// HeaderSignature is the header with the signature of the payload, in the form sha256=<hex>
+	HeaderSignature = "X-Gira-Signature"
+)
+
+// Sign returns the hex-encoded HMAC-SHA256 of the timestamp and the body, joined with a dot, keyed with the secret.
+// The timestamp is signed too, so that the receivers can reject the old payloads, that are replayed.
+func Sign(secret string, timestamp int64, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// NewSecret returns a new random secret for a webhook
+func NewSecret() (string, error) {
+	return randomHex(32)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error while generating random string: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
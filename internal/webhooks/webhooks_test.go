@@ -0,0 +1,25 @@
+package webhooks_test
+
+import (
+	"testing"
+
+	"github.com/asankov/gira/internal/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	// the signature can be computed by the receivers, e.g. with
+	// python3 -c 'import hmac,hashlib;print(hmac.new(b"secret",b"1622548800.{\"id\":\"1\"}",hashlib.sha256).hexdigest())'
+	assert.Equal(t, "d8e4d929858f320865ad49ce219b873fc8a07fa638d616eb630e4cfb752b573e", webhooks.Sign("secret", 1622548800, []byte(`{"id":"1"}`)))
+}
+
+func TestNewSecret(t *testing.T) {
+	secret, err := webhooks.NewSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 64)
+
+	another, err := webhooks.NewSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, another)
+}
@@ -0,0 +1,279 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var (
+	// ErrBoard is a generic error
+	ErrBoard = errors.New("error while processing board")
+	// ErrBoardNotFound is returned when the board, or its member or invitation, does not exist, or the user is not a member of the board
+	ErrBoardNotFound = errors.New("board not found")
+	// ErrBoardForbidden is returned when the role of the user on the board does not allow the change
+	ErrBoardForbidden = errors.New("your role on the board does not allow that")
+)
+
+// BoardRole is the role of a member of a shared board
+type BoardRole string
+
+var (
+	// BoardRoleOwner can change the games of the board, and invite and remove its members
+	BoardRoleOwner BoardRole = "owner"
+	// BoardRoleEditor can change the games of the board
+	BoardRoleEditor BoardRole = "editor"
+	// BoardRoleViewer can only see the games of the board
+	BoardRoleViewer BoardRole = "viewer"
+)
+
+// Board is a set of games, shared by a group of users
+type Board struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Role is the role of the authenticated user on the board
+	Role BoardRole `json:"role,omitempty"`
+	// Members are returned only by GetBoard
+	Members []*BoardMember `json:"members,omitempty"`
+}
+
+// BoardMember is a user, that is a member of a board
+type BoardMember struct {
+	Username string    `json:"username"`
+	Role     BoardRole `json:"role"`
+}
+
+// BoardInvitation is an invitation of a user to become a member of a board
+type BoardInvitation struct {
+	ID        string    `json:"id"`
+	BoardID   string    `json:"boardId"`
+	BoardName string    `json:"boardName"`
+	Username  string    `json:"username"`
+	Role      BoardRole `json:"role"`
+	InvitedBy string    `json:"invitedBy"`
+}
+
+// GetBoardsRequest is used when getting the boards, that the authenticated user is a member of
+type GetBoardsRequest struct {
+	Token string
+}
+
+// GetBoardsResponse is the response of GetBoards
+type GetBoardsResponse struct {
+	Boards []*Board `json:"boards"`
+}
+
+// CreateBoardRequest is used when creating a board
+type CreateBoardRequest struct {
+	Token string
+	Name  string
+}
+
+// BoardRequest is used when getting or deleting a board, or getting its games
+type BoardRequest struct {
+	Token   string
+	BoardID string
+}
+
+// InviteToBoardRequest is used when inviting a user to a board.
+// An empty Role invites the user as an editor.
+type InviteToBoardRequest struct {
+	Token    string
+	BoardID  string
+	Username string
+	Role     BoardRole
+}
+
+// BoardMemberRequest is used when changing the role of a member of a board, or removing them from it
+type BoardMemberRequest struct {
+	Token    string
+	BoardID  string
+	Username string
+	// Role is used only when changing the role of the member
+	Role BoardRole
+}
+
+// GetInvitationsRequest is used when getting the pending invitations of the authenticated user
+type GetInvitationsRequest struct {
+	Token string
+}
+
+// GetInvitationsResponse is the response of GetInvitations
+type GetInvitationsResponse struct {
+	Invitations []*BoardInvitation `json:"invitations"`
+}
+
+// InvitationRequest is used when accepting or declining an invitation
+type InvitationRequest struct {
+	Token        string
+	InvitationID string
+}
+
+// GetBoards returns the boards, that the authenticated user is a member of
+func (c *Client) GetBoards(ctx context.Context, request *GetBoardsRequest) (*GetBoardsResponse, error) {
+	var res GetBoardsResponse
+	if err := c.doBoard(ctx, http.MethodGet, request.Token, fmt.Sprintf("%s/boards", c.addr), nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// CreateBoard creates a board. The authenticated user becomes its owner.
+func (c *Client) CreateBoard(ctx context.Context, request *CreateBoardRequest) (*Board, error) {
+	body := struct {
+		Name string `json:"name"`
+	}{Name: request.Name}
+
+	var board Board
+	if err := c.doBoard(ctx, http.MethodPost, request.Token, fmt.Sprintf("%s/boards", c.addr), body, http.StatusCreated, &board); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// GetBoard returns the given board, together with its members
+func (c *Client) GetBoard(ctx context.Context, request *BoardRequest) (*Board, error) {
+	var board Board
+	if err := c.doBoard(ctx, http.MethodGet, request.Token, c.boardURL(request.BoardID, ""), nil, http.StatusOK, &board); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// DeleteBoard deletes the given board, together with its games
+func (c *Client) DeleteBoard(ctx context.Context, request *BoardRequest) error {
+	return c.doBoard(ctx, http.MethodDelete, request.Token, c.boardURL(request.BoardID, ""), nil, http.StatusNoContent, nil)
+}
+
+// GetBoardGames returns the games on the given board
+func (c *Client) GetBoardGames(ctx context.Context, request *BoardRequest) (*GetGamesResponse, error) {
+	var res GetGamesResponse
+	if err := c.doBoard(ctx, http.MethodGet, request.Token, c.boardURL(request.BoardID, "games"), nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// InviteToBoard invites the given user to the board
+func (c *Client) InviteToBoard(ctx context.Context, request *InviteToBoardRequest) (*BoardInvitation, error) {
+	body := struct {
+		Username string    `json:"username"`
+		Role     BoardRole `json:"role,omitempty"`
+	}{Username: request.Username, Role: request.Role}
+
+	var invitation BoardInvitation
+	if err := c.doBoard(ctx, http.MethodPost, request.Token, c.boardURL(request.BoardID, "invitations"), body, http.StatusCreated, &invitation); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ChangeBoardMemberRole changes the role of the given member of the board and returns the board
+func (c *Client) ChangeBoardMemberRole(ctx context.Context, request *BoardMemberRequest) (*Board, error) {
+	body := struct {
+		Role BoardRole `json:"role"`
+	}{Role: request.Role}
+
+	var board Board
+	if err := c.doBoard(ctx, http.MethodPatch, request.Token, c.boardURL(request.BoardID, "members/"+url.PathEscape(request.Username)), body, http.StatusOK, &board); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// RemoveBoardMember removes the given member from the board.
+// Removing the authenticated user makes them leave the board.
+func (c *Client) RemoveBoardMember(ctx context.Context, request *BoardMemberRequest) error {
+	return c.doBoard(ctx, http.MethodDelete, request.Token, c.boardURL(request.BoardID, "members/"+url.PathEscape(request.Username)), nil, http.StatusNoContent, nil)
+}
+
+// GetInvitations returns the pending invitations of the authenticated user
+func (c *Client) GetInvitations(ctx context.Context, request *GetInvitationsRequest) (*GetInvitationsResponse, error) {
+	var res GetInvitationsResponse
+	if err := c.doBoard(ctx, http.MethodGet, request.Token, fmt.Sprintf("%s/invitations", c.addr), nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AcceptInvitation makes the authenticated user a member of the board, that they are invited to, and returns the board
+func (c *Client) AcceptInvitation(ctx context.Context, request *InvitationRequest) (*Board, error) {
+	var board Board
+	if err := c.doBoard(ctx, http.MethodPost, request.Token, c.invitationURL(request.InvitationID, "accept"), nil, http.StatusOK, &board); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// DeclineInvitation declines the given invitation
+func (c *Client) DeclineInvitation(ctx context.Context, request *InvitationRequest) error {
+	return c.doBoard(ctx, http.MethodPost, request.Token, c.invitationURL(request.InvitationID, "decline"), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) boardURL(boardID, path string) string {
+	u := fmt.Sprintf("%s/boards/%s", c.addr, url.PathEscape(boardID))
+	if path != "" {
+		u += "/" + path
+	}
+	return u
+}
+
+func (c *Client) invitationURL(invitationID, action string) string {
+	return fmt.Sprintf("%s/invitations/%s/%s", c.addr, url.PathEscape(invitationID), action)
+}
+
+// doBoard sends the request with the given body, if any, and decodes the response into out, if any.
+func (c *Client) doBoard(ctx context.Context, method, token, u string, body interface{}, expectedCode int, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error while marshalling body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return ErrBoard
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case expectedCode:
+	case http.StatusUnauthorized:
+		return ErrNoAuthorization
+	case http.StatusForbidden:
+		return ErrBoardForbidden
+	case http.StatusNotFound:
+		return ErrBoardNotFound
+	case http.StatusBadRequest:
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil || errorResponse.Error == "" {
+			return ErrBoard
+		}
+		return errors.New(errorResponse.Error)
+	default:
+		return ErrBoard
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("error while decoding body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	boardResponse = models.Board{
+		ID:      "1",
+		Name:    "Co-op",
+		Role:    models.BoardRoleOwner,
+		Members: []*models.BoardMember{{Username: "anton", Role: models.BoardRoleOwner}, {Username: "ivan", Role: models.BoardRoleViewer}},
+	}
+	expectedBoard = &client.Board{
+		ID:      "1",
+		Name:    "Co-op",
+		Role:    client.BoardRoleOwner,
+		Members: []*client.BoardMember{{Username: "anton", Role: client.BoardRoleOwner}, {Username: "ivan", Role: client.BoardRoleViewer}},
+	}
+	invitationResponse = models.BoardInvitation{ID: "3", BoardID: "1", BoardName: "Co-op", Username: "ivan", Role: models.BoardRoleEditor, InvitedBy: "anton"}
+	expectedInvitation = &client.BoardInvitation{ID: "3", BoardID: "1", BoardName: "Co-op", Username: "ivan", Role: client.BoardRoleEditor, InvitedBy: "anton"}
+)
+
+func TestBoard(t *testing.T) {
+	testCases := []struct {
+		name   string
+		path   string
+		method string
+		code   int
+		call   func(*client.Client) (*client.Board, error)
+	}{
+		{
+			name:   "Create",
+			path:   "/boards",
+			method: http.MethodPost,
+			code:   http.StatusCreated,
+			call: func(cl *client.Client) (*client.Board, error) {
+				return cl.CreateBoard(context.Background(), &client.CreateBoardRequest{Token: token, Name: "Co-op"})
+			},
+		},
+		{
+			name:   "Get",
+			path:   "/boards/1",
+			method: http.MethodGet,
+			code:   http.StatusOK,
+			call: func(cl *client.Client) (*client.Board, error) {
+				return cl.GetBoard(context.Background(), &client.BoardRequest{Token: token, BoardID: "1"})
+			},
+		},
+		{
+			name:   "Change role",
+			path:   "/boards/1/members/ivan",
+			method: http.MethodPatch,
+			code:   http.StatusOK,
+			call: func(cl *client.Client) (*client.Board, error) {
+				return cl.ChangeBoardMemberRole(context.Background(), &client.BoardMemberRequest{Token: token, BoardID: "1", Username: "ivan", Role: client.BoardRoleViewer})
+			},
+		},
+		{
+			name:   "Accept invitation",
+			path:   "/invitations/3/accept",
+			method: http.MethodPost,
+			code:   http.StatusOK,
+			call: func(cl *client.Client) (*client.Board, error) {
+				return cl.AcceptInvitation(context.Background(), &client.InvitationRequest{Token: token, InvitationID: "3"})
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path(testCase.path).
+				Method(testCase.method).
+				Token(token).
+				Data(boardResponse).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			board, err := testCase.call(newClient(t, ts.URL))
+			require.NoError(t, err)
+			assert.Equal(t, expectedBoard, board)
+		})
+	}
+}
+
+func TestGetBoards(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/boards").
+		Method(http.MethodGet).
+		Token(token).
+		Data(models.BoardsResponse{Boards: []*models.Board{{ID: "1", Name: "Co-op", Role: models.BoardRoleViewer}}}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetBoards(context.Background(), &client.GetBoardsRequest{Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, []*client.Board{{ID: "1", Name: "Co-op", Role: client.BoardRoleViewer}}, res.Boards)
+}
+
+func TestGetBoardGames(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/boards/1/games").
+		Method(http.MethodGet).
+		Token(token).
+		Data(models.GamesResponse{Games: []*models.Game{{ID: "1", Name: "It Takes Two", Status: models.StatusInProgress, BoardID: "1"}}}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetBoardGames(context.Background(), &client.BoardRequest{Token: token, BoardID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, []*client.Game{{ID: "1", Name: "It Takes Two", Status: "In Progress", BoardID: "1"}}, res.Games)
+}
+
+func TestInviteToBoard(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/boards/1/invitations").
+		Method(http.MethodPost).
+		Token(token).
+		Data(invitationResponse).
+		Return(http.StatusCreated).
+		Build()
+	defer ts.Close()
+
+	invitation, err := newClient(t, ts.URL).InviteToBoard(context.Background(), &client.InviteToBoardRequest{Token: token, BoardID: "1", Username: "ivan"})
+	require.NoError(t, err)
+	assert.Equal(t, expectedInvitation, invitation)
+}
+
+func TestGetInvitations(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/invitations").
+		Method(http.MethodGet).
+		Token(token).
+		Data(models.BoardInvitationsResponse{Invitations: []*models.BoardInvitation{&invitationResponse}}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetInvitations(context.Background(), &client.GetInvitationsRequest{Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, []*client.BoardInvitation{expectedInvitation}, res.Invitations)
+}
+
+func TestBoardNoContent(t *testing.T) {
+	testCases := []struct {
+		name   string
+		path   string
+		method string
+		call   func(*client.Client) error
+	}{
+		{
+			name:   "Delete board",
+			path:   "/boards/1",
+			method: http.MethodDelete,
+			call: func(cl *client.Client) error {
+				return cl.DeleteBoard(context.Background(), &client.BoardRequest{Token: token, BoardID: "1"})
+			},
+		},
+		{
+			name:   "Remove member",
+			path:   "/boards/1/members/ivan",
+			method: http.MethodDelete,
+			call: func(cl *client.Client) error {
+				return cl.RemoveBoardMember(context.Background(), &client.BoardMemberRequest{Token: token, BoardID: "1", Username: "ivan"})
+			},
+		},
+		{
+			name:   "Decline invitation",
+			path:   "/invitations/3/decline",
+			method: http.MethodPost,
+			call: func(cl *client.Client) error {
+				return cl.DeclineInvitation(context.Background(), &client.InvitationRequest{Token: token, InvitationID: "3"})
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path(testCase.path).
+				Method(testCase.method).
+				Token(token).
+				Return(http.StatusNoContent).
+				Build()
+			defer ts.Close()
+
+			require.NoError(t, testCase.call(newClient(t, ts.URL)))
+		})
+	}
+}
+
+func TestBoardError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization.Error()},
+		{name: "Forbidden", code: http.StatusForbidden, expectedErr: client.ErrBoardForbidden.Error()},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrBoardNotFound.Error()},
+		{name: "Bad request", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "the user is already a member of the board"}, expectedErr: "the user is already a member of the board"},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrBoard.Error()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/boards/1/invitations").
+				Method(http.MethodPost).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			invitation, err := newClient(t, ts.URL).InviteToBoard(context.Background(), &client.InviteToBoardRequest{Token: token, BoardID: "1", Username: "ivan"})
+			assert.Nil(t, invitation)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestUpdateGameOnBoardForbidden(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/games/1").
+		Method(http.MethodPatch).
+		Token(token).
+		Return(http.StatusForbidden).
+		Build()
+	defer ts.Close()
+
+	_, err := newClient(t, ts.URL).UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{Token: token, GameID: "1", Update: client.UpdateGameProgressChange{Status: "Done"}})
+	assert.ErrorIs(t, err, client.ErrBoardForbidden)
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	// ErrStreamingChanges is a generic error
+	ErrStreamingChanges = errors.New("error while streaming changes")
+	// ErrLiveUpdatesDisabled is returned when the changes are not streamed by the server
+	ErrLiveUpdatesDisabled = errors.New("live updates are disabled")
+)
+
+// StreamChanges opens the stream of the changes of the games, that the user can see.
+// The changes are Server-Sent Events, that are read from the returned body until the context is cancelled,
+// so the body must be closed by the caller.
+func (c *Client) StreamChanges(ctx context.Context, token string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/changes", c.addr), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	req.Header.Add("Accept", "text/event-stream")
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrStreamingChanges
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		switch res.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrNoAuthorization
+		case http.StatusNotFound:
+			return nil, ErrLiveUpdatesDisabled
+		}
+		return nil, ErrStreamingChanges
+	}
+
+	return res.Body, nil
+}
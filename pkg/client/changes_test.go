@@ -0,0 +1,70 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamChanges(t *testing.T) {
+	body := []byte("event: game.deleted\ndata: {\"type\":\"game.deleted\",\"gameId\":\"1\"}\n\n")
+	ts := fixtures.NewTestServer(t).
+		Path("/changes").
+		Method(http.MethodGet).
+		Token(token).
+		Body("text/event-stream", body).
+		Build()
+	defer ts.Close()
+
+	stream, err := newClient(t, ts.URL).StreamChanges(context.Background(), token)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	content, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, body, content)
+}
+
+func TestStreamChangesError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		expectedErr error
+	}{
+		{
+			name:        "Unauthorized",
+			code:        http.StatusUnauthorized,
+			expectedErr: client.ErrNoAuthorization,
+		},
+		{
+			name:        "Disabled",
+			code:        http.StatusNotFound,
+			expectedErr: client.ErrLiveUpdatesDisabled,
+		},
+		{
+			name:        "Server error",
+			code:        http.StatusInternalServerError,
+			expectedErr: client.ErrStreamingChanges,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/changes").
+				Method(http.MethodGet).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			stream, err := newClient(t, ts.URL).StreamChanges(context.Background(), token)
+			assert.Nil(t, stream)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var (
+	// ErrChecklist is a generic error
+	ErrChecklist = errors.New("error while processing checklist")
+	// ErrChecklistNotFound is returned when the game, or the checklist item, does not exist
+	ErrChecklistNotFound = errors.New("game or checklist item not found")
+)
+
+// ChecklistItem is a named item of a game (achievement, side quest, collectible, etc.), that can be ticked off
+type ChecklistItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Done bool   `json:"done"`
+}
+
+// Checklist is the list of checklist items of a game
+type Checklist struct {
+	Items []*ChecklistItem `json:"items"`
+	// AutoProgress is true if the progress of the game is computed from the done items
+	AutoProgress bool          `json:"autoProgress"`
+	Progress     *GameProgress `json:"progress"`
+}
+
+// GetChecklistRequest is used when getting the checklist of a game
+type GetChecklistRequest struct {
+	Token  string
+	GameID string
+}
+
+// SetChecklistAutoProgressRequest is used when turning on or off the computation of the progress of a game from its checklist
+type SetChecklistAutoProgressRequest struct {
+	Token        string
+	GameID       string
+	AutoProgress bool
+}
+
+// AddChecklistItemRequest is used when adding an item to the checklist of a game
+type AddChecklistItemRequest struct {
+	Token  string
+	GameID string
+	Name   string
+}
+
+// UpdateChecklistItemRequest is used when renaming or ticking off an item of the checklist of a game.
+// An empty Name or a nil Done are left unchanged.
+type UpdateChecklistItemRequest struct {
+	Token  string
+	GameID string
+	ItemID string
+	Name   string
+	Done   *bool
+}
+
+// DeleteChecklistItemRequest is used when deleting an item from the checklist of a game
+type DeleteChecklistItemRequest struct {
+	Token  string
+	GameID string
+	ItemID string
+}
+
+// GetChecklist returns the checklist of the given game
+func (c *Client) GetChecklist(ctx context.Context, request *GetChecklistRequest) (*Checklist, error) {
+	return c.doChecklist(ctx, http.MethodGet, request.Token, c.checklistURL(request.GameID, ""), nil)
+}
+
+// SetChecklistAutoProgress turns on or off the computation of the progress of the game from its checklist
+func (c *Client) SetChecklistAutoProgress(ctx context.Context, request *SetChecklistAutoProgressRequest) (*Checklist, error) {
+	body := struct {
+		AutoProgress bool `json:"autoProgress"`
+	}{AutoProgress: request.AutoProgress}
+	return c.doChecklist(ctx, http.MethodPatch, request.Token, c.checklistURL(request.GameID, ""), body)
+}
+
+// AddChecklistItem adds an item to the checklist of the given game and returns the changed checklist
+func (c *Client) AddChecklistItem(ctx context.Context, request *AddChecklistItemRequest) (*Checklist, error) {
+	body := struct {
+		Name string `json:"name"`
+	}{Name: request.Name}
+	return c.doChecklist(ctx, http.MethodPost, request.Token, c.checklistURL(request.GameID, "items"), body)
+}
+
+// UpdateChecklistItem renames or ticks off an item of the checklist of the given game and returns the changed checklist
+func (c *Client) UpdateChecklistItem(ctx context.Context, request *UpdateChecklistItemRequest) (*Checklist, error) {
+	body := struct {
+		Name string `json:"name,omitempty"`
+		Done *bool  `json:"done,omitempty"`
+	}{Name: request.Name, Done: request.Done}
+	return c.doChecklist(ctx, http.MethodPatch, request.Token, c.checklistURL(request.GameID, "items/"+url.PathEscape(request.ItemID)), body)
+}
+
+// DeleteChecklistItem deletes an item from the checklist of the given game and returns the changed checklist
+func (c *Client) DeleteChecklistItem(ctx context.Context, request *DeleteChecklistItemRequest) (*Checklist, error) {
+	return c.doChecklist(ctx, http.MethodDelete, request.Token, c.checklistURL(request.GameID, "items/"+url.PathEscape(request.ItemID)), nil)
+}
+
+func (c *Client) checklistURL(gameID, path string) string {
+	u := fmt.Sprintf("%s/games/%s/checklist", c.addr, url.PathEscape(gameID))
+	if path != "" {
+		u += "/" + path
+	}
+	return u
+}
+
+// doChecklist sends the request with the given body, if any, and decodes the checklist from the response.
+func (c *Client) doChecklist(ctx context.Context, method, token, u string, body interface{}) (*Checklist, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("error while marshalling body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrChecklist
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrNoAuthorization
+	case http.StatusNotFound:
+		return nil, ErrChecklistNotFound
+	case http.StatusBadRequest:
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil || errorResponse.Error == "" {
+			return nil, ErrChecklist
+		}
+		return nil, errors.New(errorResponse.Error)
+	default:
+		return nil, ErrChecklist
+	}
+
+	var checklist Checklist
+	if err := json.NewDecoder(res.Body).Decode(&checklist); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+	return &checklist, nil
+}
@@ -0,0 +1,126 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var checklistResponse = models.Checklist{
+	Items: []*models.ChecklistItem{
+		{ID: "1", Name: "Find all feathers", Done: true},
+		{ID: "2", Name: "Kill all templars"},
+	},
+	AutoProgress: true,
+	Progress:     &models.GameProgress{Current: 1, Final: 2},
+}
+
+func TestChecklist(t *testing.T) {
+	done := true
+
+	testCases := []struct {
+		name   string
+		path   string
+		method string
+		call   func(*client.Client) (*client.Checklist, error)
+	}{
+		{
+			name:   "Get",
+			path:   "/games/1/checklist",
+			method: http.MethodGet,
+			call: func(cl *client.Client) (*client.Checklist, error) {
+				return cl.GetChecklist(context.Background(), &client.GetChecklistRequest{Token: token, GameID: "1"})
+			},
+		},
+		{
+			name:   "Set auto progress",
+			path:   "/games/1/checklist",
+			method: http.MethodPatch,
+			call: func(cl *client.Client) (*client.Checklist, error) {
+				return cl.SetChecklistAutoProgress(context.Background(), &client.SetChecklistAutoProgressRequest{Token: token, GameID: "1", AutoProgress: true})
+			},
+		},
+		{
+			name:   "Add item",
+			path:   "/games/1/checklist/items",
+			method: http.MethodPost,
+			call: func(cl *client.Client) (*client.Checklist, error) {
+				return cl.AddChecklistItem(context.Background(), &client.AddChecklistItemRequest{Token: token, GameID: "1", Name: "Kill all templars"})
+			},
+		},
+		{
+			name:   "Update item",
+			path:   "/games/1/checklist/items/1",
+			method: http.MethodPatch,
+			call: func(cl *client.Client) (*client.Checklist, error) {
+				return cl.UpdateChecklistItem(context.Background(), &client.UpdateChecklistItemRequest{Token: token, GameID: "1", ItemID: "1", Done: &done})
+			},
+		},
+		{
+			name:   "Delete item",
+			path:   "/games/1/checklist/items/1",
+			method: http.MethodDelete,
+			call: func(cl *client.Client) (*client.Checklist, error) {
+				return cl.DeleteChecklistItem(context.Background(), &client.DeleteChecklistItemRequest{Token: token, GameID: "1", ItemID: "1"})
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path(testCase.path).
+				Method(testCase.method).
+				Token(token).
+				Data(checklistResponse).
+				Build()
+			defer ts.Close()
+
+			checklist, err := testCase.call(newClient(t, ts.URL))
+			require.NoError(t, err)
+			assert.Equal(t, &client.Checklist{
+				Items: []*client.ChecklistItem{
+					{ID: "1", Name: "Find all feathers", Done: true},
+					{ID: "2", Name: "Kill all templars"},
+				},
+				AutoProgress: true,
+				Progress:     &client.GameProgress{Current: 1, Final: 2},
+			}, checklist)
+		})
+	}
+}
+
+func TestChecklistError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization.Error()},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrChecklistNotFound.Error()},
+		{name: "Bad request", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "'name' is required"}, expectedErr: "'name' is required"},
+		{name: "Bad request without message", code: http.StatusBadRequest, expectedErr: client.ErrChecklist.Error()},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrChecklist.Error()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/games/1/checklist/items").
+				Method(http.MethodPost).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			checklist, err := newClient(t, ts.URL).AddChecklistItem(context.Background(), &client.AddChecklistItemRequest{Token: token, GameID: "1"})
+			assert.Nil(t, checklist)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var (
+	// ErrCover is a generic error
+	ErrCover = errors.New("error while processing cover")
+	// ErrCoversNotConfigured is returned when the API has no blob store configured
+	ErrCoversNotConfigured = errors.New("covers are not configured")
+	// ErrCoverNotFound is returned when the game or franchise, or its cover, does not exist
+	ErrCoverNotFound = errors.New("cover not found")
+	// ErrCoverTooLarge is returned when the uploaded cover is larger than allowed
+	ErrCoverTooLarge = errors.New("the cover is too large")
+)
+
+// CoverKind is the kind of item, that a cover belongs to
+type CoverKind string
+
+var (
+	// CoverKindGame is the cover of a game
+	CoverKindGame CoverKind = "games"
+	// CoverKindFranchise is the cover of a franchise
+	CoverKindFranchise CoverKind = "franchises"
+)
+
+var (
+	// CoverSizeFull is the cover scaled down to at most 1024 pixels
+	CoverSizeFull = "full"
+	// CoverSizeThumbnail is the cover scaled down to at most 200 pixels
+	CoverSizeThumbnail = "thumbnail"
+)
+
+// Cover is the cover art of a game or a franchise
+type Cover struct {
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// UploadCoverRequest is used when uploading the cover of a game or a franchise
+type UploadCoverRequest struct {
+	Token string
+	Kind  CoverKind
+	ID    string
+	// Body is the image - a JPEG, PNG or GIF
+	Body io.Reader
+}
+
+// GetCoverRequest is used when getting the cover of a game or a franchise
+type GetCoverRequest struct {
+	Token string
+	Kind  CoverKind
+	ID    string
+	// Size is CoverSizeFull or CoverSizeThumbnail. Defaults to CoverSizeFull.
+	Size string
+}
+
+// GetCoverResponse is returned from the GetCover method
+type GetCoverResponse struct {
+	Data        []byte
+	ContentType string
+}
+
+// DeleteCoverRequest is used when deleting the cover of a game or a franchise
+type DeleteCoverRequest struct {
+	Token string
+	Kind  CoverKind
+	ID    string
+}
+
+// UploadCover uploads the cover of the given game or franchise, replacing the existing one, if any
+func (c *Client) UploadCover(ctx context.Context, request *UploadCoverRequest) (*Cover, error) {
+	res, err := c.doCover(ctx, http.MethodPut, request.Token, c.coverURL(request.Kind, request.ID, ""), request.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var cover Cover
+	if err := json.NewDecoder(res.Body).Decode(&cover); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+	return &cover, nil
+}
+
+// GetCover returns the cover image of the given game or franchise
+func (c *Client) GetCover(ctx context.Context, request *GetCoverRequest) (*GetCoverResponse, error) {
+	res, err := c.doCover(ctx, http.MethodGet, request.Token, c.coverURL(request.Kind, request.ID, request.Size), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading body: %w", err)
+	}
+	return &GetCoverResponse{Data: data, ContentType: res.Header.Get("Content-Type")}, nil
+}
+
+// DeleteCover deletes the cover of the given game or franchise
+func (c *Client) DeleteCover(ctx context.Context, request *DeleteCoverRequest) error {
+	res, err := c.doCover(ctx, http.MethodDelete, request.Token, c.coverURL(request.Kind, request.ID, ""), nil)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (c *Client) coverURL(kind CoverKind, id, size string) string {
+	u := fmt.Sprintf("%s/%s/%s/cover", c.addr, kind, url.PathEscape(id))
+	if size != "" {
+		u += "?" + url.Values{"size": []string{size}}.Encode()
+	}
+	return u
+}
+
+// doCover sends the request and maps the unsuccessful responses to errors.
+// The body of the returned response must be closed by the caller.
+func (c *Client) doCover(ctx context.Context, method, token, u string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrCover
+	}
+	if res.StatusCode == http.StatusOK {
+		return res, nil
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusUnauthorized:
+		return nil, ErrNoAuthorization
+	case http.StatusNotFound:
+		return nil, ErrCoverNotFound
+	case http.StatusNotImplemented:
+		return nil, ErrCoversNotConfigured
+	case http.StatusRequestEntityTooLarge:
+		return nil, ErrCoverTooLarge
+	case http.StatusBadRequest:
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil || errorResponse.Error == "" {
+			return nil, ErrCover
+		}
+		return nil, errors.New(errorResponse.Error)
+	default:
+		return nil, ErrCover
+	}
+}
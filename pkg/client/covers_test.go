@@ -0,0 +1,148 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadCover(t *testing.T) {
+	updatedAt := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	ts := fixtures.NewTestServer(t).
+		Path("/games/1/cover").
+		Method(http.MethodPut).
+		Token(token).
+		Data(models.Cover{UpdatedAt: &updatedAt}).
+		Return(http.StatusOK).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	cover, err := cl.UploadCover(context.Background(), &client.UploadCoverRequest{
+		Token: token,
+		Kind:  client.CoverKindGame,
+		ID:    "1",
+		Body:  bytes.NewReader([]byte("image")),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &client.Cover{UpdatedAt: &updatedAt}, cover)
+}
+
+func TestUploadCoverError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr error
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrCoverNotFound},
+		{name: "Too large", code: http.StatusRequestEntityTooLarge, expectedErr: client.ErrCoverTooLarge},
+		{name: "Not configured", code: http.StatusNotImplemented, expectedErr: client.ErrCoversNotConfigured},
+		{name: "Bad request without message", code: http.StatusBadRequest, expectedErr: client.ErrCover},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrCover},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/franchises/1/cover").
+				Method(http.MethodPut).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			cover, err := cl.UploadCover(context.Background(), &client.UploadCoverRequest{
+				Token: token,
+				Kind:  client.CoverKindFranchise,
+				ID:    "1",
+				Body:  bytes.NewReader([]byte("image")),
+			})
+			assert.Nil(t, cover)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestUploadCoverInvalidImage(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/games/1/cover").
+		Method(http.MethodPut).
+		Data(models.ErrorResponse{Error: "the image must be a JPEG, PNG or GIF"}).
+		Return(http.StatusBadRequest).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	_, err := cl.UploadCover(context.Background(), &client.UploadCoverRequest{
+		Token: token,
+		Kind:  client.CoverKindGame,
+		ID:    "1",
+		Body:  bytes.NewReader([]byte("not an image")),
+	})
+	assert.EqualError(t, err, "the image must be a JPEG, PNG or GIF")
+}
+
+func TestGetCover(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/games/1/cover").
+		Method(http.MethodGet).
+		Token(token).
+		Query("size=thumbnail").
+		Body("image/jpeg", []byte("thumbnail")).
+		Return(http.StatusOK).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	cover, err := cl.GetCover(context.Background(), &client.GetCoverRequest{
+		Token: token,
+		Kind:  client.CoverKindGame,
+		ID:    "1",
+		Size:  client.CoverSizeThumbnail,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &client.GetCoverResponse{Data: []byte("thumbnail"), ContentType: "image/jpeg"}, cover)
+}
+
+func TestGetCoverNotFound(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/games/1/cover").
+		Method(http.MethodGet).
+		Return(http.StatusNotFound).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	cover, err := cl.GetCover(context.Background(), &client.GetCoverRequest{Token: token, Kind: client.CoverKindGame, ID: "1"})
+	assert.Nil(t, cover)
+	assert.ErrorIs(t, err, client.ErrCoverNotFound)
+}
+
+func TestDeleteCover(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/franchises/1/cover").
+		Method(http.MethodDelete).
+		Token(token).
+		Return(http.StatusOK).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	err := cl.DeleteCover(context.Background(), &client.DeleteCoverRequest{Token: token, Kind: client.CoverKindFranchise, ID: "1"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var (
+	// ErrExportingData is a generic error
+	ErrExportingData = errors.New("error while exporting data")
+)
+
+// ExportUserDataRequest is used when exporting all data of the user
+type ExportUserDataRequest struct {
+	Token string
+	// Format is the format of the export - json (the default), or csv for a ZIP archive of CSV files
+	Format string
+}
+
+// ExportUserDataResponse is returned from the ExportUserData method.
+// The body is streamed from the server, so it must be closed by the caller.
+type ExportUserDataResponse struct {
+	ContentType        string
+	ContentDisposition string
+	Body               io.ReadCloser
+}
+
+// ExportUserData streams all data of the user from the server
+func (c *Client) ExportUserData(ctx context.Context, request *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	u := fmt.Sprintf("%s/users/me/export", c.addr)
+	if request.Format != "" {
+		u += "?format=" + url.QueryEscape(request.Format)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrExportingData
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		if res.StatusCode == http.StatusUnauthorized {
+			return nil, ErrNoAuthorization
+		}
+		return nil, ErrExportingData
+	}
+
+	return &ExportUserDataResponse{
+		ContentType:        res.Header.Get("Content-Type"),
+		ContentDisposition: res.Header.Get("Content-Disposition"),
+		Body:               res.Body,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportUserData(t *testing.T) {
+	body := []byte("PK")
+	ts := fixtures.NewTestServer(t).
+		Path("/users/me/export").
+		Method(http.MethodGet).
+		Token(token).
+		Query("format=csv").
+		Body("application/zip", body).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.ExportUserData(context.Background(), &client.ExportUserDataRequest{
+		Token:  token,
+		Format: "csv",
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "application/zip", resp.ContentType)
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, content)
+}
+
+func TestExportUserDataError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		expectedErr error
+	}{
+		{
+			name:        "Unauthorized",
+			code:        http.StatusUnauthorized,
+			expectedErr: client.ErrNoAuthorization,
+		},
+		{
+			name:        "Server error",
+			code:        http.StatusInternalServerError,
+			expectedErr: client.ErrExportingData,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/users/me/export").
+				Method(http.MethodGet).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			resp, err := cl.ExportUserData(context.Background(), &client.ExportUserDataRequest{
+				Token: token,
+			})
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
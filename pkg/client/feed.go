@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrFetchingFeed is a generic error
+	ErrFetchingFeed = errors.New("error while fetching feed")
+)
+
+// EventKind is the kind of an activity of a user
+type EventKind string
+
+var (
+	// EventStatusChanged is the event of a game changing its status, e.g. being started or finished
+	EventStatusChanged EventKind = "status_changed"
+)
+
+// Event is an activity of a user, that the authenticated user follows
+type Event struct {
+	ID       string    `json:"id"`
+	Kind     EventKind `json:"kind"`
+	Username string    `json:"username"`
+	GameID   string    `json:"gameId"`
+	GameName string    `json:"gameName"`
+	// From and To are set for EventStatusChanged
+	From      Status    `json:"from,omitempty"`
+	To        Status    `json:"to,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GetFeedRequest is used when getting a page of the feed of the authenticated user
+type GetFeedRequest struct {
+	Token string
+	// Before is the cursor of the page, returned as NextCursor with the previous one. It is empty for the first page.
+	Before string
+	// Limit is the number of events in the page. The server default is used if it is 0.
+	Limit int
+}
+
+// GetFeedResponse is a page of the feed, the newest events first
+type GetFeedResponse struct {
+	Events []*Event `json:"events"`
+	// NextCursor is passed as Before to get the next page. It is empty if there are no more events.
+	NextCursor string `json:"nextCursor"`
+}
+
+// GetFeed fetches a page of the activity of the users, that the authenticated user follows
+func (c *Client) GetFeed(ctx context.Context, request *GetFeedRequest) (*GetFeedResponse, error) {
+	query := url.Values{}
+	if request.Before != "" {
+		query.Set("before", request.Before)
+	}
+	if request.Limit > 0 {
+		query.Set("limit", strconv.Itoa(request.Limit))
+	}
+	u := fmt.Sprintf("%s/feed", c.addr)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrFetchingFeed
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		if res.StatusCode == http.StatusUnauthorized {
+			return nil, ErrNoAuthorization
+		}
+		return nil, ErrFetchingFeed
+	}
+
+	var feedResponse GetFeedResponse
+	if err := json.NewDecoder(res.Body).Decode(&feedResponse); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+	return &feedResponse, nil
+}
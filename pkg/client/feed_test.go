@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFeed(t *testing.T) {
+	createdAt := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		request *client.GetFeedRequest
+		query   string
+	}{
+		{name: "First page", request: &client.GetFeedRequest{Token: token}},
+		{name: "Next page", request: &client.GetFeedRequest{Token: token, Before: "7", Limit: 2}, query: "before=7&limit=2"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/feed").
+				Method(http.MethodGet).
+				Token(token).
+				Query(testCase.query).
+				Data(models.FeedResponse{
+					Events: []*models.Event{
+						{ID: "5", Kind: models.EventStatusChanged, Username: "ivan", GameID: "1", GameName: "Hades", From: models.StatusInProgress, To: models.StatusDone, CreatedAt: createdAt},
+					},
+					NextCursor: "5",
+				}).
+				Build()
+			defer ts.Close()
+
+			res, err := newClient(t, ts.URL).GetFeed(context.Background(), testCase.request)
+			require.NoError(t, err)
+			assert.Equal(t, &client.GetFeedResponse{
+				Events: []*client.Event{
+					{ID: "5", Kind: client.EventStatusChanged, Username: "ivan", GameID: "1", GameName: "Hades", From: "In Progress", To: "Done", CreatedAt: createdAt},
+				},
+				NextCursor: "5",
+			}, res)
+		})
+	}
+}
+
+func TestGetFeedError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		expectedErr error
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrFetchingFeed},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/feed").
+				Method(http.MethodGet).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			res, err := newClient(t, ts.URL).GetFeed(context.Background(), &client.GetFeedRequest{Token: token})
+			assert.Nil(t, res)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
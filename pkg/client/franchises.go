@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/asankov/gira/pkg/models"
 )
@@ -16,18 +18,55 @@ var (
 	ErrFetchingFranchises = errors.New("error while fetching franchises")
 	// ErrCreatingFranchise is a generic error
 	ErrCreatingFranchise = errors.New("error while creating franchise")
+	// ErrFetchingFranchise is a generic error
+	ErrFetchingFranchise = errors.New("error while fetching franchise")
+	// ErrFranchiseNotFound is returned when the franchise does not exist, or belongs to another user
+	ErrFranchiseNotFound = errors.New("franchise not found")
+	// ErrReorderingFranchise is a generic error
+	ErrReorderingFranchise = errors.New("error while ordering the games of the franchise")
+	// ErrChangingFranchiseParent is a generic error
+	ErrChangingFranchiseParent = errors.New("error while changing the parent of the franchise")
+)
+
+// FranchiseOrder is one of the orders of the games in a franchise
+type FranchiseOrder string
+
+const (
+	// FranchiseOrderRelease is the order, in which the games were released
+	FranchiseOrderRelease FranchiseOrder = "release"
+	// FranchiseOrderPlay is the order, in which the games are recommended to be played
+	FranchiseOrderPlay FranchiseOrder = "play"
 )
 
 // Franchise is the struct that represents a franchise
 type Franchise struct {
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name,omitempty"`
+	// CoverUpdatedAt is the time the cover of the franchise was uploaded, if it has one
+	CoverUpdatedAt *time.Time `json:"coverUpdatedAt,omitempty"`
+	// Games are the games of the franchise in play order. They are returned only by GetFranchise.
+	Games []*Game `json:"games,omitempty"`
+	// ParentID is the franchise, that this one is a sub-series of. It is empty for the top-level franchises.
+	ParentID string `json:"parentId,omitempty"`
+	// Children are the sub-series of the franchise, sorted by name. They are returned only by GetFranchises.
+	Children []*Franchise `json:"children,omitempty"`
+	// Completion is the completion of the games of the franchise, including the ones of its sub-series.
+	// It is returned only by GetFranchises.
+	Completion *FranchiseCompletion `json:"completion,omitempty"`
+}
+
+// FranchiseCompletion shows how many of the games of a franchise are finished
+type FranchiseCompletion struct {
+	Games    int `json:"games"`
+	Finished int `json:"finished"`
 }
 
 // CreateFranchiseRequest is used when the consumer wants to create a franchise
 type CreateFranchiseRequest struct {
 	Name  string
 	Token string
+	// ParentID makes the new franchise a sub-series of the given one
+	ParentID string `json:"parentId,omitempty"`
 }
 
 // CreateFranchiseResponse is the response that is returned from CreateFranchise
@@ -42,9 +81,47 @@ type GetFranchisesRequest struct {
 
 // GetFranchisesResponse is the response that is returned from GetFranchises
 type GetFranchisesResponse struct {
+	// Franchises are the top-level franchises, with their sub-series as children
 	Franchises []*Franchise `json:"franchises,omitempty"`
 }
 
+// All returns all franchises in the tree, each one followed by its sub-series
+func (r *GetFranchisesResponse) All() []*Franchise {
+	all := []*Franchise{}
+	var walk func([]*Franchise)
+	walk = func(franchises []*Franchise) {
+		for _, franchise := range franchises {
+			all = append(all, franchise)
+			walk(franchise.Children)
+		}
+	}
+	walk(r.Franchises)
+	return all
+}
+
+// SetFranchiseParentRequest is used when the consumer wants to make a franchise a sub-series of another one
+type SetFranchiseParentRequest struct {
+	Token       string `json:"-"`
+	FranchiseID string `json:"-"`
+	// ParentID is the new parent of the franchise. If empty, the franchise becomes a top-level one.
+	ParentID string `json:"parentId"`
+}
+
+// GetFranchiseRequest is used when the consumer wants to get a single franchise with its games
+type GetFranchiseRequest struct {
+	Token       string
+	FranchiseID string
+}
+
+// ReorderFranchiseRequest is used when the consumer wants to change the order of the games in a franchise
+type ReorderFranchiseRequest struct {
+	Token       string         `json:"-"`
+	FranchiseID string         `json:"-"`
+	Order       FranchiseOrder `json:"order"`
+	// GameIDs are the IDs of all games of the franchise, in the new order
+	GameIDs []string `json:"gameIds"`
+}
+
 // GetFranchises returns all the franchises
 func (c *Client) GetFranchises(ctx context.Context, request *GetFranchisesRequest) (*GetFranchisesResponse, error) {
 	url := fmt.Sprintf("%s/franchises", c.addr)
@@ -109,3 +186,122 @@ func (c *Client) CreateFranchise(ctx context.Context, req *CreateFranchiseReques
 
 	return &CreateFranchiseResponse{Franchise: &franchise}, nil
 }
+
+// GetFranchise returns the franchise with its games in play order
+func (c *Client) GetFranchise(ctx context.Context, request *GetFranchiseRequest) (*Franchise, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.franchiseURL(request.FranchiseID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrFetchingFranchise
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrNoAuthorization
+	case http.StatusNotFound:
+		return nil, ErrFranchiseNotFound
+	default:
+		return nil, ErrFetchingFranchise
+	}
+
+	var franchise Franchise
+	if err := json.NewDecoder(res.Body).Decode(&franchise); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+
+	return &franchise, nil
+}
+
+// ReorderFranchise stores the given order of the games in the franchise and returns the franchise with its games in the new order
+func (c *Client) ReorderFranchise(ctx context.Context, request *ReorderFranchiseRequest) (*Franchise, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error while marshalling body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.franchiseURL(request.FranchiseID)+"/order", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrReorderingFranchise
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrNoAuthorization
+	case http.StatusNotFound:
+		return nil, ErrFranchiseNotFound
+	case http.StatusBadRequest:
+		var jsonErr models.ErrorResponse
+		if err := json.NewDecoder(res.Body).Decode(&jsonErr); err == nil && jsonErr.Error != "" {
+			return nil, errors.New(jsonErr.Error)
+		}
+		return nil, ErrReorderingFranchise
+	default:
+		return nil, ErrReorderingFranchise
+	}
+
+	var franchise Franchise
+	if err := json.NewDecoder(res.Body).Decode(&franchise); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+
+	return &franchise, nil
+}
+
+// SetFranchiseParent makes the franchise a sub-series of another one, or a top-level franchise if the parent is empty
+func (c *Client) SetFranchiseParent(ctx context.Context, request *SetFranchiseParentRequest) (*Franchise, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error while marshalling body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.franchiseURL(request.FranchiseID)+"/parent", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrChangingFranchiseParent
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrNoAuthorization
+	case http.StatusNotFound:
+		return nil, ErrFranchiseNotFound
+	case http.StatusBadRequest:
+		var jsonErr models.ErrorResponse
+		if err := json.NewDecoder(res.Body).Decode(&jsonErr); err == nil && jsonErr.Error != "" {
+			return nil, errors.New(jsonErr.Error)
+		}
+		return nil, ErrChangingFranchiseParent
+	default:
+		return nil, ErrChangingFranchiseParent
+	}
+
+	var franchise Franchise
+	if err := json.NewDecoder(res.Body).Decode(&franchise); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+
+	return &franchise, nil
+}
+
+func (c *Client) franchiseURL(franchiseID string) string {
+	return fmt.Sprintf("%s/franchises/%s", c.addr, url.PathEscape(franchiseID))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/asankov/gira/internal/fixtures"
 
 	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
 
 	"github.com/stretchr/testify/require"
 )
@@ -57,3 +58,176 @@ func TestFranchisesCreate(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, franchise, frResponse.Franchise)
 }
+
+func TestFranchiseGet(t *testing.T) {
+	expected := &client.Franchise{
+		ID:   "1",
+		Name: "Batman",
+		Games: []*client.Game{
+			{ID: "2", Name: "Arkham Origins", ReleaseOrder: 3, PlayOrder: 1},
+			{ID: "1", Name: "Arkham Asylum", ReleaseOrder: 1, PlayOrder: 2},
+		},
+	}
+	ts := fixtures.NewTestServer(t).
+		Path("/franchises/1").
+		Token(token).
+		Method(http.MethodGet).
+		Data(expected).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetFranchise(context.Background(), &client.GetFranchiseRequest{Token: token, FranchiseID: "1"})
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
+func TestFranchiseGetError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		expectedErr error
+	}{
+		{name: "Auth error", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrFranchiseNotFound},
+		{name: "Other error", code: http.StatusInternalServerError, expectedErr: client.ErrFetchingFranchise},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/franchises/1").
+				Token(token).
+				Method(http.MethodGet).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			_, err := newClient(t, ts.URL).GetFranchise(context.Background(), &client.GetFranchiseRequest{Token: token, FranchiseID: "1"})
+			require.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestFranchiseReorder(t *testing.T) {
+	expected := &client.Franchise{
+		ID:   "1",
+		Name: "Batman",
+		Games: []*client.Game{
+			{ID: "1", Name: "Arkham Asylum", ReleaseOrder: 1, PlayOrder: 1},
+			{ID: "2", Name: "Arkham Origins", ReleaseOrder: 2, PlayOrder: 2},
+		},
+	}
+	ts := fixtures.NewTestServer(t).
+		Path("/franchises/1/order").
+		Token(token).
+		Method(http.MethodPut).
+		Data(expected).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).ReorderFranchise(context.Background(), &client.ReorderFranchiseRequest{
+		Token:       token,
+		FranchiseID: "1",
+		Order:       client.FranchiseOrderRelease,
+		GameIDs:     []string{"1", "2"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
+func TestFranchiseReorderError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{name: "Auth error", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization.Error()},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrFranchiseNotFound.Error()},
+		{name: "Bad request", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "the order should contain every game of the franchise exactly once"}, expectedErr: "the order should contain every game of the franchise exactly once"},
+		{name: "Other error", code: http.StatusInternalServerError, expectedErr: client.ErrReorderingFranchise.Error()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/franchises/1/order").
+				Token(token).
+				Method(http.MethodPut).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			_, err := newClient(t, ts.URL).ReorderFranchise(context.Background(), &client.ReorderFranchiseRequest{
+				Token:       token,
+				FranchiseID: "1",
+				Order:       client.FranchiseOrderPlay,
+				GameIDs:     []string{"1"},
+			})
+			require.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestFranchisesGetTree(t *testing.T) {
+	hyruleWarriors := &client.Franchise{ID: "4", Name: "Hyrule Warriors", ParentID: "3", Completion: &client.FranchiseCompletion{Games: 1, Finished: 1}}
+	spinOffs := &client.Franchise{ID: "3", Name: "Spin-offs", ParentID: "1", Completion: &client.FranchiseCompletion{Games: 3, Finished: 1}, Children: []*client.Franchise{hyruleWarriors}}
+	zelda := &client.Franchise{ID: "1", Name: "Zelda", Completion: &client.FranchiseCompletion{Games: 7, Finished: 4}, Children: []*client.Franchise{spinOffs}}
+	batman := &client.Franchise{ID: "2", Name: "Batman", Completion: &client.FranchiseCompletion{Games: 1, Finished: 1}}
+
+	ts := fixtures.NewTestServer(t).
+		Path("/franchises").
+		Token(token).
+		Method(http.MethodGet).
+		Data(&client.GetFranchisesResponse{Franchises: []*client.Franchise{batman, zelda}}).
+		Build()
+	defer ts.Close()
+
+	resp, err := newClient(t, ts.URL).GetFranchises(context.Background(), &client.GetFranchisesRequest{Token: token})
+	require.NoError(t, err)
+	require.Equal(t, []*client.Franchise{batman, zelda}, resp.Franchises)
+	require.Equal(t, []*client.Franchise{batman, zelda, spinOffs, hyruleWarriors}, resp.All())
+}
+
+func TestFranchiseSetParent(t *testing.T) {
+	expected := &client.Franchise{ID: "3", Name: "Spin-offs", ParentID: "1"}
+	ts := fixtures.NewTestServer(t).
+		Path("/franchises/3/parent").
+		Token(token).
+		Method(http.MethodPut).
+		Data(expected).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).SetFranchiseParent(context.Background(), &client.SetFranchiseParentRequest{Token: token, FranchiseID: "3", ParentID: "1"})
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
+func TestFranchiseSetParentError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{name: "Auth error", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization.Error()},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrFranchiseNotFound.Error()},
+		{name: "Cycle", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "the parent franchise is a sub-series"}, expectedErr: "the parent franchise is a sub-series"},
+		{name: "Other error", code: http.StatusInternalServerError, expectedErr: client.ErrChangingFranchiseParent.Error()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/franchises/1/parent").
+				Token(token).
+				Method(http.MethodPut).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			_, err := newClient(t, ts.URL).SetFranchiseParent(context.Background(), &client.SetFranchiseParentRequest{Token: token, FranchiseID: "1", ParentID: "3"})
+			require.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/asankov/gira/pkg/models"
 )
@@ -31,9 +32,40 @@ type Game struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
 	FranchiseID string `json:"franchiseId"`
-
-	Status   Status        `json:"status,omitempty"`
-	Progress *GameProgress `json:"progress,omitempty"`
+	Franchise   string `json:"franchise,omitempty"`
+
+	Status     Status        `json:"status,omitempty"`
+	Progress   *GameProgress `json:"progress,omitempty"`
+	UpdatedAt  *time.Time    `json:"updatedAt,omitempty"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+	// CreatedAt is the time the game was added to the backlog
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// Wishlist marks the games, that the user wants, but does not own yet
+	Wishlist bool `json:"wishlist,omitempty"`
+	// AutoProgress is true if the progress is computed from the checklist of the game
+	AutoProgress bool `json:"autoProgress,omitempty"`
+	// Playthroughs is the number of playthroughs of the game. If the game has any, its status is the status of the latest one.
+	Playthroughs int `json:"playthroughs,omitempty"`
+	// Hidden games are not shown on the public profile of the user
+	Hidden bool `json:"hidden,omitempty"`
+	// Rating is the rating of the game by the user, from 1 to 5. It is 0 for the games, that are not rated.
+	Rating int `json:"rating,omitempty"`
+	// ReleaseOrder and PlayOrder are the positions of the game in its franchise, starting from 1.
+	// They are set for the games of a franchise, returned by GetFranchise.
+	ReleaseOrder int `json:"releaseOrder,omitempty"`
+	PlayOrder    int `json:"playOrder,omitempty"`
+	// BoardID is the ID of the shared board, that the game is on. It is empty for the games, that belong only to the user.
+	BoardID string `json:"boardId,omitempty"`
+
+	// MetadataID links the game to the metadata provider, which fills the rest of the details when the game is created
+	MetadataID  string     `json:"metadataId,omitempty"`
+	CoverURL    string     `json:"coverUrl,omitempty"`
+	Developer   string     `json:"developer,omitempty"`
+	Genres      []string   `json:"genres,omitempty"`
+	ReleaseDate *time.Time `json:"releaseDate,omitempty"`
+
+	// CoverUpdatedAt is the time the cover of the game was uploaded, if it has one
+	CoverUpdatedAt *time.Time `json:"coverUpdatedAt,omitempty"`
 }
 
 // GetGamesRequest is used when the consumer wants to get all games
@@ -47,6 +79,12 @@ type GetGamesResponse struct {
 	Games []*Game
 }
 
+// GetUpcomingGamesRequest is used when the consumer wants to get the games, that are not released yet
+type GetUpcomingGamesRequest struct {
+	Token        string
+	WishlistOnly bool
+}
+
 // CreateGameRequest is used when the consumer wants to create a games
 type CreateGameRequest struct {
 	Token string
@@ -89,6 +127,36 @@ func (c *Client) GetGames(ctx context.Context, request *GetGamesRequest) (*GetGa
 	return &games, nil
 }
 
+// GetUpcomingGames returns the games of the user, that are released today or later, sorted by their release date.
+func (c *Client) GetUpcomingGames(ctx context.Context, request *GetUpcomingGamesRequest) (*GetGamesResponse, error) {
+	url := fmt.Sprintf("%s/games/upcoming", c.addr)
+	if request.WishlistOnly {
+		url += "?wishlist=true"
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrFetchingGames
+	}
+	if res.StatusCode != http.StatusOK {
+		if res.StatusCode == http.StatusUnauthorized {
+			return nil, ErrNoAuthorization
+		}
+		return nil, ErrFetchingGames
+	}
+
+	var games GetGamesResponse
+	if err := json.NewDecoder(res.Body).Decode(&games); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+
+	return &games, nil
+}
+
 // CreateGame creates a new game from the passed model.
 func (c *Client) CreateGame(ctx context.Context, request *CreateGameRequest) (*CreateGameResponse, error) {
 	body, err := json.Marshal(request.Game)
@@ -109,6 +177,9 @@ func (c *Client) CreateGame(ctx context.Context, request *CreateGameRequest) (*C
 		if res.StatusCode == http.StatusUnauthorized {
 			return nil, ErrNoAuthorization
 		}
+		if res.StatusCode == http.StatusForbidden {
+			return nil, ErrBoardForbidden
+		}
 		if res.StatusCode == http.StatusBadRequest {
 			var jsonErr models.ErrorResponse
 			if err := json.NewDecoder(res.Body).Decode(&jsonErr); err == nil {
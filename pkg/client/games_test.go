@@ -102,6 +102,70 @@ func TestGetGamesHTTPError(t *testing.T) {
 	}
 }
 
+func TestGetUpcomingGames(t *testing.T) {
+	testCases := []struct {
+		name         string
+		wishlistOnly bool
+		query        string
+	}{
+		{name: "All games"},
+		{name: "Wishlist only", wishlistOnly: true, query: "wishlist=true"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/games/upcoming").
+				Data(gameResponse).
+				Token(token).
+				Query(testCase.query).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			games, err := cl.GetUpcomingGames(context.Background(), &client.GetUpcomingGamesRequest{Token: token, WishlistOnly: testCase.wishlistOnly})
+
+			require.NoError(t, err)
+			require.Equal(t, 1, len(games.Games))
+			assert.Equal(t, game.ID, games.Games[0].ID)
+		})
+	}
+}
+
+func TestGetUpcomingGamesHTTPError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		returnCode  int
+		expectedErr error
+	}{
+		{
+			name:        "Auth error",
+			returnCode:  http.StatusUnauthorized,
+			expectedErr: client.ErrNoAuthorization,
+		},
+		{
+			name:        "Other error",
+			returnCode:  http.StatusInternalServerError,
+			expectedErr: client.ErrFetchingGames,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/games/upcoming").
+				Return(testCase.returnCode).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			games, err := cl.GetUpcomingGames(context.Background(), &client.GetUpcomingGamesRequest{Token: token})
+			assert.Nil(t, games)
+			assert.True(t, errors.Is(err, testCase.expectedErr))
+		})
+	}
+}
+
 func TestCreateGame(t *testing.T) {
 	ts := fixtures.NewTestServer(t).
 		Path("/games").
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var (
+	// ErrGoal is a generic error
+	ErrGoal = errors.New("error while processing goal")
+	// ErrGoalNotFound is returned when the goal does not exist
+	ErrGoalNotFound = errors.New("goal not found")
+)
+
+// GoalKind is the kind of a goal
+type GoalKind string
+
+var (
+	// GoalFinishGames is a goal to finish a number of games in a period, e.g. 12 games this year
+	GoalFinishGames GoalKind = "finish-games"
+	// GoalFinishFranchise is a goal to finish all games of a franchise, including the ones of its sub-series
+	GoalFinishFranchise GoalKind = "finish-franchise"
+	// GoalBacklog is a goal to clear the backlog down to a number of To Do games
+	GoalBacklog GoalKind = "backlog"
+)
+
+// GoalStatus is the status of a goal
+type GoalStatus string
+
+var (
+	// GoalInProgress is the status of a goal, that is not reached yet, but can still be
+	GoalInProgress GoalStatus = "in-progress"
+	// GoalAchieved is the status of a goal, that is reached
+	GoalAchieved GoalStatus = "achieved"
+	// GoalFailed is the status of a goal, that is not reached until its last day
+	GoalFailed GoalStatus = "failed"
+)
+
+// Goal is a goal of the user, e.g. finish 12 games this year
+type Goal struct {
+	ID   string   `json:"id,omitempty"`
+	Kind GoalKind `json:"kind"`
+	// Target is the number of games to finish for the finish-games goals and the maximum number of To Do games for the backlog goals.
+	// For the finish-franchise goals it is computed from the number of games of the franchise.
+	Target      int        `json:"target"`
+	FranchiseID string     `json:"franchiseId,omitempty"`
+	Franchise   string     `json:"franchise,omitempty"`
+	StartsAt    *time.Time `json:"startsAt,omitempty"`
+	EndsAt      *time.Time `json:"endsAt,omitempty"`
+
+	// Current is the number of finished games, or the number of To Do games for the backlog goals
+	Current    int        `json:"current,omitempty"`
+	Status     GoalStatus `json:"status,omitempty"`
+	AchievedAt *time.Time `json:"achievedAt,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+}
+
+// GetGoalsRequest is used when getting the goals of the user
+type GetGoalsRequest struct {
+	Token string
+}
+
+// GetGoalsResponse is the response of GetGoals
+type GetGoalsResponse struct {
+	Goals []*Goal `json:"goals"`
+}
+
+// CreateGoalRequest is used when creating a goal
+type CreateGoalRequest struct {
+	Token string
+	Goal  *Goal
+}
+
+// GoalRequest is used when getting or deleting a goal
+type GoalRequest struct {
+	Token  string
+	GoalID string
+}
+
+// GetGoals returns the goals of the user with their progress
+func (c *Client) GetGoals(ctx context.Context, request *GetGoalsRequest) (*GetGoalsResponse, error) {
+	var res GetGoalsResponse
+	if err := c.doGoal(ctx, http.MethodGet, request.Token, fmt.Sprintf("%s/goals", c.addr), nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// CreateGoal creates the goal and returns it with its current progress
+func (c *Client) CreateGoal(ctx context.Context, request *CreateGoalRequest) (*Goal, error) {
+	var goal Goal
+	if err := c.doGoal(ctx, http.MethodPost, request.Token, fmt.Sprintf("%s/goals", c.addr), request.Goal, http.StatusCreated, &goal); err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// GetGoal returns the given goal with its progress
+func (c *Client) GetGoal(ctx context.Context, request *GoalRequest) (*Goal, error) {
+	var goal Goal
+	if err := c.doGoal(ctx, http.MethodGet, request.Token, c.goalURL(request.GoalID), nil, http.StatusOK, &goal); err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// DeleteGoal deletes the given goal
+func (c *Client) DeleteGoal(ctx context.Context, request *GoalRequest) error {
+	return c.doGoal(ctx, http.MethodDelete, request.Token, c.goalURL(request.GoalID), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) goalURL(goalID string) string {
+	return fmt.Sprintf("%s/goals/%s", c.addr, url.PathEscape(goalID))
+}
+
+// doGoal sends the request with the given body, if any, and decodes the response into out, if any.
+func (c *Client) doGoal(ctx context.Context, method, token, u string, body interface{}, expectedCode int, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error while marshalling body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return ErrGoal
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case expectedCode:
+	case http.StatusUnauthorized:
+		return ErrNoAuthorization
+	case http.StatusNotFound:
+		return ErrGoalNotFound
+	case http.StatusBadRequest:
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil || errorResponse.Error == "" {
+			return ErrGoal
+		}
+		return errors.New(errorResponse.Error)
+	default:
+		return ErrGoal
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("error while decoding body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	goalStart    = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	goalEnd      = time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)
+	goalResponse = models.Goal{
+		ID:       "1",
+		Kind:     models.GoalFinishGames,
+		Target:   12,
+		StartsAt: &goalStart,
+		EndsAt:   &goalEnd,
+		Current:  3,
+		Status:   models.GoalInProgress,
+	}
+	expectedGoal = &client.Goal{
+		ID:       "1",
+		Kind:     client.GoalFinishGames,
+		Target:   12,
+		StartsAt: &goalStart,
+		EndsAt:   &goalEnd,
+		Current:  3,
+		Status:   client.GoalInProgress,
+	}
+)
+
+func TestGetGoals(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/goals").
+		Method(http.MethodGet).
+		Token(token).
+		Data(models.GoalsResponse{Goals: []*models.Goal{&goalResponse}}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetGoals(context.Background(), &client.GetGoalsRequest{Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, []*client.Goal{expectedGoal}, res.Goals)
+}
+
+func TestCreateGoal(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/goals").
+		Method(http.MethodPost).
+		Token(token).
+		Data(goalResponse).
+		Return(http.StatusCreated).
+		Build()
+	defer ts.Close()
+
+	goal, err := newClient(t, ts.URL).CreateGoal(context.Background(), &client.CreateGoalRequest{
+		Token: token,
+		Goal:  &client.Goal{Kind: client.GoalFinishGames, Target: 12, StartsAt: &goalStart, EndsAt: &goalEnd},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expectedGoal, goal)
+}
+
+func TestGetGoal(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/goals/1").
+		Method(http.MethodGet).
+		Token(token).
+		Data(goalResponse).
+		Build()
+	defer ts.Close()
+
+	goal, err := newClient(t, ts.URL).GetGoal(context.Background(), &client.GoalRequest{Token: token, GoalID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, expectedGoal, goal)
+}
+
+func TestDeleteGoal(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/goals/1").
+		Method(http.MethodDelete).
+		Token(token).
+		Return(http.StatusNoContent).
+		Build()
+	defer ts.Close()
+
+	err := newClient(t, ts.URL).DeleteGoal(context.Background(), &client.GoalRequest{Token: token, GoalID: "1"})
+	require.NoError(t, err)
+}
+
+func TestGoalError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization.Error()},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrGoalNotFound.Error()},
+		{name: "Bad request", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "'target' should be a positive number"}, expectedErr: "'target' should be a positive number"},
+		{name: "Bad request without message", code: http.StatusBadRequest, expectedErr: client.ErrGoal.Error()},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrGoal.Error()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/goals").
+				Method(http.MethodPost).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			goal, err := newClient(t, ts.URL).CreateGoal(context.Background(), &client.CreateGoalRequest{Token: token, Goal: &client.Goal{}})
+			assert.Nil(t, goal)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
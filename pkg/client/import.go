@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var (
+	// ErrImportingGames is a generic error
+	ErrImportingGames = errors.New("error while importing games")
+	// ErrPreviewingLibrary is a generic error
+	ErrPreviewingLibrary = errors.New("error while reading library export")
+)
+
+// ImportGamesRequest is used when importing games from a CSV or JSON file
+type ImportGamesRequest struct {
+	Token string
+	// ContentType is the content type of the body - text/csv or application/json
+	ContentType string
+	Body        io.Reader
+	// Mode is either atomic (the default) or best-effort
+	Mode   string
+	DryRun bool
+}
+
+// ImportGamesResponse is returned from the ImportGames method.
+// If an atomic import is rejected, because some of the games are invalid or conflicting,
+// it is returned with no error, Imported is 0 and Rows show the reason.
+type ImportGamesResponse struct {
+	Mode          string       `json:"mode"`
+	DryRun        bool         `json:"dryRun"`
+	Imported      int          `json:"imported"`
+	NewFranchises []string     `json:"newFranchises"`
+	Rows          []*ImportRow `json:"rows"`
+}
+
+// ImportRow is the outcome of importing a single game
+type ImportRow struct {
+	Line   int    `json:"line"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportGames imports the games from the request body
+func (c *Client) ImportGames(ctx context.Context, request *ImportGamesRequest) (*ImportGamesResponse, error) {
+	query := url.Values{}
+	if request.Mode != "" {
+		query.Set("mode", request.Mode)
+	}
+	if request.DryRun {
+		query.Set("dryRun", "true")
+	}
+	u := fmt.Sprintf("%s/games/import", c.addr)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	req.Header.Set("Content-Type", request.ContentType)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrImportingGames
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusConflict, http.StatusBadRequest:
+	case http.StatusUnauthorized:
+		return nil, ErrNoAuthorization
+	default:
+		return nil, ErrImportingGames
+	}
+
+	var importResponse struct {
+		ImportGamesResponse
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&importResponse); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+	if importResponse.Error != "" {
+		return nil, errors.New(importResponse.Error)
+	}
+	if res.StatusCode == http.StatusBadRequest {
+		return nil, ErrImportingGames
+	}
+	return &importResponse.ImportGamesResponse, nil
+}
+
+// PreviewLibraryImportRequest is used when reading the library export of a storefront
+type PreviewLibraryImportRequest struct {
+	Token string
+	// Source is the storefront that the export comes from - steam or gog
+	Source string
+	Body   io.Reader
+}
+
+// PreviewLibraryImportResponse is returned from the PreviewLibraryImport method
+type PreviewLibraryImportResponse struct {
+	Source string              `json:"source"`
+	Games  []*LibraryCandidate `json:"games"`
+}
+
+// LibraryCandidate is a game from the library export, that can be imported
+type LibraryCandidate struct {
+	Name      string `json:"name"`
+	Franchise string `json:"franchise,omitempty"`
+	Status    string `json:"status"`
+	// Playtime is the time played, in minutes
+	Playtime int `json:"playtime,omitempty"`
+	// DuplicateOf is the name of the game, that this one duplicates, if any
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+}
+
+// PreviewLibraryImport reads the games from a library export, without importing them
+func (c *Client) PreviewLibraryImport(ctx context.Context, request *PreviewLibraryImportRequest) (*PreviewLibraryImportResponse, error) {
+	u := fmt.Sprintf("%s/games/import/preview?%s", c.addr, url.Values{"source": []string{request.Source}}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrPreviewingLibrary
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrNoAuthorization
+	case http.StatusBadRequest:
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil || errorResponse.Error == "" {
+			return nil, ErrPreviewingLibrary
+		}
+		return nil, errors.New(errorResponse.Error)
+	default:
+		return nil, ErrPreviewingLibrary
+	}
+
+	var previewResponse PreviewLibraryImportResponse
+	if err := json.NewDecoder(res.Body).Decode(&previewResponse); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+	return &previewResponse, nil
+}
@@ -0,0 +1,181 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportGames(t *testing.T) {
+	testCases := []struct {
+		name string
+		code int
+	}{
+		{name: "Imported", code: http.StatusOK},
+		{name: "Rejected", code: http.StatusConflict},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := &client.ImportGamesResponse{
+				Mode:          "best-effort",
+				DryRun:        true,
+				NewFranchises: []string{"Franchise"},
+				Rows: []*client.ImportRow{
+					{Line: 1, Name: "Game", Status: "ok"},
+				},
+			}
+			ts := fixtures.NewTestServer(t).
+				Path("/games/import").
+				Method(http.MethodPost).
+				Token(token).
+				Header("Content-Type", "text/csv").
+				Query("dryRun=true&mode=best-effort").
+				Data(result).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			resp, err := cl.ImportGames(context.Background(), &client.ImportGamesRequest{
+				Token:       token,
+				ContentType: "text/csv",
+				Body:        strings.NewReader("name\nGame\n"),
+				Mode:        "best-effort",
+				DryRun:      true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, result, resp)
+		})
+	}
+}
+
+func TestImportGamesError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{
+			name:        "Unauthorized",
+			code:        http.StatusUnauthorized,
+			expectedErr: client.ErrNoAuthorization.Error(),
+		},
+		{
+			name:        "Bad request",
+			code:        http.StatusBadRequest,
+			data:        models.ErrorResponse{Error: "the CSV header must contain a 'name' column"},
+			expectedErr: "the CSV header must contain a 'name' column",
+		},
+		{
+			name:        "Server error",
+			code:        http.StatusInternalServerError,
+			expectedErr: client.ErrImportingGames.Error(),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/games/import").
+				Method(http.MethodPost).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			resp, err := cl.ImportGames(context.Background(), &client.ImportGamesRequest{
+				Token:       token,
+				ContentType: "text/csv",
+				Body:        strings.NewReader("name\nGame\n"),
+			})
+			assert.Nil(t, resp)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestPreviewLibraryImport(t *testing.T) {
+	preview := &client.PreviewLibraryImportResponse{
+		Source: "steam",
+		Games: []*client.LibraryCandidate{
+			{Name: "Far Cry 3", Franchise: "Far Cry", Status: "In Progress", Playtime: 120},
+			{Name: "Hades", Status: "To Do", DuplicateOf: "Hades"},
+		},
+	}
+	ts := fixtures.NewTestServer(t).
+		Path("/games/import/preview").
+		Method(http.MethodPost).
+		Token(token).
+		Query("source=steam").
+		Data(preview).
+		Return(http.StatusOK).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.PreviewLibraryImport(context.Background(), &client.PreviewLibraryImportRequest{
+		Token:  token,
+		Source: "steam",
+		Body:   strings.NewReader(`{"response": {"games": []}}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, preview, resp)
+}
+
+func TestPreviewLibraryImportError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{
+			name:        "Unauthorized",
+			code:        http.StatusUnauthorized,
+			expectedErr: client.ErrNoAuthorization.Error(),
+		},
+		{
+			name:        "Bad request",
+			code:        http.StatusBadRequest,
+			data:        models.ErrorResponse{Error: "source must be one of steam, gog"},
+			expectedErr: "source must be one of steam, gog",
+		},
+		{
+			name:        "Server error",
+			code:        http.StatusInternalServerError,
+			expectedErr: client.ErrPreviewingLibrary.Error(),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/games/import/preview").
+				Method(http.MethodPost).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			resp, err := cl.PreviewLibraryImport(context.Background(), &client.PreviewLibraryImportRequest{
+				Token:  token,
+				Source: "epic",
+				Body:   strings.NewReader(""),
+			})
+			assert.Nil(t, resp)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var (
+	// ErrFetchingMetadata is a generic error
+	ErrFetchingMetadata = errors.New("error while fetching game metadata")
+	// ErrMetadataNotConfigured is returned when the API has no metadata provider configured
+	ErrMetadataNotConfigured = errors.New("no metadata provider is configured")
+	// ErrMetadataNotFound is returned when the metadata provider does not know the requested game
+	ErrMetadataNotFound = errors.New("game not found in metadata provider")
+)
+
+// GameMetadata are the details of a game, provided by the metadata provider
+type GameMetadata struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	CoverURL    string     `json:"coverUrl,omitempty"`
+	ReleaseDate *time.Time `json:"releaseDate,omitempty"`
+	Genres      []string   `json:"genres,omitempty"`
+	Developer   string     `json:"developer,omitempty"`
+	// FranchiseID is the ID of the franchise of the user, that the game most likely belongs to, if any
+	FranchiseID string `json:"franchiseId,omitempty"`
+}
+
+// SearchGameMetadataRequest is used when searching the metadata provider for games by name
+type SearchGameMetadataRequest struct {
+	Token string
+	Query string
+}
+
+// SearchGameMetadataResponse is returned from the SearchGameMetadata method
+type SearchGameMetadataResponse struct {
+	Games []*GameMetadata `json:"games"`
+}
+
+// GetGameMetadataRequest is used when getting the details of a game from the metadata provider
+type GetGameMetadataRequest struct {
+	Token string
+	ID    string
+}
+
+// SearchGameMetadata searches the metadata provider for games, whose names match the query
+func (c *Client) SearchGameMetadata(ctx context.Context, request *SearchGameMetadataRequest) (*SearchGameMetadataResponse, error) {
+	var searchResponse SearchGameMetadataResponse
+	if err := c.getMetadata(ctx, request.Token, fmt.Sprintf("%s/metadata/games?%s", c.addr, url.Values{"q": []string{request.Query}}.Encode()), &searchResponse); err != nil {
+		return nil, err
+	}
+	return &searchResponse, nil
+}
+
+// GetGameMetadata returns the details of the given game from the metadata provider
+func (c *Client) GetGameMetadata(ctx context.Context, request *GetGameMetadataRequest) (*GameMetadata, error) {
+	var game GameMetadata
+	if err := c.getMetadata(ctx, request.Token, fmt.Sprintf("%s/metadata/games/%s", c.addr, url.PathEscape(request.ID)), &game); err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+func (c *Client) getMetadata(ctx context.Context, token, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return ErrFetchingMetadata
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return ErrNoAuthorization
+	case http.StatusNotImplemented:
+		return ErrMetadataNotConfigured
+	case http.StatusNotFound:
+		return ErrMetadataNotFound
+	default:
+		return ErrFetchingMetadata
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("error while decoding body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var gameMetadata = &client.GameMetadata{
+	ID:          "3328",
+	Name:        "The Witcher 3: Wild Hunt",
+	CoverURL:    "https://media.example.com/games/the-witcher-3.jpg",
+	ReleaseDate: func() *time.Time { t := time.Date(2015, time.May, 18, 0, 0, 0, 0, time.UTC); return &t }(),
+	Genres:      []string{"Action", "RPG"},
+	Developer:   "CD PROJEKT RED",
+	FranchiseID: "1",
+}
+
+func TestSearchGameMetadata(t *testing.T) {
+	result := &client.SearchGameMetadataResponse{Games: []*client.GameMetadata{gameMetadata}}
+	ts := fixtures.NewTestServer(t).
+		Path("/metadata/games").
+		Method(http.MethodGet).
+		Token(token).
+		Query("q=the+witcher").
+		Data(result).
+		Return(http.StatusOK).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.SearchGameMetadata(context.Background(), &client.SearchGameMetadataRequest{Token: token, Query: "the witcher"})
+	require.NoError(t, err)
+	assert.Equal(t, result, resp)
+}
+
+func TestGetGameMetadata(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/metadata/games/3328").
+		Method(http.MethodGet).
+		Token(token).
+		Data(gameMetadata).
+		Return(http.StatusOK).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.GetGameMetadata(context.Background(), &client.GetGameMetadataRequest{Token: token, ID: "3328"})
+	require.NoError(t, err)
+	assert.Equal(t, gameMetadata, resp)
+}
+
+func TestGetGameMetadataError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		expectedErr error
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization},
+		{name: "Not configured", code: http.StatusNotImplemented, expectedErr: client.ErrMetadataNotConfigured},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrMetadataNotFound},
+		{name: "Provider error", code: http.StatusBadGateway, expectedErr: client.ErrFetchingMetadata},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/metadata/games/3328").
+				Method(http.MethodGet).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			resp, err := cl.GetGameMetadata(context.Background(), &client.GetGameMetadataRequest{Token: token, ID: "3328"})
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrFetchingSuggestions is a generic error
+	ErrFetchingSuggestions = errors.New("error while fetching suggestions")
+)
+
+// Heuristic is a criterion, by which the games are ranked, when suggesting what to play next
+type Heuristic string
+
+var (
+	// HeuristicFranchise prefers the games, that come next in their franchise
+	HeuristicFranchise Heuristic = "franchise"
+	// HeuristicProgress prefers the games with the shortest remaining progress
+	HeuristicProgress Heuristic = "progress"
+	// HeuristicAge prefers the games, that were added to the backlog the longest time ago
+	HeuristicAge Heuristic = "age"
+	// HeuristicTags prefers the games with the preferred genres, or the genres of the finished games
+	HeuristicTags Heuristic = "tags"
+	// HeuristicRating prefers the games, whose siblings in the franchise are rated the highest
+	HeuristicRating Heuristic = "rating"
+
+	// AllHeuristics is collection of all heuristics, in the order in which they are explained
+	AllHeuristics = []Heuristic{
+		HeuristicFranchise,
+		HeuristicProgress,
+		HeuristicAge,
+		HeuristicTags,
+		HeuristicRating,
+	}
+)
+
+// Suggestion is a game, that is suggested to be played next, together with the explanation of its score
+type Suggestion struct {
+	Game    *Game               `json:"game"`
+	Score   float64             `json:"score"`
+	Reasons []*SuggestionReason `json:"reasons"`
+}
+
+// SuggestionReason is how a single heuristic scored the suggested game
+type SuggestionReason struct {
+	Heuristic Heuristic `json:"heuristic"`
+	// Value is the score of the game by the heuristic, from 0 to 1
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+	// Points is what the heuristic adds to the score of the game - the value multiplied by the weight
+	Points      float64 `json:"points"`
+	Explanation string  `json:"explanation"`
+}
+
+// GetNextGamesRequest is used when asking what to play next
+type GetNextGamesRequest struct {
+	Token string
+	// Weights are the weights of the heuristics. The server default is used for the heuristics, that are not in the map.
+	// A weight of 0 disables the heuristic.
+	Weights map[Heuristic]float64
+	// Tags are the preferred genres. If empty, the genres of the finished games are preferred.
+	Tags []string
+	// Limit is the number of suggestions. The server default is used if it is 0.
+	Limit int
+}
+
+// GetNextGamesResponse is the response of GetNextGames
+type GetNextGamesResponse struct {
+	Suggestions []*Suggestion `json:"suggestions"`
+}
+
+// GetNextGames returns the suggestions of what to play next, the best one first
+func (c *Client) GetNextGames(ctx context.Context, request *GetNextGamesRequest) (*GetNextGamesResponse, error) {
+	query := url.Values{}
+	weights := []string{}
+	for _, heuristic := range AllHeuristics {
+		if weight, ok := request.Weights[heuristic]; ok {
+			weights = append(weights, fmt.Sprintf("%s:%s", heuristic, strconv.FormatFloat(weight, 'f', -1, 64)))
+		}
+	}
+	if len(weights) > 0 {
+		query.Set("weights", strings.Join(weights, ","))
+	}
+	if len(request.Tags) > 0 {
+		query.Set("tags", strings.Join(request.Tags, ","))
+	}
+	if request.Limit > 0 {
+		query.Set("limit", strconv.Itoa(request.Limit))
+	}
+	u := fmt.Sprintf("%s/games/next", c.addr)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrFetchingSuggestions
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		if res.StatusCode == http.StatusUnauthorized {
+			return nil, ErrNoAuthorization
+		}
+		if res.StatusCode == http.StatusBadRequest {
+			var errorResponse struct {
+				Error string `json:"error"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&errorResponse); err == nil && errorResponse.Error != "" {
+				return nil, errors.New(errorResponse.Error)
+			}
+		}
+		return nil, ErrFetchingSuggestions
+	}
+
+	var suggestions GetNextGamesResponse
+	if err := json.NewDecoder(res.Body).Decode(&suggestions); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+	return &suggestions, nil
+}
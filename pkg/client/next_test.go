@@ -0,0 +1,94 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNextGames(t *testing.T) {
+	testCases := []struct {
+		name    string
+		request *client.GetNextGamesRequest
+		query   string
+	}{
+		{name: "Defaults", request: &client.GetNextGamesRequest{Token: token}},
+		{
+			name: "Configured",
+			request: &client.GetNextGamesRequest{
+				Token:   token,
+				Weights: map[client.Heuristic]float64{client.HeuristicAge: 0, client.HeuristicFranchise: 2.5},
+				Tags:    []string{"RPG", "Action"},
+				Limit:   3,
+			},
+			query: "limit=3&tags=RPG%2CAction&weights=franchise%3A2.5%2Cage%3A0",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/games/next").
+				Method(http.MethodGet).
+				Token(token).
+				Query(testCase.query).
+				Data(models.SuggestionsResponse{
+					Suggestions: []*models.Suggestion{{
+						Game:  &models.Game{ID: "1", Name: "Witcher 2", Status: models.StatusTODO},
+						Score: 1,
+						Reasons: []*models.SuggestionReason{
+							{Heuristic: models.HeuristicFranchise, Value: 1, Weight: 1, Points: 1, Explanation: "Comes next in the Witcher franchise, after Witcher 1"},
+						},
+					}},
+				}).
+				Build()
+			defer ts.Close()
+
+			res, err := newClient(t, ts.URL).GetNextGames(context.Background(), testCase.request)
+			require.NoError(t, err)
+			assert.Equal(t, &client.GetNextGamesResponse{
+				Suggestions: []*client.Suggestion{{
+					Game:  &client.Game{ID: "1", Name: "Witcher 2", Status: "To Do"},
+					Score: 1,
+					Reasons: []*client.SuggestionReason{
+						{Heuristic: client.HeuristicFranchise, Value: 1, Weight: 1, Points: 1, Explanation: "Comes next in the Witcher franchise, after Witcher 1"},
+					},
+				}},
+			}, res)
+		})
+	}
+}
+
+func TestGetNextGamesError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr error
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization},
+		{name: "Bad request", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "popularity is not a valid heuristic"}, expectedErr: errors.New("popularity is not a valid heuristic")},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrFetchingSuggestions},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/games/next").
+				Method(http.MethodGet).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			res, err := newClient(t, ts.URL).GetNextGames(context.Background(), &client.GetNextGamesRequest{Token: token})
+			assert.Nil(t, res)
+			assert.EqualError(t, err, testCase.expectedErr.Error())
+		})
+	}
+}
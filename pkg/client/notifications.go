@@ -0,0 +1,170 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrNotification is a generic error
+	ErrNotification = errors.New("error while processing notification")
+	// ErrNotificationNotFound is returned when the notification does not exist
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// NotificationKind is the kind of a notification
+type NotificationKind string
+
+var (
+	// NotificationStaleGame is a reminder about a game, that is In Progress, but was not updated for a long time
+	NotificationStaleGame NotificationKind = "stale-game"
+)
+
+// Notification is a notification of the user, e.g. a reminder about a stale game
+type Notification struct {
+	ID        string           `json:"id"`
+	Kind      NotificationKind `json:"kind"`
+	GameID    string           `json:"gameId,omitempty"`
+	GameName  string           `json:"gameName,omitempty"`
+	Message   string           `json:"message"`
+	ReadAt    *time.Time       `json:"readAt,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// NotificationPreferences are the preferences of the user about the reminders of stale games and the channels they are sent through
+type NotificationPreferences struct {
+	StaleReminders bool `json:"staleReminders"`
+	// StaleAfterDays is the number of days without an update, after which a game In Progress is stale
+	StaleAfterDays int  `json:"staleAfterDays"`
+	InApp          bool `json:"inApp"`
+	Email          bool `json:"email"`
+	// WebhookURL is the URL, that the notifications are posted to. The webhook is disabled if it is empty.
+	WebhookURL string `json:"webhookUrl"`
+}
+
+// GetNotificationsRequest is used when getting the notifications of the user
+type GetNotificationsRequest struct {
+	Token string
+	// Limit is the number of notifications. The server default is used if it is 0.
+	Limit int
+}
+
+// GetNotificationsResponse is the response of GetNotifications
+type GetNotificationsResponse struct {
+	// Notifications are the latest notifications, the newest first
+	Notifications []*Notification `json:"notifications"`
+	// Unread is the number of all unread notifications
+	Unread int `json:"unread"`
+}
+
+// NotificationRequest is used when marking a notification as read
+type NotificationRequest struct {
+	Token          string
+	NotificationID string
+}
+
+// NotificationPreferencesRequest is used when setting the notification preferences of the user
+type NotificationPreferencesRequest struct {
+	Token       string
+	Preferences *NotificationPreferences
+}
+
+// GetNotifications returns the latest notifications of the user and the number of the unread ones
+func (c *Client) GetNotifications(ctx context.Context, request *GetNotificationsRequest) (*GetNotificationsResponse, error) {
+	u := fmt.Sprintf("%s/notifications", c.addr)
+	if request.Limit > 0 {
+		u += "?limit=" + strconv.Itoa(request.Limit)
+	}
+
+	var res GetNotificationsResponse
+	if err := c.doNotification(ctx, http.MethodGet, request.Token, u, nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// MarkNotificationRead marks the given notification as read
+func (c *Client) MarkNotificationRead(ctx context.Context, request *NotificationRequest) error {
+	u := fmt.Sprintf("%s/notifications/%s/read", c.addr, url.PathEscape(request.NotificationID))
+	return c.doNotification(ctx, http.MethodPost, request.Token, u, nil, http.StatusNoContent, nil)
+}
+
+// MarkAllNotificationsRead marks all notifications of the user as read
+func (c *Client) MarkAllNotificationsRead(ctx context.Context, token string) error {
+	return c.doNotification(ctx, http.MethodPost, token, fmt.Sprintf("%s/notifications/read", c.addr), nil, http.StatusNoContent, nil)
+}
+
+// GetNotificationPreferences returns the notification preferences of the user
+func (c *Client) GetNotificationPreferences(ctx context.Context, token string) (*NotificationPreferences, error) {
+	var prefs NotificationPreferences
+	if err := c.doNotification(ctx, http.MethodGet, token, fmt.Sprintf("%s/notifications/preferences", c.addr), nil, http.StatusOK, &prefs); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// SetNotificationPreferences replaces the notification preferences of the user and returns the saved ones
+func (c *Client) SetNotificationPreferences(ctx context.Context, request *NotificationPreferencesRequest) (*NotificationPreferences, error) {
+	var prefs NotificationPreferences
+	if err := c.doNotification(ctx, http.MethodPut, request.Token, fmt.Sprintf("%s/notifications/preferences", c.addr), request.Preferences, http.StatusOK, &prefs); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// doNotification sends the request with the given body, if any, and decodes the response into out, if any.
+func (c *Client) doNotification(ctx context.Context, method, token, u string, body interface{}, expectedCode int, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error while marshalling body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return ErrNotification
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case expectedCode:
+	case http.StatusUnauthorized:
+		return ErrNoAuthorization
+	case http.StatusNotFound:
+		return ErrNotificationNotFound
+	case http.StatusBadRequest:
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil || errorResponse.Error == "" {
+			return ErrNotification
+		}
+		return errors.New(errorResponse.Error)
+	default:
+		return ErrNotification
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("error while decoding body: %w", err)
+	}
+	return nil
+}
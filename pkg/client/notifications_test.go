@@ -0,0 +1,150 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	notificationCreatedAt = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	notificationPrefs     = &client.NotificationPreferences{
+		StaleReminders: true,
+		StaleAfterDays: 30,
+		InApp:          true,
+		WebhookURL:     "https://example.com/hook",
+	}
+	notificationPrefsResponse = models.NotificationPreferences{
+		StaleReminders: true,
+		StaleAfterDays: 30,
+		InApp:          true,
+		WebhookURL:     "https://example.com/hook",
+	}
+)
+
+func TestGetNotifications(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/notifications").
+		Method(http.MethodGet).
+		Token(token).
+		Query("limit=5").
+		Data(models.NotificationsResponse{
+			Notifications: []*models.Notification{
+				{ID: "1", Kind: models.NotificationStaleGame, GameID: "2", GameName: "Hades", Message: "Hello", CreatedAt: notificationCreatedAt},
+			},
+			Unread: 1,
+		}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetNotifications(context.Background(), &client.GetNotificationsRequest{Token: token, Limit: 5})
+	require.NoError(t, err)
+	assert.Equal(t, &client.GetNotificationsResponse{
+		Notifications: []*client.Notification{
+			{ID: "1", Kind: client.NotificationStaleGame, GameID: "2", GameName: "Hades", Message: "Hello", CreatedAt: notificationCreatedAt},
+		},
+		Unread: 1,
+	}, res)
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/notifications/1/read").
+		Method(http.MethodPost).
+		Token(token).
+		Return(http.StatusNoContent).
+		Build()
+	defer ts.Close()
+
+	err := newClient(t, ts.URL).MarkNotificationRead(context.Background(), &client.NotificationRequest{Token: token, NotificationID: "1"})
+	require.NoError(t, err)
+}
+
+func TestMarkAllNotificationsRead(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/notifications/read").
+		Method(http.MethodPost).
+		Token(token).
+		Return(http.StatusNoContent).
+		Build()
+	defer ts.Close()
+
+	err := newClient(t, ts.URL).MarkAllNotificationsRead(context.Background(), token)
+	require.NoError(t, err)
+}
+
+func TestGetNotificationPreferences(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/notifications/preferences").
+		Method(http.MethodGet).
+		Token(token).
+		Data(notificationPrefsResponse).
+		Build()
+	defer ts.Close()
+
+	prefs, err := newClient(t, ts.URL).GetNotificationPreferences(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, notificationPrefs, prefs)
+}
+
+func TestSetNotificationPreferences(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/notifications/preferences").
+		Method(http.MethodPut).
+		Token(token).
+		Data(notificationPrefsResponse).
+		Build()
+	defer ts.Close()
+
+	prefs, err := newClient(t, ts.URL).SetNotificationPreferences(context.Background(), &client.NotificationPreferencesRequest{Token: token, Preferences: notificationPrefs})
+	require.NoError(t, err)
+	assert.Equal(t, notificationPrefs, prefs)
+}
+
+func TestNotificationError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization.Error()},
+		{name: "Bad request", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "'staleAfterDays' should be a number between 1 and 365"}, expectedErr: "'staleAfterDays' should be a number between 1 and 365"},
+		{name: "Bad request without message", code: http.StatusBadRequest, expectedErr: client.ErrNotification.Error()},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrNotification.Error()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/notifications/preferences").
+				Method(http.MethodPut).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			prefs, err := newClient(t, ts.URL).SetNotificationPreferences(context.Background(), &client.NotificationPreferencesRequest{Token: token, Preferences: notificationPrefs})
+			assert.Nil(t, prefs)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestMarkNotificationReadNotFound(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/notifications/1/read").
+		Method(http.MethodPost).
+		Return(http.StatusNotFound).
+		Build()
+	defer ts.Close()
+
+	err := newClient(t, ts.URL).MarkNotificationRead(context.Background(), &client.NotificationRequest{Token: token, NotificationID: "1"})
+	assert.ErrorIs(t, err, client.ErrNotificationNotFound)
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var (
+	// ErrPlaythrough is a generic error
+	ErrPlaythrough = errors.New("error while processing playthrough")
+	// ErrPlaythroughNotFound is returned when the game, or the playthrough, does not exist
+	ErrPlaythroughNotFound = errors.New("game or playthrough not found")
+)
+
+// Playthrough is a single playthrough of a game (NG+, a different class, etc.)
+type Playthrough struct {
+	ID     string `json:"id,omitempty"`
+	GameID string `json:"gameId,omitempty"`
+	// Name tells the playthroughs apart, e.g. "NG+" or "Mage"
+	Name       string        `json:"name"`
+	Status     Status        `json:"status,omitempty"`
+	Progress   *GameProgress `json:"progress,omitempty"`
+	StartedAt  *time.Time    `json:"startedAt,omitempty"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+	Notes      string        `json:"notes,omitempty"`
+}
+
+// GetPlaythroughsRequest is used when getting the playthroughs of a game
+type GetPlaythroughsRequest struct {
+	Token  string
+	GameID string
+}
+
+// GetPlaythroughsResponse is the response of GetPlaythroughs
+type GetPlaythroughsResponse struct {
+	Playthroughs []*Playthrough `json:"playthroughs"`
+}
+
+// CreatePlaythroughRequest is used when starting a new playthrough of a game
+type CreatePlaythroughRequest struct {
+	Token       string
+	GameID      string
+	Playthrough *Playthrough
+}
+
+// UpdatePlaythroughRequest is used when replacing the details of a playthrough
+type UpdatePlaythroughRequest struct {
+	Token         string
+	GameID        string
+	PlaythroughID string
+	Playthrough   *Playthrough
+}
+
+// DeletePlaythroughRequest is used when deleting a playthrough
+type DeletePlaythroughRequest struct {
+	Token         string
+	GameID        string
+	PlaythroughID string
+}
+
+// GetPlaythroughs returns the playthroughs of the given game, the oldest first
+func (c *Client) GetPlaythroughs(ctx context.Context, request *GetPlaythroughsRequest) (*GetPlaythroughsResponse, error) {
+	var res GetPlaythroughsResponse
+	if err := c.doPlaythrough(ctx, http.MethodGet, request.Token, c.playthroughURL(request.GameID, ""), nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// CreatePlaythrough starts a new playthrough of the given game. The game gets the status of the playthrough.
+func (c *Client) CreatePlaythrough(ctx context.Context, request *CreatePlaythroughRequest) (*Playthrough, error) {
+	var res Playthrough
+	if err := c.doPlaythrough(ctx, http.MethodPost, request.Token, c.playthroughURL(request.GameID, ""), request.Playthrough, http.StatusCreated, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpdatePlaythrough replaces the details of the given playthrough
+func (c *Client) UpdatePlaythrough(ctx context.Context, request *UpdatePlaythroughRequest) (*Playthrough, error) {
+	var res Playthrough
+	if err := c.doPlaythrough(ctx, http.MethodPut, request.Token, c.playthroughURL(request.GameID, request.PlaythroughID), request.Playthrough, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// DeletePlaythrough deletes the given playthrough
+func (c *Client) DeletePlaythrough(ctx context.Context, request *DeletePlaythroughRequest) error {
+	return c.doPlaythrough(ctx, http.MethodDelete, request.Token, c.playthroughURL(request.GameID, request.PlaythroughID), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) playthroughURL(gameID, playthroughID string) string {
+	u := fmt.Sprintf("%s/games/%s/playthroughs", c.addr, url.PathEscape(gameID))
+	if playthroughID != "" {
+		u += "/" + url.PathEscape(playthroughID)
+	}
+	return u
+}
+
+// doPlaythrough sends the request with the given body, if any, and decodes the response into out, if any.
+func (c *Client) doPlaythrough(ctx context.Context, method, token, u string, body interface{}, expectedCode int, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error while marshalling body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return ErrPlaythrough
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case expectedCode:
+	case http.StatusUnauthorized:
+		return ErrNoAuthorization
+	case http.StatusNotFound:
+		return ErrPlaythroughNotFound
+	case http.StatusBadRequest:
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil || errorResponse.Error == "" {
+			return ErrPlaythrough
+		}
+		return errors.New(errorResponse.Error)
+	default:
+		return ErrPlaythrough
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("error while decoding body: %w", err)
+	}
+	return nil
+}
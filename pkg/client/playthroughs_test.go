@@ -0,0 +1,129 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	playthroughStart    = time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	playthroughResponse = models.Playthrough{
+		ID:        "2",
+		GameID:    "1",
+		Name:      "NG+",
+		Status:    models.StatusInProgress,
+		Progress:  &models.GameProgress{Current: 40, Final: 100},
+		StartedAt: &playthroughStart,
+	}
+	expectedPlaythrough = &client.Playthrough{
+		ID:        "2",
+		GameID:    "1",
+		Name:      "NG+",
+		Status:    client.Status("In Progress"),
+		Progress:  &client.GameProgress{Current: 40, Final: 100},
+		StartedAt: &playthroughStart,
+	}
+)
+
+func TestGetPlaythroughs(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/games/1/playthroughs").
+		Method(http.MethodGet).
+		Token(token).
+		Data(models.PlaythroughsResponse{Playthroughs: []*models.Playthrough{&playthroughResponse}}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetPlaythroughs(context.Background(), &client.GetPlaythroughsRequest{Token: token, GameID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, []*client.Playthrough{expectedPlaythrough}, res.Playthroughs)
+}
+
+func TestCreatePlaythrough(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/games/1/playthroughs").
+		Method(http.MethodPost).
+		Token(token).
+		Data(playthroughResponse).
+		Return(http.StatusCreated).
+		Build()
+	defer ts.Close()
+
+	playthrough, err := newClient(t, ts.URL).CreatePlaythrough(context.Background(), &client.CreatePlaythroughRequest{
+		Token:       token,
+		GameID:      "1",
+		Playthrough: &client.Playthrough{Name: "NG+", Status: client.Status("In Progress")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expectedPlaythrough, playthrough)
+}
+
+func TestUpdatePlaythrough(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/games/1/playthroughs/2").
+		Method(http.MethodPut).
+		Token(token).
+		Data(playthroughResponse).
+		Build()
+	defer ts.Close()
+
+	playthrough, err := newClient(t, ts.URL).UpdatePlaythrough(context.Background(), &client.UpdatePlaythroughRequest{
+		Token:         token,
+		GameID:        "1",
+		PlaythroughID: "2",
+		Playthrough:   expectedPlaythrough,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expectedPlaythrough, playthrough)
+}
+
+func TestDeletePlaythrough(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/games/1/playthroughs/2").
+		Method(http.MethodDelete).
+		Token(token).
+		Return(http.StatusNoContent).
+		Build()
+	defer ts.Close()
+
+	err := newClient(t, ts.URL).DeletePlaythrough(context.Background(), &client.DeletePlaythroughRequest{Token: token, GameID: "1", PlaythroughID: "2"})
+	require.NoError(t, err)
+}
+
+func TestPlaythroughError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization.Error()},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrPlaythroughNotFound.Error()},
+		{name: "Bad request", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "'name' is required"}, expectedErr: "'name' is required"},
+		{name: "Bad request without message", code: http.StatusBadRequest, expectedErr: client.ErrPlaythrough.Error()},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrPlaythrough.Error()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/games/1/playthroughs").
+				Method(http.MethodPost).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			playthrough, err := newClient(t, ts.URL).CreatePlaythrough(context.Background(), &client.CreatePlaythroughRequest{Token: token, GameID: "1", Playthrough: &client.Playthrough{}})
+			assert.Nil(t, playthrough)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
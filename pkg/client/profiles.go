@@ -0,0 +1,181 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var (
+	// ErrProfile is a generic error
+	ErrProfile = errors.New("error while processing profile")
+	// ErrProfileNotFound is returned when the user does not exist, or their profile is private
+	ErrProfileNotFound = errors.New("user not found")
+)
+
+// Profile is the public part of a user, that the other users can see and follow
+type Profile struct {
+	Username string `json:"username"`
+	// Public is true if the profile can be seen and followed by the other users
+	Public bool `json:"public"`
+	// Following is true if the authenticated user follows the profile
+	Following bool `json:"following"`
+	Followers int  `json:"followers"`
+	Follows   int  `json:"follows"`
+}
+
+// GetProfileRequest is used when getting the profile of a user.
+// An empty Username gets the profile of the authenticated user.
+type GetProfileRequest struct {
+	Token    string
+	Username string
+}
+
+// SetProfilePublicRequest is used when making the profile of the authenticated user public or private
+type SetProfilePublicRequest struct {
+	Token  string
+	Public bool
+}
+
+// GetFollowingRequest is used when getting the profiles, that the authenticated user follows
+type GetFollowingRequest struct {
+	Token string
+}
+
+// GetFollowingResponse is the response of GetFollowing
+type GetFollowingResponse struct {
+	Profiles []*Profile `json:"profiles"`
+}
+
+// GetProfileGamesRequest is used when getting the games of a user, that are shown on their profile
+type GetProfileGamesRequest struct {
+	Token    string
+	Username string
+}
+
+// FollowRequest is used when following or unfollowing a user
+type FollowRequest struct {
+	Token    string
+	Username string
+}
+
+// GetProfile returns the profile of the given user, or of the authenticated user if no username is given
+func (c *Client) GetProfile(ctx context.Context, request *GetProfileRequest) (*Profile, error) {
+	u := c.profileURL(request.Username, "")
+	if request.Username == "" {
+		u = fmt.Sprintf("%s/users/me/profile", c.addr)
+	}
+
+	var profile Profile
+	if err := c.doProfile(ctx, http.MethodGet, request.Token, u, nil, http.StatusOK, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// SetProfilePublic makes the profile of the authenticated user public or private
+func (c *Client) SetProfilePublic(ctx context.Context, request *SetProfilePublicRequest) (*Profile, error) {
+	body := struct {
+		Public bool `json:"public"`
+	}{Public: request.Public}
+
+	var profile Profile
+	if err := c.doProfile(ctx, http.MethodPatch, request.Token, fmt.Sprintf("%s/users/me/profile", c.addr), body, http.StatusOK, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetFollowing returns the profiles, that the authenticated user follows
+func (c *Client) GetFollowing(ctx context.Context, request *GetFollowingRequest) (*GetFollowingResponse, error) {
+	var res GetFollowingResponse
+	if err := c.doProfile(ctx, http.MethodGet, request.Token, fmt.Sprintf("%s/users/me/following", c.addr), nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetProfileGames returns the games of the given user, that are shown on their profile
+func (c *Client) GetProfileGames(ctx context.Context, request *GetProfileGamesRequest) (*GetGamesResponse, error) {
+	var res GetGamesResponse
+	if err := c.doProfile(ctx, http.MethodGet, request.Token, c.profileURL(request.Username, "games"), nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Follow follows the given user and returns their profile
+func (c *Client) Follow(ctx context.Context, request *FollowRequest) (*Profile, error) {
+	var profile Profile
+	if err := c.doProfile(ctx, http.MethodPost, request.Token, c.profileURL(request.Username, "follow"), nil, http.StatusOK, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// Unfollow unfollows the given user
+func (c *Client) Unfollow(ctx context.Context, request *FollowRequest) error {
+	return c.doProfile(ctx, http.MethodDelete, request.Token, c.profileURL(request.Username, "follow"), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) profileURL(username, path string) string {
+	u := fmt.Sprintf("%s/users/%s", c.addr, url.PathEscape(username))
+	if path != "" {
+		u += "/" + path
+	}
+	return u
+}
+
+// doProfile sends the request with the given body, if any, and decodes the response into out, if any.
+func (c *Client) doProfile(ctx context.Context, method, token, u string, body interface{}, expectedCode int, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error while marshalling body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return ErrProfile
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case expectedCode:
+	case http.StatusUnauthorized:
+		return ErrNoAuthorization
+	case http.StatusNotFound:
+		return ErrProfileNotFound
+	case http.StatusBadRequest:
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil || errorResponse.Error == "" {
+			return ErrProfile
+		}
+		return errors.New(errorResponse.Error)
+	default:
+		return ErrProfile
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("error while decoding body: %w", err)
+	}
+	return nil
+}
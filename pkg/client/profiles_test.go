@@ -0,0 +1,145 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	profileResponse = models.Profile{Username: "ivan", Public: true, Following: true, Followers: 3, Follows: 1}
+	expectedProfile = &client.Profile{Username: "ivan", Public: true, Following: true, Followers: 3, Follows: 1}
+)
+
+func TestProfile(t *testing.T) {
+	testCases := []struct {
+		name   string
+		path   string
+		method string
+		call   func(*client.Client) (*client.Profile, error)
+	}{
+		{
+			name:   "Own profile",
+			path:   "/users/me/profile",
+			method: http.MethodGet,
+			call: func(cl *client.Client) (*client.Profile, error) {
+				return cl.GetProfile(context.Background(), &client.GetProfileRequest{Token: token})
+			},
+		},
+		{
+			name:   "Profile of another user",
+			path:   "/users/ivan",
+			method: http.MethodGet,
+			call: func(cl *client.Client) (*client.Profile, error) {
+				return cl.GetProfile(context.Background(), &client.GetProfileRequest{Token: token, Username: "ivan"})
+			},
+		},
+		{
+			name:   "Make profile public",
+			path:   "/users/me/profile",
+			method: http.MethodPatch,
+			call: func(cl *client.Client) (*client.Profile, error) {
+				return cl.SetProfilePublic(context.Background(), &client.SetProfilePublicRequest{Token: token, Public: true})
+			},
+		},
+		{
+			name:   "Follow",
+			path:   "/users/ivan/follow",
+			method: http.MethodPost,
+			call: func(cl *client.Client) (*client.Profile, error) {
+				return cl.Follow(context.Background(), &client.FollowRequest{Token: token, Username: "ivan"})
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path(testCase.path).
+				Method(testCase.method).
+				Token(token).
+				Data(profileResponse).
+				Build()
+			defer ts.Close()
+
+			profile, err := testCase.call(newClient(t, ts.URL))
+			require.NoError(t, err)
+			assert.Equal(t, expectedProfile, profile)
+		})
+	}
+}
+
+func TestGetFollowing(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/users/me/following").
+		Method(http.MethodGet).
+		Token(token).
+		Data(models.ProfilesResponse{Profiles: []*models.Profile{&profileResponse}}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetFollowing(context.Background(), &client.GetFollowingRequest{Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, []*client.Profile{expectedProfile}, res.Profiles)
+}
+
+func TestGetProfileGames(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/users/ivan/games").
+		Method(http.MethodGet).
+		Token(token).
+		Data(models.GamesResponse{Games: []*models.Game{{ID: "1", Name: "Hades", Status: models.StatusInProgress}}}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetProfileGames(context.Background(), &client.GetProfileGamesRequest{Token: token, Username: "ivan"})
+	require.NoError(t, err)
+	assert.Equal(t, []*client.Game{{ID: "1", Name: "Hades", Status: "In Progress"}}, res.Games)
+}
+
+func TestUnfollow(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/users/ivan/follow").
+		Method(http.MethodDelete).
+		Token(token).
+		Return(http.StatusNoContent).
+		Build()
+	defer ts.Close()
+
+	err := newClient(t, ts.URL).Unfollow(context.Background(), &client.FollowRequest{Token: token, Username: "ivan"})
+	require.NoError(t, err)
+}
+
+func TestProfileError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization.Error()},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrProfileNotFound.Error()},
+		{name: "Bad request", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "users can not follow themselves"}, expectedErr: "users can not follow themselves"},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrProfile.Error()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/users/ivan/follow").
+				Method(http.MethodPost).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			profile, err := newClient(t, ts.URL).Follow(context.Background(), &client.FollowRequest{Token: token, Username: "ivan"})
+			assert.Nil(t, profile)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
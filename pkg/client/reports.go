@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var (
+	// ErrFetchingReport is a generic error
+	ErrFetchingReport = errors.New("error while fetching report")
+	// ErrInvalidReportRequest is returned when the API rejected the requested year or format
+	ErrInvalidReportRequest = errors.New("invalid year or format of the report")
+)
+
+// GetYearReportRequest is used when getting the report of a single year
+type GetYearReportRequest struct {
+	Token string
+	Year  int
+}
+
+// GetYearReportResponse is returned from the GetYearReport method
+type GetYearReportResponse struct {
+	Year           int               `json:"year"`
+	Started        int               `json:"started"`
+	Finished       int               `json:"finished"`
+	Dropped        int               `json:"dropped"`
+	TotalHours     float64           `json:"totalHours"`
+	LongestRunning *YearReportGame   `json:"longestRunning,omitempty"`
+	Games          []*YearReportGame `json:"games"`
+}
+
+// YearReportGame is what happened to a single game during the year
+type YearReportGame struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Started  bool    `json:"started"`
+	Finished bool    `json:"finished"`
+	Dropped  bool    `json:"dropped"`
+	Hours    float64 `json:"hours"`
+}
+
+// ExportYearReportRequest is used when exporting the report of a single year
+type ExportYearReportRequest struct {
+	Token string
+	Year  int
+	// Format is the format of the export - json or csv
+	Format string
+}
+
+// ExportYearReportResponse is returned from the ExportYearReport method
+type ExportYearReportResponse struct {
+	ContentType string
+	Body        []byte
+}
+
+// GetYearReport fetches the report of the given year from the server
+func (c *Client) GetYearReport(ctx context.Context, request *GetYearReportRequest) (*GetYearReportResponse, error) {
+	res, err := c.yearReport(ctx, request.Token, request.Year, "")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var reportResponse GetYearReportResponse
+	if err := json.NewDecoder(res.Body).Decode(&reportResponse); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+	return &reportResponse, nil
+}
+
+// ExportYearReport fetches the report of the given year from the server, in the requested format
+func (c *Client) ExportYearReport(ctx context.Context, request *ExportYearReportRequest) (*ExportYearReportResponse, error) {
+	res, err := c.yearReport(ctx, request.Token, request.Year, request.Format)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading body: %w", err)
+	}
+	return &ExportYearReportResponse{
+		ContentType: res.Header.Get("Content-Type"),
+		Body:        body,
+	}, nil
+}
+
+func (c *Client) yearReport(ctx context.Context, token string, year int, format string) (*http.Response, error) {
+	u := fmt.Sprintf("%s/stats/years/%d", c.addr, year)
+	if format != "" {
+		u += "?format=" + url.QueryEscape(format)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrFetchingReport
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		switch res.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrNoAuthorization
+		case http.StatusBadRequest:
+			return nil, ErrInvalidReportRequest
+		}
+		return nil, ErrFetchingReport
+	}
+	return res, nil
+}
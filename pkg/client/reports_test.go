@@ -0,0 +1,117 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetYearReport(t *testing.T) {
+	report := &client.GetYearReportResponse{
+		Year:       2020,
+		Started:    1,
+		Finished:   1,
+		TotalHours: 10,
+		LongestRunning: &client.YearReportGame{
+			ID: "1", Name: "Game", Started: true, Finished: true, Hours: 10,
+		},
+		Games: []*client.YearReportGame{
+			{ID: "1", Name: "Game", Started: true, Finished: true, Hours: 10},
+		},
+	}
+	ts := fixtures.NewTestServer(t).
+		Path("/stats/years/2020").
+		Method(http.MethodGet).
+		Token(token).
+		Data(report).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.GetYearReport(context.Background(), &client.GetYearReportRequest{
+		Token: token,
+		Year:  2020,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, report, resp)
+}
+
+func TestExportYearReport(t *testing.T) {
+	body := []byte("id,name,started,finished,dropped,hours\n")
+	ts := fixtures.NewTestServer(t).
+		Path("/stats/years/2020").
+		Method(http.MethodGet).
+		Token(token).
+		Query("format=csv").
+		Body("text/csv", body).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.ExportYearReport(context.Background(), &client.ExportYearReportRequest{
+		Token:  token,
+		Year:   2020,
+		Format: "csv",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv", resp.ContentType)
+	assert.Equal(t, body, resp.Body)
+}
+
+func TestGetYearReportError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		expectedErr error
+	}{
+		{
+			name:        "Unauthorized",
+			code:        http.StatusUnauthorized,
+			expectedErr: client.ErrNoAuthorization,
+		},
+		{
+			name:        "Bad request",
+			code:        http.StatusBadRequest,
+			expectedErr: client.ErrInvalidReportRequest,
+		},
+		{
+			name:        "Server error",
+			code:        http.StatusInternalServerError,
+			expectedErr: client.ErrFetchingReport,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/stats/years/2020").
+				Method(http.MethodGet).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			resp, err := cl.GetYearReport(context.Background(), &client.GetYearReportRequest{
+				Token: token,
+				Year:  2020,
+			})
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+
+			exportResp, err := cl.ExportYearReport(context.Background(), &client.ExportYearReportRequest{
+				Token:  token,
+				Year:   2020,
+				Format: "csv",
+			})
+			assert.Nil(t, exportResp)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
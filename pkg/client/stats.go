@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrFetchingStats is a generic error
+	ErrFetchingStats = errors.New("error while fetching stats")
+)
+
+// GetStatsRequest is used when getting the stats of the backlog of the user
+type GetStatsRequest struct {
+	Token string
+}
+
+// GetStatsResponse is returned from the GetStats method
+type GetStatsResponse struct {
+	StatusCounts      map[Status]int    `json:"statusCounts"`
+	Total             int               `json:"total"`
+	FinishedThisMonth int               `json:"finishedThisMonth"`
+	FinishedThisYear  int               `json:"finishedThisYear"`
+	AverageProgress   float64           `json:"averageProgress"`
+	TopFranchises     []*FranchiseStats `json:"topFranchises"`
+	RecentlyUpdated   []*Game           `json:"recentlyUpdated"`
+	CurrentlyPlaying  []*Game           `json:"currentlyPlaying"`
+}
+
+// FranchiseStats are the stats of the games of a single franchise
+type FranchiseStats struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Games    int    `json:"games"`
+	Finished int    `json:"finished"`
+}
+
+// GetStats fetches the stats of the backlog of the user from the server
+func (c *Client) GetStats(ctx context.Context, request *GetStatsRequest) (*GetStatsResponse, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/stats", c.addr), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, request.Token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ErrFetchingStats
+	}
+	if res.StatusCode != http.StatusOK {
+		if res.StatusCode == http.StatusUnauthorized {
+			return nil, ErrNoAuthorization
+		}
+		return nil, ErrFetchingStats
+	}
+
+	var statsResponse GetStatsResponse
+	if err := json.NewDecoder(res.Body).Decode(&statsResponse); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+	return &statsResponse, nil
+}
@@ -0,0 +1,81 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStats(t *testing.T) {
+	stats := &client.GetStatsResponse{
+		StatusCounts: map[client.Status]int{
+			client.Status("To Do"): 2,
+			client.Status("Done"):  1,
+		},
+		Total:            3,
+		FinishedThisYear: 1,
+		AverageProgress:  50,
+		TopFranchises: []*client.FranchiseStats{
+			{ID: "1", Name: "Franchise", Games: 2, Finished: 1},
+		},
+		RecentlyUpdated:  []*client.Game{{ID: "1", Name: "Game"}},
+		CurrentlyPlaying: []*client.Game{},
+	}
+	ts := fixtures.NewTestServer(t).
+		Path("/stats").
+		Method(http.MethodGet).
+		Token(token).
+		Data(stats).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.GetStats(context.Background(), &client.GetStatsRequest{
+		Token: token,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, stats, resp)
+}
+
+func TestGetStatsError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		expectedErr error
+	}{
+		{
+			name:        "Unauthorized",
+			code:        http.StatusUnauthorized,
+			expectedErr: client.ErrNoAuthorization,
+		},
+		{
+			name:        "Server error",
+			code:        http.StatusInternalServerError,
+			expectedErr: client.ErrFetchingStats,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/stats").
+				Method(http.MethodGet).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			cl := newClient(t, ts.URL)
+
+			resp, err := cl.GetStats(context.Background(), &client.GetStatsRequest{
+				Token: token,
+			})
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 )
 
 type GameProgress struct {
@@ -25,8 +28,22 @@ type UpdateGameProgressRequest struct {
 }
 
 type UpdateGameProgressChange struct {
-	Status   Status        `json:"status,omitempty"`
-	Progress *GameProgress `json:"progress,omitempty"`
+	Status      Status        `json:"status,omitempty"`
+	Progress    *GameProgress `json:"progress,omitempty"`
+	Wishlist    *bool         `json:"wishlist,omitempty"`
+	ReleaseDate *time.Time    `json:"releaseDate,omitempty"`
+	// ClearReleaseDate removes the release date of the game
+	ClearReleaseDate bool `json:"clearReleaseDate,omitempty"`
+	// Hidden hides the game from the public profile of the user, or shows it on it
+	Hidden *bool `json:"hidden,omitempty"`
+	// Rating rates the game from 1 to 5. 0 removes the rating.
+	Rating *int `json:"rating,omitempty"`
+}
+
+// UpdateGameProgressResponse is the response that is returned from UpdateGameProgress
+type UpdateGameProgressResponse struct {
+	// Warnings are returned when the game is started, while the games before it in its franchise are still To Do
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 type DeleteUserGameRequest struct {
@@ -34,29 +51,37 @@ type DeleteUserGameRequest struct {
 	Token  string
 }
 
-func (c *Client) UpdateGameProgress(ctx context.Context, request *UpdateGameProgressRequest) error {
+func (c *Client) UpdateGameProgress(ctx context.Context, request *UpdateGameProgressRequest) (*UpdateGameProgressResponse, error) {
 	body, err := json.Marshal(request.Update)
 	if err != nil {
-		return fmt.Errorf("error while marshalling body: %w", err)
+		return nil, fmt.Errorf("error while marshalling body: %w", err)
 	}
 
 	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/games/%s", c.addr, request.GameID), bytes.NewBuffer((body)))
 	if err != nil {
-		return fmt.Errorf("error while building HTTP request")
+		return nil, fmt.Errorf("error while building HTTP request")
 	}
 	req.Header.Add(XAuthToken, request.Token)
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
 		if res.StatusCode == http.StatusUnauthorized {
-			return ErrNoAuthorization
+			return nil, ErrNoAuthorization
 		}
-		return ErrChangingGame
+		if res.StatusCode == http.StatusForbidden {
+			return nil, ErrBoardForbidden
+		}
+		return nil, ErrChangingGame
 	}
 
-	return nil
+	var response UpdateGameProgressResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+
+	return &response, nil
 }
 
 func (c *Client) DeleteUserGame(ctx context.Context, request *DeleteUserGameRequest) error {
@@ -73,6 +98,9 @@ func (c *Client) DeleteUserGame(ctx context.Context, request *DeleteUserGameRequ
 		if res.StatusCode == http.StatusUnauthorized {
 			return ErrNoAuthorization
 		}
+		if res.StatusCode == http.StatusForbidden {
+			return ErrBoardForbidden
+		}
 		return ErrDeletingGame
 	}
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/asankov/gira/internal/fixtures"
 	"github.com/asankov/gira/pkg/client"
@@ -21,7 +22,7 @@ func TestChangeGameStatus(t *testing.T) {
 
 	cl := newClient(t, ts.URL)
 
-	err := cl.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
+	_, err := cl.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
 		GameID: game.ID,
 		Token:  token,
 		Update: client.UpdateGameProgressChange{
@@ -60,7 +61,7 @@ func TestChangeGameStatusHTTPError(t *testing.T) {
 
 			cl := newClient(t, ts.URL)
 
-			err := cl.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
+			_, err := cl.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
 				GameID: game.ID,
 				Token:  token,
 				Update: client.UpdateGameProgressChange{
@@ -82,7 +83,7 @@ func TestChangeGameProgress(t *testing.T) {
 
 	cl := newClient(t, ts.URL)
 
-	err := cl.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
+	_, err := cl.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
 		GameID: game.ID,
 		Token:  token,
 		Update: client.UpdateGameProgressChange{
@@ -96,6 +97,29 @@ func TestChangeGameProgress(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestChangeGameWishlist(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path(fmt.Sprintf("/games/%s", game.ID)).
+		Method(http.MethodPatch).
+		Token(token).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	wishlist := true
+	releaseDate := time.Date(2023, 10, 20, 0, 0, 0, 0, time.UTC)
+	_, err := cl.UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
+		GameID: game.ID,
+		Token:  token,
+		Update: client.UpdateGameProgressChange{
+			Wishlist:    &wishlist,
+			ReleaseDate: &releaseDate,
+		},
+	})
+	assert.NoError(t, err)
+}
+
 func TestDeleteGame(t *testing.T) {
 	ts := fixtures.NewTestServer(t).
 		Path(fmt.Sprintf("/games/%s", game.ID)).
@@ -144,3 +168,22 @@ func TestDeleteGameHTTPError(t *testing.T) {
 		})
 	}
 }
+
+func TestChangeGameStatusWarnings(t *testing.T) {
+	expected := &client.UpdateGameProgressResponse{Warnings: []string{"Witcher 2 comes before this game in the Witcher franchise and is still To Do"}}
+	ts := fixtures.NewTestServer(t).
+		Path(fmt.Sprintf("/games/%s", game.ID)).
+		Method(http.MethodPatch).
+		Token(token).
+		Data(expected).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).UpdateGameProgress(context.Background(), &client.UpdateGameProgressRequest{
+		GameID: game.ID,
+		Token:  token,
+		Update: client.UpdateGameProgressChange{Status: "In Progress"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+}
@@ -44,6 +44,10 @@ type LoginUserRequest struct {
 	Username string `json:"username,omitempty"`
 	Email    string `json:"email,omitempty"`
 	Password string `json:"password,omitempty"`
+	// ClientIP is the IP of the end user, on whose behalf the login is made.
+	// It is forwarded to the API via the X-Forwarded-For header,
+	// so that failed login attempts are tracked per client, and not per front-end.
+	ClientIP string `json:"-"`
 }
 
 // UserLoginResponse is the response that is returned
@@ -52,6 +56,15 @@ type UserLoginResponse struct {
 	Token string `json:"token"`
 }
 
+// LoginUserOIDCRequest is used when the consumer wants to login a user
+// with an authorization code, issued by an OpenID Connect provider
+type LoginUserOIDCRequest struct {
+	Provider     string `json:"provider"`
+	Code         string `json:"code"`
+	CodeVerifier string `json:"codeVerifier"`
+	Nonce        string `json:"nonce"`
+}
+
 type LogoutUserRequest struct {
 	Token string
 }
@@ -118,12 +131,25 @@ func (c *Client) LoginUser(ctx context.Context, request *LoginUserRequest) (*Use
 		return nil, fmt.Errorf("error while building body: %w", err)
 	}
 	url := fmt.Sprintf("%s/users/login", c.addr)
-	res, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error while building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if request.ClientIP != "" {
+		req.Header.Set("X-Forwarded-For", request.ClientIP)
+	}
+	res, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error while calling %s: %w", url, err)
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, &ErrorResponse{Err: parseErrorBody(res)}
+		errorBody := parseErrorBody(res)
+		var errResponse ErrorResponse
+		if err := json.Unmarshal([]byte(errorBody), &errResponse); err == nil && errResponse.Err != "" {
+			return nil, &errResponse
+		}
+		return nil, &ErrorResponse{Err: errorBody}
 	}
 
 	var userResponse *UserLoginResponse
@@ -134,6 +160,32 @@ func (c *Client) LoginUser(ctx context.Context, request *LoginUserRequest) (*Use
 	return userResponse, nil
 }
 
+// LoginUserOIDC logs in the user, who was authorized by the given provider with the authorization code
+func (c *Client) LoginUserOIDC(ctx context.Context, request *LoginUserOIDCRequest) (*UserLoginResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error while building body: %w", err)
+	}
+	url := fmt.Sprintf("%s/users/login/oidc", c.addr)
+	res, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error while calling %s: %w", url, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		if errResponse := parseError(res); errResponse != nil {
+			return nil, errResponse
+		}
+		return nil, &ErrorResponse{Err: http.StatusText(res.StatusCode)}
+	}
+
+	var userResponse *UserLoginResponse
+	if err := json.NewDecoder(res.Body).Decode(&userResponse); err != nil {
+		return nil, fmt.Errorf("error while decoding body: %w", err)
+	}
+
+	return userResponse, nil
+}
+
 func (c *Client) LogoutUser(ctx context.Context, request *LogoutUserRequest) error {
 	url := fmt.Sprintf("%s/users/logout", c.addr)
 	req, err := http.NewRequest(http.MethodPost, url, nil)
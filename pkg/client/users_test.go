@@ -84,6 +84,45 @@ func TestLogin(t *testing.T) {
 	require.Equal(t, resp, userLoginResponse)
 }
 
+func TestLoginForwardsClientIP(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/users/login").
+		Method(http.MethodPost).
+		Header("X-Forwarded-For", "1.2.3.4").
+		Data(userLoginResponse).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.LoginUser(context.Background(), &client.LoginUserRequest{
+		Email:    user.Email,
+		Password: user.Password,
+		ClientIP: "1.2.3.4",
+	})
+	require.NoError(t, err)
+	require.Equal(t, resp, userLoginResponse)
+}
+
+func TestLoginError(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/users/login").
+		Method(http.MethodPost).
+		Data(&client.ErrorResponse{Err: "Wrong email/password"}).
+		Return(http.StatusUnauthorized).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.LoginUser(context.Background(), &client.LoginUserRequest{
+		Email:    user.Email,
+		Password: user.Password,
+	})
+	require.Nil(t, resp)
+	require.EqualError(t, err, "Wrong email/password")
+}
+
 func TestLogout(t *testing.T) {
 	ts := fixtures.NewTestServer(t).
 		Method(http.MethodPost).
@@ -99,3 +138,43 @@ func TestLogout(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestLoginOIDC(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/users/login/oidc").
+		Method(http.MethodPost).
+		Data(userLoginResponse).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.LoginUserOIDC(context.Background(), &client.LoginUserOIDCRequest{
+		Provider:     "google",
+		Code:         "code",
+		CodeVerifier: "verifier",
+		Nonce:        "nonce",
+	})
+	require.NoError(t, err)
+	require.Equal(t, resp, userLoginResponse)
+}
+
+func TestLoginOIDCError(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/users/login/oidc").
+		Method(http.MethodPost).
+		Data(&client.ErrorResponse{Err: "login with the provider could not be verified"}).
+		Return(http.StatusUnauthorized).
+		Build()
+	defer ts.Close()
+
+	cl := newClient(t, ts.URL)
+
+	resp, err := cl.LoginUserOIDC(context.Background(), &client.LoginUserOIDCRequest{
+		Provider:     "google",
+		Code:         "code",
+		CodeVerifier: "verifier",
+	})
+	require.Nil(t, resp)
+	require.EqualError(t, err, "login with the provider could not be verified")
+}
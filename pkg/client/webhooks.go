@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrWebhook is a generic error
+	ErrWebhook = errors.New("error while processing webhook")
+	// ErrWebhookNotFound is returned when the webhook does not exist
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+// WebhookEvent is an event of a game, that the webhooks can subscribe to
+type WebhookEvent string
+
+var (
+	// WebhookGameCreated is fired, when a game is created
+	WebhookGameCreated WebhookEvent = "game.created"
+	// WebhookGameStatusChanged is fired, when the status of a game changes
+	WebhookGameStatusChanged WebhookEvent = "game.status_changed"
+	// WebhookGameProgressChanged is fired, when the progress of a game changes
+	WebhookGameProgressChanged WebhookEvent = "game.progress_changed"
+	// WebhookGameDeleted is fired, when a game is deleted
+	WebhookGameDeleted WebhookEvent = "game.deleted"
+
+	// WebhookEvents are all events, that the webhooks can subscribe to
+	WebhookEvents = []WebhookEvent{WebhookGameCreated, WebhookGameStatusChanged, WebhookGameProgressChanged, WebhookGameDeleted}
+)
+
+// Webhook is a URL, that the events of the games of the user are posted to
+type Webhook struct {
+	ID     string         `json:"id,omitempty"`
+	URL    string         `json:"url"`
+	Events []WebhookEvent `json:"events"`
+	Active bool           `json:"active"`
+	// Secret signs the payloads. It is returned only by CreateWebhook.
+	Secret    string     `json:"secret,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// WebhookDelivery is a delivery of an event to a webhook, after all its attempts
+type WebhookDelivery struct {
+	ID        string       `json:"id"`
+	WebhookID string       `json:"webhookId"`
+	EventID   string       `json:"eventId"`
+	Event     WebhookEvent `json:"event"`
+	Payload   string       `json:"payload"`
+	Success   bool         `json:"success"`
+	// StatusCode is the status of the last response of the webhook, or 0 if it did not respond at all
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Attempts   int       `json:"attempts"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// GetWebhooksResponse is the response of GetWebhooks
+type GetWebhooksResponse struct {
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// WebhookRequest is used when creating or updating a webhook. WebhookID is empty when creating one.
+type WebhookRequest struct {
+	Token     string
+	WebhookID string
+	Webhook   *Webhook
+}
+
+// GetWebhookDeliveriesRequest is used when getting the latest deliveries of a webhook
+type GetWebhookDeliveriesRequest struct {
+	Token     string
+	WebhookID string
+	// Limit is the number of deliveries. The server default is used if it is 0.
+	Limit int
+}
+
+// GetWebhookDeliveriesResponse is the response of GetWebhookDeliveries
+type GetWebhookDeliveriesResponse struct {
+	// Deliveries are the latest deliveries, the newest first
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+}
+
+// GetWebhooks returns the webhooks of the user, without their secrets
+func (c *Client) GetWebhooks(ctx context.Context, token string) (*GetWebhooksResponse, error) {
+	var res GetWebhooksResponse
+	if err := c.doWebhook(ctx, http.MethodGet, token, fmt.Sprintf("%s/webhooks", c.addr), nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// CreateWebhook creates an active webhook and returns it with its secret
+func (c *Client) CreateWebhook(ctx context.Context, request *WebhookRequest) (*Webhook, error) {
+	var webhook Webhook
+	if err := c.doWebhook(ctx, http.MethodPost, request.Token, fmt.Sprintf("%s/webhooks", c.addr), request.Webhook, http.StatusCreated, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// UpdateWebhook replaces the URL, the events and the active flag of the given webhook
+func (c *Client) UpdateWebhook(ctx context.Context, request *WebhookRequest) (*Webhook, error) {
+	var webhook Webhook
+	if err := c.doWebhook(ctx, http.MethodPut, request.Token, c.webhookURL(request.WebhookID), request.Webhook, http.StatusOK, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// DeleteWebhook deletes the given webhook
+func (c *Client) DeleteWebhook(ctx context.Context, token, webhookID string) error {
+	return c.doWebhook(ctx, http.MethodDelete, token, c.webhookURL(webhookID), nil, http.StatusNoContent, nil)
+}
+
+// GetWebhookDeliveries returns the latest deliveries of the given webhook
+func (c *Client) GetWebhookDeliveries(ctx context.Context, request *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error) {
+	u := c.webhookURL(request.WebhookID) + "/deliveries"
+	if request.Limit > 0 {
+		u += "?limit=" + strconv.Itoa(request.Limit)
+	}
+
+	var res GetWebhookDeliveriesResponse
+	if err := c.doWebhook(ctx, http.MethodGet, request.Token, u, nil, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *Client) webhookURL(webhookID string) string {
+	return fmt.Sprintf("%s/webhooks/%s", c.addr, url.PathEscape(webhookID))
+}
+
+// doWebhook sends the request with the given body, if any, and decodes the response into out, if any.
+func (c *Client) doWebhook(ctx context.Context, method, token, u string, body interface{}, expectedCode int, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error while marshalling body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("error while building HTTP request")
+	}
+	req.Header.Add(XAuthToken, token)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return ErrWebhook
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case expectedCode:
+	case http.StatusUnauthorized:
+		return ErrNoAuthorization
+	case http.StatusNotFound:
+		return ErrWebhookNotFound
+	case http.StatusBadRequest:
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil || errorResponse.Error == "" {
+			return ErrWebhook
+		}
+		return errors.New(errorResponse.Error)
+	default:
+		return ErrWebhook
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("error while decoding body: %w", err)
+	}
+	return nil
+}
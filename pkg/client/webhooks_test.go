@@ -0,0 +1,148 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/internal/fixtures"
+	"github.com/asankov/gira/pkg/client"
+	"github.com/asankov/gira/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	webhookCreatedAt = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	webhookResponse  = models.Webhook{
+		ID:        "1",
+		URL:       "https://example.com/hook",
+		Events:    []models.WebhookEvent{models.WebhookGameCreated},
+		Active:    true,
+		CreatedAt: &webhookCreatedAt,
+	}
+	expectedWebhook = &client.Webhook{
+		ID:        "1",
+		URL:       "https://example.com/hook",
+		Events:    []client.WebhookEvent{client.WebhookGameCreated},
+		Active:    true,
+		CreatedAt: &webhookCreatedAt,
+	}
+)
+
+func TestGetWebhooks(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/webhooks").
+		Method(http.MethodGet).
+		Token(token).
+		Data(models.WebhooksResponse{Webhooks: []*models.Webhook{&webhookResponse}}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetWebhooks(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, []*client.Webhook{expectedWebhook}, res.Webhooks)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	created := webhookResponse
+	created.Secret = "secret"
+	ts := fixtures.NewTestServer(t).
+		Path("/webhooks").
+		Method(http.MethodPost).
+		Token(token).
+		Data(created).
+		Return(http.StatusCreated).
+		Build()
+	defer ts.Close()
+
+	webhook, err := newClient(t, ts.URL).CreateWebhook(context.Background(), &client.WebhookRequest{
+		Token:   token,
+		Webhook: &client.Webhook{URL: "https://example.com/hook", Events: []client.WebhookEvent{client.WebhookGameCreated}},
+	})
+	require.NoError(t, err)
+	expected := *expectedWebhook
+	expected.Secret = "secret"
+	assert.Equal(t, &expected, webhook)
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/webhooks/1").
+		Method(http.MethodPut).
+		Token(token).
+		Data(webhookResponse).
+		Build()
+	defer ts.Close()
+
+	webhook, err := newClient(t, ts.URL).UpdateWebhook(context.Background(), &client.WebhookRequest{
+		Token:     token,
+		WebhookID: "1",
+		Webhook:   &client.Webhook{URL: "https://example.com/hook", Events: []client.WebhookEvent{client.WebhookGameCreated}, Active: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expectedWebhook, webhook)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/webhooks/1").
+		Method(http.MethodDelete).
+		Token(token).
+		Return(http.StatusNoContent).
+		Build()
+	defer ts.Close()
+
+	err := newClient(t, ts.URL).DeleteWebhook(context.Background(), token, "1")
+	require.NoError(t, err)
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	ts := fixtures.NewTestServer(t).
+		Path("/webhooks/1/deliveries").
+		Method(http.MethodGet).
+		Token(token).
+		Query("limit=10").
+		Data(models.WebhookDeliveriesResponse{Deliveries: []*models.WebhookDelivery{
+			{ID: "1", WebhookID: "1", EventID: "abc", Event: models.WebhookGameDeleted, Payload: "{}", StatusCode: 500, Error: "webhook responded with 500", Attempts: 5, CreatedAt: webhookCreatedAt},
+		}}).
+		Build()
+	defer ts.Close()
+
+	res, err := newClient(t, ts.URL).GetWebhookDeliveries(context.Background(), &client.GetWebhookDeliveriesRequest{Token: token, WebhookID: "1", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []*client.WebhookDelivery{
+		{ID: "1", WebhookID: "1", EventID: "abc", Event: client.WebhookGameDeleted, Payload: "{}", StatusCode: 500, Error: "webhook responded with 500", Attempts: 5, CreatedAt: webhookCreatedAt},
+	}, res.Deliveries)
+}
+
+func TestWebhookError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		data        interface{}
+		expectedErr string
+	}{
+		{name: "Unauthorized", code: http.StatusUnauthorized, expectedErr: client.ErrNoAuthorization.Error()},
+		{name: "Not found", code: http.StatusNotFound, expectedErr: client.ErrWebhookNotFound.Error()},
+		{name: "Bad request", code: http.StatusBadRequest, data: models.ErrorResponse{Error: "'url' should be an absolute http or https URL"}, expectedErr: "'url' should be an absolute http or https URL"},
+		{name: "Bad request without message", code: http.StatusBadRequest, expectedErr: client.ErrWebhook.Error()},
+		{name: "Server error", code: http.StatusInternalServerError, expectedErr: client.ErrWebhook.Error()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := fixtures.NewTestServer(t).
+				Path("/webhooks/1").
+				Method(http.MethodPut).
+				Data(testCase.data).
+				Return(testCase.code).
+				Build()
+			defer ts.Close()
+
+			webhook, err := newClient(t, ts.URL).UpdateWebhook(context.Background(), &client.WebhookRequest{Token: token, WebhookID: "1", Webhook: &client.Webhook{}})
+			assert.Nil(t, webhook)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+	"sort"
+	"time"
 )
 
 // Game is the representation of a game
@@ -13,6 +15,41 @@ type Game struct {
 	FranchiseID string        `json:"franchiseId,omitempty"`
 	Status      Status        `json:"status,omitempty"`
 	Progress    *GameProgress `json:"progress,omitempty"`
+	UpdatedAt   *time.Time    `json:"updatedAt,omitempty"`
+	// CreatedAt is the time the game was added to the backlog.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// FinishedAt is the time the game was moved to Done.
+	// It is empty for games that are not Done.
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// Wishlist marks the games, that the user wants, but does not own yet. It is independent from the status.
+	Wishlist bool `json:"wishlist,omitempty"`
+	// AutoProgress is true if the progress is computed from the checklist of the game
+	AutoProgress bool `json:"autoProgress,omitempty"`
+	// Playthroughs is the number of playthroughs of the game. If the game has any, its status is the status of the latest one.
+	Playthroughs int `json:"playthroughs,omitempty"`
+	// Hidden games are not shown on the public profile of the user
+	Hidden bool `json:"hidden,omitempty"`
+	// Rating is the rating of the game by the user, from 1 to 5. It is 0 for the games, that are not rated.
+	Rating int `json:"rating,omitempty"`
+	// ReleaseOrder and PlayOrder are the positions of the game in its franchise, starting from 1.
+	// They are 0 for the games, that are not ordered yet. In the games of a single franchise all positions are set.
+	ReleaseOrder int `json:"releaseOrder,omitempty"`
+	PlayOrder    int `json:"playOrder,omitempty"`
+	// BoardID is the ID of the shared board, that the game is on.
+	// It is empty for the games, that belong only to the user.
+	BoardID string `json:"boardId,omitempty"`
+
+	// MetadataID is the ID of the game in the metadata provider, if the game is linked to it.
+	// The rest of the details are filled from the metadata provider.
+	MetadataID  string     `json:"metadataId,omitempty"`
+	CoverURL    string     `json:"coverUrl,omitempty"`
+	Developer   string     `json:"developer,omitempty"`
+	Genres      []string   `json:"genres,omitempty"`
+	ReleaseDate *time.Time `json:"releaseDate,omitempty"`
+
+	// CoverUpdatedAt is the time the cover of the game was uploaded.
+	// It is empty if the game has no uploaded cover.
+	CoverUpdatedAt *time.Time `json:"coverUpdatedAt,omitempty"`
 
 	UserID string `json:"-"`
 }
@@ -22,10 +59,76 @@ type GameProgress struct {
 	Final   int `json:"final,omitempty"`
 }
 
+// Playthrough is a single playthrough of a game (NG+, a different class, etc.)
+type Playthrough struct {
+	ID     string `json:"id"`
+	GameID string `json:"gameId"`
+	// Name tells the playthroughs apart, e.g. "NG+" or "Mage"
+	Name       string        `json:"name"`
+	Status     Status        `json:"status"`
+	Progress   *GameProgress `json:"progress"`
+	StartedAt  *time.Time    `json:"startedAt,omitempty"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+	Notes      string        `json:"notes,omitempty"`
+}
+
+// PlaythroughsResponse is the response of GET /games/{id}/playthroughs
+type PlaythroughsResponse struct {
+	Playthroughs []*Playthrough `json:"playthroughs"`
+}
+
+// ChecklistItem is a named item of a game (achievement, side quest, collectible, etc.), that can be ticked off
+type ChecklistItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Done bool   `json:"done"`
+}
+
+// Checklist is the list of checklist items of a game
+type Checklist struct {
+	Items []*ChecklistItem `json:"items"`
+	// AutoProgress is true if the progress of the game is computed from the checklist -
+	// the current progress is the number of done items and the final is the number of all items.
+	AutoProgress bool          `json:"autoProgress"`
+	Progress     *GameProgress `json:"progress"`
+	// ProgressChanged is true, if the change of the checklist changed the progress of the game
+	ProgressChanged bool `json:"-"`
+}
+
+// ChecklistRequest is the request for changing the settings of the checklist of a game
+type ChecklistRequest struct {
+	AutoProgress *bool `json:"autoProgress,omitempty"`
+}
+
+// ChecklistItemRequest is the request for creating or changing a checklist item.
+// When changing an item, only the given fields are changed.
+type ChecklistItemRequest struct {
+	Name string `json:"name,omitempty"`
+	Done *bool  `json:"done,omitempty"`
+}
+
 type GamesResponse struct {
 	Games []*Game `json:"games"`
 }
 
+// GameMetadata are the details of a game, provided by a metadata provider (RAWG, IGDB, etc.)
+type GameMetadata struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	CoverURL    string     `json:"coverUrl,omitempty"`
+	ReleaseDate *time.Time `json:"releaseDate,omitempty"`
+	Genres      []string   `json:"genres,omitempty"`
+	Developer   string     `json:"developer,omitempty"`
+	// FranchiseID is the ID of the franchise of the user, that the game most likely belongs to, if any.
+	// It is not provided by the metadata provider.
+	FranchiseID string `json:"franchiseId,omitempty"`
+}
+
+// GameMetadataResponse is the response that is returned from the search of game metadata
+type GameMetadataResponse struct {
+	Games []*GameMetadata `json:"games"`
+}
+
 // User is the representation of a user
 // in the database.
 type User struct {
@@ -36,6 +139,124 @@ type User struct {
 	HashedPassword []byte `json:"-"`
 }
 
+// Profile is the public part of a user, that the other users can see and follow
+type Profile struct {
+	Username string `json:"username"`
+	// Public is true if the profile can be seen and followed by the other users
+	Public bool `json:"public"`
+	// Following is true if the user, that requested the profile, follows it
+	Following bool `json:"following,omitempty"`
+	// Followers is the number of users, that follow the profile
+	Followers int `json:"followers"`
+	// Follows is the number of users, that the profile follows
+	Follows int `json:"follows"`
+}
+
+// ProfilesResponse is the response of GET /users/me/following
+type ProfilesResponse struct {
+	Profiles []*Profile `json:"profiles"`
+}
+
+// ProfileRequest is the request of PATCH /users/me/profile
+type ProfileRequest struct {
+	Public *bool `json:"public,omitempty"`
+}
+
+// BoardRole is the role of a member of a shared board
+type BoardRole string
+
+var (
+	// BoardRoleOwner can change the games of the board, and invite and remove its members
+	BoardRoleOwner BoardRole = "owner"
+	// BoardRoleEditor can change the games of the board
+	BoardRoleEditor BoardRole = "editor"
+	// BoardRoleViewer can only see the games of the board
+	BoardRoleViewer BoardRole = "viewer"
+
+	// AllBoardRoles is collection of all board roles
+	AllBoardRoles = []BoardRole{
+		BoardRoleOwner,
+		BoardRoleEditor,
+		BoardRoleViewer,
+	}
+)
+
+// Validate shows whether the role is a valid board role
+// and returns an error if not.
+func (r BoardRole) Validate() error {
+	for _, role := range AllBoardRoles {
+		if r == role {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not a valid role", r)
+}
+
+// CanEdit is true if the members with that role can change the games of the board
+func (r BoardRole) CanEdit() bool {
+	return r == BoardRoleOwner || r == BoardRoleEditor
+}
+
+// Board is a set of games, shared by a group of users
+type Board struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Role is the role of the user, that requested the board
+	Role BoardRole `json:"role,omitempty"`
+	// Members are returned only when a single board is requested
+	Members []*BoardMember `json:"members,omitempty"`
+}
+
+// BoardMember is a user, that is a member of a board
+type BoardMember struct {
+	Username string    `json:"username"`
+	Role     BoardRole `json:"role"`
+}
+
+// BoardsResponse is the response of GET /boards
+type BoardsResponse struct {
+	Boards []*Board `json:"boards"`
+}
+
+// BoardRequest is the request of POST /boards
+type BoardRequest struct {
+	Name string `json:"name"`
+}
+
+// BoardInvitation is an invitation of a user to become a member of a board
+type BoardInvitation struct {
+	ID        string    `json:"id"`
+	BoardID   string    `json:"boardId"`
+	BoardName string    `json:"boardName"`
+	Username  string    `json:"username"`
+	Role      BoardRole `json:"role"`
+	InvitedBy string    `json:"invitedBy"`
+}
+
+// BoardInvitationsResponse is the response of GET /invitations
+type BoardInvitationsResponse struct {
+	Invitations []*BoardInvitation `json:"invitations"`
+}
+
+// BoardMemberRequest is the request to invite a user to a board, or to change the role of a member
+type BoardMemberRequest struct {
+	Username string    `json:"username,omitempty"`
+	Role     BoardRole `json:"role"`
+}
+
+// Identity is an external identity of a user,
+// asserted by an OpenID Connect provider.
+type Identity struct {
+	Provider      string `json:"provider"`
+	Issuer        string `json:"issuer"`
+	Subject       string `json:"subject"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"emailVerified,omitempty"`
+	Username      string `json:"username,omitempty"`
+	// LinkByEmail is true, if the provider is trusted to link the identity to the existing user with the same verified email
+	LinkByEmail bool `json:"linkByEmail,omitempty"`
+}
+
 // Status is the type that represents the status of a game
 type Status string
 
@@ -46,12 +267,15 @@ var (
 	StatusInProgress Status = "In Progress"
 	// StatusDone is the Done status of the game
 	StatusDone Status = "Done"
+	// StatusDropped is the status of a game that was abandoned before it was finished
+	StatusDropped Status = "Dropped"
 
 	// AllStatuses is collection of all statuses
 	AllStatuses = []Status{
 		StatusTODO,
 		StatusInProgress,
 		StatusDone,
+		StatusDropped,
 	}
 )
 
@@ -77,6 +301,19 @@ type UserLoginResponse struct {
 	Token string `json:"token"`
 }
 
+// OIDCLoginRequest is the request that is sent
+// when a user logs in with an OpenID Connect provider.
+// The API exchanges the code for an ID token itself, so that only the tokens, that the provider issued for this login, are accepted.
+type OIDCLoginRequest struct {
+	Provider string `json:"provider"`
+	// Code is the authorization code, that the provider redirected back with
+	Code string `json:"code"`
+	// CodeVerifier is the PKCE code verifier, whose challenge was sent to the provider with the authorization request
+	CodeVerifier string `json:"codeVerifier"`
+	// Nonce is the nonce, that was sent to the provider with the authorization request. The ID token must contain it.
+	Nonce string `json:"nonce"`
+}
+
 // UserResponse is the response that is returned
 // from the GET /users API
 type UserResponse struct {
@@ -98,6 +335,173 @@ type UserGameResponse struct {
 	Status Status `json:"status"`
 }
 
+// Stats are the statistics of the backlog of a user.
+type Stats struct {
+	// StatusCounts is the number of games in each status.
+	StatusCounts      map[Status]int `json:"statusCounts"`
+	Total             int            `json:"total"`
+	FinishedThisMonth int            `json:"finishedThisMonth"`
+	FinishedThisYear  int            `json:"finishedThisYear"`
+	// AverageProgress is the average completion progress of the games, in percents.
+	AverageProgress  float64           `json:"averageProgress"`
+	TopFranchises    []*FranchiseStats `json:"topFranchises"`
+	RecentlyUpdated  []*Game           `json:"recentlyUpdated"`
+	CurrentlyPlaying []*Game           `json:"currentlyPlaying"`
+}
+
+// FranchiseStats are the statistics of the games of a single franchise.
+type FranchiseStats struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Games    int    `json:"games"`
+	Finished int    `json:"finished"`
+}
+
+// StatusChange is a single change of the status of a game.
+type StatusChange struct {
+	GameID    string    `json:"gameId"`
+	From      Status    `json:"from"`
+	To        Status    `json:"to"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// EventKind is the kind of an activity of a user, shown in the feed of their followers
+type EventKind string
+
+var (
+	// EventStatusChanged is the event of a game changing its status, e.g. being started or finished
+	EventStatusChanged EventKind = "status_changed"
+)
+
+// Event is an activity of a user, shown in the feed of their followers
+type Event struct {
+	ID       string    `json:"id"`
+	Kind     EventKind `json:"kind"`
+	Username string    `json:"username"`
+	GameID   string    `json:"gameId"`
+	GameName string    `json:"gameName"`
+	// From and To are set for EventStatusChanged
+	From      Status    `json:"from,omitempty"`
+	To        Status    `json:"to,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// FeedResponse is the response of GET /feed
+type FeedResponse struct {
+	Events []*Event `json:"events"`
+	// NextCursor is passed as ?before= to get the next, older, page of the feed.
+	// It is empty if there are no more events.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// YearReport is the report of what a user played during a single year.
+// It is derived from the status changes of their games.
+type YearReport struct {
+	Year     int `json:"year"`
+	Started  int `json:"started"`
+	Finished int `json:"finished"`
+	Dropped  int `json:"dropped"`
+	// TotalHours is the total time the games spent In Progress during the year.
+	TotalHours float64 `json:"totalHours"`
+	// LongestRunning is the game that spent the most time In Progress during the year.
+	LongestRunning *YearReportGame `json:"longestRunning,omitempty"`
+	// Games are all games that were played during the year, ordered by the time spent on them.
+	Games []*YearReportGame `json:"games"`
+}
+
+// Summarize computes the totals of the report from its games.
+func (r *YearReport) Summarize() {
+	r.Started, r.Finished, r.Dropped, r.TotalHours = 0, 0, 0, 0
+	r.LongestRunning = nil
+
+	for _, game := range r.Games {
+		if game.Started {
+			r.Started++
+		}
+		if game.Finished {
+			r.Finished++
+		}
+		if game.Dropped {
+			r.Dropped++
+		}
+		r.TotalHours += game.Hours
+		if game.Hours > 0 && (r.LongestRunning == nil || game.Hours > r.LongestRunning.Hours) {
+			r.LongestRunning = game
+		}
+	}
+}
+
+// YearReportGame is what happened to a single game during the year.
+type YearReportGame struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Started  bool    `json:"started"`
+	Finished bool    `json:"finished"`
+	Dropped  bool    `json:"dropped"`
+	Hours    float64 `json:"hours"`
+}
+
+// Heuristic is a criterion, by which the games in the backlog are ranked, when suggesting what to play next.
+type Heuristic string
+
+var (
+	// HeuristicFranchise prefers the games, that come next in their franchise, over the ones, whose predecessors are not played yet
+	HeuristicFranchise Heuristic = "franchise"
+	// HeuristicProgress prefers the games with the shortest remaining progress
+	HeuristicProgress Heuristic = "progress"
+	// HeuristicAge prefers the games, that were added to the backlog the longest time ago
+	HeuristicAge Heuristic = "age"
+	// HeuristicTags prefers the games with the preferred genres.
+	// If no genres are preferred explicitly, these are the genres of the finished games.
+	HeuristicTags Heuristic = "tags"
+	// HeuristicRating prefers the games, whose siblings in the franchise are rated the highest
+	HeuristicRating Heuristic = "rating"
+
+	// AllHeuristics is collection of all heuristics
+	AllHeuristics = []Heuristic{
+		HeuristicFranchise,
+		HeuristicProgress,
+		HeuristicAge,
+		HeuristicTags,
+		HeuristicRating,
+	}
+)
+
+// Validate shows whether the heuristic is a valid heuristic
+// and returns an error if not.
+func (h Heuristic) Validate() error {
+	for _, heuristic := range AllHeuristics {
+		if h == heuristic {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not a valid heuristic", h)
+}
+
+// Suggestion is a game, that is suggested to be played next, together with the explanation of its score.
+type Suggestion struct {
+	Game *Game `json:"game"`
+	// Score is the sum of the points of the reasons
+	Score   float64             `json:"score"`
+	Reasons []*SuggestionReason `json:"reasons"`
+}
+
+// SuggestionReason is how a single heuristic scored the suggested game.
+type SuggestionReason struct {
+	Heuristic Heuristic `json:"heuristic"`
+	// Value is the score of the game by the heuristic, from 0 to 1
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+	// Points is the value multiplied by the weight. It is what the heuristic adds to the score of the game.
+	Points      float64 `json:"points"`
+	Explanation string  `json:"explanation"`
+}
+
+// SuggestionsResponse is the response of GET /games/next
+type SuggestionsResponse struct {
+	Suggestions []*Suggestion `json:"suggestions"`
+}
+
 // ErrorResponse is the generic error response returned from the API,
 // when an error of any kind occurred.
 type ErrorResponse struct {
@@ -107,15 +511,543 @@ type ErrorResponse struct {
 type ChangeGameStatusRequest struct {
 	Status   Status        `json:"status,omitempty"`
 	Progress *GameProgress `json:"progress,omitempty"`
+	Wishlist *bool         `json:"wishlist,omitempty"`
+	// ReleaseDate sets the release date of the game. Only the date is taken into account.
+	ReleaseDate *time.Time `json:"releaseDate,omitempty"`
+	// ClearReleaseDate removes the release date of the game, e.g. when it is postponed indefinitely.
+	ClearReleaseDate bool `json:"clearReleaseDate,omitempty"`
+	// Hidden hides the game from the public profile of the user, or shows it on it
+	Hidden *bool `json:"hidden,omitempty"`
+	// Rating rates the game from 1 to 5. 0 removes the rating.
+	Rating *int `json:"rating,omitempty"`
 }
 
+// MaxRating is the highest rating of a game
+const MaxRating = 5
+
 type Franchise struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
+	// CoverUpdatedAt is the time the cover of the franchise was uploaded.
+	// It is empty if the franchise has no uploaded cover.
+	CoverUpdatedAt *time.Time `json:"coverUpdatedAt,omitempty"`
+	// Games are the games of the franchise, in play order.
+	// They are returned only when a single franchise is requested.
+	Games []*Game `json:"games,omitempty"`
+	// ParentID is the franchise, that this one is a sub-series of. It is empty for the top-level franchises.
+	ParentID string `json:"parentId,omitempty"`
+	// Children are the sub-series of the franchise, sorted by name.
+	// They are returned only in the tree of franchises.
+	Children []*Franchise `json:"children,omitempty"`
+	// Completion is the completion of the games of the franchise.
+	// In the tree of franchises it includes the games of all sub-series.
+	Completion *FranchiseCompletion `json:"completion,omitempty"`
 
 	UserID string `json:"-"`
 }
 
+// FranchiseCompletion shows how many of the games of a franchise are finished.
+// The games on the wishlist are not counted.
+type FranchiseCompletion struct {
+	Games    int `json:"games"`
+	Finished int `json:"finished"`
+}
+
+// FranchiseParentRequest is the request of PUT /franchises/{id}/parent
+type FranchiseParentRequest struct {
+	// ParentID is the new parent of the franchise. If empty, the franchise becomes a top-level one.
+	ParentID string `json:"parentId"`
+}
+
+// FranchiseTree links the given franchises to their parents and returns the top-level ones, sorted by name.
+// The completion of each franchise is aggregated with the completion of its sub-series.
+// The franchises, whose parent is not in the list, are top-level.
+func FranchiseTree(franchises []*Franchise) []*Franchise {
+	byID := map[string]*Franchise{}
+	for _, franchise := range franchises {
+		franchise.Children = nil
+		byID[franchise.ID] = franchise
+	}
+
+	roots := []*Franchise{}
+	for _, franchise := range franchises {
+		if parent, ok := byID[franchise.ParentID]; ok && franchise.ParentID != franchise.ID {
+			parent.Children = append(parent.Children, franchise)
+		} else {
+			roots = append(roots, franchise)
+		}
+	}
+
+	sortFranchises(roots)
+	for _, root := range roots {
+		aggregateCompletion(root)
+	}
+	return roots
+}
+
+// aggregateCompletion adds the completion of the sub-series of the franchise to its own and sorts them
+func aggregateCompletion(franchise *Franchise) *FranchiseCompletion {
+	completion := &FranchiseCompletion{}
+	if franchise.Completion != nil {
+		*completion = *franchise.Completion
+	}
+	sortFranchises(franchise.Children)
+	for _, child := range franchise.Children {
+		c := aggregateCompletion(child)
+		completion.Games += c.Games
+		completion.Finished += c.Finished
+	}
+	franchise.Completion = completion
+	return completion
+}
+
+func sortFranchises(franchises []*Franchise) {
+	sort.SliceStable(franchises, func(i, j int) bool {
+		return franchises[i].Name < franchises[j].Name
+	})
+}
+
+// FranchiseOrder is the kind of order of the games in a franchise
+type FranchiseOrder string
+
+var (
+	// FranchiseOrderRelease is the order in which the games were released
+	FranchiseOrderRelease FranchiseOrder = "release"
+	// FranchiseOrderPlay is the recommended order in which the games are played
+	FranchiseOrderPlay FranchiseOrder = "play"
+)
+
+// Validate shows whether the order is a valid franchise order
+// and returns an error if not.
+func (o FranchiseOrder) Validate() error {
+	if o != FranchiseOrderRelease && o != FranchiseOrderPlay {
+		return fmt.Errorf("%s is not a valid order, expected %s or %s", o, FranchiseOrderRelease, FranchiseOrderPlay)
+	}
+	return nil
+}
+
+// FranchiseOrderRequest is the request of PUT /franchises/{id}/order
+type FranchiseOrderRequest struct {
+	Order FranchiseOrder `json:"order"`
+	// GameIDs are the IDs of all games of the franchise, in the new order
+	GameIDs []string `json:"gameIds"`
+}
+
+// SortByReleaseOrder sorts the games of a franchise in release order.
+// The games, that are not ordered, come after the ordered ones, sorted by their release date,
+// then by the time they were added, and then by name.
+func SortByReleaseOrder(games []*Game) {
+	sort.SliceStable(games, func(i, j int) bool {
+		return releasedBefore(games[i], games[j])
+	})
+}
+
+// SortByPlayOrder sorts the games of a franchise in play order.
+// The games, that are not ordered, come after the ordered ones, in release order.
+func SortByPlayOrder(games []*Game) {
+	sort.SliceStable(games, func(i, j int) bool {
+		a, b := games[i], games[j]
+		if a.PlayOrder != b.PlayOrder {
+			return b.PlayOrder == 0 || (a.PlayOrder != 0 && a.PlayOrder < b.PlayOrder)
+		}
+		return releasedBefore(a, b)
+	})
+}
+
+func releasedBefore(a, b *Game) bool {
+	if a.ReleaseOrder != b.ReleaseOrder {
+		return b.ReleaseOrder == 0 || (a.ReleaseOrder != 0 && a.ReleaseOrder < b.ReleaseOrder)
+	}
+	switch {
+	case a.ReleaseDate != nil && b.ReleaseDate != nil:
+		if !a.ReleaseDate.Equal(*b.ReleaseDate) {
+			return a.ReleaseDate.Before(*b.ReleaseDate)
+		}
+	case a.ReleaseDate != nil:
+		return true
+	case b.ReleaseDate != nil:
+		return false
+	}
+	if a.CreatedAt != nil && b.CreatedAt != nil && !a.CreatedAt.Equal(*b.CreatedAt) {
+		return a.CreatedAt.Before(*b.CreatedAt)
+	}
+	return a.Name < b.Name
+}
+
+// GameUpdateResponse is the response of PATCH /games/{id}
+type GameUpdateResponse struct {
+	// Warnings are the things the user should know about the change,
+	// e.g. that they started a game, while a game before it in the franchise is still To Do
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 type FranchisesResponse struct {
 	Franchises []*Franchise `json:"franchises"`
 }
+
+// CoverKind is the kind of item, that a cover belongs to.
+type CoverKind string
+
+var (
+	// CoverKindGame is the cover of a game
+	CoverKindGame CoverKind = "games"
+	// CoverKindFranchise is the cover of a franchise
+	CoverKindFranchise CoverKind = "franchises"
+)
+
+// Cover is the cover art of a game or a franchise.
+type Cover struct {
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ImportMode is the way the games are imported.
+type ImportMode string
+
+var (
+	// ImportModeAtomic imports all games or none of them, if any of them is invalid or conflicting
+	ImportModeAtomic ImportMode = "atomic"
+	// ImportModeBestEffort imports the valid games and skips the rest
+	ImportModeBestEffort ImportMode = "best-effort"
+)
+
+// ImportRowStatus is the outcome of importing a single game.
+type ImportRowStatus string
+
+var (
+	// ImportRowOK is the status of a game that was (or, on dry run, would be) imported
+	ImportRowOK ImportRowStatus = "ok"
+	// ImportRowConflict is the status of a game with the same name as an existing game,
+	// or as another game from the same import
+	ImportRowConflict ImportRowStatus = "conflict"
+	// ImportRowInvalid is the status of a game with missing or invalid fields
+	ImportRowInvalid ImportRowStatus = "invalid"
+	// ImportRowFailed is the status of a game that could not be saved
+	ImportRowFailed ImportRowStatus = "failed"
+	// ImportRowSkipped is the status of a valid game that was not imported, because the atomic import was rejected
+	ImportRowSkipped ImportRowStatus = "skipped"
+)
+
+// ImportResult is the result of an import of games.
+type ImportResult struct {
+	Mode   ImportMode `json:"mode"`
+	DryRun bool       `json:"dryRun"`
+	// Imported is the number of games that were imported.
+	// It is 0 on dry run.
+	Imported int `json:"imported"`
+	// NewFranchises are the franchises that were (or, on dry run, would be) created for the imported games.
+	NewFranchises []string     `json:"newFranchises"`
+	Rows          []*ImportRow `json:"rows"`
+}
+
+// ImportRow is the outcome of importing a single game.
+type ImportRow struct {
+	// Line is the number of the game in the imported file, starting from 1.
+	Line   int             `json:"line"`
+	Name   string          `json:"name"`
+	Status ImportRowStatus `json:"status"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// ImportSource is a storefront whose library export can be imported.
+type ImportSource string
+
+var (
+	// ImportSourceSteam is the owned games JSON, returned by the GetOwnedGames method of the Steam Web API
+	ImportSourceSteam ImportSource = "steam"
+	// ImportSourceGOG is the CSV export of the GOG Galaxy library
+	ImportSourceGOG ImportSource = "gog"
+)
+
+// LibraryPreview is the preview of the import of a storefront library,
+// that the user reviews, before the games are imported.
+type LibraryPreview struct {
+	Source ImportSource        `json:"source"`
+	Games  []*LibraryCandidate `json:"games"`
+}
+
+// LibraryCandidate is a game from a storefront library, that can be imported.
+type LibraryCandidate struct {
+	Name string `json:"name"`
+	// Franchise is the guessed franchise of the game.
+	// It is either an existing franchise of the user, or one shared by several games of the library.
+	Franchise string `json:"franchise,omitempty"`
+	// Status is guessed from the playtime of the game.
+	Status Status `json:"status"`
+	// Playtime is the time played, in minutes, if the storefront tracks it.
+	Playtime int `json:"playtime"`
+	// DuplicateOf is the name of the existing game, or the game earlier in the library,
+	// that has the same normalized name, if any.
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+}
+
+// GoalKind is the kind of a goal of a user
+type GoalKind string
+
+var (
+	// GoalFinishGames is a goal to finish a number of games in a period, e.g. 12 games this year
+	GoalFinishGames GoalKind = "finish-games"
+	// GoalFinishFranchise is a goal to finish all games of a franchise, including the ones of its sub-series
+	GoalFinishFranchise GoalKind = "finish-franchise"
+	// GoalBacklog is a goal to clear the backlog down to a number of To Do games
+	GoalBacklog GoalKind = "backlog"
+)
+
+// Validate returns an error if the kind is not one of the known kinds of goals
+func (k GoalKind) Validate() error {
+	switch k {
+	case GoalFinishGames, GoalFinishFranchise, GoalBacklog:
+		return nil
+	}
+	return fmt.Errorf("%s is not a valid kind of goal, expected %s, %s or %s", k, GoalFinishGames, GoalFinishFranchise, GoalBacklog)
+}
+
+// GoalStatus is the status of a goal
+type GoalStatus string
+
+var (
+	// GoalInProgress is the status of a goal, that is not reached yet, but can still be
+	GoalInProgress GoalStatus = "in-progress"
+	// GoalAchieved is the status of a goal, that is reached
+	GoalAchieved GoalStatus = "achieved"
+	// GoalFailed is the status of a goal, that is not reached until its last day
+	GoalFailed GoalStatus = "failed"
+)
+
+// Goal is a goal of a user, e.g. finish 12 games this year. Its progress is tracked from the status changes of the games.
+type Goal struct {
+	ID   string   `json:"id"`
+	Kind GoalKind `json:"kind"`
+	// Target is the number of games to finish for the finish-games goals and the maximum number of To Do games for the backlog goals.
+	// For the finish-franchise goals it is the number of games of the franchise.
+	Target int `json:"target"`
+	// FranchiseID is the franchise to finish for the finish-franchise goals
+	FranchiseID string `json:"franchiseId,omitempty"`
+	Franchise   string `json:"franchise,omitempty"`
+	// StartsAt and EndsAt are the first and the last day of the goal.
+	// The finish-games goals count the games finished between them. The rest of the goals can have a deadline only.
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+	EndsAt   *time.Time `json:"endsAt,omitempty"`
+
+	// Current is the number of games finished for the finish-games and finish-franchise goals and the number of To Do games for the backlog goals
+	Current    int        `json:"current"`
+	Status     GoalStatus `json:"status"`
+	AchievedAt *time.Time `json:"achievedAt,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+
+	UserID string `json:"-"`
+}
+
+// Reached shows whether the current progress of the goal reaches its target
+func (g *Goal) Reached() bool {
+	if g.Kind == GoalBacklog {
+		return g.Current <= g.Target
+	}
+	return g.Target > 0 && g.Current >= g.Target
+}
+
+// Expired shows whether the last day of the goal is over
+func (g *Goal) Expired(now time.Time) bool {
+	return g.EndsAt != nil && !now.Before(g.EndsAt.AddDate(0, 0, 1))
+}
+
+// Evaluate sets the status of the goal from its progress.
+// The finish-games goals count only the games finished in their period, so they are achieved whenever they are reached.
+// The rest are achieved only if they are reached before their deadline.
+func (g *Goal) Evaluate(now time.Time) {
+	switch {
+	case g.AchievedAt != nil:
+		g.Status = GoalAchieved
+	case g.Reached() && (g.Kind == GoalFinishGames || !g.Expired(now)):
+		g.Status = GoalAchieved
+	case g.Expired(now):
+		g.Status = GoalFailed
+	default:
+		g.Status = GoalInProgress
+	}
+}
+
+// GoalsResponse is the response of GET /goals
+type GoalsResponse struct {
+	Goals []*Goal `json:"goals"`
+}
+
+// NotificationKind is the kind of a notification
+type NotificationKind string
+
+var (
+	// NotificationStaleGame reminds the user about a game, that is In Progress, but was not updated for a long time
+	NotificationStaleGame NotificationKind = "stale-game"
+)
+
+// NotificationChannel is a way of delivering the notifications to the users
+type NotificationChannel string
+
+var (
+	// NotificationInApp delivers the notifications to the inbox of the user in the application
+	NotificationInApp NotificationChannel = "in-app"
+	// NotificationEmail delivers the notifications to the email of the user
+	NotificationEmail NotificationChannel = "email"
+	// NotificationWebhook posts the notifications to the webhook URL of the user
+	NotificationWebhook NotificationChannel = "webhook"
+)
+
+// Notification is a message to a user, e.g. a reminder about a forgotten game
+type Notification struct {
+	ID       string           `json:"id"`
+	Kind     NotificationKind `json:"kind"`
+	GameID   string           `json:"gameId,omitempty"`
+	GameName string           `json:"gameName,omitempty"`
+	Message  string           `json:"message"`
+	// ReadAt is nil, if the notification is not read yet
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+
+	UserID string `json:"-"`
+}
+
+// NotificationsResponse is the response of GET /notifications
+type NotificationsResponse struct {
+	Notifications []*Notification `json:"notifications"`
+	// Unread is the number of all unread notifications of the user, not only the returned ones
+	Unread int `json:"unread"`
+}
+
+// MaxStaleAfterDays is the maximum number of days, after which a game becomes stale
+const MaxStaleAfterDays = 365
+
+// NotificationPreferences are the preferences of a user about what notifications they get, and how
+type NotificationPreferences struct {
+	// StaleReminders turns on the reminders about the games, that are In Progress, but were not updated for StaleAfterDays
+	StaleReminders bool `json:"staleReminders"`
+	StaleAfterDays int  `json:"staleAfterDays"`
+
+	InApp bool `json:"inApp"`
+	Email bool `json:"email"`
+	// WebhookURL is where the notifications are posted, or empty, if they are not
+	WebhookURL string `json:"webhookUrl"`
+}
+
+// Channels returns the channels, through which the user gets their notifications
+func (p *NotificationPreferences) Channels() []NotificationChannel {
+	channels := []NotificationChannel{}
+	if p.InApp {
+		channels = append(channels, NotificationInApp)
+	}
+	if p.Email {
+		channels = append(channels, NotificationEmail)
+	}
+	if p.WebhookURL != "" {
+		channels = append(channels, NotificationWebhook)
+	}
+	return channels
+}
+
+// StaleGame is a game In Progress, that was not played for longer than its owner prefers,
+// together with everything needed to notify them about it.
+// ProgressUpdatedAt is the last time the status or the progress of the game changed.
+type StaleGame struct {
+	Game              *Game
+	User              *User
+	Preferences       *NotificationPreferences
+	ProgressUpdatedAt *time.Time
+}
+
+// WebhookEvent is an event of a game, that the webhooks can subscribe to
+type WebhookEvent string
+
+var (
+	// WebhookGameCreated is fired, when a game is created
+	WebhookGameCreated WebhookEvent = "game.created"
+	// WebhookGameStatusChanged is fired, when the status of a game changes
+	WebhookGameStatusChanged WebhookEvent = "game.status_changed"
+	// WebhookGameProgressChanged is fired, when the progress of a game changes
+	WebhookGameProgressChanged WebhookEvent = "game.progress_changed"
+	// WebhookGameDeleted is fired, when a game is deleted
+	WebhookGameDeleted WebhookEvent = "game.deleted"
+
+	// WebhookEvents are all events, that the webhooks can subscribe to
+	WebhookEvents = []WebhookEvent{WebhookGameCreated, WebhookGameStatusChanged, WebhookGameProgressChanged, WebhookGameDeleted}
+)
+
+// Validate returns an error if the event is not one of the known events
+func (e WebhookEvent) Validate() error {
+	for _, event := range WebhookEvents {
+		if e == event {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not a valid webhook event, expected one of %v", e, WebhookEvents)
+}
+
+// Webhook is a URL, that the events of the games of a user are posted to
+type Webhook struct {
+	ID     string         `json:"id"`
+	URL    string         `json:"url"`
+	Events []WebhookEvent `json:"events"`
+	Active bool           `json:"active"`
+	// Secret signs the payloads. It is generated, when the webhook is created, and returned only then.
+	Secret    string     `json:"secret,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	UserID string `json:"-"`
+}
+
+// WebhooksResponse is the response of GET /webhooks
+type WebhooksResponse struct {
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// WebhookPayload is the body, that is posted to the webhooks.
+// Game is set for game.created, Status for game.status_changed and Progress for game.progress_changed.
+type WebhookPayload struct {
+	// ID is the ID of the event. It is the same for all deliveries and retries of the event, so that the receivers can ignore the duplicates.
+	ID        string        `json:"id"`
+	Event     WebhookEvent  `json:"event"`
+	GameID    string        `json:"gameId"`
+	Game      *Game         `json:"game,omitempty"`
+	Status    Status        `json:"status,omitempty"`
+	Progress  *GameProgress `json:"progress,omitempty"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// WebhookDelivery is a delivery of an event to a webhook, after all its attempts
+type WebhookDelivery struct {
+	ID        string       `json:"id"`
+	WebhookID string       `json:"webhookId"`
+	EventID   string       `json:"eventId"`
+	Event     WebhookEvent `json:"event"`
+	Payload   string       `json:"payload"`
+	Success   bool         `json:"success"`
+	// StatusCode is the status of the last response of the webhook, or 0 if it did not respond at all
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// Attempts is the number of times the event was sent, including the retries
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookDeliveriesResponse is the response of GET /webhooks/{id}/deliveries
+type WebhookDeliveriesResponse struct {
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+}
+
+// ChangeType is the type of a change of a game, that is published live to the users, who can see the game
+type ChangeType string
+
+var (
+	// ChangeGameCreated is published, when a game is created. The change carries the whole game.
+	ChangeGameCreated ChangeType = "game.created"
+	// ChangeGameUpdated is published, when the status or the progress of a game changes. The change carries only the new values.
+	ChangeGameUpdated ChangeType = "game.updated"
+	// ChangeGameDeleted is published, when a game is deleted
+	ChangeGameDeleted ChangeType = "game.deleted"
+)
+
+// Change is a change of a game, that is streamed to the users, who can see the game, so that their open pages stay up to date
+type Change struct {
+	Type     ChangeType    `json:"type"`
+	GameID   string        `json:"gameId"`
+	Game     *Game         `json:"game,omitempty"`
+	Status   Status        `json:"status,omitempty"`
+	Progress *GameProgress `json:"progress,omitempty"`
+}
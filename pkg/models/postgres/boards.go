@@ -0,0 +1,384 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+var (
+	// ErrForbidden is returned when a user can see a board or a game on it, but their role does not allow the change
+	ErrForbidden = errors.New("the role of the user does not allow that")
+	// ErrAlreadyMember is returned when a user, that is already a member of a board, is invited to it
+	ErrAlreadyMember = errors.New("the user is already a member of the board")
+	// ErrLastOwner is returned when the last owner of a board is removed or demoted, which would leave the board without an owner
+	ErrLastOwner = errors.New("the board must have at least one owner")
+	// ErrUnknownUser is returned when a user, that does not exist, is invited to a board
+	ErrUnknownUser = errors.New("such user does not exist")
+)
+
+// BoardModel wraps an sql.DB connection pool.
+// It manages the shared boards, their members and the invitations to them.
+//
+// A board can be seen only by its members. For everyone else, it is treated as if it does not exist.
+type BoardModel struct {
+	db *sql.DB
+}
+
+func NewBoardModel(db *sql.DB) *BoardModel {
+	return &BoardModel{db: db}
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// readableGame is the condition, that matches the games g, that the user in the given parameter can see:
+// the games of the user, that are not on a board, and the games on the boards, that the user is a member of.
+func readableGame(param int) string {
+	return fmt.Sprintf(`(g.board_id IS NULL AND g.user_id = $%[1]d OR g.board_id IN (SELECT bm.board_id FROM BOARD_MEMBERS bm WHERE bm.user_id = $%[1]d))`, param)
+}
+
+// writableGame is the condition, that matches the games g, that the user in the given parameter can change:
+// the games of the user, that are not on a board, and the games on the boards, that the user is an owner or an editor of.
+func writableGame(param int) string {
+	return fmt.Sprintf(`(g.board_id IS NULL AND g.user_id = $%[1]d OR g.board_id IN (SELECT bm.board_id FROM BOARD_MEMBERS bm WHERE bm.user_id = $%[1]d AND bm.role IN ('%s', '%s')))`,
+		param, models.BoardRoleOwner, models.BoardRoleEditor)
+}
+
+// gameAccessError returns the error for a game, that the user could not change:
+// an ErrForbidden if the user can see the game, and an ErrNoRecord otherwise.
+func gameAccessError(q queryer, userID, gameID string) error {
+	var readable bool
+	if err := q.QueryRow(`SELECT EXISTS (SELECT 1 FROM GAMES g WHERE g.id = $1 AND `+readableGame(2)+`)`, gameID, userID).Scan(&readable); err != nil {
+		return fmt.Errorf("error while fetching game from the database: %w", err)
+	}
+	if readable {
+		return ErrForbidden
+	}
+	return ErrNoRecord
+}
+
+// lockGame locks the game for a change by the user, in the given transaction, and returns its status.
+// If the user can only see the game, an ErrForbidden is returned, and if they can not see it, an ErrNoRecord.
+func lockGame(tx *sql.Tx, userID, gameID string) (models.Status, error) {
+	var status models.Status
+	if err := tx.QueryRow(`SELECT g.status FROM GAMES g WHERE g.id = $1 AND `+writableGame(2)+` FOR UPDATE`, gameID, userID).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", gameAccessError(tx, userID, gameID)
+		}
+		return "", fmt.Errorf("error while fetching game from the database: %w", err)
+	}
+	return status, nil
+}
+
+// boardRole returns the role of the user in the given board.
+// If the user is not a member of the board, an ErrNoRecord is returned.
+func boardRole(q queryer, userID, boardID string) (models.BoardRole, error) {
+	var role models.BoardRole
+	if err := q.QueryRow(`SELECT role FROM BOARD_MEMBERS WHERE board_id = $1 AND user_id = $2`, boardID, userID).Scan(&role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNoRecord
+		}
+		return "", fmt.Errorf("error while fetching board member from the database: %w", err)
+	}
+	return role, nil
+}
+
+// requireOwner returns an ErrNoRecord if the user is not a member of the board, and an ErrForbidden if they are not its owner.
+func requireOwner(q queryer, userID, boardID string) error {
+	role, err := boardRole(q, userID, boardID)
+	if err != nil {
+		return err
+	}
+	if role != models.BoardRoleOwner {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// CreateBoard creates a board with the given name. The user becomes its owner.
+func (m *BoardModel) CreateBoard(userID, name string) (*models.Board, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	board := &models.Board{Name: name, Role: models.BoardRoleOwner}
+	if err := tx.QueryRow(`INSERT INTO BOARDS (name) VALUES ($1) RETURNING id`, name).Scan(&board.ID); err != nil {
+		return nil, fmt.Errorf("error while inserting board into the database: %w", err)
+	}
+	if _, err := tx.Exec(`INSERT INTO BOARD_MEMBERS (board_id, user_id, role) VALUES ($1, $2, $3)`, board.ID, userID, models.BoardRoleOwner); err != nil {
+		return nil, fmt.Errorf("error while inserting board member into the database: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error while committing transaction: %w", err)
+	}
+	return board, nil
+}
+
+// Boards returns the boards, that the user is a member of, sorted by name.
+func (m *BoardModel) Boards(userID string) ([]*models.Board, error) {
+	rows, err := m.db.Query(`
+	SELECT b.id, b.name, bm.role
+	FROM BOARDS b
+		JOIN BOARD_MEMBERS bm ON bm.board_id = b.id
+	WHERE bm.user_id = $1
+	ORDER BY b.name, b.id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching boards from the database: %w", err)
+	}
+	defer rows.Close()
+
+	boards := []*models.Board{}
+	for rows.Next() {
+		var board models.Board
+		if err := rows.Scan(&board.ID, &board.Name, &board.Role); err != nil {
+			return nil, fmt.Errorf("error while reading boards from the database: %w", err)
+		}
+		boards = append(boards, &board)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading boards from the database: %w", err)
+	}
+	return boards, nil
+}
+
+// Board returns the given board, together with its members.
+// If the user is not a member of the board, an ErrNoRecord is returned.
+func (m *BoardModel) Board(userID, boardID string) (*models.Board, error) {
+	board := &models.Board{ID: boardID, Members: []*models.BoardMember{}}
+	if err := m.db.QueryRow(`
+	SELECT b.name, bm.role
+	FROM BOARDS b
+		JOIN BOARD_MEMBERS bm ON bm.board_id = b.id
+	WHERE b.id = $1 AND bm.user_id = $2`, boardID, userID).Scan(&board.Name, &board.Role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching board from the database: %w", err)
+	}
+
+	rows, err := m.db.Query(`
+	SELECT u.username, bm.role
+	FROM BOARD_MEMBERS bm
+		JOIN USERS u ON u.id = bm.user_id
+	WHERE bm.board_id = $1
+	ORDER BY u.username`, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching board members from the database: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member models.BoardMember
+		if err := rows.Scan(&member.Username, &member.Role); err != nil {
+			return nil, fmt.Errorf("error while reading board members from the database: %w", err)
+		}
+		board.Members = append(board.Members, &member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading board members from the database: %w", err)
+	}
+	return board, nil
+}
+
+// DeleteBoard deletes the board, together with its games. Only the owners of the board can delete it.
+func (m *BoardModel) DeleteBoard(userID, boardID string) error {
+	if err := requireOwner(m.db, userID, boardID); err != nil {
+		return err
+	}
+	if _, err := m.db.Exec(`DELETE FROM BOARDS WHERE id = $1`, boardID); err != nil {
+		return fmt.Errorf("error while deleting board: %w", err)
+	}
+	return nil
+}
+
+// InviteToBoard invites the user with the given username to the board with the given role.
+// Only the owners of the board can invite users. Inviting a user again replaces the previous invitation.
+// If there is no user with that username, an ErrUnknownUser is returned,
+// and if they are already a member of the board, an ErrAlreadyMember.
+func (m *BoardModel) InviteToBoard(userID, boardID, username string, role models.BoardRole) (*models.BoardInvitation, error) {
+	if err := requireOwner(m.db, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	var inviteeID string
+	if err := m.db.QueryRow(`SELECT id FROM USERS WHERE username = $1`, username).Scan(&inviteeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnknownUser
+		}
+		return nil, fmt.Errorf("error while fetching user from the database: %w", err)
+	}
+	if _, err := boardRole(m.db, inviteeID, boardID); err == nil {
+		return nil, ErrAlreadyMember
+	} else if !errors.Is(err, ErrNoRecord) {
+		return nil, err
+	}
+
+	invitation := &models.BoardInvitation{BoardID: boardID, Username: username, Role: role}
+	if err := m.db.QueryRow(`
+	INSERT INTO BOARD_INVITATIONS (board_id, user_id, role, invited_by)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (board_id, user_id) DO UPDATE SET role = EXCLUDED.role, invited_by = EXCLUDED.invited_by, created_at = now()
+	RETURNING id,
+		(SELECT name FROM BOARDS WHERE id = $1),
+		(SELECT username FROM USERS WHERE id = $4)`, boardID, inviteeID, role, userID).
+		Scan(&invitation.ID, &invitation.BoardName, &invitation.InvitedBy); err != nil {
+		return nil, fmt.Errorf("error while inserting invitation into the database: %w", err)
+	}
+	return invitation, nil
+}
+
+// Invitations returns the pending invitations of the user, the newest first.
+func (m *BoardModel) Invitations(userID string) ([]*models.BoardInvitation, error) {
+	rows, err := m.db.Query(`
+	SELECT i.id, b.id, b.name, u.username, i.role, ib.username
+	FROM BOARD_INVITATIONS i
+		JOIN BOARDS b ON b.id = i.board_id
+		JOIN USERS u ON u.id = i.user_id
+		JOIN USERS ib ON ib.id = i.invited_by
+	WHERE i.user_id = $1
+	ORDER BY i.id DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching invitations from the database: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []*models.BoardInvitation{}
+	for rows.Next() {
+		var invitation models.BoardInvitation
+		if err := rows.Scan(&invitation.ID, &invitation.BoardID, &invitation.BoardName, &invitation.Username, &invitation.Role, &invitation.InvitedBy); err != nil {
+			return nil, fmt.Errorf("error while reading invitations from the database: %w", err)
+		}
+		invitations = append(invitations, &invitation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading invitations from the database: %w", err)
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation makes the user a member of the board, with the role from the invitation, and returns the board.
+// If the user does not have such invitation, an ErrNoRecord is returned.
+func (m *BoardModel) AcceptInvitation(userID, invitationID string) (*models.Board, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	var board models.Board
+	if err := tx.QueryRow(`DELETE FROM BOARD_INVITATIONS WHERE id = $1 AND user_id = $2 RETURNING board_id, role`, invitationID, userID).Scan(&board.ID, &board.Role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while deleting invitation: %w", err)
+	}
+	if err := tx.QueryRow(`
+	INSERT INTO BOARD_MEMBERS (board_id, user_id, role) VALUES ($1, $2, $3)
+	RETURNING (SELECT name FROM BOARDS WHERE id = $1)`, board.ID, userID, board.Role).Scan(&board.Name); err != nil {
+		return nil, fmt.Errorf("error while inserting board member into the database: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error while committing transaction: %w", err)
+	}
+	return &board, nil
+}
+
+// DeclineInvitation deletes the invitation of the user.
+// If the user does not have such invitation, an ErrNoRecord is returned.
+func (m *BoardModel) DeclineInvitation(userID, invitationID string) error {
+	res, err := m.db.Exec(`DELETE FROM BOARD_INVITATIONS WHERE id = $1 AND user_id = $2`, invitationID, userID)
+	if err != nil {
+		return fmt.Errorf("error while deleting invitation: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while deleting invitation: %w", err)
+	}
+	if rows == 0 {
+		return ErrNoRecord
+	}
+	return nil
+}
+
+// ChangeMemberRole changes the role of the member with the given username. Only the owners of the board can change roles.
+// If there is no such member, an ErrNoRecord is returned.
+func (m *BoardModel) ChangeMemberRole(userID, boardID, username string, role models.BoardRole) error {
+	return m.changeMember(userID, boardID, username, false, func(tx *sql.Tx, memberID string) error {
+		if _, err := tx.Exec(`UPDATE BOARD_MEMBERS SET role = $1 WHERE board_id = $2 AND user_id = $3`, role, boardID, memberID); err != nil {
+			return fmt.Errorf("error while updating board member: %w", err)
+		}
+		return nil
+	})
+}
+
+// RemoveMember removes the member with the given username from the board. The owners of the board can remove anyone,
+// and the rest of the members can only leave the board themselves. The games, that the member added, stay on the board.
+// If there is no such member, an ErrNoRecord is returned.
+func (m *BoardModel) RemoveMember(userID, boardID, username string) error {
+	return m.changeMember(userID, boardID, username, true, func(tx *sql.Tx, memberID string) error {
+		if _, err := tx.Exec(`DELETE FROM BOARD_MEMBERS WHERE board_id = $1 AND user_id = $2`, boardID, memberID); err != nil {
+			return fmt.Errorf("error while deleting board member: %w", err)
+		}
+		return nil
+	})
+}
+
+// changeMember runs fn in a transaction, in which the members of the board are locked,
+// and checks that the board is left with at least one owner afterwards.
+// If self is true, the members, that are not owners, can change themselves.
+func (m *BoardModel) changeMember(userID, boardID, username string, self bool, fn func(tx *sql.Tx, memberID string) error) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	if _, err := tx.Exec(`SELECT 1 FROM BOARD_MEMBERS WHERE board_id = $1 FOR UPDATE`, boardID); err != nil {
+		return fmt.Errorf("error while locking board members: %w", err)
+	}
+	role, err := boardRole(tx, userID, boardID)
+	if err != nil {
+		return err
+	}
+
+	var memberID string
+	if err := tx.QueryRow(`
+	SELECT u.id
+	FROM USERS u
+		JOIN BOARD_MEMBERS bm ON bm.user_id = u.id
+	WHERE bm.board_id = $1 AND u.username = $2`, boardID, username).Scan(&memberID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoRecord
+		}
+		return fmt.Errorf("error while fetching board member from the database: %w", err)
+	}
+	if role != models.BoardRoleOwner && !(self && memberID == userID) {
+		return ErrForbidden
+	}
+
+	if err := fn(tx, memberID); err != nil {
+		return err
+	}
+
+	var owners int
+	if err := tx.QueryRow(`SELECT count(*) FROM BOARD_MEMBERS WHERE board_id = $1 AND role = $2`, boardID, models.BoardRoleOwner).Scan(&owners); err != nil {
+		return fmt.Errorf("error while counting board owners: %w", err)
+	}
+	if owners == 0 {
+		return ErrLastOwner
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error while committing transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// ChecklistModel wraps an sql.DB connection pool.
+// It manages the checklist items of the games and the progress of the games, that is computed from them.
+type ChecklistModel struct {
+	db *sql.DB
+}
+
+func NewChecklistModel(db *sql.DB) *ChecklistModel {
+	return &ChecklistModel{db: db}
+}
+
+// Checklist returns the checklist of the given game of the user.
+// If the user does not have such game, an ErrNoRecord is returned.
+func (m *ChecklistModel) Checklist(userID, gameID string) (*models.Checklist, error) {
+	checklist := &models.Checklist{Items: []*models.ChecklistItem{}, Progress: &models.GameProgress{}}
+	if err := m.db.QueryRow(`SELECT g.auto_progress, g.current_progress, g.final_progress FROM GAMES g WHERE g.id = $1 AND `+readableGame(2), gameID, userID).
+		Scan(&checklist.AutoProgress, &checklist.Progress.Current, &checklist.Progress.Final); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching game from the database: %w", err)
+	}
+
+	rows, err := m.db.Query(`SELECT id, name, done FROM CHECKLIST_ITEMS WHERE game_id = $1 ORDER BY id`, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching checklist items from the database: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.ChecklistItem
+		if err := rows.Scan(&item.ID, &item.Name, &item.Done); err != nil {
+			return nil, fmt.Errorf("error while reading checklist items from the database: %w", err)
+		}
+		checklist.Items = append(checklist.Items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading checklist items from the database: %w", err)
+	}
+
+	return checklist, nil
+}
+
+// AddChecklistItem adds an item with the given name to the checklist of the game and returns the changed checklist.
+// If the user does not have such game, an ErrNoRecord is returned.
+func (m *ChecklistModel) AddChecklistItem(userID, gameID, name string) (*models.Checklist, error) {
+	return m.change(userID, gameID, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`INSERT INTO CHECKLIST_ITEMS (game_id, name) VALUES ($1, $2)`, gameID, name); err != nil {
+			return fmt.Errorf("error while inserting checklist item: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateChecklistItem changes the name and/or the done flag of the given checklist item and returns the changed checklist.
+// An empty name or a nil done are left unchanged.
+// If the user does not have such game, or the game does not have such item, an ErrNoRecord is returned.
+func (m *ChecklistModel) UpdateChecklistItem(userID, gameID, itemID, name string, done *bool) (*models.Checklist, error) {
+	return m.change(userID, gameID, func(tx *sql.Tx) error {
+		return execItem(tx, `
+		UPDATE CHECKLIST_ITEMS SET
+			name = COALESCE(NULLIF($1, ''), name),
+			done = COALESCE($2, done)
+		WHERE id = $3 AND game_id = $4`, name, done, itemID, gameID)
+	})
+}
+
+// DeleteChecklistItem deletes the given item from the checklist of the game and returns the changed checklist.
+// If the user does not have such game, or the game does not have such item, an ErrNoRecord is returned.
+func (m *ChecklistModel) DeleteChecklistItem(userID, gameID, itemID string) (*models.Checklist, error) {
+	return m.change(userID, gameID, func(tx *sql.Tx) error {
+		return execItem(tx, `DELETE FROM CHECKLIST_ITEMS WHERE id = $1 AND game_id = $2`, itemID, gameID)
+	})
+}
+
+// SetChecklistAutoProgress turns on or off the computation of the progress of the game from its checklist and returns the changed checklist.
+// If the user does not have such game, an ErrNoRecord is returned.
+func (m *ChecklistModel) SetChecklistAutoProgress(userID, gameID string, autoProgress bool) (*models.Checklist, error) {
+	return m.change(userID, gameID, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`UPDATE GAMES SET auto_progress = $1 WHERE id = $2`, autoProgress, gameID); err != nil {
+			return fmt.Errorf("error while updating game: %w", err)
+		}
+		return nil
+	})
+}
+
+// change runs fn in a transaction, in which the game is locked,
+// recomputes the progress of the game, if it is computed from the checklist, and returns the changed checklist,
+// which tells whether the progress of the game changed.
+// If the user can only see the game, an ErrForbidden is returned.
+func (m *ChecklistModel) change(userID, gameID string, fn func(tx *sql.Tx) error) (*models.Checklist, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	if _, err := lockGame(tx, userID, gameID); err != nil {
+		return nil, err
+	}
+
+	if err := fn(tx); err != nil {
+		return nil, err
+	}
+
+	var old models.GameProgress
+	if err := tx.QueryRow(`SELECT current_progress, final_progress FROM GAMES WHERE id = $1`, gameID).Scan(&old.Current, &old.Final); err != nil {
+		return nil, fmt.Errorf("error while fetching game progress: %w", err)
+	}
+
+	// the progress is only recomputed, if it is computed from the checklist, so there is no row otherwise
+	var progress models.GameProgress
+	err = tx.QueryRow(`
+	UPDATE GAMES SET
+		current_progress = (SELECT count(*) FROM CHECKLIST_ITEMS WHERE game_id = $1 AND done),
+		final_progress = (SELECT count(*) FROM CHECKLIST_ITEMS WHERE game_id = $1),
+		updated_at = now()
+	WHERE id = $1 AND auto_progress
+	RETURNING current_progress, final_progress`, gameID).Scan(&progress.Current, &progress.Final)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error while updating game progress: %w", err)
+	}
+	progressChanged := err == nil && progress != old
+	if progressChanged {
+		if _, err := tx.Exec(`UPDATE GAMES SET progress_updated_at = now() WHERE id = $1`, gameID); err != nil {
+			return nil, fmt.Errorf("error while updating game progress: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error while committing transaction: %w", err)
+	}
+
+	checklist, err := m.Checklist(userID, gameID)
+	if err != nil {
+		return nil, err
+	}
+	checklist.ProgressChanged = progressChanged
+	return checklist, nil
+}
+
+func execItem(tx *sql.Tx, query string, args ...interface{}) error {
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("error while updating checklist item: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while updating checklist item: %w", err)
+	}
+	if rows == 0 {
+		return ErrNoRecord
+	}
+	return nil
+}
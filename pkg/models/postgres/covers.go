@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// CoverModel wraps an sql.DB connection pool.
+// It keeps track of which games and franchises have an uploaded cover. The covers themselves are kept in a blob store.
+type CoverModel struct {
+	db *sql.DB
+}
+
+func NewCoverModel(db *sql.DB) *CoverModel {
+	return &CoverModel{db: db}
+}
+
+// CoverUpdatedAt returns the time the cover of the given game or franchise of the user was uploaded, or nil, if it has no cover.
+// If the user does not have such game or franchise, an ErrNoRecord is returned.
+// If write is true and the user can only see the game, an ErrForbidden is returned.
+func (m *CoverModel) CoverUpdatedAt(userID string, kind models.CoverKind, id string, write bool) (*time.Time, error) {
+	table, condition, err := coverTable(kind, write)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedAt sql.NullTime
+	// the table name can not be a parameter, but it is one of the known tables
+	if err := m.db.QueryRow(fmt.Sprintf(`SELECT cover_updated_at FROM %s WHERE %s`, table, condition), id, userID).Scan(&updatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if write && kind == models.CoverKindGame {
+				return nil, gameAccessError(m.db, userID, id)
+			}
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching cover from the database: %w", err)
+	}
+	if !updatedAt.Valid {
+		return nil, nil
+	}
+	return &updatedAt.Time, nil
+}
+
+// SetCoverUpdatedAt sets the time the cover of the given game or franchise of the user was uploaded.
+// A nil time marks that the cover is deleted.
+// If the user does not have such game or franchise, an ErrNoRecord is returned,
+// and if they can only see the game, an ErrForbidden.
+func (m *CoverModel) SetCoverUpdatedAt(userID string, kind models.CoverKind, id string, updatedAt *time.Time) error {
+	table, condition, err := coverTable(kind, true)
+	if err != nil {
+		return err
+	}
+
+	res, err := m.db.Exec(fmt.Sprintf(`UPDATE %s SET cover_updated_at = $3 WHERE %s`, table, condition), id, userID, updatedAt)
+	if err != nil {
+		return fmt.Errorf("error while updating cover in the database: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while updating cover in the database: %w", err)
+	}
+	if rows == 0 {
+		if kind == models.CoverKindGame {
+			return gameAccessError(m.db, userID, id)
+		}
+		return ErrNoRecord
+	}
+	return nil
+}
+
+// coverTable returns the table of the given kind, and the condition, that matches the row with ID $1,
+// if the user $2 can see it, or change it, if write is true.
+// The games on a shared board can be seen by all of its members, but changed only by its owners and editors.
+func coverTable(kind models.CoverKind, write bool) (string, string, error) {
+	switch kind {
+	case models.CoverKindGame:
+		if write {
+			return "GAMES g", "g.id = $1 AND " + writableGame(2), nil
+		}
+		return "GAMES g", "g.id = $1 AND " + readableGame(2), nil
+	case models.CoverKindFranchise:
+		return "FRANCHISES", "id = $1 AND user_id = $2", nil
+	}
+	return "", "", fmt.Errorf("unknown cover kind %q", kind)
+}
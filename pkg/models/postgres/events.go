@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// EventModel wraps an sql.DB connection pool.
+// It reads the activity of the users, that is recorded along with the changes of their games.
+type EventModel struct {
+	db *sql.DB
+}
+
+func NewEventModel(db *sql.DB) *EventModel {
+	return &EventModel{db: db}
+}
+
+// Feed returns the events of the users, that the given user follows, the newest first.
+// Only the events of public profiles are returned, and the events of hidden games and of the games on boards are left out,
+// because the followers are not necessarily members of the boards.
+// If before is not 0, only the events older than the event with that ID are returned.
+func (m *EventModel) Feed(userID string, before int64, limit int) ([]*models.Event, error) {
+	rows, err := m.db.Query(`
+	SELECT e.id, e.kind, u.username, e.game_id, g.name, e.from_status, e.to_status, e.created_at
+	FROM EVENTS e
+		JOIN FOLLOWS fl ON fl.followee_id = e.user_id
+		JOIN USERS u ON u.id = e.user_id
+		JOIN GAMES g ON g.id = e.game_id
+	WHERE fl.follower_id = $1 AND u.public AND NOT g.hidden AND g.board_id IS NULL AND ($2 = 0 OR e.id < $2)
+	ORDER BY e.id DESC
+	LIMIT $3`, userID, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching feed from the database: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.Event{}
+	for rows.Next() {
+		var event models.Event
+		var from, to sql.NullString
+		if err := rows.Scan(&event.ID, &event.Kind, &event.Username, &event.GameID, &event.GameName, &from, &to, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error while reading feed from the database: %w", err)
+		}
+		event.From = models.Status(from.String)
+		event.To = models.Status(to.String)
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading feed from the database: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,48 @@
+//go:build integration_tests
+
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedWithoutBoardGames(t *testing.T) {
+	db := newTestDB(t)
+
+	// the names are unique, so that the test can run more than once on the same database
+	suffix := time.Now().Format("20060102150405.000000000")
+	userModel := postgres.NewUserModel(db)
+	owner, err := userModel.Insert(&models.User{Username: "feed-owner-" + suffix, Email: fmt.Sprintf("feed-owner-%s@example.com", suffix), Password: "password"})
+	require.NoError(t, err)
+	follower, err := userModel.Insert(&models.User{Username: "feed-follower-" + suffix, Email: fmt.Sprintf("feed-follower-%s@example.com", suffix), Password: "password"})
+	require.NoError(t, err)
+
+	socialModel := postgres.NewSocialModel(db)
+	require.NoError(t, socialModel.SetProfilePublic(owner.ID, true))
+	require.NoError(t, socialModel.Follow(follower.ID, owner.Username))
+
+	gameModel := postgres.NewGameModel(db)
+	game, err := gameModel.Insert(&models.Game{Name: "Hades", UserID: owner.ID})
+	require.NoError(t, err)
+	require.NoError(t, gameModel.ChangeGameStatus(owner.ID, game.ID, models.StatusInProgress))
+
+	// the follower is not a member of the board, so the changes of its games are not in the feed
+	board, err := postgres.NewBoardModel(db).CreateBoard(owner.ID, "Co-op")
+	require.NoError(t, err)
+	boardGame, err := gameModel.Insert(&models.Game{Name: "It Takes Two", UserID: owner.ID, BoardID: board.ID})
+	require.NoError(t, err)
+	require.NoError(t, gameModel.ChangeGameStatus(owner.ID, boardGame.ID, models.StatusInProgress))
+
+	events, err := postgres.NewEventModel(db).Feed(follower.ID, 0, 20)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, game.ID, events[0].GameID)
+	assert.Equal(t, models.StatusInProgress, events[0].To)
+}
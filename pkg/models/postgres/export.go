@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// ExportModel wraps an sql.DB connection pool.
+// Instead of returning slices, its methods call a function for each row,
+// so that all data of a user can be exported without loading it in memory.
+type ExportModel struct {
+	db *sql.DB
+}
+
+func NewExportModel(db *sql.DB) *ExportModel {
+	return &ExportModel{db: db}
+}
+
+// Franchises calls fn for each franchise of the given user, ordered by ID.
+// It stops at the first error returned by fn and returns it.
+func (m *ExportModel) Franchises(userID string, fn func(*models.Franchise) error) error {
+	rows, err := m.db.Query(`SELECT f.id, f.name FROM FRANCHISES f WHERE f.user_id = $1 ORDER BY f.id`, userID)
+	if err != nil {
+		return fmt.Errorf("error while fetching franchises from the database: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var franchise models.Franchise
+		if err := rows.Scan(&franchise.ID, &franchise.Name); err != nil {
+			return fmt.Errorf("error while reading franchises from the database: %w", err)
+		}
+		if err := fn(&franchise); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error while reading franchises from the database: %w", err)
+	}
+	return nil
+}
+
+// Games calls fn for each game of the given user, ordered by ID.
+// It stops at the first error returned by fn and returns it.
+func (m *ExportModel) Games(userID string, fn func(*models.Game) error) error {
+	rows, err := m.db.Query(`
+	SELECT
+		g.id,
+		g.name,
+		g.franchise_id,
+		f.name,
+		g.status,
+		g.current_progress,
+		g.final_progress,
+		g.updated_at,
+		g.finished_at,
+		g.wishlist,
+		g.release_date
+	FROM GAMES g
+		LEFT JOIN FRANCHISES f ON f.id = g.franchise_id
+	WHERE g.user_id = $1 AND g.board_id IS NULL
+	ORDER BY g.id`, userID)
+	if err != nil {
+		return fmt.Errorf("error while fetching games from the database: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		game := models.Game{Progress: &models.GameProgress{}}
+
+		var fID, fName sql.NullString
+		var updatedAt time.Time
+		var finishedAt, releaseDate sql.NullTime
+		if err := rows.Scan(&game.ID, &game.Name, &fID, &fName, &game.Status, &game.Progress.Current, &game.Progress.Final, &updatedAt, &finishedAt,
+			&game.Wishlist, &releaseDate); err != nil {
+			return fmt.Errorf("error while reading games from the database: %w", err)
+		}
+		game.FranchiseID = fID.String
+		game.Franchise = fName.String
+		game.UpdatedAt = &updatedAt
+		if finishedAt.Valid {
+			game.FinishedAt = &finishedAt.Time
+		}
+		if releaseDate.Valid {
+			game.ReleaseDate = &releaseDate.Time
+		}
+
+		if err := fn(&game); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error while reading games from the database: %w", err)
+	}
+	return nil
+}
+
+// StatusChanges calls fn for each status change of the games of the given user, in the order they happened.
+// It stops at the first error returned by fn and returns it.
+func (m *ExportModel) StatusChanges(userID string, fn func(*models.StatusChange) error) error {
+	rows, err := m.db.Query(`
+	SELECT c.game_id, c.from_status, c.to_status, c.changed_at
+	FROM GAME_STATUS_CHANGES c
+	WHERE c.user_id = $1
+	ORDER BY c.changed_at, c.id`, userID)
+	if err != nil {
+		return fmt.Errorf("error while fetching status changes from the database: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change models.StatusChange
+		if err := rows.Scan(&change.GameID, &change.From, &change.To, &change.ChangedAt); err != nil {
+			return fmt.Errorf("error while reading status changes from the database: %w", err)
+		}
+		if err := fn(&change); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error while reading status changes from the database: %w", err)
+	}
+	return nil
+}
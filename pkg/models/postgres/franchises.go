@@ -2,12 +2,20 @@ package postgres
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/asankov/gira/pkg/models"
 	"github.com/lib/pq"
 )
 
+var (
+	// ErrInvalidOrder is returned when the games in the requested order are not exactly the games of the franchise
+	ErrInvalidOrder = errors.New("the order should contain every game of the franchise exactly once")
+	// ErrInvalidParent is returned when the parent franchise does not exist, or is the franchise itself or one of its sub-series
+	ErrInvalidParent = errors.New("the parent franchise does not exist, or is the franchise itself or one of its sub-series")
+)
+
 type FranchiseModel struct {
 	db *sql.DB
 }
@@ -16,10 +24,21 @@ func NewFranchiseModel(db *sql.DB) *FranchiseModel {
 	return &FranchiseModel{db: db}
 }
 
+// Insert creates the given franchise.
+// If it has a parent, that does not belong to the same user, an ErrInvalidParent is returned.
 func (m *FranchiseModel) Insert(franchise *models.Franchise) (*models.Franchise, error) {
-	row := m.db.QueryRow(`INSERT INTO FRANCHISES (name, user_id) VALUES ($1, $2) RETURNING name, user_id`, franchise.Name, franchise.UserID)
+	if franchise.ParentID != "" {
+		if err := m.db.QueryRow(`SELECT id FROM FRANCHISES WHERE id = $1 AND user_id = $2`, franchise.ParentID, franchise.UserID).Scan(&franchise.ParentID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrInvalidParent
+			}
+			return nil, fmt.Errorf("error while fetching parent franchise from the database: %w", err)
+		}
+	}
+
+	row := m.db.QueryRow(`INSERT INTO FRANCHISES (name, user_id, parent_id) VALUES ($1, $2, $3) RETURNING id, name`, franchise.Name, franchise.UserID, nullString(franchise.ParentID))
 
-	var f models.Franchise
+	f := models.Franchise{ParentID: franchise.ParentID}
 	if err := row.Scan(&f.ID, &f.Name); err != nil {
 		return nil, handleInsertFranchiseError(err)
 	}
@@ -29,15 +48,27 @@ func (m *FranchiseModel) Insert(franchise *models.Franchise) (*models.Franchise,
 
 func handleInsertFranchiseError(err error) error {
 	if err, ok := err.(*pq.Error); ok {
-		if err.Constraint == "franchises_name_key" {
+		if err.Constraint == "franchises_uc_name_user_id" {
 			return ErrNameAlreadyExists
 		}
 	}
 	return fmt.Errorf("error while inserting record into the database: %w", err)
 }
 
+// All fetches the franchises of the user, with their parents and the completion of their own games.
 func (m *FranchiseModel) All(userID string) ([]*models.Franchise, error) {
-	rows, err := m.db.Query(`SELECT id, name FROM FRANCHISES f WHERE f.user_id = $1`, userID)
+	rows, err := m.db.Query(`
+	SELECT
+		f.id,
+		f.name,
+		f.cover_updated_at,
+		f.parent_id,
+		COUNT(g.id),
+		COUNT(g.id) FILTER (WHERE g.status = $2)
+	FROM FRANCHISES f
+		LEFT JOIN GAMES g ON g.franchise_id = f.id AND g.board_id IS NULL AND NOT g.wishlist
+	WHERE f.user_id = $1
+	GROUP BY f.id`, userID, models.StatusDone)
 	if err != nil {
 		return nil, fmt.Errorf("error while fetching franchises from the database: %w", err)
 	}
@@ -45,11 +76,17 @@ func (m *FranchiseModel) All(userID string) ([]*models.Franchise, error) {
 
 	franchises := []*models.Franchise{}
 	for rows.Next() {
-		var franchise models.Franchise
+		franchise := models.Franchise{Completion: &models.FranchiseCompletion{}}
 
-		if err = rows.Scan(&franchise.ID, &franchise.Name); err != nil {
+		var coverUpdatedAt sql.NullTime
+		var parentID sql.NullString
+		if err = rows.Scan(&franchise.ID, &franchise.Name, &coverUpdatedAt, &parentID, &franchise.Completion.Games, &franchise.Completion.Finished); err != nil {
 			return nil, fmt.Errorf("error while reading franchises from the database: %w", err)
 		}
+		if coverUpdatedAt.Valid {
+			franchise.CoverUpdatedAt = &coverUpdatedAt.Time
+		}
+		franchise.ParentID = parentID.String
 
 		franchises = append(franchises, &franchise)
 	}
@@ -60,3 +97,195 @@ func (m *FranchiseModel) All(userID string) ([]*models.Franchise, error) {
 
 	return franchises, nil
 }
+
+// Get fetches the given franchise, together with its games in play order.
+// The positions of all games in both orders are set, including the ones, that are not ordered explicitly.
+// If the franchise does not exist, or belongs to another user, an ErrNoRecord is returned.
+func (m *FranchiseModel) Get(userID, franchiseID string) (*models.Franchise, error) {
+	var franchise models.Franchise
+	var coverUpdatedAt sql.NullTime
+	var parentID sql.NullString
+	if err := m.db.QueryRow(`SELECT id, name, cover_updated_at, parent_id FROM FRANCHISES f WHERE f.id = $1 AND f.user_id = $2`, franchiseID, userID).
+		Scan(&franchise.ID, &franchise.Name, &coverUpdatedAt, &parentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching franchise from the database: %w", err)
+	}
+	if coverUpdatedAt.Valid {
+		franchise.CoverUpdatedAt = &coverUpdatedAt.Time
+	}
+	franchise.ParentID = parentID.String
+
+	games, err := m.franchiseGames(userID, franchiseID)
+	if err != nil {
+		return nil, err
+	}
+
+	models.SortByReleaseOrder(games)
+	for i, game := range games {
+		game.ReleaseOrder = i + 1
+	}
+	models.SortByPlayOrder(games)
+	for i, game := range games {
+		game.PlayOrder = i + 1
+	}
+	franchise.Games = games
+
+	return &franchise, nil
+}
+
+// Reorder stores the given order of the games of the franchise.
+// gameIDs must be the IDs of all games of the franchise, otherwise an ErrInvalidOrder is returned.
+// If the franchise does not exist, or belongs to another user, an ErrNoRecord is returned.
+func (m *FranchiseModel) Reorder(userID, franchiseID string, order models.FranchiseOrder, gameIDs []string) error {
+	column := "play_order"
+	if order == models.FranchiseOrderRelease {
+		column = "release_order"
+	}
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	if err := tx.QueryRow(`SELECT id FROM FRANCHISES WHERE id = $1 AND user_id = $2 FOR UPDATE`, franchiseID, userID).Scan(&franchiseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoRecord
+		}
+		return fmt.Errorf("error while fetching franchise from the database: %w", err)
+	}
+
+	rows, err := tx.Query(`SELECT id FROM GAMES WHERE franchise_id = $1 AND user_id = $2 AND board_id IS NULL FOR UPDATE`, franchiseID, userID)
+	if err != nil {
+		return fmt.Errorf("error while fetching games from the database: %w", err)
+	}
+	existing := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("error while reading games from the database: %w", err)
+		}
+		existing[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error while reading games from the database: %w", err)
+	}
+
+	if len(gameIDs) != len(existing) {
+		return ErrInvalidOrder
+	}
+	for i, gameID := range gameIDs {
+		if !existing[gameID] {
+			return ErrInvalidOrder
+		}
+		// every game is listed only once
+		delete(existing, gameID)
+
+		if _, err := tx.Exec(`UPDATE GAMES SET `+column+` = $1 WHERE id = $2`, i+1, gameID); err != nil {
+			return fmt.Errorf("error while ordering games: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error while committing transaction: %w", err)
+	}
+	return nil
+}
+
+// SetParent makes the franchise a sub-series of the given parent, or a top-level franchise if parentID is empty.
+// If the parent does not exist, or is the franchise itself or one of its sub-series, an ErrInvalidParent is returned.
+// If the franchise does not exist, or belongs to another user, an ErrNoRecord is returned.
+func (m *FranchiseModel) SetParent(userID, franchiseID, parentID string) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	// all franchises of the user are locked, so that two concurrent changes can not make a cycle
+	if _, err := tx.Exec(`SELECT id FROM FRANCHISES WHERE user_id = $1 FOR UPDATE`, userID); err != nil {
+		return fmt.Errorf("error while locking franchises: %w", err)
+	}
+	if err := tx.QueryRow(`SELECT id FROM FRANCHISES WHERE id = $1 AND user_id = $2`, franchiseID, userID).Scan(&franchiseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoRecord
+		}
+		return fmt.Errorf("error while fetching franchise from the database: %w", err)
+	}
+
+	if parentID != "" {
+		// the parent must not be the franchise itself or one of its sub-series, i.e. the franchise must not be one of the ancestors of the parent
+		var cycle bool
+		if err := tx.QueryRow(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM FRANCHISES WHERE id = $1 AND user_id = $2
+			UNION
+			SELECT f.id, f.parent_id FROM FRANCHISES f JOIN ancestors a ON f.id = a.parent_id
+		)
+		SELECT bool_or(id = $3) FROM ancestors HAVING COUNT(*) > 0`, parentID, userID, franchiseID).Scan(&cycle); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidParent
+			}
+			return fmt.Errorf("error while fetching the parents of the franchise from the database: %w", err)
+		}
+		if cycle {
+			return ErrInvalidParent
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE FRANCHISES SET parent_id = $1 WHERE id = $2`, nullString(parentID), franchiseID); err != nil {
+		return fmt.Errorf("error while changing the parent of the franchise: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error while committing transaction: %w", err)
+	}
+	return nil
+}
+
+// GamesBefore fetches the games, that come before the given game in the play order of its franchise.
+// If the game has no franchise, no games are returned.
+// If the game does not exist, or belongs to another user, an ErrNoRecord is returned.
+func (m *FranchiseModel) GamesBefore(userID, gameID string) ([]*models.Game, error) {
+	var franchiseID sql.NullString
+	if err := m.db.QueryRow(`SELECT franchise_id FROM GAMES WHERE id = $1 AND user_id = $2 AND board_id IS NULL`, gameID, userID).Scan(&franchiseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching game from the database: %w", err)
+	}
+	if !franchiseID.Valid {
+		return []*models.Game{}, nil
+	}
+
+	games, err := m.franchiseGames(userID, franchiseID.String)
+	if err != nil {
+		return nil, err
+	}
+	models.SortByPlayOrder(games)
+	for i, game := range games {
+		if game.ID == gameID {
+			return games[:i], nil
+		}
+	}
+	return games, nil
+}
+
+// franchiseGames fetches the games of the given franchise, that belong only to the user
+func (m *FranchiseModel) franchiseGames(userID, franchiseID string) ([]*models.Game, error) {
+	rows, err := m.db.Query(`
+	SELECT `+gameColumns+`
+	FROM GAMES g
+		LEFT JOIN FRANCHISES f ON f.id = g.franchise_id
+	WHERE g.franchise_id = $1 AND g.user_id = $2 AND g.board_id IS NULL`, franchiseID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching games from the database: %w", err)
+	}
+	defer rows.Close()
+
+	return scanGames(rows)
+}
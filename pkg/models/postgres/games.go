@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/asankov/gira/pkg/models"
 	"github.com/lib/pq"
@@ -27,20 +29,35 @@ func NewGameModel(db *sql.DB) *GameModel {
 
 // Insert inserts the passed Game into the database.
 // It returns the ID of the created game, or error if such occurred.
-// If a game with the same name already exists, an ErrNameAlreadyExists is returned
+// If a game with the same name already exists, an ErrNameAlreadyExists is returned.
+// If the game is added to a board, the user must be an owner or an editor of it,
+// otherwise an ErrNoRecord or an ErrForbidden is returned.
 func (m *GameModel) Insert(game *models.Game) (*models.Game, error) {
-	var (
-		row *sql.Row
-	)
-
-	if game.FranchiseID == "" {
-		row = m.db.QueryRow(`INSERT INTO GAMES (name, user_id) VALUES ($1, $2) RETURNING id, name, franchise_id, current_progress, final_progress, status`, game.Name, game.UserID)
-	} else {
-		row = m.db.QueryRow(`INSERT INTO GAMES (name, user_id, franchise_id) VALUES ($1, $2, $3) RETURNING id, name, franchise_id, current_progress, final_progress, status`, game.Name, game.UserID, game.FranchiseID)
+	if game.BoardID != "" {
+		role, err := boardRole(m.db, game.UserID, game.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		if !role.CanEdit() {
+			return nil, ErrForbidden
+		}
 	}
 
+	row := m.db.QueryRow(`
+	INSERT INTO GAMES (name, user_id, franchise_id, metadata_id, cover_url, developer, genres, release_date, wishlist, board_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING id, name, franchise_id, current_progress, final_progress, status`,
+		game.Name, game.UserID, nullString(game.FranchiseID), nullString(game.MetadataID), nullString(game.CoverURL), nullString(game.Developer), pq.Array(game.Genres), game.ReleaseDate, game.Wishlist, nullString(game.BoardID))
+
 	g := &models.Game{
-		Progress: &models.GameProgress{},
+		BoardID:     game.BoardID,
+		Wishlist:    game.Wishlist,
+		Progress:    &models.GameProgress{},
+		MetadataID:  game.MetadataID,
+		CoverURL:    game.CoverURL,
+		Developer:   game.Developer,
+		Genres:      game.Genres,
+		ReleaseDate: game.ReleaseDate,
 	}
 	var fID sql.NullString
 	if err := row.Scan(&g.ID, &g.Name, &fID, &g.Progress.Current, &g.Progress.Final, &g.Status); err != nil {
@@ -51,9 +68,79 @@ func (m *GameModel) Insert(game *models.Game) (*models.Game, error) {
 	return g, nil
 }
 
+// InsertMany inserts the passed games for the given user in a single transaction,
+// so either all of them are inserted, or none.
+// Unlike Insert, it also sets the status and progress of the games.
+// The franchises with the given names are created in the same transaction,
+// and the games without a FranchiseID are linked to them by the name of their franchise, regardless of the case.
+func (m *GameModel) InsertMany(userID string, franchises []string, games []*models.Game) ([]*models.Game, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	franchiseIDs := make(map[string]string, len(franchises))
+	for _, name := range franchises {
+		var id string
+		if err := tx.QueryRow(`INSERT INTO FRANCHISES (name, user_id) VALUES ($1, $2) RETURNING id`, name, userID).Scan(&id); err != nil {
+			return nil, handleInsertFranchiseError(err)
+		}
+		franchiseIDs[strings.ToLower(name)] = id
+	}
+
+	inserted := make([]*models.Game, 0, len(games))
+	for _, game := range games {
+		status := game.Status
+		if status == "" {
+			status = models.StatusTODO
+		}
+		progress := &models.GameProgress{Current: 0, Final: 100}
+		if game.Progress != nil {
+			progress = game.Progress
+		}
+
+		franchiseID := game.FranchiseID
+		if franchiseID == "" && game.Franchise != "" {
+			franchiseID = franchiseIDs[strings.ToLower(game.Franchise)]
+		}
+
+		g := &models.Game{
+			Name:        game.Name,
+			FranchiseID: franchiseID,
+			Status:      status,
+			Progress:    &models.GameProgress{Current: progress.Current, Final: progress.Final},
+		}
+		if err := tx.QueryRow(`
+		INSERT INTO GAMES (name, user_id, franchise_id, status, current_progress, final_progress, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CASE WHEN $4 = $7 THEN now() END)
+		RETURNING id`, game.Name, userID, nullString(franchiseID), status, progress.Current, progress.Final, models.StatusDone).Scan(&g.ID); err != nil {
+			return nil, handleInsertGameError(err)
+		}
+
+		if status != models.StatusTODO {
+			if _, err := tx.Exec(`INSERT INTO GAME_STATUS_CHANGES (game_id, user_id, from_status, to_status) VALUES ($1, $2, $3, $4)`, g.ID, userID, models.StatusTODO, status); err != nil {
+				return nil, fmt.Errorf("error while recording game status change: %w", err)
+			}
+		}
+
+		inserted = append(inserted, g)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error while committing transaction: %w", err)
+	}
+	return inserted, nil
+}
+
+// nullString returns a NULL string for the empty string, so that empty optional fields are stored as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 func handleInsertGameError(err error) error {
 	if err, ok := err.(*pq.Error); ok {
-		if err.Constraint == "games_uc_name_user_id" {
+		if err.Constraint == "games_uc_name_user_id" || err.Constraint == "games_uc_name_board_id" {
 			return ErrNameAlreadyExists
 		}
 	}
@@ -75,35 +162,124 @@ func (m *GameModel) Get(id string) (*models.Game, error) {
 	return &g, nil
 }
 
-// AllForUser fetches all games for the given user from the database and returns them, or an error if such occurred.
-func (m *GameModel) AllForUser(userID string) ([]*models.Game, error) {
-	rows, err := m.db.Query(`
-	SELECT 
-		g.id, 
-		g.name, 
-		g.franchise_id, 
+// gameColumns are the columns of a game, in the order in which scanGames reads them
+const gameColumns = `
+		g.id,
+		g.name,
+		g.franchise_id,
 		f.name AS frachise_name,
 		g.status,
 		g.current_progress,
-		g.final_progress
+		g.final_progress,
+		g.updated_at,
+		g.finished_at,
+		g.metadata_id,
+		g.cover_url,
+		g.developer,
+		g.genres,
+		g.release_date,
+		g.cover_updated_at,
+		g.wishlist,
+		g.auto_progress,
+		(SELECT count(*) FROM PLAYTHROUGHS p WHERE p.game_id = g.id),
+		g.hidden,
+		g.board_id,
+		g.created_at,
+		g.rating,
+		g.release_order,
+		g.play_order`
+
+// AllForUser fetches all games for the given user from the database and returns them, or an error if such occurred.
+// The games on the shared boards are not included. They are fetched with AllForBoard.
+func (m *GameModel) AllForUser(userID string) ([]*models.Game, error) {
+	rows, err := m.db.Query(`
+	SELECT `+gameColumns+`
 	FROM GAMES g 
 		LEFT JOIN FRANCHISES f ON f.id = g.franchise_id 
-	WHERE g.user_id = $1`, userID)
+	WHERE g.user_id = $1 AND g.board_id IS NULL`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching games from the database: %w", err)
+	}
+	defer rows.Close()
+
+	return scanGames(rows)
+}
+
+// AllForBoard fetches all games on the given board, sorted by name.
+// If the user is not a member of the board, an ErrNoRecord is returned.
+func (m *GameModel) AllForBoard(userID, boardID string) ([]*models.Game, error) {
+	if _, err := boardRole(m.db, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Query(`
+	SELECT `+gameColumns+`
+	FROM GAMES g
+		LEFT JOIN FRANCHISES f ON f.id = g.franchise_id
+	WHERE g.board_id = $1
+	ORDER BY g.name`, boardID)
 	if err != nil {
 		return nil, fmt.Errorf("error while fetching games from the database: %w", err)
 	}
 	defer rows.Close()
 
+	return scanGames(rows)
+}
+
+// Upcoming fetches the games of the given user, that are released on or after from, sorted by their release date.
+// If wishlistOnly is true, only the games on the wishlist are returned.
+func (m *GameModel) Upcoming(userID string, from time.Time, wishlistOnly bool) ([]*models.Game, error) {
+	rows, err := m.db.Query(`
+	SELECT `+gameColumns+`
+	FROM GAMES g
+		LEFT JOIN FRANCHISES f ON f.id = g.franchise_id
+	WHERE g.user_id = $1 AND g.board_id IS NULL AND g.release_date >= $2 AND (g.wishlist OR NOT $3)
+	ORDER BY g.release_date, g.name`, userID, from.Format("2006-01-02"), wishlistOnly)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching upcoming games from the database: %w", err)
+	}
+	defer rows.Close()
+
+	return scanGames(rows)
+}
+
+func scanGames(rows *sql.Rows) ([]*models.Game, error) {
 	games := []*models.Game{}
 	for rows.Next() {
 		game := models.Game{Progress: &models.GameProgress{}}
 
-		var fID, fName sql.NullString
-		if err = rows.Scan(&game.ID, &game.Name, &fID, &fName, &game.Status, &game.Progress.Current, &game.Progress.Final); err != nil {
+		var fID, fName, metadataID, coverURL, developer, boardID sql.NullString
+		var updatedAt, createdAt time.Time
+		var rating, releaseOrder, playOrder sql.NullInt64
+		var finishedAt, releaseDate, coverUpdatedAt sql.NullTime
+		var genres pq.StringArray
+		if err := rows.Scan(&game.ID, &game.Name, &fID, &fName, &game.Status, &game.Progress.Current, &game.Progress.Final, &updatedAt, &finishedAt,
+			&metadataID, &coverURL, &developer, &genres, &releaseDate, &coverUpdatedAt, &game.Wishlist, &game.AutoProgress, &game.Playthroughs, &game.Hidden, &boardID, &createdAt, &rating, &releaseOrder, &playOrder); err != nil {
 			return nil, fmt.Errorf("error while reading games from the database: %w", err)
 		}
 		game.Franchise = fName.String
 		game.FranchiseID = fID.String
+		game.UpdatedAt = &updatedAt
+		game.CreatedAt = &createdAt
+		game.Rating = int(rating.Int64)
+		game.ReleaseOrder = int(releaseOrder.Int64)
+		game.PlayOrder = int(playOrder.Int64)
+		if finishedAt.Valid {
+			game.FinishedAt = &finishedAt.Time
+		}
+		game.BoardID = boardID.String
+		game.MetadataID = metadataID.String
+		game.CoverURL = coverURL.String
+		game.Developer = developer.String
+		if len(genres) > 0 {
+			game.Genres = genres
+		}
+		if releaseDate.Valid {
+			game.ReleaseDate = &releaseDate.Time
+		}
+		if coverUpdatedAt.Valid {
+			game.CoverUpdatedAt = &coverUpdatedAt.Time
+		}
 
 		games = append(games, &game)
 	}
@@ -115,23 +291,141 @@ func (m *GameModel) AllForUser(userID string) ([]*models.Game, error) {
 	return games, nil
 }
 
+// Viewers returns the IDs of the users, that can see the game: the user it belongs to,
+// or all members of its board, if it is on a board.
+func (m *GameModel) Viewers(gameID string) ([]string, error) {
+	rows, err := m.db.Query(`
+	SELECT g.user_id FROM GAMES g WHERE g.id = $1 AND g.board_id IS NULL
+	UNION
+	SELECT bm.user_id FROM GAMES g JOIN BOARD_MEMBERS bm ON bm.board_id = g.board_id WHERE g.id = $1`, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching viewers of game from the database: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("error while reading viewers of game from the database: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading viewers of game from the database: %w", err)
+	}
+	return userIDs, nil
+}
+
+// DeleteGame deletes the game.
+// If the user can only see the game, an ErrForbidden is returned, and if they can not see it, an ErrNoRecord.
 func (m *GameModel) DeleteGame(userID, gameID string) error {
-	if _, err := m.db.Exec(`DELETE FROM GAMES G WHERE G.id = $1 AND G.user_id = $2`, gameID, userID); err != nil {
+	return m.updateGame(userID, gameID, `DELETE FROM GAMES g WHERE g.id = $1 AND `+writableGame(2), gameID, userID)
+}
+
+// ChangeGameStatus changes the status of the game and records the change in the status history of the game.
+func (m *GameModel) ChangeGameStatus(userID, gameID string, status models.Status) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	oldStatus, err := lockGame(tx, userID, gameID)
+	if err != nil {
 		return err
 	}
+
+	if err := updateGameStatus(tx, userID, gameID, oldStatus, status); err != nil {
+		return err
+	}
+
+	// the status of a game with playthroughs is the status of its latest playthrough, so it is changed as well
+	if _, err := tx.Exec(`
+	UPDATE PLAYTHROUGHS SET status = $1
+	WHERE id = (SELECT max(id) FROM PLAYTHROUGHS WHERE game_id = $2)`, status, gameID); err != nil {
+		return fmt.Errorf("error while updating playthrough status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error while committing transaction: %w", err)
+	}
 	return nil
 }
 
-func (m *GameModel) ChangeGameStatus(userID, gameID string, status models.Status) error {
-	if _, err := m.db.Exec("UPDATE GAMES SET status = $1 WHERE id = $2 AND user_id = $3", status, gameID, userID); err != nil {
+// updateGameStatus changes the status of the game, that is locked by the given transaction,
+// and records the change in the status history of the game and in the feed of the user, that changed it.
+func updateGameStatus(tx *sql.Tx, userID, gameID string, oldStatus, status models.Status) error {
+	if _, err := tx.Exec(`
+	UPDATE GAMES SET 
+		status = $1,
+		updated_at = now(),
+		progress_updated_at = CASE WHEN status <> $1 THEN now() ELSE progress_updated_at END,
+		finished_at = CASE WHEN $1 = $3 THEN COALESCE(finished_at, now()) ELSE NULL END
+	WHERE id = $2`, status, gameID, models.StatusDone); err != nil {
 		return fmt.Errorf("error while updating game status: %w", err)
 	}
+
+	if oldStatus != status {
+		if _, err := tx.Exec(`INSERT INTO GAME_STATUS_CHANGES (game_id, user_id, from_status, to_status) VALUES ($1, $2, $3, $4)`, gameID, userID, oldStatus, status); err != nil {
+			return fmt.Errorf("error while recording game status change: %w", err)
+		}
+		if _, err := tx.Exec(`INSERT INTO EVENTS (user_id, game_id, kind, from_status, to_status) VALUES ($1, $2, $3, $4, $5)`, userID, gameID, models.EventStatusChanged, oldStatus, status); err != nil {
+			return fmt.Errorf("error while recording event: %w", err)
+		}
+	}
 	return nil
 }
 
+// ChangeGameProgress sets the progress of the game.
+// The progress is no longer computed from the checklist of the game, once it is set manually.
 func (m *GameModel) ChangeGameProgress(userID, gameID string, progress *models.GameProgress) error {
-	if _, err := m.db.Exec("UPDATE GAMES SET current_progress =  $1, final_progress = $2 WHERE id = $3 AND user_id = $4", progress.Current, progress.Final, gameID, userID); err != nil {
-		return fmt.Errorf("error while updating game progress: %w", err)
+	return m.updateGame(userID, gameID, `UPDATE GAMES g SET current_progress = $3, final_progress = $4, auto_progress = false, updated_at = now(), progress_updated_at = now() WHERE g.id = $1 AND `+writableGame(2),
+		gameID, userID, progress.Current, progress.Final)
+}
+
+// ChangeGameWishlist adds the game to the wishlist of the user, or removes it from it.
+// If the user can only see the game, an ErrForbidden is returned, and if they can not see it, an ErrNoRecord.
+func (m *GameModel) ChangeGameWishlist(userID, gameID string, wishlist bool) error {
+	return m.updateGame(userID, gameID, `UPDATE GAMES g SET wishlist = $3, updated_at = now() WHERE g.id = $1 AND `+writableGame(2), gameID, userID, wishlist)
+}
+
+// ChangeGameHidden hides the game from the public profile of the user, or shows it on it.
+// If the user can only see the game, an ErrForbidden is returned, and if they can not see it, an ErrNoRecord.
+func (m *GameModel) ChangeGameHidden(userID, gameID string, hidden bool) error {
+	return m.updateGame(userID, gameID, `UPDATE GAMES g SET hidden = $3, updated_at = now() WHERE g.id = $1 AND `+writableGame(2), gameID, userID, hidden)
+}
+
+// ChangeGameRating rates the game, or removes its rating, if rating is 0.
+// If the user can only see the game, an ErrForbidden is returned, and if they can not see it, an ErrNoRecord.
+func (m *GameModel) ChangeGameRating(userID, gameID string, rating int) error {
+	r := sql.NullInt64{Int64: int64(rating), Valid: rating != 0}
+	return m.updateGame(userID, gameID, `UPDATE GAMES g SET rating = $3, updated_at = now() WHERE g.id = $1 AND `+writableGame(2), gameID, userID, r)
+}
+
+// ChangeGameReleaseDate sets the release date of the game, or clears it, if releaseDate is nil.
+// If the user can only see the game, an ErrForbidden is returned, and if they can not see it, an ErrNoRecord.
+func (m *GameModel) ChangeGameReleaseDate(userID, gameID string, releaseDate *time.Time) error {
+	var date sql.NullString
+	if releaseDate != nil {
+		date = sql.NullString{String: releaseDate.Format("2006-01-02"), Valid: true}
+	}
+	return m.updateGame(userID, gameID, `UPDATE GAMES g SET release_date = $3, updated_at = now() WHERE g.id = $1 AND `+writableGame(2), gameID, userID, date)
+}
+
+// updateGame runs the given query, that changes the game, if the user can change it.
+// If no game is changed, an ErrForbidden or an ErrNoRecord is returned, depending on whether the user can see the game.
+func (m *GameModel) updateGame(userID, gameID, query string, args ...interface{}) error {
+	res, err := m.db.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("error while updating game: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while updating game: %w", err)
+	}
+	if rows == 0 {
+		return gameAccessError(m.db, userID, gameID)
 	}
 	return nil
 }
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// ErrUnknownFranchise is returned when the franchise of a goal does not exist, or belongs to another user
+var ErrUnknownFranchise = errors.New("such franchise does not exist")
+
+// GoalModel wraps an sql.DB connection pool.
+// It manages the goals of the users and computes their progress from the games and their status changes.
+type GoalModel struct {
+	db *sql.DB
+}
+
+func NewGoalModel(db *sql.DB) *GoalModel {
+	return &GoalModel{db: db}
+}
+
+const goalColumns = `
+		g.id,
+		g.kind,
+		g.target,
+		g.franchise_id,
+		COALESCE(f.name, ''),
+		g.starts_at,
+		g.ends_at,
+		g.achieved_at,
+		g.created_at`
+
+// InsertGoal creates the given goal and returns it with its progress.
+// If the franchise of the goal does not belong to the user, an ErrUnknownFranchise is returned.
+func (m *GoalModel) InsertGoal(goal *models.Goal, now time.Time) (*models.Goal, error) {
+	if goal.FranchiseID != "" {
+		var id string
+		if err := m.db.QueryRow(`SELECT id FROM FRANCHISES WHERE id = $1 AND user_id = $2`, goal.FranchiseID, goal.UserID).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrUnknownFranchise
+			}
+			return nil, fmt.Errorf("error while fetching franchise from the database: %w", err)
+		}
+	}
+
+	var id string
+	if err := m.db.QueryRow(`
+	INSERT INTO GOALS (user_id, kind, target, franchise_id, starts_at, ends_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id`, goal.UserID, goal.Kind, goal.Target, nullString(goal.FranchiseID), goal.StartsAt, goal.EndsAt).Scan(&id); err != nil {
+		return nil, fmt.Errorf("error while inserting goal into the database: %w", err)
+	}
+
+	return m.Goal(goal.UserID, id, now)
+}
+
+// Goals returns the goals of the user with their progress, the oldest first.
+func (m *GoalModel) Goals(userID string, now time.Time) ([]*models.Goal, error) {
+	return m.goals(userID, `g.user_id = $1`, now)
+}
+
+// Goal returns the given goal of the user with its progress.
+// If the user does not have such goal, an ErrNoRecord is returned.
+func (m *GoalModel) Goal(userID, goalID string, now time.Time) (*models.Goal, error) {
+	goals, err := m.goals(userID, `g.user_id = $1 AND g.id = $2`, now, goalID)
+	if err != nil {
+		return nil, err
+	}
+	if len(goals) == 0 {
+		return nil, ErrNoRecord
+	}
+	return goals[0], nil
+}
+
+// DeleteGoal deletes the given goal of the user.
+// If the user does not have such goal, an ErrNoRecord is returned.
+func (m *GoalModel) DeleteGoal(userID, goalID string) error {
+	res, err := m.db.Exec(`DELETE FROM GOALS WHERE id = $1 AND user_id = $2`, goalID, userID)
+	if err != nil {
+		return fmt.Errorf("error while deleting goal: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while deleting goal: %w", err)
+	}
+	if n == 0 {
+		return ErrNoRecord
+	}
+	return nil
+}
+
+// TrackGoals marks the goals of the user, that are reached now, as achieved,
+// so that they stay achieved, even if e.g. the backlog grows again later.
+// It should be called after the status of a game of the user changes.
+func (m *GoalModel) TrackGoals(userID string, now time.Time) error {
+	goals, err := m.goals(userID, `g.user_id = $1 AND g.achieved_at IS NULL`, now)
+	if err != nil {
+		return err
+	}
+
+	for _, goal := range goals {
+		if goal.Status != models.GoalAchieved {
+			continue
+		}
+		if _, err := m.db.Exec(`UPDATE GOALS SET achieved_at = $1 WHERE id = $2 AND achieved_at IS NULL`, now, goal.ID); err != nil {
+			return fmt.Errorf("error while marking goal %s as achieved: %w", goal.ID, err)
+		}
+	}
+	return nil
+}
+
+// goals returns the goals of the user, that match the given condition, with their progress.
+// The ID of the user is the first argument of the condition, and the given args follow it.
+func (m *GoalModel) goals(userID string, condition string, now time.Time, args ...interface{}) ([]*models.Goal, error) {
+	rows, err := m.db.Query(`
+	SELECT `+goalColumns+`
+	FROM GOALS g
+		LEFT JOIN FRANCHISES f ON f.id = g.franchise_id
+	WHERE `+condition+`
+	ORDER BY g.id`, append([]interface{}{userID}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching goals from the database: %w", err)
+	}
+	defer rows.Close()
+
+	goals := []*models.Goal{}
+	for rows.Next() {
+		var goal models.Goal
+		var franchiseID sql.NullString
+		var startsAt, endsAt, achievedAt, createdAt sql.NullTime
+		if err := rows.Scan(&goal.ID, &goal.Kind, &goal.Target, &franchiseID, &goal.Franchise, &startsAt, &endsAt, &achievedAt, &createdAt); err != nil {
+			return nil, fmt.Errorf("error while reading goals from the database: %w", err)
+		}
+		goal.FranchiseID = franchiseID.String
+		if startsAt.Valid {
+			goal.StartsAt = &startsAt.Time
+		}
+		if endsAt.Valid {
+			goal.EndsAt = &endsAt.Time
+		}
+		if achievedAt.Valid {
+			goal.AchievedAt = &achievedAt.Time
+		}
+		if createdAt.Valid {
+			goal.CreatedAt = &createdAt.Time
+		}
+		goals = append(goals, &goal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading goals from the database: %w", err)
+	}
+	rows.Close()
+
+	for _, goal := range goals {
+		if err := m.progress(userID, goal); err != nil {
+			return nil, err
+		}
+		goal.Evaluate(now)
+	}
+	return goals, nil
+}
+
+// progress computes the current progress of the goal.
+// Only the games, that belong only to the user, are counted, and not the ones on shared boards or on the wishlist.
+func (m *GoalModel) progress(userID string, goal *models.Goal) error {
+	var err error
+	switch goal.Kind {
+	case models.GoalFinishGames:
+		// the period includes its last day, and a game finished more than once is counted once
+		err = m.db.QueryRow(`
+		SELECT COUNT(DISTINCT c.game_id)
+		FROM GAME_STATUS_CHANGES c
+			JOIN GAMES g ON g.id = c.game_id
+		WHERE c.user_id = $1 AND g.board_id IS NULL AND c.to_status = $2
+			AND c.changed_at >= $3 AND c.changed_at < $4`,
+			userID, models.StatusDone, goal.StartsAt, goal.EndsAt.AddDate(0, 0, 1)).Scan(&goal.Current)
+	case models.GoalFinishFranchise:
+		// the franchise includes its sub-series
+		err = m.db.QueryRow(`
+		WITH RECURSIVE franchises AS (
+			SELECT id FROM FRANCHISES WHERE id = $2 AND user_id = $1
+			UNION
+			SELECT f.id FROM FRANCHISES f JOIN franchises p ON f.parent_id = p.id
+		)
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE g.status = $3)
+		FROM GAMES g
+		WHERE g.franchise_id IN (SELECT id FROM franchises) AND g.user_id = $1 AND g.board_id IS NULL AND NOT g.wishlist`,
+			userID, goal.FranchiseID, models.StatusDone).Scan(&goal.Target, &goal.Current)
+	case models.GoalBacklog:
+		err = m.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM GAMES g
+		WHERE g.user_id = $1 AND g.board_id IS NULL AND NOT g.wishlist AND g.status = $2`,
+			userID, models.StatusTODO).Scan(&goal.Current)
+	}
+	if err != nil {
+		return fmt.Errorf("error while computing the progress of goal %s: %w", goal.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MetadataCacheModel wraps an sql.DB connection pool.
+// It stores the responses of the metadata providers.
+type MetadataCacheModel struct {
+	db *sql.DB
+}
+
+func NewMetadataCacheModel(db *sql.DB) *MetadataCacheModel {
+	return &MetadataCacheModel{db: db}
+}
+
+// Get returns the cached data of the given provider for the given key.
+// If there is no such data, or it is older than maxAge, an ErrNoRecord is returned.
+func (m *MetadataCacheModel) Get(provider, key string, maxAge time.Duration) ([]byte, error) {
+	var data []byte
+	if err := m.db.QueryRow(`
+	SELECT data FROM GAME_METADATA_CACHE
+	WHERE provider = $1 AND key = $2 AND fetched_at > $3`, provider, key, time.Now().Add(-maxAge)).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching metadata from the database: %w", err)
+	}
+	return data, nil
+}
+
+// Put stores the data of the given provider for the given key, replacing the previous data, if any.
+func (m *MetadataCacheModel) Put(provider, key string, data []byte) error {
+	if _, err := m.db.Exec(`
+	INSERT INTO GAME_METADATA_CACHE (provider, key, data, fetched_at) VALUES ($1, $2, $3, now())
+	ON CONFLICT (provider, key) DO UPDATE SET data = EXCLUDED.data, fetched_at = EXCLUDED.fetched_at`, provider, key, data); err != nil {
+		return fmt.Errorf("error while saving metadata into the database: %w", err)
+	}
+	return nil
+}
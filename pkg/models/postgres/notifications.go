@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// NotificationModel wraps an sql.DB connection pool.
+// It manages the notification preferences and the in-app notifications of the users,
+// and finds the stale games, that the users should be reminded about.
+type NotificationModel struct {
+	db *sql.DB
+}
+
+func NewNotificationModel(db *sql.DB) *NotificationModel {
+	return &NotificationModel{db: db}
+}
+
+// preferenceColumns are the preferences of the user, or the defaults of NOTIFICATION_PREFERENCES, if the user has not set any
+const preferenceColumns = `
+		COALESCE(np.stale_reminders, true),
+		COALESCE(np.stale_after_days, 30),
+		COALESCE(np.in_app, true),
+		COALESCE(np.email, false),
+		COALESCE(np.webhook_url, '')`
+
+// NotificationPreferences returns the notification preferences of the user, or the defaults, if the user has not set any.
+func (m *NotificationModel) NotificationPreferences(userID string) (*models.NotificationPreferences, error) {
+	var prefs models.NotificationPreferences
+	if err := m.db.QueryRow(`
+	SELECT `+preferenceColumns+`
+	FROM USERS u
+		LEFT JOIN NOTIFICATION_PREFERENCES np ON np.user_id = u.id
+	WHERE u.id = $1`, userID).Scan(&prefs.StaleReminders, &prefs.StaleAfterDays, &prefs.InApp, &prefs.Email, &prefs.WebhookURL); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching notification preferences from the database: %w", err)
+	}
+	return &prefs, nil
+}
+
+// SetNotificationPreferences replaces the notification preferences of the user.
+func (m *NotificationModel) SetNotificationPreferences(userID string, prefs *models.NotificationPreferences) error {
+	if _, err := m.db.Exec(`
+	INSERT INTO NOTIFICATION_PREFERENCES (user_id, stale_reminders, stale_after_days, in_app, email, webhook_url)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (user_id) DO UPDATE SET
+		stale_reminders = EXCLUDED.stale_reminders,
+		stale_after_days = EXCLUDED.stale_after_days,
+		in_app = EXCLUDED.in_app,
+		email = EXCLUDED.email,
+		webhook_url = EXCLUDED.webhook_url`,
+		userID, prefs.StaleReminders, prefs.StaleAfterDays, prefs.InApp, prefs.Email, prefs.WebhookURL); err != nil {
+		return fmt.Errorf("error while storing notification preferences: %w", err)
+	}
+	return nil
+}
+
+// StaleGames returns the games In Progress, whose status or progress did not change for longer than their owners prefer,
+// and whose owners were not reminded about them during that time.
+// Only the games, that belong only to the user, are returned, and not the ones on shared boards or on the wishlist.
+func (m *NotificationModel) StaleGames(now time.Time) ([]*models.StaleGame, error) {
+	rows, err := m.db.Query(`
+	SELECT g.id, g.name, g.progress_updated_at, u.id, u.username, u.email, `+preferenceColumns+`
+	FROM GAMES g
+		JOIN USERS u ON u.id = g.user_id
+		LEFT JOIN NOTIFICATION_PREFERENCES np ON np.user_id = u.id
+	WHERE g.status = $1 AND g.board_id IS NULL AND NOT g.wishlist
+		AND COALESCE(np.stale_reminders, true)
+		AND g.progress_updated_at < $2 - make_interval(days => COALESCE(np.stale_after_days, 30))
+		AND (g.stale_reminded_at IS NULL OR g.stale_reminded_at < $2 - make_interval(days => COALESCE(np.stale_after_days, 30)))
+	ORDER BY g.id`, models.StatusInProgress, now)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching stale games from the database: %w", err)
+	}
+	defer rows.Close()
+
+	games := []*models.StaleGame{}
+	for rows.Next() {
+		game := &models.StaleGame{Game: &models.Game{Status: models.StatusInProgress}, User: &models.User{}, Preferences: &models.NotificationPreferences{}}
+		var progressUpdatedAt time.Time
+		prefs := game.Preferences
+		if err := rows.Scan(&game.Game.ID, &game.Game.Name, &progressUpdatedAt, &game.User.ID, &game.User.Username, &game.User.Email,
+			&prefs.StaleReminders, &prefs.StaleAfterDays, &prefs.InApp, &prefs.Email, &prefs.WebhookURL); err != nil {
+			return nil, fmt.Errorf("error while reading stale games from the database: %w", err)
+		}
+		game.ProgressUpdatedAt = &progressUpdatedAt
+		game.Game.UserID = game.User.ID
+		games = append(games, game)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading stale games from the database: %w", err)
+	}
+	return games, nil
+}
+
+// MarkReminded records that the owner of the game was reminded about it, so that they are not reminded again too soon.
+func (m *NotificationModel) MarkReminded(gameID string, now time.Time) error {
+	if _, err := m.db.Exec(`UPDATE GAMES SET stale_reminded_at = $2 WHERE id = $1`, gameID, now); err != nil {
+		return fmt.Errorf("error while marking game %s as reminded: %w", gameID, err)
+	}
+	return nil
+}
+
+// InsertNotification stores the notification in the inbox of its user.
+func (m *NotificationModel) InsertNotification(notification *models.Notification) (*models.Notification, error) {
+	n := *notification
+	if err := m.db.QueryRow(`
+	INSERT INTO NOTIFICATIONS (user_id, kind, game_id, message)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at`, n.UserID, n.Kind, nullString(n.GameID), n.Message).Scan(&n.ID, &n.CreatedAt); err != nil {
+		return nil, fmt.Errorf("error while inserting notification into the database: %w", err)
+	}
+	return &n, nil
+}
+
+// Notifications returns the latest notifications of the user, the newest first, and the number of all unread ones.
+func (m *NotificationModel) Notifications(userID string, limit int) ([]*models.Notification, int, error) {
+	var unread int
+	if err := m.db.QueryRow(`SELECT COUNT(*) FROM NOTIFICATIONS WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&unread); err != nil {
+		return nil, 0, fmt.Errorf("error while counting unread notifications: %w", err)
+	}
+
+	rows, err := m.db.Query(`
+	SELECT n.id, n.kind, n.game_id, COALESCE(g.name, ''), n.message, n.read_at, n.created_at
+	FROM NOTIFICATIONS n
+		LEFT JOIN GAMES g ON g.id = n.game_id
+	WHERE n.user_id = $1
+	ORDER BY n.id DESC
+	LIMIT $2`, userID, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error while fetching notifications from the database: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []*models.Notification{}
+	for rows.Next() {
+		var n models.Notification
+		var gameID sql.NullString
+		var readAt sql.NullTime
+		if err := rows.Scan(&n.ID, &n.Kind, &gameID, &n.GameName, &n.Message, &readAt, &n.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("error while reading notifications from the database: %w", err)
+		}
+		n.GameID = gameID.String
+		if readAt.Valid {
+			n.ReadAt = &readAt.Time
+		}
+		notifications = append(notifications, &n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error while reading notifications from the database: %w", err)
+	}
+	return notifications, unread, nil
+}
+
+// MarkNotificationRead marks the given notification of the user as read.
+// If the user does not have such notification, an ErrNoRecord is returned.
+func (m *NotificationModel) MarkNotificationRead(userID, notificationID string) error {
+	res, err := m.db.Exec(`UPDATE NOTIFICATIONS SET read_at = COALESCE(read_at, now()) WHERE id = $1 AND user_id = $2`, notificationID, userID)
+	if err != nil {
+		return fmt.Errorf("error while marking notification as read: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while marking notification as read: %w", err)
+	}
+	if n == 0 {
+		return ErrNoRecord
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks all notifications of the user as read.
+func (m *NotificationModel) MarkAllNotificationsRead(userID string) error {
+	if _, err := m.db.Exec(`UPDATE NOTIFICATIONS SET read_at = now() WHERE user_id = $1 AND read_at IS NULL`, userID); err != nil {
+		return fmt.Errorf("error while marking notifications as read: %w", err)
+	}
+	return nil
+}
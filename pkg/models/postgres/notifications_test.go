@@ -0,0 +1,52 @@
+//go:build integration_tests
+
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleGamesByProgress(t *testing.T) {
+	db := newTestDB(t)
+
+	// the names are unique, so that the test can run more than once on the same database
+	suffix := time.Now().Format("20060102150405.000000000")
+	user, err := postgres.NewUserModel(db).Insert(&models.User{Username: "stale-" + suffix, Email: fmt.Sprintf("stale-%s@example.com", suffix), Password: "password"})
+	require.NoError(t, err)
+
+	gameModel := postgres.NewGameModel(db)
+	game, err := gameModel.Insert(&models.Game{Name: "Hades", UserID: user.ID})
+	require.NoError(t, err)
+	require.NoError(t, gameModel.ChangeGameStatus(user.ID, game.ID, models.StatusInProgress))
+
+	// the game was last played two months ago
+	_, err = db.Exec(`UPDATE GAMES SET updated_at = now() - interval '60 days', progress_updated_at = now() - interval '60 days' WHERE id = $1`, game.ID)
+	require.NoError(t, err)
+
+	notificationModel := postgres.NewNotificationModel(db)
+	isStale := func() bool {
+		games, err := notificationModel.StaleGames(time.Now())
+		require.NoError(t, err)
+		for _, g := range games {
+			if g.Game.ID == game.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the rating and the release date are not progress, so the game stays stale
+	require.NoError(t, gameModel.ChangeGameRating(user.ID, game.ID, 4))
+	require.NoError(t, gameModel.ChangeGameReleaseDate(user.ID, game.ID, nil))
+	assert.True(t, isStale())
+
+	require.NoError(t, gameModel.ChangeGameProgress(user.ID, game.ID, &models.GameProgress{Current: 10, Final: 100}))
+	assert.False(t, isStale())
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// PlaythroughModel wraps an sql.DB connection pool.
+// It manages the playthroughs of the games and keeps the status of each game in sync with its latest playthrough.
+type PlaythroughModel struct {
+	db *sql.DB
+}
+
+func NewPlaythroughModel(db *sql.DB) *PlaythroughModel {
+	return &PlaythroughModel{db: db}
+}
+
+// Playthroughs returns the playthroughs of the given game of the user, the oldest first.
+// If the user does not have such game, an ErrNoRecord is returned.
+func (m *PlaythroughModel) Playthroughs(userID, gameID string) ([]*models.Playthrough, error) {
+	var id string
+	if err := m.db.QueryRow(`SELECT g.id FROM GAMES g WHERE g.id = $1 AND `+readableGame(2), gameID, userID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching game from the database: %w", err)
+	}
+
+	rows, err := m.db.Query(`
+	SELECT id, game_id, name, status, current_progress, final_progress, started_at, finished_at, notes
+	FROM PLAYTHROUGHS
+	WHERE game_id = $1
+	ORDER BY id`, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching playthroughs from the database: %w", err)
+	}
+	defer rows.Close()
+
+	playthroughs := []*models.Playthrough{}
+	for rows.Next() {
+		p := models.Playthrough{Progress: &models.GameProgress{}}
+		var startedAt, finishedAt sql.NullTime
+		if err := rows.Scan(&p.ID, &p.GameID, &p.Name, &p.Status, &p.Progress.Current, &p.Progress.Final, &startedAt, &finishedAt, &p.Notes); err != nil {
+			return nil, fmt.Errorf("error while reading playthroughs from the database: %w", err)
+		}
+		if startedAt.Valid {
+			p.StartedAt = &startedAt.Time
+		}
+		if finishedAt.Valid {
+			p.FinishedAt = &finishedAt.Time
+		}
+		playthroughs = append(playthroughs, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading playthroughs from the database: %w", err)
+	}
+
+	return playthroughs, nil
+}
+
+// InsertPlaythrough adds the playthrough to its game. It becomes the latest playthrough, so the game gets its status.
+// The new status of the game is returned, if it changed, or an empty status otherwise.
+// If the user does not have such game, an ErrNoRecord is returned.
+func (m *PlaythroughModel) InsertPlaythrough(userID string, playthrough *models.Playthrough) (*models.Playthrough, models.Status, error) {
+	p := *playthrough
+	status, err := m.change(userID, p.GameID, func(tx *sql.Tx) error {
+		if err := tx.QueryRow(`
+		INSERT INTO PLAYTHROUGHS (game_id, name, status, current_progress, final_progress, started_at, finished_at, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`, p.GameID, p.Name, p.Status, p.Progress.Current, p.Progress.Final, p.StartedAt, p.FinishedAt, p.Notes).Scan(&p.ID); err != nil {
+			return fmt.Errorf("error while inserting playthrough: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return &p, status, nil
+}
+
+// UpdatePlaythrough replaces the details of the playthrough. If it is the latest playthrough, the game gets its status.
+// The new status of the game is returned, if it changed, or an empty status otherwise.
+// If the user does not have such game, or the game does not have such playthrough, an ErrNoRecord is returned.
+func (m *PlaythroughModel) UpdatePlaythrough(userID string, playthrough *models.Playthrough) (*models.Playthrough, models.Status, error) {
+	p := *playthrough
+	status, err := m.change(userID, p.GameID, func(tx *sql.Tx) error {
+		return execPlaythrough(tx, `
+		UPDATE PLAYTHROUGHS SET
+			name = $1,
+			status = $2,
+			current_progress = $3,
+			final_progress = $4,
+			started_at = $5,
+			finished_at = $6,
+			notes = $7
+		WHERE id = $8 AND game_id = $9`, p.Name, p.Status, p.Progress.Current, p.Progress.Final, p.StartedAt, p.FinishedAt, p.Notes, p.ID, p.GameID)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return &p, status, nil
+}
+
+// DeletePlaythrough deletes the playthrough. The game gets the status of the playthrough before it, if there is such.
+// The new status of the game is returned, if it changed, or an empty status otherwise.
+// If the user does not have such game, or the game does not have such playthrough, an ErrNoRecord is returned.
+func (m *PlaythroughModel) DeletePlaythrough(userID, gameID, playthroughID string) (models.Status, error) {
+	return m.change(userID, gameID, func(tx *sql.Tx) error {
+		return execPlaythrough(tx, `DELETE FROM PLAYTHROUGHS WHERE id = $1 AND game_id = $2`, playthroughID, gameID)
+	})
+}
+
+// change runs fn in a transaction, in which the game is locked,
+// and then sets the status of the game to the status of its latest playthrough.
+// It returns the new status of the game, if it changed, or an empty status otherwise.
+// If the user can only see the game, an ErrForbidden is returned.
+func (m *PlaythroughModel) change(userID, gameID string, fn func(tx *sql.Tx) error) (models.Status, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return "", fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	oldStatus, err := lockGame(tx, userID, gameID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := fn(tx); err != nil {
+		return "", err
+	}
+
+	var status models.Status
+	err = tx.QueryRow(`SELECT status FROM PLAYTHROUGHS WHERE game_id = $1 ORDER BY id DESC LIMIT 1`, gameID).Scan(&status)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// the last playthrough is deleted, so the game keeps the status it has
+		status = ""
+	case err != nil:
+		return "", fmt.Errorf("error while fetching latest playthrough: %w", err)
+	case status == oldStatus:
+		status = ""
+	default:
+		if err := updateGameStatus(tx, userID, gameID, oldStatus, status); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("error while committing transaction: %w", err)
+	}
+	return status, nil
+}
+
+func execPlaythrough(tx *sql.Tx, query string, args ...interface{}) error {
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("error while updating playthrough: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while updating playthrough: %w", err)
+	}
+	if rows == 0 {
+		return ErrNoRecord
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+// ErrFollowSelf is returned when a user tries to follow themselves
+var ErrFollowSelf = errors.New("users can not follow themselves")
+
+// SocialModel wraps an sql.DB connection pool.
+// It manages the public profiles of the users and who follows whom.
+//
+// A profile can be seen by its owner, and by the other users only if it is public.
+// Otherwise, it is treated as if it does not exist, so that the private profiles are not revealed.
+type SocialModel struct {
+	db *sql.DB
+}
+
+func NewSocialModel(db *sql.DB) *SocialModel {
+	return &SocialModel{db: db}
+}
+
+// profileColumns are the columns of the profile of the user u, as seen by the user $1, in the order in which scanProfile reads them
+const profileColumns = `
+		u.username,
+		u.public,
+		EXISTS (SELECT 1 FROM FOLLOWS f WHERE f.follower_id = $1 AND f.followee_id = u.id),
+		(SELECT count(*) FROM FOLLOWS f WHERE f.followee_id = u.id),
+		(SELECT count(*) FROM FOLLOWS f WHERE f.follower_id = u.id)`
+
+// Profile returns the profile of the user with the given username, as seen by the viewer.
+// If there is no such user, or the profile is private and it is not of the viewer, an ErrNoRecord is returned.
+func (m *SocialModel) Profile(viewerID, username string) (*models.Profile, error) {
+	profile, err := scanProfile(m.db.QueryRow(`
+	SELECT `+profileColumns+`
+	FROM USERS u
+	WHERE u.username = $2 AND (u.public OR u.id = $1)`, viewerID, username))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching profile from the database: %w", err)
+	}
+	return profile, nil
+}
+
+// SetProfilePublic makes the profile of the user public or private.
+// The followers of a profile, that becomes private, are kept, but they can not see it until it becomes public again.
+func (m *SocialModel) SetProfilePublic(userID string, public bool) error {
+	if _, err := m.db.Exec(`UPDATE USERS SET public = $1 WHERE id = $2`, public, userID); err != nil {
+		return fmt.Errorf("error while updating profile: %w", err)
+	}
+	return nil
+}
+
+// Follow makes the follower follow the user with the given username. Following a user twice is not an error.
+// If there is no such user, or the profile is private, an ErrNoRecord is returned.
+func (m *SocialModel) Follow(followerID, username string) error {
+	var followeeID string
+	if err := m.db.QueryRow(`SELECT id FROM USERS WHERE username = $1 AND (public OR id = $2)`, username, followerID).Scan(&followeeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoRecord
+		}
+		return fmt.Errorf("error while fetching user from the database: %w", err)
+	}
+	if followeeID == followerID {
+		return ErrFollowSelf
+	}
+
+	if _, err := m.db.Exec(`INSERT INTO FOLLOWS (follower_id, followee_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, followerID, followeeID); err != nil {
+		return fmt.Errorf("error while following user: %w", err)
+	}
+	return nil
+}
+
+// Unfollow makes the follower stop following the user with the given username.
+// Unfollowing a user, that is not followed, is not an error.
+func (m *SocialModel) Unfollow(followerID, username string) error {
+	if _, err := m.db.Exec(`
+	DELETE FROM FOLLOWS f
+	USING USERS u
+	WHERE f.followee_id = u.id AND u.username = $1 AND f.follower_id = $2`, username, followerID); err != nil {
+		return fmt.Errorf("error while unfollowing user: %w", err)
+	}
+	return nil
+}
+
+// Following returns the profiles, that the user follows, sorted by username.
+// The private ones are returned as well, so that the user can unfollow them.
+func (m *SocialModel) Following(userID string) ([]*models.Profile, error) {
+	rows, err := m.db.Query(`
+	SELECT `+profileColumns+`
+	FROM FOLLOWS fl
+		JOIN USERS u ON u.id = fl.followee_id
+	WHERE fl.follower_id = $1
+	ORDER BY u.username`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching followed profiles from the database: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []*models.Profile{}
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading followed profiles from the database: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading followed profiles from the database: %w", err)
+	}
+	return profiles, nil
+}
+
+// ProfileGames returns the games of the user with the given username, as seen by the viewer.
+// The owner of the profile sees all of their games, and the rest see only the ones that are not hidden.
+// If there is no such user, or the profile is private and it is not of the viewer, an ErrNoRecord is returned.
+func (m *SocialModel) ProfileGames(viewerID, username string) ([]*models.Game, error) {
+	var userID string
+	if err := m.db.QueryRow(`SELECT id FROM USERS WHERE username = $1 AND (public OR id = $2)`, username, viewerID).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, fmt.Errorf("error while fetching user from the database: %w", err)
+	}
+
+	rows, err := m.db.Query(`
+	SELECT `+gameColumns+`
+	FROM GAMES g
+		LEFT JOIN FRANCHISES f ON f.id = g.franchise_id
+	WHERE g.user_id = $1 AND g.board_id IS NULL AND (g.user_id = $2 OR NOT g.hidden)
+	ORDER BY g.name`, userID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching games from the database: %w", err)
+	}
+	defer rows.Close()
+
+	return scanGames(rows)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProfile(row scanner) (*models.Profile, error) {
+	var profile models.Profile
+	if err := row.Scan(&profile.Username, &profile.Public, &profile.Following, &profile.Followers, &profile.Follows); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
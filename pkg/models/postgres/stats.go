@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+)
+
+var (
+	// topFranchisesLimit is the number of franchises returned in the stats
+	topFranchisesLimit = 5
+	// recentlyUpdatedLimit is the number of recently updated games returned in the stats
+	recentlyUpdatedLimit = 5
+)
+
+// StatsModel wraps an sql.DB connection pool.
+type StatsModel struct {
+	db *sql.DB
+}
+
+func NewStatsModel(db *sql.DB) *StatsModel {
+	return &StatsModel{db: db}
+}
+
+// StatsForUser computes the statistics of the backlog of the given user.
+// Only the games of the user are counted, not the ones on the boards they are a member of.
+// The games finished this month and this year are counted relative to now.
+func (m *StatsModel) StatsForUser(userID string, now time.Time) (*models.Stats, error) {
+	stats := &models.Stats{
+		StatusCounts: map[models.Status]int{},
+	}
+	for _, status := range models.AllStatuses {
+		stats.StatusCounts[status] = 0
+	}
+
+	if err := m.countByStatus(userID, stats); err != nil {
+		return nil, err
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	yearStart := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	var averageProgress sql.NullFloat64
+	if err := m.db.QueryRow(`
+	SELECT
+		COUNT(*) FILTER (WHERE g.finished_at >= $2),
+		COUNT(*) FILTER (WHERE g.finished_at >= $3),
+		AVG(LEAST(g.current_progress, g.final_progress) * 100.0 / g.final_progress) FILTER (WHERE g.final_progress > 0)
+	FROM GAMES g
+	WHERE g.user_id = $1 AND g.board_id IS NULL`, userID, monthStart, yearStart).Scan(&stats.FinishedThisMonth, &stats.FinishedThisYear, &averageProgress); err != nil {
+		return nil, fmt.Errorf("error while fetching progress stats from the database: %w", err)
+	}
+	stats.AverageProgress = averageProgress.Float64
+
+	franchises, err := m.topFranchises(userID)
+	if err != nil {
+		return nil, err
+	}
+	stats.TopFranchises = franchises
+
+	if stats.RecentlyUpdated, err = m.games(userID, `ORDER BY g.updated_at DESC LIMIT $2`, recentlyUpdatedLimit); err != nil {
+		return nil, err
+	}
+	if stats.CurrentlyPlaying, err = m.games(userID, `AND g.status = $2 ORDER BY g.updated_at DESC`, models.StatusInProgress); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (m *StatsModel) countByStatus(userID string, stats *models.Stats) error {
+	rows, err := m.db.Query(`SELECT g.status, COUNT(*) FROM GAMES g WHERE g.user_id = $1 AND g.board_id IS NULL GROUP BY g.status`, userID)
+	if err != nil {
+		return fmt.Errorf("error while fetching status stats from the database: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status models.Status
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return fmt.Errorf("error while reading status stats from the database: %w", err)
+		}
+		stats.StatusCounts[status] = count
+		stats.Total += count
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error while reading status stats from the database: %w", err)
+	}
+	return nil
+}
+
+func (m *StatsModel) topFranchises(userID string) ([]*models.FranchiseStats, error) {
+	rows, err := m.db.Query(`
+	SELECT
+		f.id,
+		f.name,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE g.status = $2)
+	FROM GAMES g
+		JOIN FRANCHISES f ON f.id = g.franchise_id
+	WHERE g.user_id = $1 AND g.board_id IS NULL
+	GROUP BY f.id, f.name
+	ORDER BY COUNT(*) DESC, f.name
+	LIMIT $3`, userID, models.StatusDone, topFranchisesLimit)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching franchise stats from the database: %w", err)
+	}
+	defer rows.Close()
+
+	franchises := []*models.FranchiseStats{}
+	for rows.Next() {
+		var f models.FranchiseStats
+		if err := rows.Scan(&f.ID, &f.Name, &f.Games, &f.Finished); err != nil {
+			return nil, fmt.Errorf("error while reading franchise stats from the database: %w", err)
+		}
+		franchises = append(franchises, &f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading franchise stats from the database: %w", err)
+	}
+	return franchises, nil
+}
+
+// games returns the games of the user, that are not on a board, filtered and ordered by the given clause.
+// The clause can use a single parameter - $2.
+func (m *StatsModel) games(userID string, clause string, arg interface{}) ([]*models.Game, error) {
+	rows, err := m.db.Query(`
+	SELECT
+		g.id,
+		g.name,
+		g.franchise_id,
+		f.name,
+		g.status,
+		g.current_progress,
+		g.final_progress,
+		g.updated_at
+	FROM GAMES g
+		LEFT JOIN FRANCHISES f ON f.id = g.franchise_id
+	WHERE g.user_id = $1 AND g.board_id IS NULL `+clause, userID, arg)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching games from the database: %w", err)
+	}
+	defer rows.Close()
+
+	games := []*models.Game{}
+	for rows.Next() {
+		game := models.Game{Progress: &models.GameProgress{}}
+
+		var fID, fName sql.NullString
+		var updatedAt time.Time
+		if err := rows.Scan(&game.ID, &game.Name, &fID, &fName, &game.Status, &game.Progress.Current, &game.Progress.Final, &updatedAt); err != nil {
+			return nil, fmt.Errorf("error while reading games from the database: %w", err)
+		}
+		game.FranchiseID = fID.String
+		game.Franchise = fName.String
+		game.UpdatedAt = &updatedAt
+
+		games = append(games, &game)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading games from the database: %w", err)
+	}
+	return games, nil
+}
+
+// YearReport computes the report of the given year for the given user, from the status history of their games.
+// The time a game spent In Progress is clipped to the year, and if the game is still In Progress, it is counted until now.
+// Like the stats, the report only covers the games of the user, that are not on a board.
+func (m *StatsModel) YearReport(userID string, year int, now time.Time) (*models.YearReport, error) {
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+
+	rows, err := m.db.Query(`
+	WITH changes AS (
+		SELECT
+			c.game_id,
+			c.to_status,
+			c.changed_at,
+			COALESCE(LEAD(c.changed_at) OVER (PARTITION BY c.game_id ORDER BY c.changed_at, c.id), $4) AS ended_at
+		FROM GAME_STATUS_CHANGES c
+		WHERE c.user_id = $1 AND c.changed_at < $3
+	)
+	SELECT * FROM (
+		SELECT
+			g.id,
+			g.name,
+			COALESCE(BOOL_OR(ch.to_status = $5 AND ch.changed_at >= $2), false) AS started,
+			COALESCE(BOOL_OR(ch.to_status = $6 AND ch.changed_at >= $2), false) AS finished,
+			COALESCE(BOOL_OR(ch.to_status = $7 AND ch.changed_at >= $2), false) AS dropped,
+			COALESCE(SUM(EXTRACT(EPOCH FROM LEAST(ch.ended_at, $3) - GREATEST(ch.changed_at, $2))) FILTER (WHERE ch.to_status = $5 AND ch.ended_at > $2), 0) AS seconds
+		FROM changes ch
+			JOIN GAMES g ON g.id = ch.game_id
+		WHERE g.board_id IS NULL
+		GROUP BY g.id, g.name
+	) r
+	WHERE r.started OR r.finished OR r.dropped OR r.seconds > 0
+	ORDER BY r.seconds DESC, r.name`, userID, yearStart, yearEnd, now, models.StatusInProgress, models.StatusDone, models.StatusDropped)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching year report from the database: %w", err)
+	}
+	defer rows.Close()
+
+	report := &models.YearReport{
+		Year:  year,
+		Games: []*models.YearReportGame{},
+	}
+	for rows.Next() {
+		var game models.YearReportGame
+		var seconds float64
+		if err := rows.Scan(&game.ID, &game.Name, &game.Started, &game.Finished, &game.Dropped, &seconds); err != nil {
+			return nil, fmt.Errorf("error while reading year report from the database: %w", err)
+		}
+		game.Hours = seconds / time.Hour.Seconds()
+
+		report.Games = append(report.Games, &game)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading year report from the database: %w", err)
+	}
+
+	report.Summarize()
+	return report, nil
+}
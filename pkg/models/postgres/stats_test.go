@@ -0,0 +1,75 @@
+//go:build integration_tests
+
+package postgres_test
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	_ "github.com/lib/pq"
+	"github.com/pressly/goose"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDB connects to the database from GIRA_TEST_DB, e.g.
+// GIRA_TEST_DB='host=localhost port=5432 user=gira dbname=gira_test password=password sslmode=disable',
+// and runs the migrations on it. The test is skipped, if GIRA_TEST_DB is not set.
+func newTestDB(t *testing.T) *sql.DB {
+	dsn := os.Getenv("GIRA_TEST_DB")
+	if dsn == "" {
+		t.Skip("GIRA_TEST_DB is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	require.NoError(t, goose.Up(db, "../../../sql"))
+	return db
+}
+
+func TestStatsForUserWithoutBoardGames(t *testing.T) {
+	db := newTestDB(t)
+
+	// the names are unique, so that the test can run more than once on the same database
+	suffix := time.Now().Format("20060102150405.000000000")
+	user, err := postgres.NewUserModel(db).Insert(&models.User{Username: "stats-" + suffix, Email: fmt.Sprintf("stats-%s@example.com", suffix), Password: "password"})
+	require.NoError(t, err)
+
+	gameModel := postgres.NewGameModel(db)
+	_, err = gameModel.InsertMany(user.ID, []string{"Hades"}, []*models.Game{{Name: "Hades II", Franchise: "Hades", Status: models.StatusInProgress}})
+	require.NoError(t, err)
+
+	// the game on the board is finished by the user, but it belongs to the board, so it is not in the stats of the user
+	board, err := postgres.NewBoardModel(db).CreateBoard(user.ID, "Co-op")
+	require.NoError(t, err)
+	boardGame, err := gameModel.Insert(&models.Game{Name: "It Takes Two", UserID: user.ID, BoardID: board.ID})
+	require.NoError(t, err)
+	require.NoError(t, gameModel.ChangeGameStatus(user.ID, boardGame.ID, models.StatusDone))
+
+	stats, err := postgres.NewStatsModel(db).StatsForUser(user.ID, time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, stats.Total)
+	assert.Equal(t, 1, stats.StatusCounts[models.StatusInProgress])
+	assert.Equal(t, 0, stats.StatusCounts[models.StatusDone])
+	assert.Equal(t, 0, stats.FinishedThisYear)
+	require.Len(t, stats.TopFranchises, 1)
+	assert.Equal(t, "Hades", stats.TopFranchises[0].Name)
+	require.Len(t, stats.RecentlyUpdated, 1)
+	assert.Equal(t, "Hades II", stats.RecentlyUpdated[0].Name)
+	require.Len(t, stats.CurrentlyPlaying, 1)
+	assert.Equal(t, "Hades II", stats.CurrentlyPlaying[0].Name)
+
+	report, err := postgres.NewStatsModel(db).YearReport(user.ID, time.Now().Year(), time.Now())
+	require.NoError(t, err)
+	for _, game := range report.Games {
+		assert.NotEqual(t, boardGame.ID, game.ID, "the game on the board should not be in the report")
+	}
+}
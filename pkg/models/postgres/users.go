@@ -15,8 +15,12 @@ var (
 	ErrEmailAlreadyExists = errors.New("user with the same email already exists")
 	// ErrUsernameAlreadyExists is returned when a user with the same username already exists in the database
 	ErrUsernameAlreadyExists = errors.New("user with the same username already exists")
-	// ErrWrongPassword is returned when the given password does not match the user password
-	ErrWrongPassword = errors.New("the given password does not match the user password")
+	// ErrInvalidCredentials is returned when there is no user with the given email,
+	// or the given password does not match the user password.
+	// The two cases are intentionally not distinguished, to not reveal which emails are registered.
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrIdentityEmailRequired is returned when a user has to be created for an external identity that has no email
+	ErrIdentityEmailRequired = errors.New("the external identity has no email")
 )
 
 // UserModel wraps a DB connection pool.
@@ -58,19 +62,107 @@ func handleInsertUserError(err error) error {
 	return fmt.Errorf("error while inserting user into the database: %w", err)
 }
 
+// dummyHash is compared against the password, when there is no user with the given email,
+// so that the response time does not reveal whether the email is registered.
+// It has the same cost as the hashes of the real passwords.
+var dummyHash = []byte("$2a$12$nhVicavnBnDJs2J.WPOIROECX61sv21P/3FjWuZY5YywplOvrnVDW")
+
 // Authenticate authenticates a use with these credentials
 // and returns the user or an error if such occurred.
+// If the credentials are not valid, an ErrInvalidCredentials is returned.
 func (m *UserModel) Authenticate(email, password string) (*models.User, error) {
 	usr := models.User{}
 	if err := m.db.QueryRow("SELECT id, username, email, hashed_password FROM USERS U WHERE U.EMAIL = $1", email).Scan(&usr.ID, &usr.Username, &usr.Email, &usr.HashedPassword); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+			return nil, ErrInvalidCredentials
+		}
 		return nil, fmt.Errorf("error while fetching user from the database: %w", err)
 	}
+	if usr.HashedPassword == nil {
+		// users created via an external identity provider can't login with a password
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, ErrInvalidCredentials
+	}
 	if err := bcrypt.CompareHashAndPassword(usr.HashedPassword, []byte(password)); err != nil {
-		return nil, ErrWrongPassword
+		return nil, ErrInvalidCredentials
+	}
+	return &usr, nil
+}
+
+// AuthenticateIdentity returns the user linked to the given external identity.
+// If no user is linked to it yet, the identity is linked to the user with the same email,
+// if the provider has verified that email and is trusted to link by email, or to a newly created user without a password otherwise.
+// Without the trust, a provider, that verifies emails loosely, could take over any account with a matching email.
+func (m *UserModel) AuthenticateIdentity(identity *models.Identity) (*models.User, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	usr := models.User{}
+	err = tx.QueryRow(`
+	SELECT u.id, u.username, u.email
+	FROM USERS u
+		JOIN USER_IDENTITIES i ON i.user_id = u.id
+	WHERE i.issuer = $1 AND i.subject = $2`, identity.Issuer, identity.Subject).Scan(&usr.ID, &usr.Username, &usr.Email)
+	if err == nil {
+		return &usr, tx.Commit()
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error while fetching user from the database: %w", err)
+	}
+
+	linked := false
+	if identity.LinkByEmail && identity.EmailVerified && identity.Email != "" {
+		err := tx.QueryRow("SELECT id, username, email FROM USERS U WHERE U.EMAIL = $1", identity.Email).Scan(&usr.ID, &usr.Username, &usr.Email)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("error while fetching user from the database: %w", err)
+		}
+		linked = err == nil
+	}
+
+	if !linked {
+		if identity.Email == "" {
+			return nil, ErrIdentityEmailRequired
+		}
+		username, err := availableUsername(tx, identity.Username)
+		if err != nil {
+			return nil, err
+		}
+		row := tx.QueryRow("INSERT INTO USERS (username, email) VALUES ($1, $2) RETURNING id, username, email", username, identity.Email)
+		if err := row.Scan(&usr.ID, &usr.Username, &usr.Email); err != nil {
+			return nil, handleInsertUserError(err)
+		}
+	}
+
+	if _, err := tx.Exec("INSERT INTO USER_IDENTITIES (user_id, issuer, subject, email) VALUES ($1, $2, $3, $4)", usr.ID, identity.Issuer, identity.Subject, identity.Email); err != nil {
+		return nil, fmt.Errorf("error while linking identity to user: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error while committing transaction: %w", err)
 	}
 	return &usr, nil
 }
 
+// availableUsername returns the given username, if it is not taken,
+// or the username with the first free numeric suffix otherwise.
+func availableUsername(tx *sql.Tx, username string) (string, error) {
+	candidate := username
+	for i := 2; ; i++ {
+		var taken bool
+		if err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM USERS WHERE username = $1)", candidate).Scan(&taken); err != nil {
+			return "", fmt.Errorf("error while checking username: %w", err)
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", username, i)
+	}
+}
+
 // AssociateTokenWithUser associated the given token with the given userID
 func (m *UserModel) AssociateTokenWithUser(userID, token string) error {
 	if _, err := m.db.Exec("INSERT INTO user_tokens (user_id, token) VALUES ($1, $2)", userID, token); err != nil {
@@ -0,0 +1,34 @@
+//go:build integration_tests
+
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/asankov/gira/pkg/models/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateIdentityLinkByEmail(t *testing.T) {
+	db := newTestDB(t)
+
+	// the names are unique, so that the test can run more than once on the same database
+	suffix := time.Now().Format("20060102150405.000000000")
+	userModel := postgres.NewUserModel(db)
+	user, err := userModel.Insert(&models.User{Username: "identity-" + suffix, Email: fmt.Sprintf("identity-%s@example.com", suffix), Password: "password"})
+	require.NoError(t, err)
+
+	// the provider is not trusted to link by email, so the identity is not linked to the existing user
+	identity := &models.Identity{Provider: "loose", Issuer: "https://loose.example.com", Subject: suffix, Email: user.Email, EmailVerified: true, Username: user.Username}
+	_, err = userModel.AuthenticateIdentity(identity)
+	assert.ErrorIs(t, err, postgres.ErrEmailAlreadyExists)
+
+	identity = &models.Identity{Provider: "trusted", Issuer: "https://trusted.example.com", Subject: suffix, Email: user.Email, EmailVerified: true, Username: user.Username, LinkByEmail: true}
+	linked, err := userModel.AuthenticateIdentity(identity)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, linked.ID)
+}
@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/asankov/gira/pkg/models"
+	"github.com/lib/pq"
+)
+
+// maxWebhookDeliveries is the number of the latest deliveries, that are kept in the log of a webhook
+const maxWebhookDeliveries = 100
+
+// WebhookModel wraps an sql.DB connection pool.
+// It manages the webhooks of the users and the log of their deliveries.
+type WebhookModel struct {
+	db *sql.DB
+}
+
+func NewWebhookModel(db *sql.DB) *WebhookModel {
+	return &WebhookModel{db: db}
+}
+
+// InsertWebhook creates the given webhook and returns it, together with its secret.
+func (m *WebhookModel) InsertWebhook(webhook *models.Webhook) (*models.Webhook, error) {
+	w := *webhook
+	var createdAt sql.NullTime
+	if err := m.db.QueryRow(`
+	INSERT INTO WEBHOOKS (user_id, url, secret, events, active)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, created_at`, w.UserID, w.URL, w.Secret, pq.Array(eventStrings(w.Events)), w.Active).Scan(&w.ID, &createdAt); err != nil {
+		return nil, fmt.Errorf("error while inserting webhook into the database: %w", err)
+	}
+	w.CreatedAt = &createdAt.Time
+	return &w, nil
+}
+
+// Webhooks returns the webhooks of the user, the oldest first, without their secrets.
+func (m *WebhookModel) Webhooks(userID string) ([]*models.Webhook, error) {
+	rows, err := m.db.Query(`
+	SELECT id, url, events, active, created_at
+	FROM WEBHOOKS
+	WHERE user_id = $1
+	ORDER BY id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching webhooks from the database: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []*models.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading webhooks from the database: %w", err)
+	}
+	return webhooks, nil
+}
+
+// Webhook returns the given webhook of the user, without its secret.
+// If the user does not have such webhook, an ErrNoRecord is returned.
+func (m *WebhookModel) Webhook(userID, webhookID string) (*models.Webhook, error) {
+	webhook, err := scanWebhook(m.db.QueryRow(`
+	SELECT id, url, events, active, created_at
+	FROM WEBHOOKS
+	WHERE id = $1 AND user_id = $2`, webhookID, userID).Scan)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook replaces the URL, the events and the active flag of the webhook. The secret stays the same.
+// If the user does not have such webhook, an ErrNoRecord is returned.
+func (m *WebhookModel) UpdateWebhook(webhook *models.Webhook) error {
+	res, err := m.db.Exec(`
+	UPDATE WEBHOOKS SET url = $3, events = $4, active = $5
+	WHERE id = $1 AND user_id = $2`, webhook.ID, webhook.UserID, webhook.URL, pq.Array(eventStrings(webhook.Events)), webhook.Active)
+	if err != nil {
+		return fmt.Errorf("error while updating webhook: %w", err)
+	}
+	return expectAffected(res, "updating webhook")
+}
+
+// DeleteWebhook deletes the given webhook of the user, together with its deliveries.
+// If the user does not have such webhook, an ErrNoRecord is returned.
+func (m *WebhookModel) DeleteWebhook(userID, webhookID string) error {
+	res, err := m.db.Exec(`DELETE FROM WEBHOOKS WHERE id = $1 AND user_id = $2`, webhookID, userID)
+	if err != nil {
+		return fmt.Errorf("error while deleting webhook: %w", err)
+	}
+	return expectAffected(res, "deleting webhook")
+}
+
+// WebhookDeliveries returns the latest deliveries of the given webhook of the user, the newest first.
+// If the user does not have such webhook, an ErrNoRecord is returned.
+func (m *WebhookModel) WebhookDeliveries(userID, webhookID string, limit int) ([]*models.WebhookDelivery, error) {
+	if _, err := m.Webhook(userID, webhookID); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Query(`
+	SELECT id, webhook_id, event_id, event, payload, success, status_code, error, attempts, created_at
+	FROM WEBHOOK_DELIVERIES
+	WHERE webhook_id = $1
+	ORDER BY id DESC
+	LIMIT $2`, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching webhook deliveries from the database: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.WebhookDelivery{}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.Event, &d.Payload, &d.Success, &d.StatusCode, &d.Error, &d.Attempts, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error while reading webhook deliveries from the database: %w", err)
+		}
+		deliveries = append(deliveries, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading webhook deliveries from the database: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ActiveWebhooks returns the active webhooks of the user, that are subscribed to the given event, together with their secrets.
+func (m *WebhookModel) ActiveWebhooks(userID string, event models.WebhookEvent) ([]*models.Webhook, error) {
+	rows, err := m.db.Query(`
+	SELECT id, url, secret, events
+	FROM WEBHOOKS
+	WHERE user_id = $1 AND active AND $2 = ANY(events)
+	ORDER BY id`, userID, event)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching active webhooks from the database: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []*models.Webhook{}
+	for rows.Next() {
+		webhook := models.Webhook{Active: true, UserID: userID}
+		var events pq.StringArray
+		if err := rows.Scan(&webhook.ID, &webhook.URL, &webhook.Secret, &events); err != nil {
+			return nil, fmt.Errorf("error while reading active webhooks from the database: %w", err)
+		}
+		webhook.Events = webhookEvents(events)
+		webhooks = append(webhooks, &webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading active webhooks from the database: %w", err)
+	}
+	return webhooks, nil
+}
+
+// InsertDelivery adds the delivery to the log of its webhook.
+// Only the latest deliveries of each webhook are kept, the older ones are deleted.
+func (m *WebhookModel) InsertDelivery(delivery *models.WebhookDelivery) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error while starting transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	if _, err := tx.Exec(`
+	INSERT INTO WEBHOOK_DELIVERIES (webhook_id, event_id, event, payload, success, status_code, error, attempts, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		delivery.WebhookID, delivery.EventID, delivery.Event, delivery.Payload, delivery.Success, delivery.StatusCode, delivery.Error, delivery.Attempts, delivery.CreatedAt); err != nil {
+		return fmt.Errorf("error while inserting webhook delivery into the database: %w", err)
+	}
+
+	if _, err := tx.Exec(`
+	DELETE FROM WEBHOOK_DELIVERIES
+	WHERE webhook_id = $1 AND id NOT IN (
+		SELECT id FROM WEBHOOK_DELIVERIES WHERE webhook_id = $1 ORDER BY id DESC LIMIT $2
+	)`, delivery.WebhookID, maxWebhookDeliveries); err != nil {
+		return fmt.Errorf("error while deleting old webhook deliveries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error while committing transaction: %w", err)
+	}
+	return nil
+}
+
+// scanWebhook reads a webhook without its secret with the given scan function of a row
+func scanWebhook(scan func(dest ...interface{}) error) (*models.Webhook, error) {
+	var webhook models.Webhook
+	var events pq.StringArray
+	var createdAt sql.NullTime
+	if err := scan(&webhook.ID, &webhook.URL, &events, &webhook.Active, &createdAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error while reading webhook from the database: %w", err)
+	}
+	webhook.Events = webhookEvents(events)
+	if createdAt.Valid {
+		webhook.CreatedAt = &createdAt.Time
+	}
+	return &webhook, nil
+}
+
+// expectAffected returns an ErrNoRecord, if the statement did not affect any rows
+func expectAffected(res sql.Result, action string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while %s: %w", action, err)
+	}
+	if n == 0 {
+		return ErrNoRecord
+	}
+	return nil
+}
+
+func eventStrings(events []models.WebhookEvent) []string {
+	strs := make([]string, 0, len(events))
+	for _, event := range events {
+		strs = append(strs, string(event))
+	}
+	return strs
+}
+
+func webhookEvents(strs []string) []models.WebhookEvent {
+	events := make([]models.WebhookEvent, 0, len(strs))
+	for _, str := range strs {
+		events = append(events, models.WebhookEvent(str))
+	}
+	return events
+}
@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- public profiles can be seen and followed by the other users. The profiles are private by default.
+ALTER TABLE USERS ADD COLUMN public BOOLEAN NOT NULL DEFAULT false;
+
+-- hidden games are not shown on the public profile of the user.
+ALTER TABLE GAMES ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE FOLLOWS (
+  follower_id INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  followee_id INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  PRIMARY KEY (follower_id, followee_id),
+  CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX follows_idx_followee_id ON FOLLOWS (followee_id);
+
+-- +goose Down
+DROP TABLE FOLLOWS;
+ALTER TABLE GAMES DROP COLUMN hidden;
+ALTER TABLE USERS DROP COLUMN public;
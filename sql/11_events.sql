@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- EVENTS is the activity of the users (e.g. a game is started or finished), shown in the feed of their followers.
+-- The past status changes are not turned into events, so that the feeds do not start with the imported history.
+CREATE TABLE EVENTS (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  game_id INTEGER REFERENCES GAMES(id) ON DELETE CASCADE NOT NULL,
+  kind VARCHAR(255) NOT NULL,
+  from_status VARCHAR(255),
+  to_status VARCHAR(255),
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX events_idx_user_id_id ON EVENTS (user_id, id);
+
+-- +goose Down
+DROP TABLE EVENTS;
@@ -0,0 +1,51 @@
+-- +goose Up
+
+-- boards are sets of games, that are shared by a group of users, e.g. for co-op play.
+CREATE TABLE BOARDS (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- the role of a member is one of owner, editor and viewer.
+-- owners manage the members of the board, editors change its games and viewers can only see them.
+CREATE TABLE BOARD_MEMBERS (
+  board_id INTEGER REFERENCES BOARDS(id) ON DELETE CASCADE NOT NULL,
+  user_id INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  role VARCHAR(16) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  PRIMARY KEY (board_id, user_id)
+);
+
+CREATE INDEX board_members_idx_user_id ON BOARD_MEMBERS (user_id);
+
+CREATE TABLE BOARD_INVITATIONS (
+  id SERIAL PRIMARY KEY,
+  board_id INTEGER REFERENCES BOARDS(id) ON DELETE CASCADE NOT NULL,
+  user_id INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  role VARCHAR(16) NOT NULL,
+  invited_by INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  UNIQUE (board_id, user_id)
+);
+
+-- the games on a board belong to the board, user_id is the member who added them.
+-- the games, that are not on a board, belong only to the user.
+ALTER TABLE GAMES ADD COLUMN board_id INTEGER REFERENCES BOARDS(id) ON DELETE CASCADE;
+
+CREATE INDEX games_idx_board_id ON GAMES (board_id);
+
+-- the names of the games are unique per user, or per board for the games on a board.
+ALTER TABLE GAMES DROP CONSTRAINT games_uc_name_user_id;
+CREATE UNIQUE INDEX games_uc_name_user_id ON GAMES (name, user_id) WHERE board_id IS NULL;
+CREATE UNIQUE INDEX games_uc_name_board_id ON GAMES (name, board_id) WHERE board_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX games_uc_name_board_id;
+DROP INDEX games_uc_name_user_id;
+DELETE FROM GAMES WHERE board_id IS NOT NULL;
+ALTER TABLE GAMES ADD CONSTRAINT games_uc_name_user_id UNIQUE (name, user_id);
+ALTER TABLE GAMES DROP COLUMN board_id;
+DROP TABLE BOARD_INVITATIONS;
+DROP TABLE BOARD_MEMBERS;
+DROP TABLE BOARDS;
@@ -0,0 +1,7 @@
+-- +goose Up
+
+-- rating is the rating of the game by the user, from 1 to 5 stars. It is empty for the games, that are not rated.
+ALTER TABLE GAMES ADD COLUMN rating SMALLINT CHECK (rating BETWEEN 1 AND 5);
+
+-- +goose Down
+ALTER TABLE GAMES DROP COLUMN rating;
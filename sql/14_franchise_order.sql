@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- release_order and play_order are the positions of the game in its franchise, starting from 1.
+-- They are empty for the games, that are not ordered yet, which come after the ordered ones, by their release date.
+ALTER TABLE GAMES ADD COLUMN release_order INTEGER;
+ALTER TABLE GAMES ADD COLUMN play_order INTEGER;
+
+CREATE INDEX games_idx_franchise_id ON GAMES (franchise_id);
+
+-- +goose Down
+DROP INDEX games_idx_franchise_id;
+ALTER TABLE GAMES DROP COLUMN play_order;
+ALTER TABLE GAMES DROP COLUMN release_order;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- parent_id is the franchise, that the franchise is a sub-series of, e.g. a spin-off of the main line.
+-- It is empty for the top-level franchises.
+ALTER TABLE FRANCHISES ADD COLUMN parent_id INTEGER REFERENCES FRANCHISES(id) ON DELETE SET NULL;
+
+CREATE INDEX franchises_idx_parent_id ON FRANCHISES (parent_id);
+
+-- +goose Down
+DROP INDEX franchises_idx_parent_id;
+ALTER TABLE FRANCHISES DROP COLUMN parent_id;
@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- GOALS are the goals of the users, e.g. finish 12 games this year.
+-- target is the number of games to finish for the finish-games goals and the maximum number of To Do games for the backlog goals.
+-- The finish-franchise goals have no target, because it is the number of games of the franchise.
+-- starts_at and ends_at are the first and the last day of the goal. Only the finish-games goals have a start.
+-- achieved_at is set, when the goal is reached for the first time.
+CREATE TABLE GOALS (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  kind VARCHAR(32) NOT NULL,
+  target INTEGER NOT NULL DEFAULT 0,
+  franchise_id INTEGER REFERENCES FRANCHISES(id) ON DELETE CASCADE,
+  starts_at DATE,
+  ends_at DATE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  achieved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX goals_idx_user_id ON GOALS (user_id);
+
+-- +goose Down
+DROP TABLE GOALS;
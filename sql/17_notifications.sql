@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- the notification preferences of the users. The users without preferences get the defaults of the columns.
+-- stale_after_days is the number of days without an update, after which a game In Progress is stale.
+-- webhook_url is where the notifications are posted, if it is set.
+CREATE TABLE NOTIFICATION_PREFERENCES (
+  user_id INTEGER PRIMARY KEY REFERENCES USERS(id) ON DELETE CASCADE,
+  stale_reminders BOOLEAN NOT NULL DEFAULT true,
+  stale_after_days INTEGER NOT NULL DEFAULT 30,
+  in_app BOOLEAN NOT NULL DEFAULT true,
+  email BOOLEAN NOT NULL DEFAULT false,
+  webhook_url VARCHAR(2048) NOT NULL DEFAULT ''
+);
+
+-- the in-app notifications of the users. read_at is set, when the user reads the notification.
+CREATE TABLE NOTIFICATIONS (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  kind VARCHAR(32) NOT NULL,
+  game_id INTEGER REFERENCES GAMES(id) ON DELETE CASCADE,
+  message TEXT NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX notifications_idx_user_id_id ON NOTIFICATIONS (user_id, id DESC);
+
+-- stale_reminded_at is set, when the user is reminded about the game, so that they are not reminded again too soon.
+-- It is not an update of the game, so it does not change updated_at.
+ALTER TABLE GAMES ADD COLUMN stale_reminded_at TIMESTAMP WITH TIME ZONE;
+
+-- +goose Down
+ALTER TABLE GAMES DROP COLUMN stale_reminded_at;
+DROP TABLE NOTIFICATIONS;
+DROP TABLE NOTIFICATION_PREFERENCES;
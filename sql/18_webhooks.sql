@@ -0,0 +1,38 @@
+-- +goose Up
+
+-- WEBHOOKS are the URLs, that the events of the games of the users are posted to.
+-- events are the events, that the webhook is subscribed to, e.g. game.created.
+-- secret signs the payloads, so that the receivers can verify that they come from Gira.
+CREATE TABLE WEBHOOKS (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  url VARCHAR(2048) NOT NULL,
+  secret VARCHAR(128) NOT NULL,
+  events TEXT[] NOT NULL,
+  active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhooks_idx_user_id ON WEBHOOKS (user_id);
+
+-- WEBHOOK_DELIVERIES is the log of the deliveries of the events to the webhooks, including the failed ones.
+-- status_code is the last response of the webhook, or 0 if it did not respond at all.
+-- attempts is the number of times the event was sent, including the retries.
+CREATE TABLE WEBHOOK_DELIVERIES (
+  id SERIAL PRIMARY KEY,
+  webhook_id INTEGER REFERENCES WEBHOOKS(id) ON DELETE CASCADE NOT NULL,
+  event_id VARCHAR(64) NOT NULL,
+  event VARCHAR(64) NOT NULL,
+  payload TEXT NOT NULL,
+  success BOOLEAN NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  error TEXT NOT NULL DEFAULT '',
+  attempts INTEGER NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_deliveries_idx_webhook_id_id ON WEBHOOK_DELIVERIES (webhook_id, id DESC);
+
+-- +goose Down
+DROP TABLE WEBHOOK_DELIVERIES;
+DROP TABLE WEBHOOKS;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- progress_updated_at is set, when the status or the progress of the game changes, i.e. when the user plays it.
+-- Unlike updated_at, it does not change with the other details of the game, e.g. the wishlist, the rating or the cover,
+-- so it tells for how long a game In Progress was not played.
+-- It is unknown when the existing games were played, so they get the time of their last update.
+ALTER TABLE GAMES ADD COLUMN progress_updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+UPDATE GAMES SET progress_updated_at = updated_at;
+
+-- +goose Down
+ALTER TABLE GAMES DROP COLUMN progress_updated_at;
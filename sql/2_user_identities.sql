@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- users that sign-in only via an external identity provider don't have a password
+ALTER TABLE users ALTER COLUMN hashed_password DROP NOT NULL;
+
+CREATE TABLE USER_IDENTITIES (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER REFERENCES USERS(id) ON DELETE CASCADE NOT NULL,
+  issuer TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  email VARCHAR(255)
+);
+
+ALTER TABLE user_identities ADD CONSTRAINT user_identities_uc_issuer_subject UNIQUE (issuer, subject);
+
+-- +goose Down
+DROP TABLE USER_IDENTITIES;
+ALTER TABLE users ALTER COLUMN hashed_password SET NOT NULL;
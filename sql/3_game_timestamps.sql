@@ -0,0 +1,15 @@
+-- +goose Up
+
+ALTER TABLE GAMES ADD COLUMN created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+ALTER TABLE GAMES ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+-- finished_at is set when the game is moved to Done, and cleared when it is moved out of it.
+-- It is unknown when the games that are already Done were finished, so it is left empty for them.
+ALTER TABLE GAMES ADD COLUMN finished_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX games_idx_user_id_updated_at ON GAMES (user_id, updated_at DESC);
+
+-- +goose Down
+DROP INDEX games_idx_user_id_updated_at;
+ALTER TABLE GAMES DROP COLUMN finished_at;
+ALTER TABLE GAMES DROP COLUMN updated_at;
+ALTER TABLE GAMES DROP COLUMN created_at;
@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- GAME_STATUS_CHANGES is the history of the status changes of the games,
+-- used to compute how long the games were played, when they were started, finished, etc.
+CREATE TABLE GAME_STATUS_CHANGES (
+  id SERIAL PRIMARY KEY,
+  game_id INTEGER REFERENCES GAMES(id) ON DELETE CASCADE NOT NULL,
+  user_id INTEGER REFERENCES USERS(id) NOT NULL,
+  from_status VARCHAR(255) NOT NULL,
+  to_status VARCHAR(255) NOT NULL,
+  changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX game_status_changes_idx_user_id_changed_at ON GAME_STATUS_CHANGES (user_id, changed_at);
+
+-- the games that are already started have no history, so their last update is the best guess of when they got their current status
+INSERT INTO GAME_STATUS_CHANGES (game_id, user_id, from_status, to_status, changed_at)
+SELECT id, user_id, 'To Do', status, COALESCE(finished_at, updated_at)
+FROM GAMES
+WHERE status IS NOT NULL AND status <> 'To Do';
+
+-- +goose Down
+DROP TABLE GAME_STATUS_CHANGES;
@@ -0,0 +1,27 @@
+-- +goose Up
+
+ALTER TABLE GAMES ADD COLUMN metadata_id VARCHAR(255);
+ALTER TABLE GAMES ADD COLUMN cover_url TEXT;
+ALTER TABLE GAMES ADD COLUMN developer TEXT;
+ALTER TABLE GAMES ADD COLUMN genres TEXT[];
+ALTER TABLE GAMES ADD COLUMN release_date DATE;
+
+-- GAME_METADATA_CACHE holds the responses of the metadata providers,
+-- so that the same game is not fetched from the provider again and again.
+CREATE TABLE GAME_METADATA_CACHE (
+  provider VARCHAR(255) NOT NULL,
+  key TEXT NOT NULL,
+  data JSONB NOT NULL,
+  fetched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (provider, key)
+);
+
+-- +goose Down
+DROP TABLE GAME_METADATA_CACHE;
+
+ALTER TABLE GAMES DROP COLUMN release_date;
+ALTER TABLE GAMES DROP COLUMN genres;
+ALTER TABLE GAMES DROP COLUMN developer;
+ALTER TABLE GAMES DROP COLUMN cover_url;
+ALTER TABLE GAMES DROP COLUMN metadata_id;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- cover_updated_at is set when a cover is uploaded for the game or the franchise, and cleared when it is deleted.
+-- The cover itself is kept in the blob store.
+ALTER TABLE GAMES ADD COLUMN cover_updated_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE FRANCHISES ADD COLUMN cover_updated_at TIMESTAMP WITH TIME ZONE;
+
+-- +goose Down
+ALTER TABLE FRANCHISES DROP COLUMN cover_updated_at;
+ALTER TABLE GAMES DROP COLUMN cover_updated_at;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- wishlist marks the games, that the user wants, but does not own yet (e.g. because they are not released).
+-- It is independent from the status of the game.
+ALTER TABLE GAMES ADD COLUMN wishlist BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX games_idx_user_id_release_date ON GAMES (user_id, release_date);
+
+-- +goose Down
+DROP INDEX games_idx_user_id_release_date;
+ALTER TABLE GAMES DROP COLUMN wishlist;
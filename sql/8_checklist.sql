@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- CHECKLIST_ITEMS are the named items of a game (achievements, side quests, collectibles, etc.), that can be ticked off.
+CREATE TABLE CHECKLIST_ITEMS (
+  id SERIAL PRIMARY KEY,
+  game_id INTEGER REFERENCES GAMES(id) ON DELETE CASCADE NOT NULL,
+  name TEXT NOT NULL,
+  done BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX checklist_items_idx_game_id ON CHECKLIST_ITEMS (game_id);
+
+-- auto_progress marks the games, whose progress is computed from the done checklist items, instead of being set by the user.
+ALTER TABLE GAMES ADD COLUMN auto_progress BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE GAMES DROP COLUMN auto_progress;
+DROP TABLE CHECKLIST_ITEMS;
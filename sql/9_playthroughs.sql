@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- PLAYTHROUGHS are the separate playthroughs of a game (NG+, a different class, etc.).
+-- The status of a game, that has playthroughs, is the status of its latest playthrough.
+-- The games, that were played before, have no playthroughs, and keep their own status.
+CREATE TABLE PLAYTHROUGHS (
+  id SERIAL PRIMARY KEY,
+  game_id INTEGER REFERENCES GAMES(id) ON DELETE CASCADE NOT NULL,
+  name TEXT NOT NULL,
+  status VARCHAR(255) NOT NULL DEFAULT 'To Do',
+  current_progress INTEGER NOT NULL DEFAULT 0,
+  final_progress INTEGER NOT NULL DEFAULT 100,
+  started_at DATE,
+  finished_at DATE,
+  notes TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX playthroughs_idx_game_id ON PLAYTHROUGHS (game_id);
+
+-- +goose Down
+DROP TABLE PLAYTHROUGHS;
@@ -21,3 +21,86 @@ function handleEsc(handler) {
 		}
 	};
 }
+
+// the pages with games (the list of games and the boards) are kept up to date with the changes,
+// that are made from other tabs or scripts, via the stream of changes
+document.addEventListener('DOMContentLoaded', () => {
+	const container = document.querySelector('[data-live-board]');
+	if (!container || !window.EventSource) {
+		return;
+	}
+
+	const row = gameId => document.querySelector(`tr[data-game-id="${CSS.escape(gameId)}"]`);
+
+	const setField = (element, value) => {
+		if (element.tagName === 'SELECT') {
+			for (let i = 0; i < element.options.length; i++) {
+				const option = element.options[i];
+				// the list of games does not allow saving the current status again
+				if (element.dataset.liveDisableCurrent) {
+					option.disabled = option.value === value;
+				}
+				option.selected = option.value === value;
+			}
+		} else if (element.tagName === 'INPUT') {
+			element.value = value;
+		} else {
+			element.textContent = value;
+		}
+	};
+
+	const updateFields = (tr, field, value) => {
+		tr.querySelectorAll(`[data-live-field="${field}"]`).forEach(element => setField(element, value));
+	};
+
+	const showNotice = text => {
+		const notice = document.createElement('p');
+		notice.className = 'live-notice';
+		notice.textContent = text + ' ';
+		const reload = document.createElement('a');
+		reload.href = window.location.pathname;
+		reload.textContent = 'Reload';
+		notice.appendChild(reload);
+		container.before(notice);
+	};
+
+	const changes = new EventSource('/changes');
+
+	changes.addEventListener('game.created', e => {
+		const change = JSON.parse(e.data);
+		// only the games, that belong to the page, are announced - the games of the user on the list, or the games of the board on the board
+		if ((change.game.boardId || '') === container.dataset.liveBoard && !row(change.gameId)) {
+			showNotice(`${change.game.name} was added.`);
+		}
+	});
+
+	changes.addEventListener('game.updated', e => {
+		const change = JSON.parse(e.data);
+		const tr = row(change.gameId);
+		if (!tr) {
+			return;
+		}
+		if (change.status) {
+			updateFields(tr, 'status', change.status);
+		}
+		if (change.progress) {
+			updateFields(tr, 'progress-current', change.progress.current);
+			updateFields(tr, 'progress-final', change.progress.final || 0);
+			tr.querySelectorAll('progress[data-live-field="progress"]').forEach(bar => {
+				bar.max = change.progress.final || 0;
+				bar.value = change.progress.current;
+			});
+		}
+		tr.classList.remove('live-updated');
+		// the reflow restarts the highlight, if the row is updated again while it is still highlighted
+		void tr.offsetWidth;
+		tr.classList.add('live-updated');
+	});
+
+	changes.addEventListener('game.deleted', e => {
+		const tr = row(JSON.parse(e.data).gameId);
+		if (tr) {
+			tr.remove();
+		}
+	});
+});